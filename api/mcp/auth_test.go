@@ -0,0 +1,122 @@
+package mcp_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpAPI "github.com/ricardogrande-masmovil/billing-mcp/api/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	apiKey      = "0123456789abcdef0123456789abcdef"
+	agentAPIKey = "agent-0123456789abcdef0123456789"
+)
+
+// newAPIKeyGuard accepts apiKey, for an admin, and agentAPIKey, for an agent granted account_A.
+func newAPIKeyGuard(t *testing.T) *auth.Guard {
+	apiKeys, err := auth.NewAPIKeys([]auth.APIKey{
+		{Name: "backoffice", Key: apiKey, Grant: auth.Grant{Role: auth.RoleAdmin}},
+		{Name: "agent", Key: agentAPIKey, Grant: auth.Grant{Role: auth.RoleAgent, Accounts: []string{"account_A"}}},
+	})
+	require.NoError(t, err)
+	return auth.NewGuard(zerolog.Nop(), apiKeys)
+}
+
+// principalOf calls the GetInvoices tool, whose mock answers with the principal of the request.
+func principalOf(t *testing.T, c *client.Client) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, c.Start(ctx))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err := c.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = "GetInvoices"
+	callRequest.Params.Arguments = map[string]any{"accountId": "account_A"}
+	result, err := c.CallTool(ctx, callRequest)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestAuth_RejectsUnauthenticatedRequests(t *testing.T) {
+	e, _ := newGuardedServer(t, mcpAPI.Transports{mcpAPI.TransportSSE, mcpAPI.TransportStreamableHTTP}, newAPIKeyGuard(t))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+	}{
+		{"SSE stream without key", http.MethodGet, "/sse", ""},
+		{"SSE message without key", http.MethodPost, "/message?sessionId=any", ""},
+		{"Streamable HTTP without key", http.MethodPost, "/mcp", ""},
+		{"Streamable HTTP with a wrong key", http.MethodPost, "/mcp", strings.Repeat("x", 32)},
+		{"PDF without key", http.MethodGet, "/invoices/0f8fad5b-d9cb-469f-a165-70867728950e/pdf", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader("{}"))
+			require.NoError(t, err)
+			if tt.key != "" {
+				request.Header.Set(auth.APIKeyHeader, tt.key)
+			}
+			response, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			response.Body.Close()
+
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			assert.Contains(t, response.Header.Get("WWW-Authenticate"), "Bearer")
+		})
+	}
+}
+
+func TestAuth_PassesThePrincipalToTheHandlers(t *testing.T) {
+	e, _ := newGuardedServer(t, mcpAPI.Transports{mcpAPI.TransportSSE, mcpAPI.TransportStreamableHTTP}, newAPIKeyGuard(t))
+	server := httptest.NewServer(e)
+	defer server.Close()
+	headers := map[string]string{auth.APIKeyHeader: apiKey}
+
+	sse, err := client.NewSSEMCPClient(server.URL+"/sse", transport.WithHeaders(headers))
+	require.NoError(t, err)
+	defer sse.Close()
+	assert.Equal(t, "api-key:backoffice", principalOf(t, sse))
+
+	streamable, err := client.NewStreamableHttpClient(server.URL+"/mcp", transport.WithHTTPHeaders(headers))
+	require.NoError(t, err)
+	defer streamable.Close()
+	assert.Equal(t, "api-key:backoffice", principalOf(t, streamable))
+}
+
+func TestAuth_TrustsTheStdioClient(t *testing.T) {
+	_, s := newGuardedServer(t, mcpAPI.Transports{mcpAPI.TransportStdio}, newAPIKeyGuard(t))
+
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = mcpAPI.ServeStdio(ctx, s, serverIn, serverOut)
+	}()
+
+	c := client.NewClient(transport.NewIO(clientIn, clientOut, io.NopCloser(strings.NewReader(""))))
+	assert.Equal(t, "stdio:stdio", principalOf(t, c))
+	require.NoError(t, clientOut.Close())
+}
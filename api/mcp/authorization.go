@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+
+	mcpSdk "github.com/mark3labs/mcp-go/mcp"
+	serverSdk "github.com/mark3labs/mcp-go/server"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+)
+
+// errMissingAccountId is the error of a request to an account tool or prompt without the accountId argument.
+var errMissingAccountId = errors.New("accountId is required")
+
+// accountTool only runs the handler when the principal may access the account of the accountId argument. Requests
+// without it are rejected, the tools working across accounts are guarded by accountOrAllAccountsTool or allAccountsTool.
+func accountTool(access auth.Access, handler serverSdk.ToolHandlerFunc) serverSdk.ToolHandlerFunc {
+	return func(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+		accountId := request.GetString("accountId", "")
+		if accountId == "" {
+			return toolerror.InvalidArgument("Missing accountId", errMissingAccountId), nil
+		}
+		if err := auth.Authorize(ctx, accountId, access); err != nil {
+			return accessDenied(err), nil
+		}
+		return handler(ctx, request)
+	}
+}
+
+// allAccountsTool only runs the handler when the principal may access every account, for the tools working across them.
+func allAccountsTool(access auth.Access, handler serverSdk.ToolHandlerFunc) serverSdk.ToolHandlerFunc {
+	return func(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+		if err := auth.AuthorizeAll(ctx, access); err != nil {
+			return accessDenied(err), nil
+		}
+		return handler(ctx, request)
+	}
+}
+
+// accountOrAllAccountsTool only runs the handler when the principal may access the account of the accountId argument,
+// or every account when it is omitted, for the tools working on an account or across them.
+func accountOrAllAccountsTool(access auth.Access, handler serverSdk.ToolHandlerFunc) serverSdk.ToolHandlerFunc {
+	return func(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+		if accountId := request.GetString("accountId", ""); accountId != "" {
+			return accountTool(access, handler)(ctx, request)
+		}
+		return allAccountsTool(access, handler)(ctx, request)
+	}
+}
+
+// accessDenied is the tool error every tool answers when the principal may not access the account.
+func accessDenied(err error) *mcpSdk.CallToolResult {
+	return toolerror.Result(toolerror.CodeForbidden, "Access denied", err)
+}
+
+// accountResource only reads the resource when the principal may read the account of its URI.
+func accountResource(handler serverSdk.ResourceTemplateHandlerFunc) serverSdk.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcpSdk.ReadResourceRequest) ([]mcpSdk.ResourceContents, error) {
+		if err := auth.Authorize(ctx, resourceAccountId(request), auth.AccessRead); err != nil {
+			return nil, err
+		}
+		return handler(ctx, request)
+	}
+}
+
+// accountPrompt only renders the prompt when the principal may read the account of the accountId argument, which is
+// required.
+func accountPrompt(handler serverSdk.PromptHandlerFunc) serverSdk.PromptHandlerFunc {
+	return func(ctx context.Context, request mcpSdk.GetPromptRequest) (*mcpSdk.GetPromptResult, error) {
+		accountId := request.Params.Arguments["accountId"]
+		if accountId == "" {
+			return nil, errMissingAccountId
+		}
+		if err := auth.Authorize(ctx, accountId, auth.AccessRead); err != nil {
+			return nil, err
+		}
+		return handler(ctx, request)
+	}
+}
+
+// resourceAccountId reads the accountId variable of a resource template. The server gives the values of the URI as lists.
+func resourceAccountId(request mcpSdk.ReadResourceRequest) string {
+	if values, ok := request.Params.Arguments["accountId"].([]string); ok && len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
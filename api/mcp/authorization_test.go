@@ -0,0 +1,124 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpAPI "github.com/ricardogrande-masmovil/billing-mcp/api/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAgentClient connects to the server with the API key of the agent granted account_A.
+func newAgentClient(t *testing.T) (context.Context, *client.Client) {
+	e, _ := newGuardedServer(t, mcpAPI.Transports{mcpAPI.TransportStreamableHTTP}, newAPIKeyGuard(t))
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	c, err := client.NewStreamableHttpClient(server.URL+"/mcp", transport.WithHTTPHeaders(map[string]string{auth.APIKeyHeader: agentAPIKey}))
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	require.NoError(t, c.Start(ctx))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = c.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+	return ctx, c
+}
+
+func callTool(t *testing.T, ctx context.Context, c *client.Client, name string, args map[string]any) *mcp.CallToolResult {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = args
+	result, err := c.CallTool(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	return result
+}
+
+func TestAuthorization_GrantedAccount(t *testing.T) {
+	ctx, c := newAgentClient(t)
+
+	result := callTool(t, ctx, c, "GetInvoices", map[string]any{"accountId": "account_A"})
+	assert.False(t, result.IsError)
+	assert.Equal(t, "api-key:agent", result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestAuthorization_DeniesOtherAccounts(t *testing.T) {
+	ctx, c := newAgentClient(t)
+
+	// The mocked handlers have no expectations for these calls, so they fail the test if they are reached
+	tests := []struct {
+		tool string
+		args map[string]any
+	}{
+		{"GetInvoices", map[string]any{"accountId": "account_B"}},
+		{"GetInvoice", map[string]any{"accountId": "account_B", "invoiceId": "0f8fad5b-d9cb-469f-a165-70867728950e"}},
+		{"CreateMovement", map[string]any{"accountId": "account_B", "amount": "10", "movementType": "DEBIT"}},
+		{"RegisterPayment", map[string]any{"accountId": "account_B", "amount": "10", "method": "CASH"}},
+		{"RunBilling", map[string]any{"periodStart": "2025-01-01", "periodEnd": "2025-01-31"}},
+		{"GetAgingReport", map[string]any{"accountId": "account_B"}},
+		{"GetAgingReport", map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			result := callTool(t, ctx, c, tt.tool, tt.args)
+			assert.True(t, result.IsError)
+
+			var toolErr toolerror.Error
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &toolErr))
+			assert.Equal(t, toolerror.CodeForbidden, toolErr.Code)
+			assert.Contains(t, toolErr.Message, "Access denied")
+			assert.False(t, toolErr.Retryable)
+		})
+	}
+}
+
+func TestAuthorization_DeniesResourcesAndPromptsOfOtherAccounts(t *testing.T) {
+	ctx, c := newAgentClient(t)
+
+	resourceRequest := mcp.ReadResourceRequest{}
+	resourceRequest.Params.URI = "billing://accounts/account_B/invoices/0f8fad5b-d9cb-469f-a165-70867728950e"
+	_, err := c.ReadResource(ctx, resourceRequest)
+	assert.ErrorContains(t, err, "access denied")
+
+	promptRequest := mcp.GetPromptRequest{}
+	promptRequest.Params.Name = "ExplainInvoice"
+	promptRequest.Params.Arguments = map[string]string{"accountId": "account_B", "invoiceId": "0f8fad5b-d9cb-469f-a165-70867728950e"}
+	_, err = c.GetPrompt(ctx, promptRequest)
+	assert.ErrorContains(t, err, "access denied")
+}
+
+func TestAuthorization_RequiresTheAccount(t *testing.T) {
+	ctx, c := newAgentClient(t)
+
+	// The mocked handlers have no expectations for these calls, so they fail the test if they are reached
+	for _, tool := range []string{"GetInvoices", "CreateMovement", "GetAccount"} {
+		t.Run(tool, func(t *testing.T) {
+			result := callTool(t, ctx, c, tool, map[string]any{})
+			assert.True(t, result.IsError)
+
+			var toolErr toolerror.Error
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &toolErr))
+			assert.Equal(t, toolerror.CodeInvalidArgument, toolErr.Code)
+			assert.Contains(t, toolErr.Message, "accountId is required")
+		})
+	}
+
+	promptRequest := mcp.GetPromptRequest{}
+	promptRequest.Params.Name = "ExplainInvoice"
+	promptRequest.Params.Arguments = map[string]string{"invoiceId": "0f8fad5b-d9cb-469f-a165-70867728950e"}
+	_, err := c.GetPrompt(ctx, promptRequest)
+	assert.ErrorContains(t, err, "accountId is required")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/labstack/echo/v4"
 	mcpSdk "github.com/mark3labs/mcp-go/mcp"
 	serverSdk "github.com/mark3labs/mcp-go/server"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
 )
 
 // Http Controllers
@@ -13,53 +14,140 @@ type HealthController interface {
 	IsHealthy(ectx echo.Context) error
 }
 
-// MCP Controllers (tool handlers)
+type InvoicesHTTPController interface {
+	ServeInvoicePDF(ectx echo.Context) error
+}
+
+// MCP Controllers (tool, resource and prompt handlers)
 type InvoicesController interface {
 	GetInvoice(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
 	GetInvoices(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
 	GetInvoiceMovements(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	CreateInvoice(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	AddInvoiceLine(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	SendInvoice(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	VoidInvoice(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	MarkInvoiceUnpaid(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	GetInvoiceStatusHistory(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	IssueCreditNote(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	ExportInvoice(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	GetInvoicePDF(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	GetAgingReport(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	ReadInvoiceResource(ctx context.Context, request mcpSdk.ReadResourceRequest) ([]mcpSdk.ResourceContents, error)
+	ReadInvoicePDFResource(ctx context.Context, request mcpSdk.ReadResourceRequest) ([]mcpSdk.ResourceContents, error)
+	ListInvoiceResources(ctx context.Context, accountId string) ([]mcpSdk.Resource, error)
+	ExplainInvoicePrompt(ctx context.Context, request mcpSdk.GetPromptRequest) (*mcpSdk.GetPromptResult, error)
+	CompareInvoicesPrompt(ctx context.Context, request mcpSdk.GetPromptRequest) (*mcpSdk.GetPromptResult, error)
+	DisputeResponsePrompt(ctx context.Context, request mcpSdk.GetPromptRequest) (*mcpSdk.GetPromptResult, error)
 }
 
 type MovementsController interface {
 	GetMovement(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	CreateMovement(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	SearchMovements(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	CancelMovement(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	UpdateMovementStatus(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	ReadMovementResource(ctx context.Context, request mcpSdk.ReadResourceRequest) ([]mcpSdk.ResourceContents, error)
+	ListMovementResources(ctx context.Context, accountID string) ([]mcpSdk.Resource, error)
+}
+
+type BillingController interface {
+	RunBilling(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+}
+
+type PaymentsController interface {
+	RegisterPayment(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	GetInvoiceBalance(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+}
+
+type AccountsController interface {
+	GetAccount(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	UpdateBillingProfile(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
+	GetAccountStatement(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error)
 }
 
 type MCPServer struct {
 	HealthController
+	InvoicesHTTPController
 	InvoicesController
 	MovementsController
+	BillingController
+	PaymentsController
+	AccountsController
 }
 
-func NewMCPServer(healthController HealthController, invoicesController InvoicesController, movementsController MovementsController) *MCPServer {
+func NewMCPServer(healthController HealthController, invoicesHTTPController InvoicesHTTPController, invoicesController InvoicesController, movementsController MovementsController, billingController BillingController, paymentsController PaymentsController, accountsController AccountsController) *MCPServer {
 	return &MCPServer{
-		HealthController:    healthController,
-		InvoicesController:  invoicesController,
-		MovementsController: movementsController,
+		HealthController:       healthController,
+		InvoicesHTTPController: invoicesHTTPController,
+		InvoicesController:     invoicesController,
+		MovementsController:    movementsController,
+		BillingController:      billingController,
+		PaymentsController:     paymentsController,
+		AccountsController:     accountsController,
 	}
 }
 
-func Setup(e *echo.Echo, s *serverSdk.MCPServer, mcpServer *MCPServer) (err error) {
-	sse := serverSdk.NewSSEServer(s,
-		serverSdk.WithHTTPServer(e.Server),
-		serverSdk.WithUseFullURLForMessageEndpoint(true),
-	)
+// Setup registers the tools, resources and prompts of the MCP server, and the HTTP routes of the enabled transports.
+// The routes serving billing data are protected by the guard. The stdio transport is served apart with ServeStdio.
+func Setup(e *echo.Echo, s *serverSdk.MCPServer, mcpServer *MCPServer, transports Transports, guard *auth.Guard) (err error) {
+	if err = transports.Validate(); err != nil {
+		return
+	}
 
-	registerHandlers(e, sse, mcpServer)
+	guard.Register(e)
+	registerHandlers(e, mcpServer, guard)
+	registerTransports(e, s, transports, guard)
 	registerTools(s, mcpServer)
+	registerResources(s, mcpServer)
+	registerPrompts(s, mcpServer)
 
 	return
 }
 
-func registerHandlers(e *echo.Echo, sse *serverSdk.SSEServer, mcp *MCPServer) {
+func registerHandlers(e *echo.Echo, mcp *MCPServer, guard *auth.Guard) {
 	e.GET("/health", mcp.HealthController.IsHealthy)
-
-	e.GET("/sse", echo.WrapHandler(sse.SSEHandler()))
-	e.POST("/message", echo.WrapHandler(sse.MessageHandler()))
+	e.GET("/invoices/:invoiceId/pdf", mcp.InvoicesHTTPController.ServeInvoicePDF, guard.Middleware)
 }
 
+// registerTools adds the tools, each one only running when the principal may access the accounts it works on.
 func registerTools(s *serverSdk.MCPServer, mcp *MCPServer) {
-	s.AddTool(invoiceTool, mcp.InvoicesController.GetInvoice)
-	s.AddTool(invoicesTool, mcp.InvoicesController.GetInvoices)
-	s.AddTool(invoiceMovementsTool, mcp.InvoicesController.GetInvoiceMovements)
-	s.AddTool(movementTool, mcp.MovementsController.GetMovement)
+	s.AddTool(invoiceTool, accountTool(auth.AccessRead, mcp.InvoicesController.GetInvoice))
+	s.AddTool(invoicesTool, accountTool(auth.AccessRead, mcp.InvoicesController.GetInvoices))
+	s.AddTool(invoiceMovementsTool, accountTool(auth.AccessRead, mcp.InvoicesController.GetInvoiceMovements))
+	s.AddTool(createInvoiceTool, accountTool(auth.AccessWrite, mcp.InvoicesController.CreateInvoice))
+	s.AddTool(addInvoiceLineTool, accountTool(auth.AccessWrite, mcp.InvoicesController.AddInvoiceLine))
+	s.AddTool(sendInvoiceTool, accountTool(auth.AccessWrite, mcp.InvoicesController.SendInvoice))
+	s.AddTool(voidInvoiceTool, accountTool(auth.AccessWrite, mcp.InvoicesController.VoidInvoice))
+	s.AddTool(markInvoiceUnpaidTool, accountTool(auth.AccessWrite, mcp.InvoicesController.MarkInvoiceUnpaid))
+	s.AddTool(invoiceStatusHistoryTool, accountTool(auth.AccessRead, mcp.InvoicesController.GetInvoiceStatusHistory))
+	s.AddTool(issueCreditNoteTool, accountTool(auth.AccessWrite, mcp.InvoicesController.IssueCreditNote))
+	s.AddTool(exportInvoiceTool, accountTool(auth.AccessRead, mcp.InvoicesController.ExportInvoice))
+	s.AddTool(invoicePDFTool, accountTool(auth.AccessRead, mcp.InvoicesController.GetInvoicePDF))
+	s.AddTool(agingReportTool, accountOrAllAccountsTool(auth.AccessRead, mcp.InvoicesController.GetAgingReport))
+	s.AddTool(movementTool, accountTool(auth.AccessRead, mcp.MovementsController.GetMovement))
+	s.AddTool(createMovementTool, accountTool(auth.AccessWrite, mcp.MovementsController.CreateMovement))
+	s.AddTool(searchMovementsTool, accountTool(auth.AccessRead, mcp.MovementsController.SearchMovements))
+	s.AddTool(cancelMovementTool, accountTool(auth.AccessWrite, mcp.MovementsController.CancelMovement))
+	s.AddTool(updateMovementStatusTool, accountTool(auth.AccessWrite, mcp.MovementsController.UpdateMovementStatus))
+	s.AddTool(runBillingTool, allAccountsTool(auth.AccessWrite, mcp.BillingController.RunBilling))
+	s.AddTool(registerPaymentTool, accountTool(auth.AccessWrite, mcp.PaymentsController.RegisterPayment))
+	s.AddTool(invoiceBalanceTool, accountTool(auth.AccessRead, mcp.PaymentsController.GetInvoiceBalance))
+	s.AddTool(getAccountTool, accountTool(auth.AccessRead, mcp.AccountsController.GetAccount))
+	s.AddTool(updateBillingProfileTool, accountTool(auth.AccessWrite, mcp.AccountsController.UpdateBillingProfile))
+	s.AddTool(accountStatementTool, accountTool(auth.AccessRead, mcp.AccountsController.GetAccountStatement))
+}
+
+func registerResources(s *serverSdk.MCPServer, mcp *MCPServer) {
+	s.AddResourceTemplate(invoiceResource, accountResource(mcp.InvoicesController.ReadInvoiceResource))
+	s.AddResourceTemplate(invoicePDFResource, accountResource(mcp.InvoicesController.ReadInvoicePDFResource))
+	// Movement URIs have no account, the handler authorizes the account of the movement
+	s.AddResourceTemplate(movementResource, mcp.MovementsController.ReadMovementResource)
+	s.AddResourceTemplate(accountResources, accountResource(readAccountResources(mcp)))
+}
+
+func registerPrompts(s *serverSdk.MCPServer, mcp *MCPServer) {
+	s.AddPrompt(explainInvoicePrompt, accountPrompt(mcp.InvoicesController.ExplainInvoicePrompt))
+	s.AddPrompt(compareInvoicesPrompt, accountPrompt(mcp.InvoicesController.CompareInvoicesPrompt))
+	s.AddPrompt(disputeResponsePrompt, accountPrompt(mcp.InvoicesController.DisputeResponsePrompt))
 }
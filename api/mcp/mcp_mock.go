@@ -0,0 +1,695 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api/mcp/mcp.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api/mcp/mcp.go -destination=api/mcp/mcp_mock.go -package=mcp HealthController,InvoicesHTTPController,InvoicesController,MovementsController,BillingController,PaymentsController,AccountsController
+//
+
+// Package mcp is a generated GoMock package.
+package mcp
+
+import (
+	context "context"
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	mcp "github.com/mark3labs/mcp-go/mcp"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHealthController is a mock of HealthController interface.
+type MockHealthController struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthControllerMockRecorder is the mock recorder for MockHealthController.
+type MockHealthControllerMockRecorder struct {
+	mock *MockHealthController
+}
+
+// NewMockHealthController creates a new mock instance.
+func NewMockHealthController(ctrl *gomock.Controller) *MockHealthController {
+	mock := &MockHealthController{ctrl: ctrl}
+	mock.recorder = &MockHealthControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthController) EXPECT() *MockHealthControllerMockRecorder {
+	return m.recorder
+}
+
+// IsHealthy mocks base method.
+func (m *MockHealthController) IsHealthy(ectx echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHealthy", ectx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IsHealthy indicates an expected call of IsHealthy.
+func (mr *MockHealthControllerMockRecorder) IsHealthy(ectx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHealthy", reflect.TypeOf((*MockHealthController)(nil).IsHealthy), ectx)
+}
+
+// MockInvoicesHTTPController is a mock of InvoicesHTTPController interface.
+type MockInvoicesHTTPController struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoicesHTTPControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoicesHTTPControllerMockRecorder is the mock recorder for MockInvoicesHTTPController.
+type MockInvoicesHTTPControllerMockRecorder struct {
+	mock *MockInvoicesHTTPController
+}
+
+// NewMockInvoicesHTTPController creates a new mock instance.
+func NewMockInvoicesHTTPController(ctrl *gomock.Controller) *MockInvoicesHTTPController {
+	mock := &MockInvoicesHTTPController{ctrl: ctrl}
+	mock.recorder = &MockInvoicesHTTPControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoicesHTTPController) EXPECT() *MockInvoicesHTTPControllerMockRecorder {
+	return m.recorder
+}
+
+// ServeInvoicePDF mocks base method.
+func (m *MockInvoicesHTTPController) ServeInvoicePDF(ectx echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServeInvoicePDF", ectx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ServeInvoicePDF indicates an expected call of ServeInvoicePDF.
+func (mr *MockInvoicesHTTPControllerMockRecorder) ServeInvoicePDF(ectx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeInvoicePDF", reflect.TypeOf((*MockInvoicesHTTPController)(nil).ServeInvoicePDF), ectx)
+}
+
+// MockInvoicesController is a mock of InvoicesController interface.
+type MockInvoicesController struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoicesControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoicesControllerMockRecorder is the mock recorder for MockInvoicesController.
+type MockInvoicesControllerMockRecorder struct {
+	mock *MockInvoicesController
+}
+
+// NewMockInvoicesController creates a new mock instance.
+func NewMockInvoicesController(ctrl *gomock.Controller) *MockInvoicesController {
+	mock := &MockInvoicesController{ctrl: ctrl}
+	mock.recorder = &MockInvoicesControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoicesController) EXPECT() *MockInvoicesControllerMockRecorder {
+	return m.recorder
+}
+
+// AddInvoiceLine mocks base method.
+func (m *MockInvoicesController) AddInvoiceLine(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInvoiceLine", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddInvoiceLine indicates an expected call of AddInvoiceLine.
+func (mr *MockInvoicesControllerMockRecorder) AddInvoiceLine(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInvoiceLine", reflect.TypeOf((*MockInvoicesController)(nil).AddInvoiceLine), ctx, request)
+}
+
+// CompareInvoicesPrompt mocks base method.
+func (m *MockInvoicesController) CompareInvoicesPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareInvoicesPrompt", ctx, request)
+	ret0, _ := ret[0].(*mcp.GetPromptResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareInvoicesPrompt indicates an expected call of CompareInvoicesPrompt.
+func (mr *MockInvoicesControllerMockRecorder) CompareInvoicesPrompt(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareInvoicesPrompt", reflect.TypeOf((*MockInvoicesController)(nil).CompareInvoicesPrompt), ctx, request)
+}
+
+// CreateInvoice mocks base method.
+func (m *MockInvoicesController) CreateInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoice", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvoice indicates an expected call of CreateInvoice.
+func (mr *MockInvoicesControllerMockRecorder) CreateInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoicesController)(nil).CreateInvoice), ctx, request)
+}
+
+// DisputeResponsePrompt mocks base method.
+func (m *MockInvoicesController) DisputeResponsePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisputeResponsePrompt", ctx, request)
+	ret0, _ := ret[0].(*mcp.GetPromptResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisputeResponsePrompt indicates an expected call of DisputeResponsePrompt.
+func (mr *MockInvoicesControllerMockRecorder) DisputeResponsePrompt(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisputeResponsePrompt", reflect.TypeOf((*MockInvoicesController)(nil).DisputeResponsePrompt), ctx, request)
+}
+
+// ExplainInvoicePrompt mocks base method.
+func (m *MockInvoicesController) ExplainInvoicePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainInvoicePrompt", ctx, request)
+	ret0, _ := ret[0].(*mcp.GetPromptResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainInvoicePrompt indicates an expected call of ExplainInvoicePrompt.
+func (mr *MockInvoicesControllerMockRecorder) ExplainInvoicePrompt(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainInvoicePrompt", reflect.TypeOf((*MockInvoicesController)(nil).ExplainInvoicePrompt), ctx, request)
+}
+
+// ExportInvoice mocks base method.
+func (m *MockInvoicesController) ExportInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportInvoice", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportInvoice indicates an expected call of ExportInvoice.
+func (mr *MockInvoicesControllerMockRecorder) ExportInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportInvoice", reflect.TypeOf((*MockInvoicesController)(nil).ExportInvoice), ctx, request)
+}
+
+// GetAgingReport mocks base method.
+func (m *MockInvoicesController) GetAgingReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgingReport", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgingReport indicates an expected call of GetAgingReport.
+func (mr *MockInvoicesControllerMockRecorder) GetAgingReport(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgingReport", reflect.TypeOf((*MockInvoicesController)(nil).GetAgingReport), ctx, request)
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoicesController) GetInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoicesControllerMockRecorder) GetInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoicesController)(nil).GetInvoice), ctx, request)
+}
+
+// GetInvoiceMovements mocks base method.
+func (m *MockInvoicesController) GetInvoiceMovements(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceMovements", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceMovements indicates an expected call of GetInvoiceMovements.
+func (mr *MockInvoicesControllerMockRecorder) GetInvoiceMovements(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceMovements", reflect.TypeOf((*MockInvoicesController)(nil).GetInvoiceMovements), ctx, request)
+}
+
+// GetInvoicePDF mocks base method.
+func (m *MockInvoicesController) GetInvoicePDF(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicePDF", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicePDF indicates an expected call of GetInvoicePDF.
+func (mr *MockInvoicesControllerMockRecorder) GetInvoicePDF(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicePDF", reflect.TypeOf((*MockInvoicesController)(nil).GetInvoicePDF), ctx, request)
+}
+
+// GetInvoiceStatusHistory mocks base method.
+func (m *MockInvoicesController) GetInvoiceStatusHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceStatusHistory", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceStatusHistory indicates an expected call of GetInvoiceStatusHistory.
+func (mr *MockInvoicesControllerMockRecorder) GetInvoiceStatusHistory(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceStatusHistory", reflect.TypeOf((*MockInvoicesController)(nil).GetInvoiceStatusHistory), ctx, request)
+}
+
+// GetInvoices mocks base method.
+func (m *MockInvoicesController) GetInvoices(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoices", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoices indicates an expected call of GetInvoices.
+func (mr *MockInvoicesControllerMockRecorder) GetInvoices(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoices", reflect.TypeOf((*MockInvoicesController)(nil).GetInvoices), ctx, request)
+}
+
+// IssueCreditNote mocks base method.
+func (m *MockInvoicesController) IssueCreditNote(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueCreditNote", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueCreditNote indicates an expected call of IssueCreditNote.
+func (mr *MockInvoicesControllerMockRecorder) IssueCreditNote(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCreditNote", reflect.TypeOf((*MockInvoicesController)(nil).IssueCreditNote), ctx, request)
+}
+
+// ListInvoiceResources mocks base method.
+func (m *MockInvoicesController) ListInvoiceResources(ctx context.Context, accountId string) ([]mcp.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoiceResources", ctx, accountId)
+	ret0, _ := ret[0].([]mcp.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoiceResources indicates an expected call of ListInvoiceResources.
+func (mr *MockInvoicesControllerMockRecorder) ListInvoiceResources(ctx, accountId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoiceResources", reflect.TypeOf((*MockInvoicesController)(nil).ListInvoiceResources), ctx, accountId)
+}
+
+// MarkInvoiceUnpaid mocks base method.
+func (m *MockInvoicesController) MarkInvoiceUnpaid(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkInvoiceUnpaid", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkInvoiceUnpaid indicates an expected call of MarkInvoiceUnpaid.
+func (mr *MockInvoicesControllerMockRecorder) MarkInvoiceUnpaid(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInvoiceUnpaid", reflect.TypeOf((*MockInvoicesController)(nil).MarkInvoiceUnpaid), ctx, request)
+}
+
+// ReadInvoicePDFResource mocks base method.
+func (m *MockInvoicesController) ReadInvoicePDFResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInvoicePDFResource", ctx, request)
+	ret0, _ := ret[0].([]mcp.ResourceContents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInvoicePDFResource indicates an expected call of ReadInvoicePDFResource.
+func (mr *MockInvoicesControllerMockRecorder) ReadInvoicePDFResource(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInvoicePDFResource", reflect.TypeOf((*MockInvoicesController)(nil).ReadInvoicePDFResource), ctx, request)
+}
+
+// ReadInvoiceResource mocks base method.
+func (m *MockInvoicesController) ReadInvoiceResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadInvoiceResource", ctx, request)
+	ret0, _ := ret[0].([]mcp.ResourceContents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadInvoiceResource indicates an expected call of ReadInvoiceResource.
+func (mr *MockInvoicesControllerMockRecorder) ReadInvoiceResource(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadInvoiceResource", reflect.TypeOf((*MockInvoicesController)(nil).ReadInvoiceResource), ctx, request)
+}
+
+// SendInvoice mocks base method.
+func (m *MockInvoicesController) SendInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvoice", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendInvoice indicates an expected call of SendInvoice.
+func (mr *MockInvoicesControllerMockRecorder) SendInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvoice", reflect.TypeOf((*MockInvoicesController)(nil).SendInvoice), ctx, request)
+}
+
+// VoidInvoice mocks base method.
+func (m *MockInvoicesController) VoidInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidInvoice", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidInvoice indicates an expected call of VoidInvoice.
+func (mr *MockInvoicesControllerMockRecorder) VoidInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidInvoice", reflect.TypeOf((*MockInvoicesController)(nil).VoidInvoice), ctx, request)
+}
+
+// MockMovementsController is a mock of MovementsController interface.
+type MockMovementsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovementsControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockMovementsControllerMockRecorder is the mock recorder for MockMovementsController.
+type MockMovementsControllerMockRecorder struct {
+	mock *MockMovementsController
+}
+
+// NewMockMovementsController creates a new mock instance.
+func NewMockMovementsController(ctrl *gomock.Controller) *MockMovementsController {
+	mock := &MockMovementsController{ctrl: ctrl}
+	mock.recorder = &MockMovementsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovementsController) EXPECT() *MockMovementsControllerMockRecorder {
+	return m.recorder
+}
+
+// CancelMovement mocks base method.
+func (m *MockMovementsController) CancelMovement(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelMovement", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelMovement indicates an expected call of CancelMovement.
+func (mr *MockMovementsControllerMockRecorder) CancelMovement(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelMovement", reflect.TypeOf((*MockMovementsController)(nil).CancelMovement), ctx, request)
+}
+
+// CreateMovement mocks base method.
+func (m *MockMovementsController) CreateMovement(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMovement", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMovement indicates an expected call of CreateMovement.
+func (mr *MockMovementsControllerMockRecorder) CreateMovement(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMovement", reflect.TypeOf((*MockMovementsController)(nil).CreateMovement), ctx, request)
+}
+
+// GetMovement mocks base method.
+func (m *MockMovementsController) GetMovement(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovement", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovement indicates an expected call of GetMovement.
+func (mr *MockMovementsControllerMockRecorder) GetMovement(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovement", reflect.TypeOf((*MockMovementsController)(nil).GetMovement), ctx, request)
+}
+
+// ListMovementResources mocks base method.
+func (m *MockMovementsController) ListMovementResources(ctx context.Context, accountID string) ([]mcp.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovementResources", ctx, accountID)
+	ret0, _ := ret[0].([]mcp.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovementResources indicates an expected call of ListMovementResources.
+func (mr *MockMovementsControllerMockRecorder) ListMovementResources(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovementResources", reflect.TypeOf((*MockMovementsController)(nil).ListMovementResources), ctx, accountID)
+}
+
+// ReadMovementResource mocks base method.
+func (m *MockMovementsController) ReadMovementResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadMovementResource", ctx, request)
+	ret0, _ := ret[0].([]mcp.ResourceContents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadMovementResource indicates an expected call of ReadMovementResource.
+func (mr *MockMovementsControllerMockRecorder) ReadMovementResource(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMovementResource", reflect.TypeOf((*MockMovementsController)(nil).ReadMovementResource), ctx, request)
+}
+
+// SearchMovements mocks base method.
+func (m *MockMovementsController) SearchMovements(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMovements", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMovements indicates an expected call of SearchMovements.
+func (mr *MockMovementsControllerMockRecorder) SearchMovements(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMovements", reflect.TypeOf((*MockMovementsController)(nil).SearchMovements), ctx, request)
+}
+
+// UpdateMovementStatus mocks base method.
+func (m *MockMovementsController) UpdateMovementStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMovementStatus", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMovementStatus indicates an expected call of UpdateMovementStatus.
+func (mr *MockMovementsControllerMockRecorder) UpdateMovementStatus(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMovementStatus", reflect.TypeOf((*MockMovementsController)(nil).UpdateMovementStatus), ctx, request)
+}
+
+// MockBillingController is a mock of BillingController interface.
+type MockBillingController struct {
+	ctrl     *gomock.Controller
+	recorder *MockBillingControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockBillingControllerMockRecorder is the mock recorder for MockBillingController.
+type MockBillingControllerMockRecorder struct {
+	mock *MockBillingController
+}
+
+// NewMockBillingController creates a new mock instance.
+func NewMockBillingController(ctrl *gomock.Controller) *MockBillingController {
+	mock := &MockBillingController{ctrl: ctrl}
+	mock.recorder = &MockBillingControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBillingController) EXPECT() *MockBillingControllerMockRecorder {
+	return m.recorder
+}
+
+// RunBilling mocks base method.
+func (m *MockBillingController) RunBilling(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunBilling", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunBilling indicates an expected call of RunBilling.
+func (mr *MockBillingControllerMockRecorder) RunBilling(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunBilling", reflect.TypeOf((*MockBillingController)(nil).RunBilling), ctx, request)
+}
+
+// MockPaymentsController is a mock of PaymentsController interface.
+type MockPaymentsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentsControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentsControllerMockRecorder is the mock recorder for MockPaymentsController.
+type MockPaymentsControllerMockRecorder struct {
+	mock *MockPaymentsController
+}
+
+// NewMockPaymentsController creates a new mock instance.
+func NewMockPaymentsController(ctrl *gomock.Controller) *MockPaymentsController {
+	mock := &MockPaymentsController{ctrl: ctrl}
+	mock.recorder = &MockPaymentsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentsController) EXPECT() *MockPaymentsControllerMockRecorder {
+	return m.recorder
+}
+
+// GetInvoiceBalance mocks base method.
+func (m *MockPaymentsController) GetInvoiceBalance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceBalance", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceBalance indicates an expected call of GetInvoiceBalance.
+func (mr *MockPaymentsControllerMockRecorder) GetInvoiceBalance(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceBalance", reflect.TypeOf((*MockPaymentsController)(nil).GetInvoiceBalance), ctx, request)
+}
+
+// RegisterPayment mocks base method.
+func (m *MockPaymentsController) RegisterPayment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPayment", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterPayment indicates an expected call of RegisterPayment.
+func (mr *MockPaymentsControllerMockRecorder) RegisterPayment(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPayment", reflect.TypeOf((*MockPaymentsController)(nil).RegisterPayment), ctx, request)
+}
+
+// MockAccountsController is a mock of AccountsController interface.
+type MockAccountsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountsControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountsControllerMockRecorder is the mock recorder for MockAccountsController.
+type MockAccountsControllerMockRecorder struct {
+	mock *MockAccountsController
+}
+
+// NewMockAccountsController creates a new mock instance.
+func NewMockAccountsController(ctrl *gomock.Controller) *MockAccountsController {
+	mock := &MockAccountsController{ctrl: ctrl}
+	mock.recorder = &MockAccountsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountsController) EXPECT() *MockAccountsControllerMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountsController) GetAccount(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountsControllerMockRecorder) GetAccount(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountsController)(nil).GetAccount), ctx, request)
+}
+
+// GetAccountStatement mocks base method.
+func (m *MockAccountsController) GetAccountStatement(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStatement", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStatement indicates an expected call of GetAccountStatement.
+func (mr *MockAccountsControllerMockRecorder) GetAccountStatement(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatement", reflect.TypeOf((*MockAccountsController)(nil).GetAccountStatement), ctx, request)
+}
+
+// UpdateBillingProfile mocks base method.
+func (m *MockAccountsController) UpdateBillingProfile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBillingProfile", ctx, request)
+	ret0, _ := ret[0].(*mcp.CallToolResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBillingProfile indicates an expected call of UpdateBillingProfile.
+func (mr *MockAccountsControllerMockRecorder) UpdateBillingProfile(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBillingProfile", reflect.TypeOf((*MockAccountsController)(nil).UpdateBillingProfile), ctx, request)
+}
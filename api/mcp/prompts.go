@@ -0,0 +1,27 @@
+package mcp
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+var (
+	explainInvoicePrompt = mcp.NewPrompt(
+		"ExplainInvoice",
+		mcp.WithPromptDescription("Explain an invoice to its customer: what each line charges, the taxes applied and how the total is reached"),
+		mcp.WithArgument("accountId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID of the account the invoice belongs to")),
+		mcp.WithArgument("invoiceId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID of the invoice to explain")),
+	)
+
+	compareInvoicesPrompt = mcp.NewPrompt(
+		"CompareInvoices",
+		mcp.WithPromptDescription("Compare the invoices of an account issued in a month with the ones issued in the previous month"),
+		mcp.WithArgument("accountId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID of the account")),
+		mcp.WithArgument("period", mcp.RequiredArgument(), mcp.ArgumentDescription("The month to compare with the previous one in YYYY-MM format, e.g. 2025-03")),
+	)
+
+	disputeResponsePrompt = mcp.NewPrompt(
+		"DisputeResponse",
+		mcp.WithPromptDescription("Draft the answer to a customer disputing an invoice, grounded on its lines, taxes and status history"),
+		mcp.WithArgument("accountId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID of the account the invoice belongs to")),
+		mcp.WithArgument("invoiceId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID of the disputed invoice")),
+		mcp.WithArgument("complaint", mcp.ArgumentDescription("What the customer wrote, to answer it point by point")),
+	)
+)
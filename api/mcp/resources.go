@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const jsonMIMEType = "application/json"
+
+var (
+	invoiceResource = mcp.NewResourceTemplate(
+		"billing://accounts/{accountId}/invoices/{invoiceId}",
+		"Invoice",
+		mcp.WithTemplateDescription("An invoice of an account, including its lines and its tax breakdown per regime and rate"),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+
+	invoicePDFResource = mcp.NewResourceTemplate(
+		"billing://accounts/{accountId}/invoices/{invoiceId}/pdf",
+		"Invoice PDF",
+		mcp.WithTemplateDescription("The printable PDF document of an issued invoice of an account"),
+		mcp.WithTemplateMIMEType("application/pdf"),
+	)
+
+	movementResource = mcp.NewResourceTemplate(
+		"billing://movements/{movementId}",
+		"Movement",
+		mcp.WithTemplateDescription("A billing movement (charge or credit) of an account"),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+
+	accountResources = mcp.NewResourceTemplate(
+		"billing://accounts/{accountId}/resources",
+		"Account resources",
+		mcp.WithTemplateDescription("The list of the invoice and movement resources of an account, to pick the ones to attach to the context"),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+)
+
+// accountResourceList is the content of the account resources
+type accountResourceList struct {
+	AccountID string         `json:"account_id"`
+	Resources []mcp.Resource `json:"resources"`
+}
+
+// readAccountResources lists the resources of the invoices and the movements of an account
+func readAccountResources(server *MCPServer) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		accountId := resourceAccountId(request)
+		if accountId == "" {
+			return nil, errors.New("accountId is required")
+		}
+
+		invoices, err := server.InvoicesController.ListInvoiceResources(ctx, accountId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the invoices of account %s: %w", accountId, err)
+		}
+		movements, err := server.MovementsController.ListMovementResources(ctx, accountId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the movements of account %s: %w", accountId, err)
+		}
+
+		jsonData, err := json.Marshal(accountResourceList{AccountID: accountId, Resources: append(invoices, movements...)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the resources of account %s to JSON: %w", accountId, err)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, MIMEType: jsonMIMEType, Text: string(jsonData)},
+		}, nil
+	}
+}
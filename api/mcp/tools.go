@@ -2,30 +2,164 @@ package mcp
 
 import (
 	"github.com/mark3labs/mcp-go/mcp"
+	accounts "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	movements "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 )
 
 var (
 	invoiceTool = mcp.NewTool(
 		"GetInvoice",
-		mcp.WithDescription("Get an invoice by ID"),
+		mcp.WithDescription("Get an invoice by ID, including its tax breakdown per regime and rate"),
 		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account to retrieve the invoice for")),
 		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice to retrieve")),
 	)
 
 	invoicesTool = mcp.NewTool(
 		"GetInvoices",
-		mcp.WithDescription("Get all invoices for an account"),
+		mcp.WithDescription("Get a page of the invoices of an account. When there are more, the response has a nextCursor to pass as cursor for the next page"),
 		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account to retrieve invoices for")),
-		mcp.WithString("status", mcp.Description("The status of the invoices to retrieve")),
-		mcp.WithString("issueDateFrom", mcp.Description("The start date of the invoices to retrieve in RFC3339 format")),
-		mcp.WithString("issueDateTo", mcp.Description("The end date of the invoices to retrieve in RFC3339 format")),
+		mcp.WithArray("status",
+			mcp.Description("Only return invoices in any of these statuses"),
+			mcp.Items(map[string]any{"type": "string", "enum": []string{"DRAFT", "SENT", "OVERDUE", "UNPAID", "PAID", "VOID"}}),
+		),
+		mcp.WithString("issueDateFrom", mcp.Description("Only return invoices issued on or after this date, in RFC3339 or YYYY-MM-DD format")),
+		mcp.WithString("issueDateTo", mcp.Description("Only return invoices issued on or before this date, in RFC3339 or YYYY-MM-DD format")),
+		mcp.WithString("dueDateFrom", mcp.Description("Only return invoices due on or after this date, in RFC3339 or YYYY-MM-DD format")),
+		mcp.WithString("dueDateTo", mcp.Description("Only return invoices due on or before this date, in RFC3339 or YYYY-MM-DD format")),
+		mcp.WithString("totalFrom", mcp.Description("Only return invoices whose total with taxes is at least this decimal amount, e.g. 100.50")),
+		mcp.WithString("totalTo", mcp.Description("Only return invoices whose total with taxes is at most this decimal amount, e.g. 500")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of totalFrom and totalTo, defaults to EUR. Only invoices in this currency match an amount range")),
+		mcp.WithString("invoiceNumberPrefix", mcp.Description("Only return invoices whose number starts with this text, e.g. FAC-2025-")),
+		mcp.WithString("lineText", mcp.Description("Only return invoices with a line whose description contains this text, ignoring case. At least 3 characters")),
+		mcp.WithBoolean("hasOverdueBalance", mcp.Description("Only return issued invoices past their due date that are not fully paid")),
+		withPageSize(),
+		withCursor(),
+		withSortBy(invoices.InvoiceSortFields, invoices.InvoiceDefaultSort),
+		withSortOrder(invoices.InvoiceDefaultSort),
 	)
 
 	invoiceMovementsTool = mcp.NewTool(
 		"GetInvoiceMovements",
-		mcp.WithDescription("Get all movements/lines for a specific invoice"),
+		mcp.WithDescription("Get a page of the movements/lines of a specific invoice. When there are more, the response has a nextCursor to pass as cursor for the next page"),
 		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
 		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice to retrieve movements for")),
+		withPageSize(),
+		withCursor(),
+		withSortBy(invoices.LineSortFields, invoices.LineDefaultSort),
+		withSortOrder(invoices.LineDefaultSort),
+	)
+
+	createInvoiceTool = mcp.NewTool(
+		"CreateInvoice",
+		mcp.WithDescription("Create a new draft invoice for an account with a billing profile. Drafts are not numbered, the invoice gets the next number of its series when it is sent. The customer location is the fiscal address of the account"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account to invoice")),
+		mcp.WithString("issueDate", mcp.Required(), mcp.Description("The date of the draft in YYYY-MM-DD format. The invoice is dated on the day it is sent, keeping the days between the issue and due dates")),
+		mcp.WithString("dueDate", mcp.Required(), mcp.Description("The due date of the invoice in YYYY-MM-DD format")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of the invoice, defaults to EUR")),
+		mcp.WithString("customerCountry", mcp.Description("The ISO 3166 alpha-2 country of the customer, to check it against the fiscal address of the account. Customers outside Spain are invoiced exempt of Spanish taxes")),
+		mcp.WithString("customerPostalCode", mcp.Description("The postal code of the customer, to check it against the fiscal address of the account. Spanish ones tell the Canary Islands (IGIC), Ceuta and Melilla (IPSI) from the rest of Spain (IVA)")),
+	)
+
+	addInvoiceLineTool = mcp.NewTool(
+		"AddInvoiceLine",
+		mcp.WithDescription("Add a line to a draft invoice and update its totals"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the draft invoice")),
+		mcp.WithString("description", mcp.Required(), mcp.Description("The description of the line")),
+		mcp.WithString("amountWithoutTax", mcp.Required(), mcp.Description("The amount of the line before tax as a decimal string, e.g. 100.50")),
+		mcp.WithString("taxPercentage", mcp.Description("The tax percentage applied to the line as a decimal string, e.g. 21. Required unless a product category is given")),
+		mcp.WithString("productCategory", mcp.Enum(taxes.Categories()...), mcp.Description("The category of the product or service sold. When given, the tax is resolved from it, the customer location and the transaction date")),
+		mcp.WithString("operationType", mcp.Required(), mcp.Enum("CREDIT", "DEBIT"), mcp.Description("The operation type of the line")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of the amount, defaults to the invoice currency. Other currencies are converted with the rate of the transaction date")),
+		mcp.WithString("transactionDate", mcp.Description("The date of the transaction in YYYY-MM-DD format, defaults to today")),
+	)
+
+	sendInvoiceTool = mcp.NewTool(
+		"SendInvoice",
+		mcp.WithDescription("Issue a draft invoice on today's date, giving it the next number of its series and marking it as sent. The due date keeps its payment term"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice to send")),
+	)
+
+	voidInvoiceTool = mcp.NewTool(
+		"VoidInvoice",
+		mcp.WithDescription("Void an invoice that has not been paid"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice to void")),
+	)
+
+	markInvoiceUnpaidTool = mcp.NewTool(
+		"MarkInvoiceUnpaid",
+		mcp.WithDescription("Mark an issued invoice as unpaid, e.g. when its payment has been rejected or returned. A paid invoice cannot be marked as unpaid while its payments and credit notes still settle it"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice to mark as unpaid")),
+	)
+
+	invoiceStatusHistoryTool = mcp.NewTool(
+		"GetInvoiceStatusHistory",
+		mcp.WithDescription("Get every status transition of an invoice, oldest first"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice")),
+	)
+
+	issueCreditNoteTool = mcp.NewTool(
+		"IssueCreditNote",
+		mcp.WithDescription("Issue a credit note rectifying an issued invoice, in full or in part. The credit note is numbered in its own series and has negative lines cancelling the credited lines of the invoice"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice to rectify")),
+		mcp.WithString("reason", mcp.Required(), mcp.Description("Why the invoice is being rectified")),
+		mcp.WithString("issueDate", mcp.Description("The issue date of the credit note in YYYY-MM-DD format, defaults to today")),
+		mcp.WithArray("lines",
+			mcp.Description("The invoice lines to credit. Without an amount a line is credited for all that is left of it. Each line may be given once. When omitted every line of the invoice is credited in full"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"movementId": map[string]any{"type": "string", "description": "The ID of the movement of the invoice line"},
+					"amount":     map[string]any{"type": "string", "description": "The amount without tax to credit as a decimal string"},
+				},
+				"required": []string{"movementId"},
+			}),
+		),
+	)
+
+	exportInvoiceTool = mcp.NewTool(
+		"ExportInvoice",
+		mcp.WithDescription("Export an issued invoice or credit note as an electronic invoice XML document: an unsigned Facturae 3.2.2 document, the format required by Spanish public administrations, or a UBL 2.1 document following Peppol BIS Billing 3.0, checked against its mandatory business rules. The seller is the configured company"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the issued invoice to export")),
+		mcp.WithString("format", mcp.Enum("facturae", "ubl"), mcp.Description("The format of the document, defaults to facturae")),
+		mcp.WithString("buyerTaxId", mcp.Required(), mcp.Description("The NIF of the buyer, or its VAT number when it is established outside Spain")),
+		mcp.WithString("buyerName", mcp.Required(), mcp.Description("The legal name of the buyer, or the name followed by the surnames of a natural person")),
+		mcp.WithString("buyerAddress", mcp.Required(), mcp.Description("The street address of the buyer")),
+		mcp.WithString("buyerTown", mcp.Required(), mcp.Description("The town of the buyer")),
+		mcp.WithString("buyerProvince", mcp.Required(), mcp.Description("The province or region of the buyer")),
+		mcp.WithString("buyerPostalCode", mcp.Description("The postal code of the buyer, defaults to the customer postal code of the invoice")),
+		mcp.WithString("buyerCountry", mcp.Description("The ISO 3166 alpha-2 country of the buyer, defaults to the customer country of the invoice")),
+	)
+
+	invoicePDFTool = mcp.NewTool(
+		"GetInvoicePDF",
+		mcp.WithDescription("Render an issued invoice or credit note as a printable PDF branded for the configured company, returned as an embedded base64 resource. The same document is served over HTTP at /invoices/{invoiceId}/pdf, taking the buyer arguments as query parameters. Without a buyer the document identifies the customer by the account and location of the invoice"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the issued invoice to render")),
+		mcp.WithString("buyerTaxId", mcp.Description("The NIF of the buyer, or its VAT number when it is established outside Spain. When given, the buyer name, address, town and province are required too")),
+		mcp.WithString("buyerName", mcp.Description("The legal name of the buyer")),
+		mcp.WithString("buyerAddress", mcp.Description("The street address of the buyer")),
+		mcp.WithString("buyerTown", mcp.Description("The town of the buyer")),
+		mcp.WithString("buyerProvince", mcp.Description("The province or region of the buyer")),
+		mcp.WithString("buyerPostalCode", mcp.Description("The postal code of the buyer, defaults to the customer postal code of the invoice")),
+		mcp.WithString("buyerCountry", mcp.Description("The ISO 3166 alpha-2 country of the buyer, defaults to the customer country of the invoice")),
+	)
+
+	agingReportTool = mcp.NewTool(
+		"GetAgingReport",
+		mcp.WithDescription("Get the accounts receivable aging: the outstanding amount of the sent, overdue and unpaid invoices, less their payments and credit notes, grouped into buckets by the days passed since their due date (current, 1-30, 31-60, 61-90 and over 90 days), with totals per bucket, per status and per account, the accounts owing the most first. Without an account the report covers every account"),
+		mcp.WithString("accountId", mcp.Description("The ID of the account to report on, every account when omitted")),
+		mcp.WithString("asOf", mcp.Description("The day the invoices are aged on in YYYY-MM-DD format, defaults to today")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of the invoices to report on, defaults to EUR")),
 	)
 
 	movementTool = mcp.NewTool(
@@ -34,4 +168,134 @@ var (
 		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
 		mcp.WithString("movementId", mcp.Required(), mcp.Description("The ID of the movement to retrieve")),
 	)
+
+	createMovementTool = mcp.NewTool(
+		"CreateMovement",
+		mcp.WithDescription("Record a new pending movement (charge or adjustment) for an account. It is invoiced by the billing run of its period; use AddInvoiceLine to add a line to a draft invoice instead"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("amount", mcp.Required(), mcp.Description("The amount of the movement including tax as a decimal string, e.g. 100.50. It cannot be zero")),
+		mcp.WithString("taxPercentage", mcp.Description("The tax percentage included in the amount as a decimal string, e.g. 21. Only used when no product category is given")),
+		mcp.WithString("productCategory", mcp.Enum(taxes.Categories()...), mcp.Description("The category of the product or service sold. When given, the tax is resolved from it and the customer location when the movement is invoiced. Without a category or tax percentage the GENERAL category applies")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of the amount, defaults to EUR")),
+		mcp.WithString("movementType", mcp.Required(), mcp.Enum("CREDIT", "DEBIT"), mcp.Description("The type of the movement")),
+		mcp.WithString("description", mcp.Required(), mcp.Description("A description of the movement")),
+	)
+
+	searchMovementsTool = mcp.NewTool(
+		"SearchMovements",
+		mcp.WithDescription("Search movements by invoice and status, a page at a time. When there are more, the response has a nextCursor to pass as cursor for the next page"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Description("Only return movements of this invoice")),
+		mcp.WithString("status", mcp.Enum("PENDING", "INVOICED", "CANCELLED"), mcp.Description("Only return movements in this status")),
+		withPageSize(),
+		withCursor(),
+		withSortBy(movements.SortFields, movements.DefaultSort),
+		withSortOrder(movements.DefaultSort),
+	)
+
+	cancelMovementTool = mcp.NewTool(
+		"CancelMovement",
+		mcp.WithDescription("Cancel a pending movement so it is never invoiced"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("movementId", mcp.Required(), mcp.Description("The ID of the movement to cancel")),
+	)
+
+	updateMovementStatusTool = mcp.NewTool(
+		"UpdateMovementStatus",
+		mcp.WithDescription("Change the status of a movement. Only pending movements can change status, to CANCELLED; movements become INVOICED when they are invoiced"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("movementId", mcp.Required(), mcp.Description("The ID of the movement to update")),
+		mcp.WithString("status", mcp.Required(), mcp.Enum("CANCELLED"), mcp.Description("The new status of the movement")),
+	)
+
+	runBillingTool = mcp.NewTool(
+		"RunBilling",
+		mcp.WithDescription("Invoice the pending movements of a billing period, creating one draft invoice per account. Running a period again only invoices the movements still pending, adding them to the account's draft in the run or to a new draft once that invoice has been issued"),
+		mcp.WithString("periodStart", mcp.Required(), mcp.Description("The first day of the billing period in YYYY-MM-DD format")),
+		mcp.WithString("periodEnd", mcp.Required(), mcp.Description("The last day of the billing period in YYYY-MM-DD format")),
+	)
+
+	registerPaymentTool = mcp.NewTool(
+		"RegisterPayment",
+		mcp.WithDescription("Record a payment received from an account and allocate it to its invoices. Invoices left without outstanding balance are marked as paid, and any amount not allocated stays as a credit of the account"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account that paid")),
+		mcp.WithString("amount", mcp.Required(), mcp.Description("The amount received as a decimal string, e.g. 100.50")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of the amount, defaults to EUR")),
+		mcp.WithString("method", mcp.Required(), mcp.Enum("TRANSFER", "CARD", "DIRECT_DEBIT", "CASH"), mcp.Description("How the payment was made")),
+		mcp.WithString("paymentDate", mcp.Description("The date of the payment in YYYY-MM-DD format, defaults to today")),
+		mcp.WithString("reference", mcp.Description("An external reference of the payment, e.g. the bank transfer ID")),
+		mcp.WithArray("allocations",
+			mcp.Description("The invoices to apply the payment to, in order. Without an amount an allocation settles as much of the invoice as the payment still covers. When omitted the payment is applied to the open invoices of the account, oldest due date first"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"invoiceId": map[string]any{"type": "string", "description": "The ID of the invoice"},
+					"amount":    map[string]any{"type": "string", "description": "The amount to apply to the invoice as a decimal string"},
+				},
+				"required": []string{"invoiceId"},
+			}),
+		),
+	)
+
+	invoiceBalanceTool = mcp.NewTool(
+		"GetInvoiceBalance",
+		mcp.WithDescription("Get the amounts credited and paid and the outstanding balance of an invoice, with the payments allocated to it"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("invoiceId", mcp.Required(), mcp.Description("The ID of the invoice")),
+	)
+
+	getAccountTool = mcp.NewTool(
+		"GetAccount",
+		mcp.WithDescription("Get an account with its billing profile: legal name, tax ID, fiscal address, billing email, preferred language, payment method and billing cycle day"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+	)
+
+	updateBillingProfileTool = mcp.NewTool(
+		"UpdateBillingProfile",
+		mcp.WithDescription("Change the billing profile of an account, only the given fields are changed. An account without profile gets one, which needs the legal name, the tax ID, the fiscal address and the billing email. Invoices can only be created for accounts with a billing profile"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("legalName", mcp.Description("The legal name of the company, or the full name of the person")),
+		mcp.WithString("taxId", mcp.Description("The tax ID, validated with its check digits: a NIF or CIF for a fiscal address in Spain, the VAT number in other member states of the European Union")),
+		mcp.WithString("street", mcp.Description("The street and number of the fiscal address")),
+		mcp.WithString("postalCode", mcp.Description("The postal code of the fiscal address, required in Spain")),
+		mcp.WithString("town", mcp.Description("The town of the fiscal address")),
+		mcp.WithString("province", mcp.Description("The province of the fiscal address")),
+		mcp.WithString("country", mcp.Description("The ISO 3166 alpha-2 country of the fiscal address. It decides the taxes of the invoices of the account")),
+		mcp.WithString("billingEmail", mcp.Description("The email address invoices are sent to")),
+		mcp.WithString("preferredLanguage", mcp.Enum("es", "en"), mcp.Description("The language the account is addressed in, defaults to es")),
+		mcp.WithString("paymentMethod", mcp.Enum("TRANSFER", "CARD", "DIRECT_DEBIT"), mcp.Description("How the account pays its invoices, defaults to TRANSFER")),
+		mcp.WithNumber("billingCycleDay", mcp.Min(1), mcp.Max(accounts.MaxBillingCycleDay), mcp.Description("The day of the month the account is billed on, defaults to 1")),
+	)
+
+	accountStatementTool = mcp.NewTool(
+		"GetAccountStatement",
+		mcp.WithDescription("Get the statement of an account over a period: the balance it opened with, its issued invoices, credit notes and payments with the running balance after each of them, the balance it closed with and since when it has owed it. The movements not invoiced yet are listed apart with their total, as they are not owed yet. Only the documents in the statement currency are included"),
+		mcp.WithString("accountId", mcp.Required(), mcp.Description("The ID of the account")),
+		mcp.WithString("from", mcp.Description("The first day of the period in YYYY-MM-DD format, defaults to the first day of the month of the last one")),
+		mcp.WithString("to", mcp.Description("The last day of the period in YYYY-MM-DD format, defaults to today")),
+		mcp.WithString("currency", mcp.Description("The ISO 4217 currency of the statement, defaults to EUR")),
+		mcp.WithString("format", mcp.Enum("json", "csv"), mcp.Description("The format of the statement, defaults to json. The csv format has a row per entry between the opening and closing balances, followed by the pending movements")),
+	)
 )
+
+// The arguments of the tools returning a list a page at a time
+
+func withPageSize() mcp.ToolOption {
+	return mcp.WithNumber("pageSize",
+		mcp.Min(1), mcp.Max(pagination.MaxPageSize), mcp.DefaultNumber(pagination.DefaultPageSize),
+		mcp.Description("The number of items of the page. Larger sizes are lowered to the maximum"))
+}
+
+func withCursor() mcp.ToolOption {
+	return mcp.WithString("cursor", mcp.Description("The nextCursor of the previous page, to get the page after it. It keeps the sort of that page"))
+}
+
+func withSortBy(fields []string, defaultSort pagination.Sort) mcp.ToolOption {
+	return mcp.WithString("sortBy", mcp.Enum(fields...), mcp.DefaultString(defaultSort.Field), mcp.Description("The field to sort by"))
+}
+
+func withSortOrder(defaultSort pagination.Sort) mcp.ToolOption {
+	return mcp.WithString("sortOrder",
+		mcp.Enum(string(pagination.Ascending), string(pagination.Descending)), mcp.DefaultString(string(defaultSort.Direction)),
+		mcp.Description("The direction of the sort"))
+}
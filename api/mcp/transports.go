@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/labstack/echo/v4"
+	serverSdk "github.com/mark3labs/mcp-go/server"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+)
+
+// Transports the MCP server can be served through.
+const (
+	TransportSSE            = "sse"             // GET /sse and POST /message on the HTTP server
+	TransportStreamableHTTP = "streamable-http" // /mcp on the HTTP server
+	TransportStdio          = "stdio"           // Standard input and output of the process
+)
+
+var ErrUnknownTransport = errors.New("unknown MCP transport")
+
+// Transports are the transports the MCP server is served through, several of them can be enabled at once.
+type Transports []string
+
+// Validate checks there is at least one transport and all of them are known.
+func (t Transports) Validate() error {
+	if len(t) == 0 {
+		return fmt.Errorf("%w: no transport enabled", ErrUnknownTransport)
+	}
+	for _, transport := range t {
+		switch transport {
+		case TransportSSE, TransportStreamableHTTP, TransportStdio:
+		default:
+			return fmt.Errorf("%w: %q, expected %q, %q or %q", ErrUnknownTransport, transport, TransportSSE, TransportStreamableHTTP, TransportStdio)
+		}
+	}
+	return nil
+}
+
+// Has tells whether the given transport is enabled.
+func (t Transports) Has(transport string) bool {
+	for _, enabled := range t {
+		if enabled == transport {
+			return true
+		}
+	}
+	return false
+}
+
+// ServesHTTP tells whether any transport is served by the HTTP server.
+func (t Transports) ServesHTTP() bool {
+	return t.Has(TransportSSE) || t.Has(TransportStreamableHTTP)
+}
+
+// registerTransports serves the MCP server through the HTTP transports that are enabled. The guard authenticates every
+// request, and the tool, resource and prompt handlers get the principal in the context derived from the request's.
+func registerTransports(e *echo.Echo, s *serverSdk.MCPServer, transports Transports, guard *auth.Guard) {
+	if transports.Has(TransportSSE) {
+		sse := serverSdk.NewSSEServer(s,
+			serverSdk.WithHTTPServer(e.Server),
+			serverSdk.WithUseFullURLForMessageEndpoint(true),
+		)
+		e.GET("/sse", echo.WrapHandler(sse.SSEHandler()), guard.Middleware)
+		e.POST("/message", echo.WrapHandler(sse.MessageHandler()), guard.Middleware)
+	}
+
+	if transports.Has(TransportStreamableHTTP) {
+		streamable := serverSdk.NewStreamableHTTPServer(s)
+		e.Any("/mcp", echo.WrapHandler(streamable), guard.Middleware)
+	}
+}
+
+// ServeStdio serves the MCP server through the given input and output, the standard ones of the process when it is
+// launched by a client. It returns when the input is closed or the context is cancelled. The client is trusted as
+// the operator who launched the server, so the handlers get a stdio principal with the admin role.
+func ServeStdio(ctx context.Context, s *serverSdk.MCPServer, in io.Reader, out io.Writer) error {
+	stdio := serverSdk.NewStdioServer(s)
+	stdio.SetContextFunc(func(ctx context.Context) context.Context {
+		return auth.WithPrincipal(ctx, auth.Principal{Subject: "stdio", Method: auth.MethodStdio, Grant: auth.Grant{Role: auth.RoleAdmin}})
+	})
+	err := stdio.Listen(ctx, in, out)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("failed to serve MCP over stdio: %w", err)
+	}
+	return nil
+}
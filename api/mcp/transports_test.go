@@ -0,0 +1,197 @@
+package mcp_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	serverSdk "github.com/mark3labs/mcp-go/server"
+	mcpAPI "github.com/ricardogrande-masmovil/billing-mcp/api/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const invoiceJSON = `{"id":"0f8fad5b-d9cb-469f-a165-70867728950e","invoice_number":"FAC-2025-000001"}`
+
+// newServer sets up the MCP server with mocked controllers, answering the GetInvoice tool with invoiceJSON.
+func newServer(t *testing.T, transports mcpAPI.Transports) (*echo.Echo, *serverSdk.MCPServer) {
+	return newGuardedServer(t, transports, auth.NewGuard(zerolog.Nop()))
+}
+
+// newGuardedServer sets up the MCP server like newServer, with its endpoints protected by the guard.
+func newGuardedServer(t *testing.T, transports mcpAPI.Transports, guard *auth.Guard) (*echo.Echo, *serverSdk.MCPServer) {
+	ctrl := gomock.NewController(t)
+	invoices := mcpAPI.NewMockInvoicesController(ctrl)
+	invoices.EXPECT().GetInvoice(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", request.GetArguments()["invoiceId"])
+			return mcp.NewToolResultText(invoiceJSON), nil
+		}).AnyTimes()
+	// GetInvoices answers with the principal the handlers get in their context
+	invoices.EXPECT().GetInvoices(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok {
+				return mcp.NewToolResultText("anonymous"), nil
+			}
+			return mcp.NewToolResultText(principal.Method + ":" + principal.Subject), nil
+		}).AnyTimes()
+
+	e := echo.New()
+	s := serverSdk.NewMCPServer("billing-mcp", "test")
+	server := mcpAPI.NewMCPServer(
+		mcpAPI.NewMockHealthController(ctrl),
+		mcpAPI.NewMockInvoicesHTTPController(ctrl),
+		invoices,
+		mcpAPI.NewMockMovementsController(ctrl),
+		mcpAPI.NewMockBillingController(ctrl),
+		mcpAPI.NewMockPaymentsController(ctrl),
+		mcpAPI.NewMockAccountsController(ctrl),
+	)
+	require.NoError(t, mcpAPI.Setup(e, s, server, transports, guard))
+	return e, s
+}
+
+// checkClient initializes a client and checks it sees the registered tools, resources and prompts and can call a tool.
+func checkClient(t *testing.T, c *client.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, c.Start(ctx))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "billing-test", Version: "test"}
+	initResult, err := c.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "billing-mcp", initResult.ServerInfo.Name)
+
+	tools, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	names := make([]string, len(tools.Tools))
+	for i, tool := range tools.Tools {
+		names[i] = tool.Name
+	}
+	assert.Contains(t, names, "GetInvoice")
+	assert.Contains(t, names, "CreateMovement")
+	assert.Contains(t, names, "RunBilling")
+	assert.Contains(t, names, "RegisterPayment")
+
+	templates, err := c.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, templates.ResourceTemplates)
+
+	prompts, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, prompts.Prompts)
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = "GetInvoice"
+	callRequest.Params.Arguments = map[string]any{"accountId": "account_A", "invoiceId": "0f8fad5b-d9cb-469f-a165-70867728950e"}
+	result, err := c.CallTool(ctx, callRequest)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, invoiceJSON, result.Content[0].(mcp.TextContent).Text)
+}
+
+func TestTransports_SSE(t *testing.T) {
+	e, _ := newServer(t, mcpAPI.Transports{mcpAPI.TransportSSE})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	c, err := client.NewSSEMCPClient(server.URL + "/sse")
+	require.NoError(t, err)
+	defer c.Close()
+
+	checkClient(t, c)
+
+	response, err := http.Post(server.URL+"/mcp", "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode, "Streamable HTTP is not served unless enabled")
+}
+
+func TestTransports_StreamableHTTP(t *testing.T) {
+	e, _ := newServer(t, mcpAPI.Transports{mcpAPI.TransportStreamableHTTP})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	c, err := client.NewStreamableHttpClient(server.URL + "/mcp")
+	require.NoError(t, err)
+	defer c.Close()
+
+	checkClient(t, c)
+
+	response, err := http.Get(server.URL + "/sse")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode, "SSE is not served unless enabled")
+}
+
+func TestTransports_Stdio(t *testing.T) {
+	_, s := newServer(t, mcpAPI.Transports{mcpAPI.TransportStdio})
+
+	// The client writes to the input of the server and reads its output
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() {
+		served <- mcpAPI.ServeStdio(ctx, s, serverIn, serverOut)
+	}()
+
+	c := client.NewClient(transport.NewIO(clientIn, clientOut, io.NopCloser(strings.NewReader(""))))
+	checkClient(t, c)
+
+	// Closing the input of the server stops it, like a client process closing its stdin
+	require.NoError(t, clientOut.Close())
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeStdio did not return after its input was closed")
+	}
+}
+
+func TestTransports_Several(t *testing.T) {
+	e, _ := newServer(t, mcpAPI.Transports{mcpAPI.TransportSSE, mcpAPI.TransportStreamableHTTP, mcpAPI.TransportStdio})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	sse, err := client.NewSSEMCPClient(server.URL + "/sse")
+	require.NoError(t, err)
+	defer sse.Close()
+	checkClient(t, sse)
+
+	streamable, err := client.NewStreamableHttpClient(server.URL + "/mcp")
+	require.NoError(t, err)
+	defer streamable.Close()
+	checkClient(t, streamable)
+}
+
+func TestSetup_InvalidTransports(t *testing.T) {
+	tests := []struct {
+		name       string
+		transports mcpAPI.Transports
+	}{
+		{"unknown transport", mcpAPI.Transports{mcpAPI.TransportSSE, "websocket"}},
+		{"no transport", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mcpAPI.Setup(echo.New(), serverSdk.NewMCPServer("billing-mcp", "test"), &mcpAPI.MCPServer{}, tt.transports, auth.NewGuard(zerolog.Nop()))
+			assert.ErrorIs(t, err, mcpAPI.ErrUnknownTransport)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/cmd/di"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/ports"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+const agingReportCommand = "aging-report"
+
+// parseAgingReportArgs parses the arguments of the aging-report subcommand, e.g.
+//
+//	billing-mcp aging-report -as-of 2025-06-30 -account account_mock_A
+func parseAgingReportArgs(args []string, output io.Writer) (model.AgingQuery, error) {
+	flags := flag.NewFlagSet(agingReportCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+	account := flags.String("account", "", "ID of the account to report on, every account when omitted")
+	asOf := flags.String("as-of", "", "day the invoices are aged on (YYYY-MM-DD), defaults to today")
+	currency := flags.String("currency", string(money.DefaultCurrency), "ISO 4217 currency of the invoices to report on")
+	if err := flags.Parse(args); err != nil {
+		return model.AgingQuery{}, err
+	}
+
+	query := model.AgingQuery{AccountID: *account, AsOf: time.Now().UTC().Truncate(24 * time.Hour)}
+	if *asOf != "" {
+		date, err := time.Parse(time.DateOnly, *asOf)
+		if err != nil {
+			return model.AgingQuery{}, fmt.Errorf("invalid -as-of date %q, expected YYYY-MM-DD", *asOf)
+		}
+		query.AsOf = date
+	}
+	parsed, err := money.ParseCurrency(*currency)
+	if err != nil {
+		return model.AgingQuery{}, fmt.Errorf("invalid -currency %q: %w", *currency, err)
+	}
+	query.Currency = parsed
+	return query, nil
+}
+
+// AgingReport writes the accounts receivable aging of the query as JSON, the same report the GetAgingReport tool returns.
+func AgingReport(ctx context.Context, app *di.App, query model.AgingQuery, output io.Writer) error {
+	report, err := app.InvoiceService.GetAgingReport(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to build aging report: %w", err)
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(ports.NewConverter().ConvertAgingReport(report)); err != nil {
+		return fmt.Errorf("failed to write aging report: %w", err)
+	}
+	return nil
+}
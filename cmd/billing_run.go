@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/cmd/di"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	billingPorts "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/ports"
+)
+
+const billingRunCommand = "billing-run"
+
+// parseBillingRunArgs parses the arguments of the billing-run subcommand, e.g.
+//
+//	billing-mcp billing-run -from 2025-01-01 -to 2025-01-31
+func parseBillingRunArgs(args []string, output io.Writer) (model.Period, error) {
+	flags := flag.NewFlagSet(billingRunCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+	from := flags.String("from", "", "first day of the billing period (YYYY-MM-DD)")
+	to := flags.String("to", "", "last day of the billing period (YYYY-MM-DD)")
+	if err := flags.Parse(args); err != nil {
+		return model.Period{}, err
+	}
+
+	start, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		return model.Period{}, fmt.Errorf("invalid -from date %q, expected YYYY-MM-DD", *from)
+	}
+	end, err := time.Parse(time.DateOnly, *to)
+	if err != nil {
+		return model.Period{}, fmt.Errorf("invalid -to date %q, expected YYYY-MM-DD", *to)
+	}
+	return model.NewPeriod(start, end)
+}
+
+// RunBilling bills the period and writes the outcome of the run as JSON.
+// It fails when any account could not be billed, so schedulers can retry the period.
+func RunBilling(ctx context.Context, app *di.App, period model.Period, output io.Writer) error {
+	result, err := app.BillingService.Run(ctx, period)
+	if err != nil {
+		return fmt.Errorf("billing run failed: %w", err)
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(billingPorts.NewConverter().ConvertRunResult(result)); err != nil {
+		return fmt.Errorf("failed to write billing run result: %w", err)
+	}
+
+	if result.Run.Status == model.RunStatusFailed {
+		return fmt.Errorf("billing run %s finished with failed accounts", result.Run.ID)
+	}
+	return nil
+}
@@ -4,6 +4,9 @@
 package di
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	mcpServerSdk "github.com/mark3labs/mcp-go/server"
@@ -11,7 +14,19 @@ import (
 	"github.com/ricardogrande-masmovil/billing-mcp/api"
 	mcpAPI "github.com/ricardogrande-masmovil/billing-mcp/api/mcp"
 	"github.com/ricardogrande-masmovil/billing-mcp/config"
+	accountsDomain "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain"
+	accountsPersistence "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/infrastructure/persistence"
+	accountsSQL "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/infrastructure/persistence/sql"
+	accountsPorts "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/ports"
+	billingDomain "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain"
+	billingPersistence "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/infrastructure/persistence"
+	billingSQL "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/infrastructure/persistence/sql"
+	billingPorts "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/ports"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	invoiceModel "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/facturae"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/pdf"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/ubl"
 	invoicePersistence "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/persistence"
 	invoiceSQL "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/persistence/sql"
 	invoicePorts "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/ports"
@@ -19,7 +34,16 @@ import (
 	movementsPersistence "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence"
 	movementsSQL "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence/sql"
 	movementsPorts "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/ports"
+	paymentsDomain "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain"
+	paymentsPersistence "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/infrastructure/persistence"
+	paymentsSQL "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/infrastructure/persistence/sql"
+	paymentsPorts "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/ports"
+	taxesDomain "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain"
+	taxesPersistence "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/infrastructure/persistence"
+	taxesSQL "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/infrastructure/persistence/sql"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
 	pkgPersistence "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/scheduler"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -35,8 +59,16 @@ type App struct {
 	MCPServerAPI        *mcpAPI.MCPServer // Added field for the API specific MCP server
 	HealthController    mcpAPI.HealthController
 	InvoicesController  mcpAPI.InvoicesController
+	InvoiceService      invoicePorts.InvoiceService
+	DocumentExporters   invoicePorts.DocumentExporters
 	MovementsController mcpAPI.MovementsController
 	MovementsService    movementsDomain.MovementService
+	BillingController   mcpAPI.BillingController
+	BillingService      billingDomain.Service
+	PaymentsController  mcpAPI.PaymentsController
+	AccountsController  mcpAPI.AccountsController
+	Scheduler           *scheduler.Scheduler
+	AuthGuard           *auth.Guard
 }
 
 // --- Core Providers ---
@@ -82,14 +114,64 @@ func ProvideMCP(cfg *config.Config) *mcpServerSdk.MCPServer {
 }
 
 // Provider for the API specific MCPServer
-func ProvideMCPServerAPI(healthController mcpAPI.HealthController, invoicesController mcpAPI.InvoicesController, movementsController mcpAPI.MovementsController) *mcpAPI.MCPServer {
-	return mcpAPI.NewMCPServer(healthController, invoicesController, movementsController)
+func ProvideMCPServerAPI(healthController mcpAPI.HealthController, invoicesHTTPController mcpAPI.InvoicesHTTPController, invoicesController mcpAPI.InvoicesController, movementsController mcpAPI.MovementsController, billingController mcpAPI.BillingController, paymentsController mcpAPI.PaymentsController, accountsController mcpAPI.AccountsController) *mcpAPI.MCPServer {
+	return mcpAPI.NewMCPServer(healthController, invoicesHTTPController, invoicesController, movementsController, billingController, paymentsController, accountsController)
+}
+
+// ProvideAuthGuard builds the authentication of the endpoints from the methods configured in the auth section.
+func ProvideAuthGuard(cfg *config.Config, logger zerolog.Logger) (*auth.Guard, error) {
+	var authenticators []auth.Authenticator
+
+	if len(cfg.Auth.APIKeys) > 0 {
+		keys := make([]auth.APIKey, len(cfg.Auth.APIKeys))
+		for i, apiKey := range cfg.Auth.APIKeys {
+			keys[i] = auth.APIKey{
+				Name:  apiKey.Name,
+				Key:   apiKey.Key,
+				Grant: auth.Grant{Role: apiKey.Role, Accounts: apiKey.Accounts},
+			}
+		}
+		apiKeys, err := auth.NewAPIKeys(keys)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API keys: %w", err)
+		}
+		authenticators = append(authenticators, apiKeys)
+	}
+
+	if cfg.Auth.HMAC.Secret != "" {
+		hmacTokens, err := auth.NewHMACTokens(cfg.Auth.HMAC.Secret, cfg.Auth.HMAC.Issuer, cfg.Auth.HMAC.Audience)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HMAC authentication: %w", err)
+		}
+		authenticators = append(authenticators, hmacTokens)
+	}
+
+	if cfg.Auth.OAuth.Resource != "" {
+		oauthTokens, err := auth.LoadOAuthTokens(auth.ResourceServer{
+			Resource:             cfg.Auth.OAuth.Resource,
+			AuthorizationServers: cfg.Auth.OAuth.AuthorizationServers,
+			Scopes:               cfg.Auth.OAuth.Scopes,
+		}, cfg.Auth.OAuth.JWKSPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OAuth authentication: %w", err)
+		}
+		authenticators = append(authenticators, oauthTokens)
+	}
+
+	if len(authenticators) == 0 {
+		logger.Warn().Msg("No authentication method configured, the MCP and HTTP endpoints are open to anyone reaching the server")
+	}
+	return auth.NewGuard(logger, authenticators...), nil
 }
 
 func ProvideHealthController() mcpAPI.HealthController {
 	return api.NewHealthController()
 }
 
+func ProvideTransactor(db *gorm.DB) pkgPersistence.Transactor {
+	return pkgPersistence.NewTransactor(db)
+}
+
 // --- Invoice Feature Providers ---
 func ProvideInvoiceSqlClient(db *gorm.DB, cfg *config.Config) invoiceSQL.InvoiceSqlClient {
 	return invoiceSQL.NewInvoiceSqlClient(db, cfg.Database.MaxRetries)
@@ -103,16 +185,124 @@ func ProvideInvoicePersistenceRepository(client invoiceSQL.InvoiceSqlClient, con
 	return invoicePersistence.NewRepository(client, converter)
 }
 
-func ProvideInvoiceDomainService(repo domain.Repository) domain.Service {
-	return domain.NewService(repo)
+func ProvideInvoiceTransactor(transactor pkgPersistence.Transactor) domain.Transactor {
+	return transactor
+}
+
+func ProvideInvoiceNumbering(sequences domain.SequenceRepository, cfg *config.Config) (domain.Numbering, error) {
+	invoices, err := numberingSeries(cfg.Numbering.Invoices)
+	if err != nil {
+		return domain.Numbering{}, fmt.Errorf("invalid invoice numbering series: %w", err)
+	}
+	creditNotes, err := numberingSeries(cfg.Numbering.CreditNotes)
+	if err != nil {
+		return domain.Numbering{}, fmt.Errorf("invalid credit note numbering series: %w", err)
+	}
+	if invoices.Prefix == creditNotes.Prefix {
+		return domain.Numbering{}, fmt.Errorf("invoices and credit notes must be numbered in different series, both use %q", invoices.Prefix)
+	}
+	return domain.NewNumbering(sequences, invoices, creditNotes), nil
+}
+
+func numberingSeries(cfg config.SeriesConfig) (invoiceModel.NumberingSeries, error) {
+	reset, err := invoiceModel.ParseSeriesReset(cfg.Reset)
+	if err != nil {
+		return invoiceModel.NumberingSeries{}, err
+	}
+	return invoiceModel.NewNumberingSeries(cfg.Prefix, reset, cfg.Padding)
+}
+
+func ProvideInvoiceDomainService(repo domain.Repository, rates domain.ExchangeRateRepository, transactor domain.Transactor, numbering domain.Numbering, taxes domain.TaxResolver, accounts domain.AccountRegistry) domain.Service {
+	return domain.NewService(repo, rates, transactor, numbering, taxes, accounts)
+}
+
+func ProvideCurrencyConverter(rates domain.ExchangeRateRepository) domain.CurrencyConverter {
+	return domain.NewCurrencyConverter(rates)
 }
 
 func ProvideInvoicePortsService(domainService domain.Service) invoicePorts.InvoiceService {
 	return domainService
 }
 
-func ProvideInvoicesController(service invoicePorts.InvoiceService) mcpAPI.InvoicesController {
-	return invoicePorts.NewController(service)
+// ProvideDocumentExporters renders invoices issued by the configured company in every supported format.
+// Without a company, the server still starts and exports fail until it is configured.
+func ProvideDocumentExporters(cfg *config.Config, logger zerolog.Logger) invoicePorts.DocumentExporters {
+	seller, err := companyParty(cfg)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Company is not configured, invoices cannot be exported")
+	}
+	return invoicePorts.DocumentExporters{
+		invoicePorts.ExportFormatFacturae: facturae.NewExporter(seller),
+		invoicePorts.ExportFormatUBL:      ubl.NewExporter(seller),
+	}
+}
+
+// ProvideInvoiceRenderer renders the PDF invoices of the configured company with its branding.
+// A branding that cannot be applied is replaced by the default one rather than failing to start.
+func ProvideInvoiceRenderer(cfg *config.Config, logger zerolog.Logger) invoicePorts.DocumentRenderer {
+	seller, _ := companyParty(cfg)
+	brandingConfig := cfg.Company.Branding
+	branding := pdf.Branding{
+		Template:    brandingConfig.Template,
+		Language:    brandingConfig.Language,
+		AccentColor: brandingConfig.AccentColor,
+		Footer:      brandingConfig.Footer,
+	}
+	if brandingConfig.LogoPath != "" {
+		logo, err := os.ReadFile(brandingConfig.LogoPath)
+		if err != nil {
+			logger.Warn().Err(err).Str("logo_path", brandingConfig.LogoPath).Msg("Failed to read company logo, PDF invoices are rendered without it")
+		}
+		branding.Logo = logo
+	}
+
+	renderer, err := pdf.NewRenderer(seller, branding)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid company branding, PDF invoices are rendered with the default one")
+		renderer, _ = pdf.NewRenderer(seller, pdf.Branding{})
+	}
+	return renderer
+}
+
+// companyParty is the configured company as the seller of the invoices.
+func companyParty(cfg *config.Config) (invoiceModel.Party, error) {
+	address := cfg.Company.Address
+	return invoiceModel.NewParty(cfg.Company.TaxID, cfg.Company.LegalName, invoiceModel.Address{
+		Street:     address.Street,
+		PostalCode: address.PostalCode,
+		Town:       address.Town,
+		Province:   address.Province,
+		Country:    address.Country,
+	})
+}
+
+func ProvideInvoicesController(service invoicePorts.InvoiceService, exporters invoicePorts.DocumentExporters, renderer invoicePorts.DocumentRenderer) mcpAPI.InvoicesController {
+	return invoicePorts.NewController(service, exporters, renderer)
+}
+
+func ProvideInvoicesHTTPController(service invoicePorts.InvoiceService, renderer invoicePorts.DocumentRenderer) mcpAPI.InvoicesHTTPController {
+	return invoicePorts.NewHTTPController(service, renderer)
+}
+
+// --- Tax Feature Providers ---
+func ProvideTaxSqlClient(db *gorm.DB) taxesSQL.TaxSqlClient {
+	return taxesSQL.NewTaxSqlClient(db)
+}
+
+func ProvideTaxSqlConverter() taxesSQL.TaxSqlConverter {
+	return taxesSQL.NewTaxSqlConverter()
+}
+
+func ProvideTaxRepository(client taxesSQL.TaxSqlClient, converter taxesSQL.TaxSqlConverter) taxesPersistence.Repository {
+	return taxesPersistence.NewRepository(client, converter)
+}
+
+func ProvideTaxService(repo taxesDomain.Repository) taxesDomain.Service {
+	return taxesDomain.NewService(repo)
+}
+
+func ProvideTaxResolver(service taxesDomain.Service) domain.TaxResolver {
+	return service
 }
 
 // --- Movement Feature Providers ---
@@ -135,6 +325,98 @@ func ProvideMovementService(logger zerolog.Logger, repo movementsDomain.Movement
 	return *movementsDomain.NewMovementService(logger, repo)
 }
 
+// --- Billing Feature Providers ---
+func ProvideBillingSqlClient(db *gorm.DB) billingSQL.BillingSqlClient {
+	return billingSQL.NewBillingSqlClient(db)
+}
+
+func ProvideBillingSqlConverter() billingSQL.BillingSqlConverter {
+	return billingSQL.NewBillingSqlConverter()
+}
+
+func ProvideBillingRepository(client billingSQL.BillingSqlClient, converter billingSQL.BillingSqlConverter) billingPersistence.Repository {
+	return billingPersistence.NewRepository(client, converter)
+}
+
+func ProvideBillingTransactor(transactor pkgPersistence.Transactor) billingDomain.Transactor {
+	return transactor
+}
+
+func ProvideBillingService(repo billingDomain.Repository, transactor billingDomain.Transactor, converter domain.CurrencyConverter, taxes domain.TaxResolver, accounts domain.AccountRegistry, cfg *config.Config) billingDomain.Service {
+	return billingDomain.NewService(repo, transactor, converter, taxes, accounts, billingDomain.Settings{
+		PaymentTermDays: cfg.Billing.PaymentTermDays,
+	})
+}
+
+func ProvideBillingController(service billingDomain.Service) mcpAPI.BillingController {
+	return billingPorts.NewController(service)
+}
+
+// --- Payment Feature Providers ---
+func ProvidePaymentSqlClient(db *gorm.DB) paymentsSQL.PaymentSqlClient {
+	return paymentsSQL.NewPaymentSqlClient(db)
+}
+
+func ProvidePaymentSqlConverter() paymentsSQL.PaymentSqlConverter {
+	return paymentsSQL.NewPaymentSqlConverter()
+}
+
+func ProvidePaymentRepository(client paymentsSQL.PaymentSqlClient, converter paymentsSQL.PaymentSqlConverter) paymentsPersistence.Repository {
+	return paymentsPersistence.NewRepository(client, converter)
+}
+
+func ProvidePaymentTransactor(transactor pkgPersistence.Transactor) paymentsDomain.Transactor {
+	return transactor
+}
+
+func ProvideInvoiceSettler(invoiceService domain.Service) paymentsDomain.InvoiceSettler {
+	return invoiceService
+}
+
+func ProvidePaymentService(repo paymentsDomain.Repository, transactor paymentsDomain.Transactor, settler paymentsDomain.InvoiceSettler) paymentsDomain.Service {
+	return paymentsDomain.NewService(repo, transactor, settler)
+}
+
+func ProvidePaymentsController(service paymentsDomain.Service) mcpAPI.PaymentsController {
+	return paymentsPorts.NewController(service)
+}
+
+// --- Account Feature Providers ---
+func ProvideAccountSqlClient(db *gorm.DB) accountsSQL.AccountSqlClient {
+	return accountsSQL.NewAccountSqlClient(db)
+}
+
+func ProvideAccountSqlConverter() accountsSQL.AccountSqlConverter {
+	return accountsSQL.NewAccountSqlConverter()
+}
+
+func ProvideAccountRepository(client accountsSQL.AccountSqlClient, converter accountsSQL.AccountSqlConverter) accountsPersistence.Repository {
+	return accountsPersistence.NewRepository(client, converter)
+}
+
+func ProvideAccountTransactor(transactor pkgPersistence.Transactor) accountsDomain.Transactor {
+	return transactor
+}
+
+func ProvideAccountService(repo accountsDomain.Repository, transactor accountsDomain.Transactor) accountsDomain.Service {
+	return accountsDomain.NewService(repo, transactor)
+}
+
+func ProvideAccountRegistry(service accountsDomain.Service) domain.AccountRegistry {
+	return service
+}
+
+func ProvideAccountsController(service accountsDomain.Service) mcpAPI.AccountsController {
+	return accountsPorts.NewController(service)
+}
+
+// --- Scheduler Providers ---
+func ProvideScheduler(db *gorm.DB, logger zerolog.Logger, invoiceService invoicePorts.InvoiceService, cfg *config.Config) *scheduler.Scheduler {
+	return scheduler.NewScheduler(scheduler.NewPostgresGuard(db), logger,
+		invoicePorts.NewMarkOverdueInvoicesJob(invoiceService, cfg.Scheduler.OverdueInterval),
+	)
+}
+
 // --- Provider Sets ---
 var CoreSet = wire.NewSet(
 	ProvideConfig,
@@ -144,6 +426,8 @@ var CoreSet = wire.NewSet(
 	ProvideMCP,
 	ProvideMCPServerAPI,
 	ProvideHealthController,
+	ProvideTransactor,
+	ProvideAuthGuard,
 )
 
 var InvoiceFeatureSet = wire.NewSet(
@@ -151,9 +435,26 @@ var InvoiceFeatureSet = wire.NewSet(
 	ProvideInvoiceSqlConverter,
 	ProvideInvoicePersistenceRepository,
 	wire.Bind(new(domain.Repository), new(invoicePersistence.Repository)),
+	wire.Bind(new(domain.ExchangeRateRepository), new(invoicePersistence.Repository)),
+	wire.Bind(new(domain.SequenceRepository), new(invoicePersistence.Repository)),
+	ProvideCurrencyConverter,
+	ProvideInvoiceTransactor,
+	ProvideInvoiceNumbering,
 	ProvideInvoiceDomainService,
 	wire.Bind(new(invoicePorts.InvoiceService), new(domain.Service)),
+	ProvideDocumentExporters,
+	ProvideInvoiceRenderer,
 	ProvideInvoicesController,
+	ProvideInvoicesHTTPController,
+)
+
+var TaxFeatureSet = wire.NewSet(
+	ProvideTaxSqlClient,
+	ProvideTaxSqlConverter,
+	ProvideTaxRepository,
+	wire.Bind(new(taxesDomain.Repository), new(taxesPersistence.Repository)),
+	ProvideTaxService,
+	ProvideTaxResolver,
 )
 
 var MovementFeatureSet = wire.NewSet(
@@ -164,10 +465,47 @@ var MovementFeatureSet = wire.NewSet(
 	ProvideMovementsController,
 )
 
+var BillingFeatureSet = wire.NewSet(
+	ProvideBillingSqlClient,
+	ProvideBillingSqlConverter,
+	ProvideBillingRepository,
+	wire.Bind(new(billingDomain.Repository), new(billingPersistence.Repository)),
+	ProvideBillingTransactor,
+	ProvideBillingService,
+	ProvideBillingController,
+)
+
+var PaymentFeatureSet = wire.NewSet(
+	ProvidePaymentSqlClient,
+	ProvidePaymentSqlConverter,
+	ProvidePaymentRepository,
+	wire.Bind(new(paymentsDomain.Repository), new(paymentsPersistence.Repository)),
+	ProvidePaymentTransactor,
+	ProvideInvoiceSettler,
+	ProvidePaymentService,
+	ProvidePaymentsController,
+)
+
+var AccountFeatureSet = wire.NewSet(
+	ProvideAccountSqlClient,
+	ProvideAccountSqlConverter,
+	ProvideAccountRepository,
+	wire.Bind(new(accountsDomain.Repository), new(accountsPersistence.Repository)),
+	ProvideAccountTransactor,
+	ProvideAccountService,
+	ProvideAccountRegistry,
+	ProvideAccountsController,
+)
+
 var AppSet = wire.NewSet(
 	CoreSet,
 	InvoiceFeatureSet,
+	TaxFeatureSet,
 	MovementFeatureSet,
+	BillingFeatureSet,
+	PaymentFeatureSet,
+	AccountFeatureSet,
+	ProvideScheduler,
 	wire.Struct(new(App), "*"),
 )
 
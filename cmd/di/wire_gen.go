@@ -7,24 +7,47 @@
 package di
 
 import (
+	"fmt"
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/ricardogrande-masmovil/billing-mcp/api"
 	"github.com/ricardogrande-masmovil/billing-mcp/api/mcp"
 	"github.com/ricardogrande-masmovil/billing-mcp/config"
-	domain2 "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	domain6 "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain"
+	persistence7 "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/infrastructure/persistence"
+	sql6 "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/infrastructure/persistence/sql"
+	ports5 "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/ports"
+	domain2 "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain"
+	persistence5 "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/infrastructure/persistence"
+	sql4 "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/infrastructure/persistence/sql"
+	ports3 "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/ports"
+	domain3 "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/facturae"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/pdf"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/ubl"
 	persistence2 "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/persistence"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/persistence/sql"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/ports"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain"
-	persistence3 "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence"
-	sql2 "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence/sql"
+	persistence4 "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence"
+	sql3 "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence/sql"
 	ports2 "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/ports"
+	domain5 "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain"
+	persistence6 "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/infrastructure/persistence"
+	sql5 "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/infrastructure/persistence/sql"
+	ports4 "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/ports"
+	domain4 "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain"
+	persistence3 "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/infrastructure/persistence"
+	sql2 "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/infrastructure/persistence/sql"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
 	"github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/scheduler"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"os"
 )
 
 // Injectors from wire.go:
@@ -45,14 +68,56 @@ func InitializeApp(configFile string) (*App, func(), error) {
 	invoiceSqlClient := ProvideInvoiceSqlClient(db, config)
 	invoiceSqlConverter := ProvideInvoiceSqlConverter()
 	repository := ProvideInvoicePersistenceRepository(invoiceSqlClient, invoiceSqlConverter)
-	service := ProvideInvoiceDomainService(repository)
-	invoicesController := ProvideInvoicesController(service)
+	transactor := ProvideTransactor(db)
+	domainTransactor := ProvideInvoiceTransactor(transactor)
+	numbering, err := ProvideInvoiceNumbering(repository, config)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	taxSqlClient := ProvideTaxSqlClient(db)
+	taxSqlConverter := ProvideTaxSqlConverter()
+	persistenceRepository := ProvideTaxRepository(taxSqlClient, taxSqlConverter)
+	service := ProvideTaxService(persistenceRepository)
+	taxResolver := ProvideTaxResolver(service)
+	accountSqlClient := ProvideAccountSqlClient(db)
+	accountSqlConverter := ProvideAccountSqlConverter()
+	repository2 := ProvideAccountRepository(accountSqlClient, accountSqlConverter)
+	transactor2 := ProvideAccountTransactor(transactor)
+	domainService := ProvideAccountService(repository2, transactor2)
+	accountRegistry := ProvideAccountRegistry(domainService)
+	service2 := ProvideInvoiceDomainService(repository, repository, domainTransactor, numbering, taxResolver, accountRegistry)
+	documentRenderer := ProvideInvoiceRenderer(config, logger)
+	invoicesHTTPController := ProvideInvoicesHTTPController(service2, documentRenderer)
+	documentExporters := ProvideDocumentExporters(config, logger)
+	invoicesController := ProvideInvoicesController(service2, documentExporters, documentRenderer)
 	movementSqlClient := ProvideMovementSqlClient(db, logger)
 	movementConverter := ProvideMovementConverter()
 	movementRepository := ProvideMovementRepository(movementSqlClient, movementConverter, logger)
 	movementService := ProvideMovementService(logger, movementRepository)
 	movementsController := ProvideMovementsController(movementService, logger)
-	mcpMCPServer := ProvideMCPServerAPI(healthController, invoicesController, movementsController)
+	billingSqlClient := ProvideBillingSqlClient(db)
+	billingSqlConverter := ProvideBillingSqlConverter()
+	repository3 := ProvideBillingRepository(billingSqlClient, billingSqlConverter)
+	transactor3 := ProvideBillingTransactor(transactor)
+	currencyConverter := ProvideCurrencyConverter(repository)
+	service3 := ProvideBillingService(repository3, transactor3, currencyConverter, taxResolver, accountRegistry, config)
+	billingController := ProvideBillingController(service3)
+	paymentSqlClient := ProvidePaymentSqlClient(db)
+	paymentSqlConverter := ProvidePaymentSqlConverter()
+	repository4 := ProvidePaymentRepository(paymentSqlClient, paymentSqlConverter)
+	transactor4 := ProvidePaymentTransactor(transactor)
+	invoiceSettler := ProvideInvoiceSettler(service2)
+	service4 := ProvidePaymentService(repository4, transactor4, invoiceSettler)
+	paymentsController := ProvidePaymentsController(service4)
+	accountsController := ProvideAccountsController(domainService)
+	mcpMCPServer := ProvideMCPServerAPI(healthController, invoicesHTTPController, invoicesController, movementsController, billingController, paymentsController, accountsController)
+	scheduler := ProvideScheduler(db, logger, service2, config)
+	guard, err := ProvideAuthGuard(config, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	app := &App{
 		Config:              config,
 		Logger:              logger,
@@ -62,8 +127,16 @@ func InitializeApp(configFile string) (*App, func(), error) {
 		MCPServerAPI:        mcpMCPServer,
 		HealthController:    healthController,
 		InvoicesController:  invoicesController,
+		InvoiceService:      service2,
+		DocumentExporters:   documentExporters,
 		MovementsController: movementsController,
 		MovementsService:    movementService,
+		BillingController:   billingController,
+		BillingService:      service3,
+		PaymentsController:  paymentsController,
+		AccountsController:  accountsController,
+		Scheduler:           scheduler,
+		AuthGuard:           guard,
 	}
 	return app, func() {
 		cleanup()
@@ -82,8 +155,16 @@ type App struct {
 	MCPServerAPI        *mcp.MCPServer // Added field for the API specific MCP server
 	HealthController    mcp.HealthController
 	InvoicesController  mcp.InvoicesController
+	InvoiceService      ports.InvoiceService
+	DocumentExporters   ports.DocumentExporters
 	MovementsController mcp.MovementsController
 	MovementsService    domain.MovementService
+	BillingController   mcp.BillingController
+	BillingService      domain2.Service
+	PaymentsController  mcp.PaymentsController
+	AccountsController  mcp.AccountsController
+	Scheduler           *scheduler.Scheduler
+	AuthGuard           *auth.Guard
 }
 
 // --- Core Providers ---
@@ -129,14 +210,64 @@ func ProvideMCP(cfg *config.Config) *server.MCPServer {
 }
 
 // Provider for the API specific MCPServer
-func ProvideMCPServerAPI(healthController mcp.HealthController, invoicesController mcp.InvoicesController, movementsController mcp.MovementsController) *mcp.MCPServer {
-	return mcp.NewMCPServer(healthController, invoicesController, movementsController)
+func ProvideMCPServerAPI(healthController mcp.HealthController, invoicesHTTPController mcp.InvoicesHTTPController, invoicesController mcp.InvoicesController, movementsController mcp.MovementsController, billingController mcp.BillingController, paymentsController mcp.PaymentsController, accountsController mcp.AccountsController) *mcp.MCPServer {
+	return mcp.NewMCPServer(healthController, invoicesHTTPController, invoicesController, movementsController, billingController, paymentsController, accountsController)
+}
+
+// ProvideAuthGuard builds the authentication of the endpoints from the methods configured in the auth section.
+func ProvideAuthGuard(cfg *config.Config, logger zerolog.Logger) (*auth.Guard, error) {
+	var authenticators []auth.Authenticator
+
+	if len(cfg.Auth.APIKeys) > 0 {
+		keys := make([]auth.APIKey, len(cfg.Auth.APIKeys))
+		for i, apiKey := range cfg.Auth.APIKeys {
+			keys[i] = auth.APIKey{
+				Name:  apiKey.Name,
+				Key:   apiKey.Key,
+				Grant: auth.Grant{Role: apiKey.Role, Accounts: apiKey.Accounts},
+			}
+		}
+		apiKeys, err := auth.NewAPIKeys(keys)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API keys: %w", err)
+		}
+		authenticators = append(authenticators, apiKeys)
+	}
+
+	if cfg.Auth.HMAC.Secret != "" {
+		hmacTokens, err := auth.NewHMACTokens(cfg.Auth.HMAC.Secret, cfg.Auth.HMAC.Issuer, cfg.Auth.HMAC.Audience)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HMAC authentication: %w", err)
+		}
+		authenticators = append(authenticators, hmacTokens)
+	}
+
+	if cfg.Auth.OAuth.Resource != "" {
+		oauthTokens, err := auth.LoadOAuthTokens(auth.ResourceServer{
+			Resource:             cfg.Auth.OAuth.Resource,
+			AuthorizationServers: cfg.Auth.OAuth.AuthorizationServers,
+			Scopes:               cfg.Auth.OAuth.Scopes,
+		}, cfg.Auth.OAuth.JWKSPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OAuth authentication: %w", err)
+		}
+		authenticators = append(authenticators, oauthTokens)
+	}
+
+	if len(authenticators) == 0 {
+		logger.Warn().Msg("No authentication method configured, the MCP and HTTP endpoints are open to anyone reaching the server")
+	}
+	return auth.NewGuard(logger, authenticators...), nil
 }
 
 func ProvideHealthController() mcp.HealthController {
 	return api.NewHealthController()
 }
 
+func ProvideTransactor(db *gorm.DB) persistence.Transactor {
+	return persistence.NewTransactor(db)
+}
+
 // --- Invoice Feature Providers ---
 func ProvideInvoiceSqlClient(db *gorm.DB, cfg *config.Config) sql.InvoiceSqlClient {
 	return sql.NewInvoiceSqlClient(db, cfg.Database.MaxRetries)
@@ -150,16 +281,121 @@ func ProvideInvoicePersistenceRepository(client sql.InvoiceSqlClient, converter
 	return persistence2.NewRepository(client, converter)
 }
 
-func ProvideInvoiceDomainService(repo domain2.Repository) domain2.Service {
-	return domain2.NewService(repo)
+func ProvideInvoiceTransactor(transactor persistence.Transactor) domain3.Transactor {
+	return transactor
+}
+
+func ProvideInvoiceNumbering(sequences domain3.SequenceRepository, cfg *config.Config) (domain3.Numbering, error) {
+	invoices, err := numberingSeries(cfg.Numbering.Invoices)
+	if err != nil {
+		return domain3.Numbering{}, fmt.Errorf("invalid invoice numbering series: %w", err)
+	}
+	creditNotes, err := numberingSeries(cfg.Numbering.CreditNotes)
+	if err != nil {
+		return domain3.Numbering{}, fmt.Errorf("invalid credit note numbering series: %w", err)
+	}
+	if invoices.Prefix == creditNotes.Prefix {
+		return domain3.Numbering{}, fmt.Errorf("invoices and credit notes must be numbered in different series, both use %q", invoices.Prefix)
+	}
+	return domain3.NewNumbering(sequences, invoices, creditNotes), nil
+}
+
+func numberingSeries(cfg config.SeriesConfig) (model.NumberingSeries, error) {
+	reset, err := model.ParseSeriesReset(cfg.Reset)
+	if err != nil {
+		return model.NumberingSeries{}, err
+	}
+	return model.NewNumberingSeries(cfg.Prefix, reset, cfg.Padding)
+}
+
+func ProvideInvoiceDomainService(repo domain3.Repository, rates domain3.ExchangeRateRepository, transactor domain3.Transactor, numbering domain3.Numbering, taxes domain3.TaxResolver, accounts domain3.AccountRegistry) domain3.Service {
+	return domain3.NewService(repo, rates, transactor, numbering, taxes, accounts)
 }
 
-func ProvideInvoicePortsService(domainService domain2.Service) ports.InvoiceService {
+func ProvideCurrencyConverter(rates domain3.ExchangeRateRepository) domain3.CurrencyConverter {
+	return domain3.NewCurrencyConverter(rates)
+}
+
+func ProvideInvoicePortsService(domainService domain3.Service) ports.InvoiceService {
 	return domainService
 }
 
-func ProvideInvoicesController(service ports.InvoiceService) mcp.InvoicesController {
-	return ports.NewController(service)
+// ProvideDocumentExporters renders invoices issued by the configured company in every supported format.
+// Without a company, the server still starts and exports fail until it is configured.
+func ProvideDocumentExporters(cfg *config.Config, logger zerolog.Logger) ports.DocumentExporters {
+	seller, err := companyParty(cfg)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Company is not configured, invoices cannot be exported")
+	}
+	return ports.DocumentExporters{ports.ExportFormatFacturae: facturae.NewExporter(seller), ports.ExportFormatUBL: ubl.NewExporter(seller)}
+}
+
+// ProvideInvoiceRenderer renders the PDF invoices of the configured company with its branding.
+// A branding that cannot be applied is replaced by the default one rather than failing to start.
+func ProvideInvoiceRenderer(cfg *config.Config, logger zerolog.Logger) ports.DocumentRenderer {
+	seller, _ := companyParty(cfg)
+	brandingConfig := cfg.Company.Branding
+	branding := pdf.Branding{
+		Template:    brandingConfig.Template,
+		Language:    brandingConfig.Language,
+		AccentColor: brandingConfig.AccentColor,
+		Footer:      brandingConfig.Footer,
+	}
+	if brandingConfig.LogoPath != "" {
+		logo, err := os.ReadFile(brandingConfig.LogoPath)
+		if err != nil {
+			logger.Warn().Err(err).Str("logo_path", brandingConfig.LogoPath).Msg("Failed to read company logo, PDF invoices are rendered without it")
+		}
+		branding.Logo = logo
+	}
+
+	renderer, err := pdf.NewRenderer(seller, branding)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Invalid company branding, PDF invoices are rendered with the default one")
+		renderer, _ = pdf.NewRenderer(seller, pdf.Branding{})
+	}
+	return renderer
+}
+
+// companyParty is the configured company as the seller of the invoices.
+func companyParty(cfg *config.Config) (model.Party, error) {
+	address := cfg.Company.Address
+	return model.NewParty(cfg.Company.TaxID, cfg.Company.LegalName, model.Address{
+		Street:     address.Street,
+		PostalCode: address.PostalCode,
+		Town:       address.Town,
+		Province:   address.Province,
+		Country:    address.Country,
+	})
+}
+
+func ProvideInvoicesController(service ports.InvoiceService, exporters ports.DocumentExporters, renderer ports.DocumentRenderer) mcp.InvoicesController {
+	return ports.NewController(service, exporters, renderer)
+}
+
+func ProvideInvoicesHTTPController(service ports.InvoiceService, renderer ports.DocumentRenderer) mcp.InvoicesHTTPController {
+	return ports.NewHTTPController(service, renderer)
+}
+
+// --- Tax Feature Providers ---
+func ProvideTaxSqlClient(db *gorm.DB) sql2.TaxSqlClient {
+	return sql2.NewTaxSqlClient(db)
+}
+
+func ProvideTaxSqlConverter() sql2.TaxSqlConverter {
+	return sql2.NewTaxSqlConverter()
+}
+
+func ProvideTaxRepository(client sql2.TaxSqlClient, converter sql2.TaxSqlConverter) persistence3.Repository {
+	return persistence3.NewRepository(client, converter)
+}
+
+func ProvideTaxService(repo domain4.Repository) domain4.Service {
+	return domain4.NewService(repo)
+}
+
+func ProvideTaxResolver(service domain4.Service) domain3.TaxResolver {
+	return service
 }
 
 // --- Movement Feature Providers ---
@@ -167,22 +403,112 @@ func ProvideMovementsController(movementService domain.MovementService, logger z
 	return ports2.NewMCPMovementsHandler(movementService, logger)
 }
 
-func ProvideMovementSqlClient(db *gorm.DB, logger zerolog.Logger) *sql2.MovementSqlClient {
-	return sql2.NewMovementSqlClient(db, logger)
+func ProvideMovementSqlClient(db *gorm.DB, logger zerolog.Logger) *sql3.MovementSqlClient {
+	return sql3.NewMovementSqlClient(db, logger)
 }
 
-func ProvideMovementConverter() *sql2.MovementConverter {
-	return sql2.NewMovementConverter()
+func ProvideMovementConverter() *sql3.MovementConverter {
+	return sql3.NewMovementConverter()
 }
 
-func ProvideMovementRepository(client *sql2.MovementSqlClient, converter *sql2.MovementConverter, logger zerolog.Logger) domain.MovementRepository {
-	return persistence3.NewMovementSQLRepository(client, converter, logger)
+func ProvideMovementRepository(client *sql3.MovementSqlClient, converter *sql3.MovementConverter, logger zerolog.Logger) domain.MovementRepository {
+	return persistence4.NewMovementSQLRepository(client, converter, logger)
 }
 
 func ProvideMovementService(logger zerolog.Logger, repo domain.MovementRepository) domain.MovementService {
 	return *domain.NewMovementService(logger, repo)
 }
 
+// --- Billing Feature Providers ---
+func ProvideBillingSqlClient(db *gorm.DB) sql4.BillingSqlClient {
+	return sql4.NewBillingSqlClient(db)
+}
+
+func ProvideBillingSqlConverter() sql4.BillingSqlConverter {
+	return sql4.NewBillingSqlConverter()
+}
+
+func ProvideBillingRepository(client sql4.BillingSqlClient, converter sql4.BillingSqlConverter) persistence5.Repository {
+	return persistence5.NewRepository(client, converter)
+}
+
+func ProvideBillingTransactor(transactor persistence.Transactor) domain2.Transactor {
+	return transactor
+}
+
+func ProvideBillingService(repo domain2.Repository, transactor domain2.Transactor, converter domain3.CurrencyConverter, taxes domain3.TaxResolver, accounts domain3.AccountRegistry, cfg *config.Config) domain2.Service {
+	return domain2.NewService(repo, transactor, converter, taxes, accounts, domain2.Settings{
+		PaymentTermDays: cfg.Billing.PaymentTermDays,
+	})
+}
+
+func ProvideBillingController(service domain2.Service) mcp.BillingController {
+	return ports3.NewController(service)
+}
+
+// --- Payment Feature Providers ---
+func ProvidePaymentSqlClient(db *gorm.DB) sql5.PaymentSqlClient {
+	return sql5.NewPaymentSqlClient(db)
+}
+
+func ProvidePaymentSqlConverter() sql5.PaymentSqlConverter {
+	return sql5.NewPaymentSqlConverter()
+}
+
+func ProvidePaymentRepository(client sql5.PaymentSqlClient, converter sql5.PaymentSqlConverter) persistence6.Repository {
+	return persistence6.NewRepository(client, converter)
+}
+
+func ProvidePaymentTransactor(transactor persistence.Transactor) domain5.Transactor {
+	return transactor
+}
+
+func ProvideInvoiceSettler(invoiceService domain3.Service) domain5.InvoiceSettler {
+	return invoiceService
+}
+
+func ProvidePaymentService(repo domain5.Repository, transactor domain5.Transactor, settler domain5.InvoiceSettler) domain5.Service {
+	return domain5.NewService(repo, transactor, settler)
+}
+
+func ProvidePaymentsController(service domain5.Service) mcp.PaymentsController {
+	return ports4.NewController(service)
+}
+
+// --- Account Feature Providers ---
+func ProvideAccountSqlClient(db *gorm.DB) sql6.AccountSqlClient {
+	return sql6.NewAccountSqlClient(db)
+}
+
+func ProvideAccountSqlConverter() sql6.AccountSqlConverter {
+	return sql6.NewAccountSqlConverter()
+}
+
+func ProvideAccountRepository(client sql6.AccountSqlClient, converter sql6.AccountSqlConverter) persistence7.Repository {
+	return persistence7.NewRepository(client, converter)
+}
+
+func ProvideAccountTransactor(transactor persistence.Transactor) domain6.Transactor {
+	return transactor
+}
+
+func ProvideAccountService(repo domain6.Repository, transactor domain6.Transactor) domain6.Service {
+	return domain6.NewService(repo, transactor)
+}
+
+func ProvideAccountRegistry(service domain6.Service) domain3.AccountRegistry {
+	return service
+}
+
+func ProvideAccountsController(service domain6.Service) mcp.AccountsController {
+	return ports5.NewController(service)
+}
+
+// --- Scheduler Providers ---
+func ProvideScheduler(db *gorm.DB, logger zerolog.Logger, invoiceService ports.InvoiceService, cfg *config.Config) *scheduler.Scheduler {
+	return scheduler.NewScheduler(scheduler.NewPostgresGuard(db), logger, ports.NewMarkOverdueInvoicesJob(invoiceService, cfg.Scheduler.OverdueInterval))
+}
+
 // --- Provider Sets ---
 var CoreSet = wire.NewSet(
 	ProvideConfig,
@@ -192,12 +518,27 @@ var CoreSet = wire.NewSet(
 	ProvideMCP,
 	ProvideMCPServerAPI,
 	ProvideHealthController,
+	ProvideTransactor,
+	ProvideAuthGuard,
 )
 
 var InvoiceFeatureSet = wire.NewSet(
 	ProvideInvoiceSqlClient,
 	ProvideInvoiceSqlConverter,
-	ProvideInvoicePersistenceRepository, wire.Bind(new(domain2.Repository), new(persistence2.Repository)), ProvideInvoiceDomainService, wire.Bind(new(ports.InvoiceService), new(domain2.Service)), ProvideInvoicesController,
+	ProvideInvoicePersistenceRepository, wire.Bind(new(domain3.Repository), new(persistence2.Repository)), wire.Bind(new(domain3.ExchangeRateRepository), new(persistence2.Repository)), wire.Bind(new(domain3.SequenceRepository), new(persistence2.Repository)), ProvideCurrencyConverter,
+	ProvideInvoiceTransactor,
+	ProvideInvoiceNumbering,
+	ProvideInvoiceDomainService, wire.Bind(new(ports.InvoiceService), new(domain3.Service)), ProvideDocumentExporters,
+	ProvideInvoiceRenderer,
+	ProvideInvoicesController,
+	ProvideInvoicesHTTPController,
+)
+
+var TaxFeatureSet = wire.NewSet(
+	ProvideTaxSqlClient,
+	ProvideTaxSqlConverter,
+	ProvideTaxRepository, wire.Bind(new(domain4.Repository), new(persistence3.Repository)), ProvideTaxService,
+	ProvideTaxResolver,
 )
 
 var MovementFeatureSet = wire.NewSet(
@@ -208,8 +549,39 @@ var MovementFeatureSet = wire.NewSet(
 	ProvideMovementsController,
 )
 
+var BillingFeatureSet = wire.NewSet(
+	ProvideBillingSqlClient,
+	ProvideBillingSqlConverter,
+	ProvideBillingRepository, wire.Bind(new(domain2.Repository), new(persistence5.Repository)), ProvideBillingTransactor,
+	ProvideBillingService,
+	ProvideBillingController,
+)
+
+var PaymentFeatureSet = wire.NewSet(
+	ProvidePaymentSqlClient,
+	ProvidePaymentSqlConverter,
+	ProvidePaymentRepository, wire.Bind(new(domain5.Repository), new(persistence6.Repository)), ProvidePaymentTransactor,
+	ProvideInvoiceSettler,
+	ProvidePaymentService,
+	ProvidePaymentsController,
+)
+
+var AccountFeatureSet = wire.NewSet(
+	ProvideAccountSqlClient,
+	ProvideAccountSqlConverter,
+	ProvideAccountRepository, wire.Bind(new(domain6.Repository), new(persistence7.Repository)), ProvideAccountTransactor,
+	ProvideAccountService,
+	ProvideAccountRegistry,
+	ProvideAccountsController,
+)
+
 var AppSet = wire.NewSet(
 	CoreSet,
 	InvoiceFeatureSet,
-	MovementFeatureSet, wire.Struct(new(App), "*"),
+	TaxFeatureSet,
+	MovementFeatureSet,
+	BillingFeatureSet,
+	PaymentFeatureSet,
+	AccountFeatureSet,
+	ProvideScheduler, wire.Struct(new(App), "*"),
 )
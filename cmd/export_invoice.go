@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/cmd/di"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/ports"
+)
+
+const exportInvoiceCommand = "export-invoice"
+
+// exportInvoiceArgs are the arguments of the export-invoice subcommand.
+// The buyer postal code and country default to the customer location of the invoice.
+type exportInvoiceArgs struct {
+	invoiceID model.InvoiceID
+	format    ports.ExportFormat
+	buyer     model.Party
+}
+
+// parseExportInvoiceArgs parses the arguments of the export-invoice subcommand, e.g.
+//
+//	billing-mcp export-invoice -format ubl -invoice 6f1c... -buyer-tax-id B87654321 -buyer-name "Cliente S.L." \
+//	    -buyer-address "Calle Sol 2" -buyer-town Sevilla -buyer-province Sevilla -buyer-postal-code 41001
+func parseExportInvoiceArgs(args []string, output io.Writer) (exportInvoiceArgs, error) {
+	flags := flag.NewFlagSet(exportInvoiceCommand, flag.ContinueOnError)
+	flags.SetOutput(output)
+	invoice := flags.String("invoice", "", "ID of the issued invoice to export")
+	format := flags.String("format", string(ports.ExportFormatFacturae), "format of the document, facturae or ubl")
+	taxID := flags.String("buyer-tax-id", "", "NIF of the buyer, or its VAT number outside Spain")
+	name := flags.String("buyer-name", "", "legal name of the buyer")
+	var address model.Address
+	flags.StringVar(&address.Street, "buyer-address", "", "street address of the buyer")
+	flags.StringVar(&address.Town, "buyer-town", "", "town of the buyer")
+	flags.StringVar(&address.Province, "buyer-province", "", "province or region of the buyer")
+	flags.StringVar(&address.PostalCode, "buyer-postal-code", "", "postal code of the buyer, defaults to the one of the invoice")
+	flags.StringVar(&address.Country, "buyer-country", "", "ISO 3166 alpha-2 country of the buyer, defaults to the one of the invoice")
+	if err := flags.Parse(args); err != nil {
+		return exportInvoiceArgs{}, err
+	}
+
+	if exportFormat := ports.ExportFormat(*format); exportFormat != ports.ExportFormatFacturae && exportFormat != ports.ExportFormatUBL {
+		return exportInvoiceArgs{}, fmt.Errorf("unsupported -format %q", *format)
+	}
+	invoiceID, err := model.ParseInvoiceID(*invoice)
+	if err != nil {
+		return exportInvoiceArgs{}, fmt.Errorf("invalid -invoice ID %q", *invoice)
+	}
+	if *taxID == "" || *name == "" {
+		return exportInvoiceArgs{}, errors.New("-buyer-tax-id and -buyer-name are required")
+	}
+	return exportInvoiceArgs{
+		invoiceID: invoiceID,
+		format:    ports.ExportFormat(*format),
+		buyer:     model.Party{TaxID: *taxID, Name: *name, Address: address},
+	}, nil
+}
+
+// ExportInvoice writes the document of an issued invoice in the requested format.
+func ExportInvoice(ctx context.Context, app *di.App, args exportInvoiceArgs, output io.Writer) error {
+	invoice, err := app.InvoiceService.GetInvoiceByID(args.invoiceID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch invoice %s: %w", args.invoiceID, err)
+	}
+
+	address := args.buyer.Address
+	if address.PostalCode == "" {
+		address.PostalCode = invoice.CustomerLocation.PostalCode
+	}
+	if address.Country == "" {
+		address.Country = invoice.CustomerLocation.Country
+	}
+	buyer, err := model.NewParty(args.buyer.TaxID, args.buyer.Name, address)
+	if err != nil {
+		return fmt.Errorf("invalid buyer: %w", err)
+	}
+
+	document, err := app.InvoiceService.GetInvoiceDocument(ctx, args.invoiceID, buyer)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice document: %w", err)
+	}
+	xml, err := app.DocumentExporters.Export(args.format, document)
+	if err != nil {
+		return fmt.Errorf("failed to export invoice %s: %w", invoice.InvoiceNumber, err)
+	}
+	if _, err := output.Write(xml); err != nil {
+		return fmt.Errorf("failed to write %s document: %w", args.format, err)
+	}
+	return nil
+}
@@ -17,6 +17,9 @@ import (
 	"github.com/ricardogrande-masmovil/billing-mcp/api/mcp"
 	"github.com/ricardogrande-masmovil/billing-mcp/cmd/di"
 	"github.com/ricardogrande-masmovil/billing-mcp/config"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
 	"github.com/rs/zerolog"
 )
 
@@ -35,6 +38,35 @@ func main() {
 		configFile = cp
 	}
 
+	// Subcommands run once and exit instead of serving MCP
+	var billingPeriod *model.Period
+	if len(os.Args) > 1 && os.Args[1] == billingRunCommand {
+		period, err := parseBillingRunArgs(os.Args[2:], os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid %s arguments: %v\n", billingRunCommand, err)
+			os.Exit(2)
+		}
+		billingPeriod = &period
+	}
+	var invoiceExport *exportInvoiceArgs
+	if len(os.Args) > 1 && os.Args[1] == exportInvoiceCommand {
+		args, err := parseExportInvoiceArgs(os.Args[2:], os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid %s arguments: %v\n", exportInvoiceCommand, err)
+			os.Exit(2)
+		}
+		invoiceExport = &args
+	}
+	var agingQuery *invoices.AgingQuery
+	if len(os.Args) > 1 && os.Args[1] == agingReportCommand {
+		query, err := parseAgingReportArgs(os.Args[2:], os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid %s arguments: %v\n", agingReportCommand, err)
+			os.Exit(2)
+		}
+		agingQuery = &query
+	}
+
 	app, cleanup, err := di.InitializeApp(configFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize application: %v\n", err)
@@ -57,44 +89,101 @@ func main() {
 		}
 	}
 
+	if billingPeriod != nil {
+		if err := RunBilling(context.Background(), app, *billingPeriod, os.Stdout); err != nil {
+			logger.Error().Err(err).Str("period", billingPeriod.String()).Msg("Billing run failed")
+			cleanup()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if invoiceExport != nil {
+		if err := ExportInvoice(context.Background(), app, *invoiceExport, os.Stdout); err != nil {
+			logger.Error().Err(err).Str("invoice_id", invoiceExport.invoiceID.String()).Str("format", string(invoiceExport.format)).Msg("Invoice export failed")
+			cleanup()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if agingQuery != nil {
+		if err := AgingReport(context.Background(), app, *agingQuery, os.Stdout); err != nil {
+			logger.Error().Err(err).Str("account_id", agingQuery.AccountID).Msg("Aging report failed")
+			cleanup()
+			os.Exit(1)
+		}
+		return
+	}
+
 	logger.Info().Msg("Starting the application...")
 
 	ctx := context.Background()
 
 	exitChannel := make(chan bool, 1)
+	stdioClosed := make(chan bool, 1)
+
+	go InitMCP(ctx, app.Echo, app.MCPServer, app.MCPServerAPI, app.AuthGuard, app.Config, app.Logger, exitChannel, stdioClosed)
 
-	go InitMCP(ctx, app.Echo, app.MCPServer, app.MCPServerAPI, app.Config, app.Logger, exitChannel)
+	// Background jobs share the lifecycle of the server
+	app.Scheduler.Start(ctx)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
-	<-quit
+	select {
+	case <-quit:
+		logger.Info().Msg("Received shutdown signal, shutting down...")
+	case <-stdioClosed:
+		// A client that launched the server over stdio stops it by closing its input
+		logger.Info().Msg("Stdio client disconnected, shutting down...")
+	}
 
-	logger.Info().Msg("Received shutdown signal, shutting down...")
 	exitChannel <- true
+	app.Scheduler.Stop()
 	<-exitChannel
 	logger.Info().Msg("Application shutdown complete.")
 }
 
-func InitMCP(ctx context.Context, e *echo.Echo, sdkServer *mcpServerSdk.MCPServer, appMCPServer *mcp.MCPServer, cfg *config.Config, logger zerolog.Logger, exitChan chan bool) {
-	err := mcp.Setup(e, sdkServer, appMCPServer)
+func InitMCP(ctx context.Context, e *echo.Echo, sdkServer *mcpServerSdk.MCPServer, appMCPServer *mcp.MCPServer, guard *auth.Guard, cfg *config.Config, logger zerolog.Logger, exitChan chan bool, stdioClosed chan<- bool) {
+	transports := mcp.Transports(cfg.Server.Transports)
+	err := mcp.Setup(e, sdkServer, appMCPServer, transports, guard)
 	if err != nil {
 		logger.Panic().Err(err).Msg("Failed to setup MCP server")
 	}
 
-	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	logger.Info().Str("address", serverAddr).Msg("Starting MCP server...")
+	stdioCtx, stopStdio := context.WithCancel(ctx)
+	defer stopStdio()
+	if transports.Has(mcp.TransportStdio) {
+		// Stdout carries the MCP messages, so Echo must not print its banner there (logs go to stderr)
+		e.HideBanner = true
+		e.HidePort = true
+
+		logger.Info().Msg("Starting MCP server over stdio...")
+		go func() {
+			if err := mcp.ServeStdio(stdioCtx, sdkServer, os.Stdin, os.Stdout); err != nil {
+				logger.Error().Err(err).Msg("MCP stdio transport failed")
+			}
+			stdioClosed <- true
+		}()
+	}
 
-	go func() {
-		if err := e.Start(serverAddr); err != nil && err != http.ErrServerClosed { // Check for http.ErrServerClosed
-			logger.Error().Err(err).Msg("MCP server failed to start")
-		} else if err == http.ErrServerClosed {
-			logger.Info().Msg("MCP server stopped gracefully.")
-		} else {
-			logger.Info().Msg("MCP server stopped.")
-		}
-	}()
+	if transports.ServesHTTP() {
+		serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
+		logger.Info().Str("address", serverAddr).Strs("transports", transports).Msg("Starting MCP server...")
+
+		go func() {
+			if err := e.Start(serverAddr); err != nil && err != http.ErrServerClosed { // Check for http.ErrServerClosed
+				logger.Error().Err(err).Msg("MCP server failed to start")
+			} else if err == http.ErrServerClosed {
+				logger.Info().Msg("MCP server stopped gracefully.")
+			} else {
+				logger.Info().Msg("MCP server stopped.")
+			}
+		}()
+	}
 
 	<-exitChan
+	stopStdio()
 
 	logger.Warn().Msg("Shutting down MCP server...")
 
@@ -3,13 +3,15 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type ServerConfig struct {
-	Port string `yaml:"port"`
-	Host string `yaml:"host"`
+	Port       string   `yaml:"port"`
+	Host       string   `yaml:"host"`
+	Transports []string `yaml:"transports"` // MCP transports to serve: "sse", "streamable-http" and/or "stdio"
 }
 
 type DatabaseConfig struct {
@@ -22,13 +24,98 @@ type DatabaseConfig struct {
 	MaxRetries int    `yaml:"maxRetries"`
 }
 
+// BillingConfig holds the settings applied by billing runs.
+type BillingConfig struct {
+	PaymentTermDays int `yaml:"paymentTermDays"` // Days between the issue and due dates of generated invoices
+}
+
+// SchedulerConfig holds the intervals of the background jobs.
+type SchedulerConfig struct {
+	OverdueInterval time.Duration `yaml:"overdueInterval"` // How often past due invoices are marked as overdue, e.g. "1h"
+}
+
+// SeriesConfig describes a numbering series, e.g. prefix FAC, yearly reset and padding 6 give FAC-2025-000001.
+type SeriesConfig struct {
+	Prefix  string `yaml:"prefix"`  // Leading part of every number of the series
+	Reset   string `yaml:"reset"`   // When numbers start over: "yearly" or "never"
+	Padding int    `yaml:"padding"` // Digits of the sequential part, left padded with zeros
+}
+
+// NumberingConfig holds the numbering series of issued invoices.
+type NumberingConfig struct {
+	Invoices    SeriesConfig `yaml:"invoices"`
+	CreditNotes SeriesConfig `yaml:"creditNotes"`
+}
+
+// AddressConfig is a postal address.
+type AddressConfig struct {
+	Street     string `yaml:"street"`
+	PostalCode string `yaml:"postalCode"`
+	Town       string `yaml:"town"`
+	Province   string `yaml:"province"`
+	Country    string `yaml:"country"` // ISO 3166-1 alpha-2 code, defaults to ES
+}
+
+// BrandingConfig customizes the PDF invoices of the company.
+type BrandingConfig struct {
+	Template    string `yaml:"template"`    // Layout of the documents: "classic" or "compact"
+	Language    string `yaml:"language"`    // Language of the labels, dates and amounts: "es" or "en"
+	AccentColor string `yaml:"accentColor"` // Hex RGB color of the title, the table headings and the total
+	LogoPath    string `yaml:"logoPath"`    // JPEG image printed on the header, optional
+	Footer      string `yaml:"footer"`      // Text printed at the bottom of every page, e.g. the registry details
+}
+
+// CompanyConfig identifies the company issuing the invoices, the seller party of every invoice.
+type CompanyConfig struct {
+	LegalName string         `yaml:"legalName"`
+	TaxID     string         `yaml:"taxId"` // NIF of the company
+	Address   AddressConfig  `yaml:"address"`
+	Branding  BrandingConfig `yaml:"branding"`
+}
+
+// APIKeyConfig is a static API key, sent by clients in the X-API-Key header.
+type APIKeyConfig struct {
+	Name     string   `yaml:"name"`     // Identifies the client holding the key
+	Key      string   `yaml:"key"`      // At least 32 characters
+	Role     string   `yaml:"role"`     // agent, supervisor or admin, defaults to agent
+	Accounts []string `yaml:"accounts"` // Accounts an agent or supervisor operates
+}
+
+// HMACConfig accepts bearer JWTs signed with HS256 with a secret shared with the services issuing them.
+type HMACConfig struct {
+	Secret   string `yaml:"secret"`   // At least 32 bytes, HMAC tokens are not accepted when empty
+	Issuer   string `yaml:"issuer"`   // Expected iss claim, optional
+	Audience string `yaml:"audience"` // Expected aud claim, optional
+}
+
+// OAuthConfig accepts the access tokens of OAuth 2.1 authorization servers, as described by the MCP authorization specification.
+type OAuthConfig struct {
+	Resource             string   `yaml:"resource"`             // Canonical URI of the server, e.g. https://billing.example.com/mcp, OAuth tokens are not accepted when empty
+	AuthorizationServers []string `yaml:"authorizationServers"` // Issuers of the tokens, advertised in the protected resource metadata
+	JWKSPath             string   `yaml:"jwksPath"`             // JSON Web Key Set with the public keys of the authorization servers
+	Scopes               []string `yaml:"scopes"`               // Scopes every token must be granted
+}
+
+// AuthConfig holds the methods accepted to authenticate the clients of the MCP and HTTP endpoints.
+// Requests are not authenticated when no method is configured.
+type AuthConfig struct {
+	APIKeys []APIKeyConfig `yaml:"apiKeys"`
+	HMAC    HMACConfig     `yaml:"hmac"`
+	OAuth   OAuthConfig    `yaml:"oauth"`
+}
+
 // Config holds the application configuration.
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	LogLevel string         `yaml:"logLevel"`
-	Version  string         `yaml:"version"`
-	RunSeeds bool           `yaml:"runSeeds"` // Added RunSeeds flag
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Billing   BillingConfig   `yaml:"billing"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	Numbering NumberingConfig `yaml:"numbering"`
+	Company   CompanyConfig   `yaml:"company"`
+	Auth      AuthConfig      `yaml:"auth"`
+	LogLevel  string          `yaml:"logLevel"`
+	Version   string          `yaml:"version"`
+	RunSeeds  bool            `yaml:"runSeeds"` // Added RunSeeds flag
 }
 
 // LoadConfig loads configuration from the given YAML file path.
@@ -49,16 +136,52 @@ func LoadConfig(configPath string) (*Config, error) {
 	if cfg.Server.Port == "" {
 		cfg.Server.Port = "8080" // Default port
 	}
+	if len(cfg.Server.Transports) == 0 {
+		cfg.Server.Transports = []string{"sse"} // Default MCP transport
+	}
 	if cfg.Database.SSLMode == "" {
 		cfg.Database.SSLMode = "disable" // Default SSLMode
 	}
 	if cfg.Database.MaxRetries == 0 {
 		cfg.Database.MaxRetries = 3 // Default MaxRetries
 	}
+	if cfg.Billing.PaymentTermDays == 0 {
+		cfg.Billing.PaymentTermDays = 30 // Default payment term
+	}
+	if cfg.Scheduler.OverdueInterval == 0 {
+		cfg.Scheduler.OverdueInterval = time.Hour // Default overdue detection interval
+	}
+	cfg.Numbering.Invoices.setDefaults("FAC")
+	cfg.Numbering.CreditNotes.setDefaults("R")
+	if cfg.Company.Address.Country == "" {
+		cfg.Company.Address.Country = "ES" // Default company country
+	}
+	if cfg.Company.Branding.Template == "" {
+		cfg.Company.Branding.Template = "classic" // Default PDF template
+	}
+	if cfg.Company.Branding.Language == "" {
+		cfg.Company.Branding.Language = "es" // Default PDF language
+	}
+	if cfg.Company.Branding.AccentColor == "" {
+		cfg.Company.Branding.AccentColor = "#1F4E79" // Default PDF accent color
+	}
 	
 	return &cfg, nil
 }
 
+// setDefaults fills the unset fields of a series: the given prefix, yearly reset and 6 digits.
+func (s *SeriesConfig) setDefaults(prefix string) {
+	if s.Prefix == "" {
+		s.Prefix = prefix
+	}
+	if s.Reset == "" {
+		s.Reset = "yearly"
+	}
+	if s.Padding == 0 {
+		s.Padding = 6
+	}
+}
+
 // GetDSN constructs the Data Source Name (DSN) for connecting to the PostgreSQL database.
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
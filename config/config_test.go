@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,8 +15,9 @@ func TestLoadConfig_Success(t *testing.T) {
 
 	expectedConfig := &Config{
 		Server: ServerConfig{
-			Host: "localhost",
-			Port: "8080",
+			Host:       "localhost",
+			Port:       "8080",
+			Transports: []string{"sse", "streamable-http"},
 		},
 		Database: DatabaseConfig{
 			Host:       "localhost",
@@ -26,6 +28,42 @@ func TestLoadConfig_Success(t *testing.T) {
 			SSLMode:    "disable",
 			MaxRetries: 3, // Added MaxRetries
 		},
+		Billing: BillingConfig{
+			PaymentTermDays: 30,
+		},
+		Scheduler: SchedulerConfig{
+			OverdueInterval: 15 * time.Minute,
+		},
+		Numbering: NumberingConfig{
+			Invoices:    SeriesConfig{Prefix: "FAC", Reset: "yearly", Padding: 6},
+			CreditNotes: SeriesConfig{Prefix: "R", Reset: "yearly", Padding: 6},
+		},
+		Company: CompanyConfig{
+			LegalName: "Billing MCP S.L.",
+			TaxID:     "B12345674",
+			Address: AddressConfig{
+				Street:     "Calle Mayor 1",
+				PostalCode: "28013",
+				Town:       "Madrid",
+				Province:   "Madrid",
+				Country:    "ES",
+			},
+			Branding: BrandingConfig{
+				Template:    "classic",
+				Language:    "es",
+				AccentColor: "#1F4E79",
+				Footer:      "Billing MCP S.L. - Registro Mercantil de Madrid",
+			},
+		},
+		Auth: AuthConfig{
+			APIKeys: []APIKeyConfig{{Name: "backoffice", Key: "change-me-to-a-random-key-of-32-chars-or-more", Role: "admin"}},
+			HMAC:    HMACConfig{Issuer: "billing-backoffice", Audience: "billing-mcp"},
+			OAuth: OAuthConfig{
+				AuthorizationServers: []string{"https://auth.example.com"},
+				JWKSPath:             "./jwks.json",
+				Scopes:               []string{"billing"},
+			},
+		},
 		LogLevel: "info",
 		Version:  "0.0.1",
 		RunSeeds: false, // Assuming default is false and not set in .config.example.yaml
@@ -65,9 +103,15 @@ version: "0.0.1"
 	require.NoError(t, err, "LoadConfig() should not return an error for valid temp file")
 
 	assert.Equal(t, "8080", cfg.Server.Port, "Default server port should be applied")
+	assert.Equal(t, []string{"sse"}, cfg.Server.Transports, "Default MCP transport should be applied")
 	assert.Equal(t, "disable", cfg.Database.SSLMode, "Default SSL mode should be applied")
 	assert.Equal(t, 3, cfg.Database.MaxRetries, "Default MaxRetries should be applied")
 	assert.False(t, cfg.RunSeeds, "Default RunSeeds should be false")
+	assert.Equal(t, 30, cfg.Billing.PaymentTermDays, "Default payment term should be applied")
+	assert.Equal(t, time.Hour, cfg.Scheduler.OverdueInterval, "Default overdue interval should be applied")
+	assert.Equal(t, SeriesConfig{Prefix: "FAC", Reset: "yearly", Padding: 6}, cfg.Numbering.Invoices, "Default invoice series should be applied")
+	assert.Equal(t, SeriesConfig{Prefix: "R", Reset: "yearly", Padding: 6}, cfg.Numbering.CreditNotes, "Default credit note series should be applied")
+	assert.Equal(t, BrandingConfig{Template: "classic", Language: "es", AccentColor: "#1F4E79"}, cfg.Company.Branding, "Default branding should be applied")
 
 	// Check other values are loaded correctly
 	assert.Equal(t, "testhost", cfg.Server.Host)
@@ -0,0 +1,12 @@
+-- Filename: 0004_use_decimal_amounts.down.sql
+-- Description: Restores the original amount column types
+
+ALTER TABLE movements
+ALTER COLUMN amount TYPE DECIMAL(10, 2),
+ALTER COLUMN amount_without_tax TYPE DECIMAL(10, 2),
+ALTER COLUMN amount_with_tax TYPE DECIMAL(10, 2);
+
+ALTER TABLE invoices
+ALTER COLUMN tax_amount TYPE FLOAT8,
+ALTER COLUMN total_amount_without_tax TYPE FLOAT8,
+ALTER COLUMN total_amount_with_tax TYPE FLOAT8;
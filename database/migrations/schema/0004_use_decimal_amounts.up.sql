@@ -0,0 +1,12 @@
+-- Filename: 0004_use_decimal_amounts.up.sql
+-- Description: Stores every monetary amount as an exact DECIMAL instead of FLOAT8
+
+ALTER TABLE invoices
+ALTER COLUMN tax_amount TYPE DECIMAL(12, 2) USING ROUND(tax_amount::NUMERIC, 2),
+ALTER COLUMN total_amount_without_tax TYPE DECIMAL(12, 2) USING ROUND(total_amount_without_tax::NUMERIC, 2),
+ALTER COLUMN total_amount_with_tax TYPE DECIMAL(12, 2) USING ROUND(total_amount_with_tax::NUMERIC, 2);
+
+ALTER TABLE movements
+ALTER COLUMN amount TYPE DECIMAL(12, 2),
+ALTER COLUMN amount_without_tax TYPE DECIMAL(12, 2),
+ALTER COLUMN amount_with_tax TYPE DECIMAL(12, 2);
@@ -0,0 +1,11 @@
+-- Filename: 0005_add_currencies.down.sql
+-- Description: Removes currencies and exchange rates
+
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE movements
+DROP COLUMN IF EXISTS exchange_rate,
+DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE invoices
+DROP COLUMN IF EXISTS currency;
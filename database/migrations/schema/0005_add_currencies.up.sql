@@ -0,0 +1,24 @@
+-- Filename: 0005_add_currencies.up.sql
+-- Description: Adds a currency to invoices and movements and stores the exchange rates used to invoice them
+
+ALTER TABLE invoices
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'EUR';
+
+-- The movement amount keeps its original currency; the invoice line amounts are in the invoice currency
+ALTER TABLE movements
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'EUR',
+ADD COLUMN exchange_rate DECIMAL(18, 8);
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate DECIMAL(18, 8) NOT NULL CHECK (rate > 0),
+    rate_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    UNIQUE (base_currency, quote_currency, rate_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_lookup ON exchange_rates(base_currency, quote_currency, rate_date DESC);
@@ -0,0 +1,17 @@
+-- Filename: 0006_create_billing_runs.down.sql
+-- Description: Removes billing runs and the account of movements
+
+DROP INDEX IF EXISTS idx_invoices_billing_run_account;
+
+ALTER TABLE invoices
+DROP COLUMN IF EXISTS billing_run_id;
+
+DROP TABLE IF EXISTS billing_runs;
+
+DROP INDEX IF EXISTS idx_movements_account_id_status;
+
+DELETE FROM movements WHERE invoice_id IS NULL;
+
+ALTER TABLE movements
+ALTER COLUMN invoice_id SET NOT NULL,
+DROP COLUMN IF EXISTS account_id;
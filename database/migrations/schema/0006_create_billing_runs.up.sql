@@ -0,0 +1,36 @@
+-- Filename: 0006_create_billing_runs.up.sql
+-- Description: Lets movements be recorded for an account before they are invoiced and tracks billing runs
+
+-- Pending movements belong to an account and only get an invoice when a billing run invoices them
+ALTER TABLE movements
+ADD COLUMN account_id VARCHAR(255),
+ALTER COLUMN invoice_id DROP NOT NULL;
+
+UPDATE movements
+SET account_id = invoices.account_id
+FROM invoices
+WHERE movements.invoice_id = invoices.id;
+
+CREATE INDEX IF NOT EXISTS idx_movements_account_id_status ON movements (account_id, status);
+
+CREATE TABLE IF NOT EXISTS billing_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    invoices_created INTEGER NOT NULL DEFAULT 0,
+    movements_invoiced INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    CONSTRAINT uq_billing_runs_period UNIQUE (period_start, period_end),
+    CONSTRAINT chk_billing_runs_period CHECK (period_start <= period_end)
+);
+
+-- A billing run creates at most one invoice per account, so re-running a period never duplicates invoices
+ALTER TABLE invoices
+ADD COLUMN billing_run_id UUID REFERENCES billing_runs (id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_billing_run_account ON invoices (billing_run_id, account_id) WHERE billing_run_id IS NOT NULL;
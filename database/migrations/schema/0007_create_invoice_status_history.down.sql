@@ -0,0 +1,6 @@
+-- Filename: 0007_create_invoice_status_history.down.sql
+-- Description: Removes the invoice status history
+
+DROP INDEX IF EXISTS idx_invoice_status_history_invoice_id;
+
+DROP TABLE IF EXISTS invoice_status_history;
@@ -0,0 +1,12 @@
+-- Filename: 0007_create_invoice_status_history.up.sql
+-- Description: Records every status transition of an invoice
+
+CREATE TABLE IF NOT EXISTS invoice_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_status_history_invoice_id ON invoice_status_history (invoice_id, changed_at);
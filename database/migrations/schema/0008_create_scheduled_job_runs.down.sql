@@ -0,0 +1,6 @@
+-- Filename: 0008_create_scheduled_job_runs.down.sql
+-- Description: Removes the record of scheduled job runs
+
+DROP INDEX IF EXISTS idx_invoices_status_due_date;
+
+DROP TABLE IF EXISTS scheduled_job_runs;
@@ -0,0 +1,12 @@
+-- Filename: 0008_create_scheduled_job_runs.up.sql
+-- Description: Records the last run of every scheduled background job
+
+CREATE TABLE IF NOT EXISTS scheduled_job_runs (
+    name VARCHAR(100) PRIMARY KEY,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_status_due_date ON invoices (status, due_date);
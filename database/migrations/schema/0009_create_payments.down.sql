@@ -0,0 +1,10 @@
+-- Filename: 0009_create_payments.down.sql
+-- Description: Removes payments and their allocations
+
+DROP INDEX IF EXISTS idx_payment_allocations_invoice_id;
+
+DROP TABLE IF EXISTS payment_allocations;
+
+DROP INDEX IF EXISTS idx_payments_account_id;
+
+DROP TABLE IF EXISTS payments;
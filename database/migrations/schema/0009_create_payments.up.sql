@@ -0,0 +1,30 @@
+-- Filename: 0009_create_payments.up.sql
+-- Description: Records payments received from accounts and their allocation to invoices
+
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    account_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    method VARCHAR(50) NOT NULL,
+    payment_date DATE NOT NULL,
+    reference VARCHAR(255),
+    CONSTRAINT chk_payments_amount CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_account_id ON payments (account_id);
+
+CREATE TABLE IF NOT EXISTS payment_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    payment_id UUID NOT NULL REFERENCES payments (id),
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    amount DECIMAL(12, 2) NOT NULL,
+    CONSTRAINT uq_payment_allocations_payment_invoice UNIQUE (payment_id, invoice_id),
+    CONSTRAINT chk_payment_allocations_amount CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_invoice_id ON payment_allocations (invoice_id);
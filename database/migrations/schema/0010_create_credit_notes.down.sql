@@ -0,0 +1,18 @@
+-- Filename: 0010_create_credit_notes.down.sql
+-- Description: Removes credit notes and numbering series
+
+DROP TABLE IF EXISTS number_sequences;
+
+DROP INDEX IF EXISTS idx_movements_corrected_movement_id;
+
+ALTER TABLE movements
+    DROP COLUMN IF EXISTS corrected_movement_id;
+
+DROP INDEX IF EXISTS idx_invoices_corrected_invoice_id;
+
+ALTER TABLE invoices
+    DROP CONSTRAINT IF EXISTS chk_invoices_correction,
+    DROP CONSTRAINT IF EXISTS chk_invoices_invoice_type,
+    DROP COLUMN IF EXISTS correction_reason,
+    DROP COLUMN IF EXISTS corrected_invoice_id,
+    DROP COLUMN IF EXISTS invoice_type;
@@ -0,0 +1,25 @@
+-- Filename: 0010_create_credit_notes.up.sql
+-- Description: Adds credit notes rectifying issued invoices and the numbering series they are numbered in
+
+ALTER TABLE invoices
+    ADD COLUMN IF NOT EXISTS invoice_type VARCHAR(20) NOT NULL DEFAULT 'STANDARD',
+    ADD COLUMN IF NOT EXISTS corrected_invoice_id UUID REFERENCES invoices (id),
+    ADD COLUMN IF NOT EXISTS correction_reason TEXT;
+
+ALTER TABLE invoices
+    ADD CONSTRAINT chk_invoices_invoice_type CHECK (invoice_type IN ('STANDARD', 'CREDIT_NOTE')),
+    ADD CONSTRAINT chk_invoices_correction CHECK ((invoice_type = 'CREDIT_NOTE') = (corrected_invoice_id IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_invoices_corrected_invoice_id ON invoices (corrected_invoice_id);
+
+ALTER TABLE movements
+    ADD COLUMN IF NOT EXISTS corrected_movement_id UUID REFERENCES movements (id);
+
+CREATE INDEX IF NOT EXISTS idx_movements_corrected_movement_id ON movements (corrected_movement_id);
+
+CREATE TABLE IF NOT EXISTS number_sequences (
+    series VARCHAR(20) NOT NULL,
+    year INT NOT NULL,
+    last_number BIGINT NOT NULL,
+    PRIMARY KEY (series, year)
+);
@@ -0,0 +1,10 @@
+-- Filename: 0011_number_invoices_on_issue.down.sql
+-- Description: Requires a number on every invoice again, drafts get a provisional one
+
+ALTER TABLE invoices
+    DROP CONSTRAINT IF EXISTS chk_invoices_issued_number;
+
+UPDATE invoices SET invoice_number = 'DRAFT-' || id WHERE invoice_number IS NULL;
+
+ALTER TABLE invoices
+    ALTER COLUMN invoice_number SET NOT NULL;
@@ -0,0 +1,12 @@
+-- Filename: 0011_number_invoices_on_issue.up.sql
+-- Description: Leaves drafts unnumbered, invoices get the next number of their series when they are issued
+
+ALTER TABLE invoices
+    ALTER COLUMN invoice_number DROP NOT NULL;
+
+UPDATE invoices SET invoice_number = NULL WHERE status = 'DRAFT';
+
+ALTER TABLE invoices
+    ADD CONSTRAINT chk_invoices_issued_number CHECK (status = 'DRAFT' OR invoice_number IS NOT NULL);
+
+COMMENT ON COLUMN number_sequences.year IS 'Year numbered by the sequence, 0 for series that never reset';
@@ -0,0 +1,14 @@
+-- Filename: 0012_create_tax_rates.down.sql
+-- Description: Removes the tax rates and the tax breakdown of invoices
+
+ALTER TABLE movements
+    DROP COLUMN IF EXISTS product_category,
+    DROP COLUMN IF EXISTS tax_exemption_cause,
+    DROP COLUMN IF EXISTS tax_regime;
+
+ALTER TABLE invoices
+    DROP COLUMN IF EXISTS tax_breakdown,
+    DROP COLUMN IF EXISTS customer_postal_code,
+    DROP COLUMN IF EXISTS customer_country;
+
+DROP TABLE IF EXISTS tax_rates;
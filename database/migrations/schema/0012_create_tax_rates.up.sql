@@ -0,0 +1,71 @@
+-- Filename: 0012_create_tax_rates.up.sql
+-- Description: Stores the tax rates of each Spanish tax territory and the tax breakdown of invoices per regime and rate
+
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    territory VARCHAR(20) NOT NULL,
+    rate_type VARCHAR(20) NOT NULL,
+    percentage DECIMAL(5, 2) NOT NULL,
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    CONSTRAINT chk_tax_rates_territory CHECK (territory IN ('MAINLAND', 'CANARY_ISLANDS', 'CEUTA', 'MELILLA')),
+    CONSTRAINT chk_tax_rates_rate_type CHECK (rate_type IN ('GENERAL', 'REDUCED', 'SUPER_REDUCED')),
+    CONSTRAINT chk_tax_rates_percentage CHECK (percentage >= 0),
+    CONSTRAINT chk_tax_rates_validity CHECK (valid_to IS NULL OR valid_to >= valid_from),
+    CONSTRAINT uq_tax_rates_territory_type_from UNIQUE (territory, rate_type, valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tax_rates_lookup ON tax_rates (territory, rate_type, valid_from DESC);
+
+-- IVA on the Peninsula and the Balearic Islands, IGIC on the Canary Islands and IPSI in Ceuta and Melilla
+INSERT INTO tax_rates (territory, rate_type, percentage, valid_from) VALUES
+    ('MAINLAND', 'GENERAL', 21.00, '2012-09-01'),
+    ('MAINLAND', 'REDUCED', 10.00, '2012-09-01'),
+    ('MAINLAND', 'SUPER_REDUCED', 4.00, '2012-09-01'),
+    ('CANARY_ISLANDS', 'GENERAL', 7.00, '2019-01-01'),
+    ('CANARY_ISLANDS', 'REDUCED', 3.00, '2019-01-01'),
+    ('CANARY_ISLANDS', 'SUPER_REDUCED', 0.00, '2019-01-01'),
+    ('CEUTA', 'GENERAL', 3.00, '2015-01-01'),
+    ('CEUTA', 'REDUCED', 1.00, '2015-01-01'),
+    ('CEUTA', 'SUPER_REDUCED', 0.50, '2015-01-01'),
+    ('MELILLA', 'GENERAL', 4.00, '2015-01-01'),
+    ('MELILLA', 'REDUCED', 1.00, '2015-01-01'),
+    ('MELILLA', 'SUPER_REDUCED', 0.50, '2015-01-01')
+ON CONFLICT DO NOTHING;
+
+-- The location of the customer decides the tax territory of an invoice
+ALTER TABLE invoices
+    ADD COLUMN customer_country CHAR(2) NOT NULL DEFAULT 'ES',
+    ADD COLUMN customer_postal_code VARCHAR(10),
+    ADD COLUMN tax_breakdown JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE movements
+    ADD COLUMN tax_regime VARCHAR(10) NOT NULL DEFAULT 'IVA',
+    ADD COLUMN tax_exemption_cause VARCHAR(2),
+    ADD COLUMN product_category VARCHAR(50);
+
+-- Existing invoices were all taxed under IVA, their breakdown is rebuilt from their lines
+UPDATE invoices
+SET tax_breakdown = breakdown.summaries
+FROM (
+    SELECT invoice_id, jsonb_agg(summary ORDER BY percentage DESC) AS summaries
+    FROM (
+        SELECT invoice_id,
+               tax_percentage AS percentage,
+               jsonb_build_object(
+                   'regime', 'IVA',
+                   'percentage', tax_percentage,
+                   'taxable_base', SUM(amount_without_tax),
+                   'tax_amount', ROUND(SUM(amount_without_tax) * tax_percentage / 100, 2)
+               ) AS summary
+        FROM movements
+        WHERE invoice_id IS NOT NULL AND deleted_at IS NULL
+          AND amount_without_tax IS NOT NULL AND tax_percentage IS NOT NULL
+        GROUP BY invoice_id, tax_percentage
+    ) AS rates
+    GROUP BY invoice_id
+) AS breakdown
+WHERE invoices.id = breakdown.invoice_id;
@@ -0,0 +1,6 @@
+-- Filename: 0013_create_accounts.down.sql
+-- Description: Removes the billing profiles of the accounts
+
+DROP INDEX IF EXISTS idx_accounts_tax_id;
+
+DROP TABLE IF EXISTS accounts;
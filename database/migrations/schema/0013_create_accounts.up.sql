@@ -0,0 +1,26 @@
+-- Filename: 0013_create_accounts.up.sql
+-- Description: Stores the billing profile of the accounts invoices are issued to
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    legal_name VARCHAR(255) NOT NULL,
+    tax_id_type VARCHAR(10) NOT NULL,
+    tax_id VARCHAR(20) NOT NULL,
+    street VARCHAR(255) NOT NULL,
+    postal_code VARCHAR(20) NOT NULL,
+    town VARCHAR(100) NOT NULL,
+    province VARCHAR(100) NOT NULL,
+    country CHAR(2) NOT NULL,
+    billing_email VARCHAR(255) NOT NULL,
+    language CHAR(2) NOT NULL,
+    payment_method VARCHAR(20) NOT NULL,
+    billing_cycle_day SMALLINT NOT NULL,
+    CONSTRAINT chk_accounts_tax_id_type CHECK (tax_id_type IN ('NIF', 'CIF', 'VAT', 'FOREIGN')),
+    CONSTRAINT chk_accounts_language CHECK (language IN ('es', 'en')),
+    CONSTRAINT chk_accounts_payment_method CHECK (payment_method IN ('TRANSFER', 'CARD', 'DIRECT_DEBIT')),
+    CONSTRAINT chk_accounts_billing_cycle_day CHECK (billing_cycle_day BETWEEN 1 AND 28)
+);
+
+CREATE INDEX IF NOT EXISTS idx_accounts_tax_id ON accounts (tax_id);
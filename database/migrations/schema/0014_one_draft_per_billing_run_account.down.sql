@@ -0,0 +1,6 @@
+-- Filename: 0014_one_draft_per_billing_run_account.down.sql
+-- Description: Allows a single invoice per account in each billing run again
+
+DROP INDEX IF EXISTS idx_invoices_billing_run_account_draft;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_billing_run_account ON invoices (billing_run_id, account_id) WHERE billing_run_id IS NOT NULL;
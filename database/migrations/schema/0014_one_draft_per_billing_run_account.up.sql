@@ -0,0 +1,6 @@
+-- Filename: 0014_one_draft_per_billing_run_account.up.sql
+-- Description: Lets a billing run create a new draft for an account once its previous invoice has been issued
+
+DROP INDEX IF EXISTS idx_invoices_billing_run_account;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_billing_run_account_draft ON invoices (billing_run_id, account_id) WHERE billing_run_id IS NOT NULL AND status = 'DRAFT';
@@ -0,0 +1,9 @@
+-- Filename: 0003_seed_exchange_rates.down.sql
+-- Description: Removes seed data from the exchange_rates table
+
+DELETE FROM exchange_rates WHERE id IN (
+'333e4567-e89b-12d3-a456-426614174001',
+'333e4567-e89b-12d3-a456-426614174002',
+'333e4567-e89b-12d3-a456-426614174003',
+'333e4567-e89b-12d3-a456-426614174004'
+);
@@ -0,0 +1,8 @@
+-- Filename: 0003_seed_exchange_rates.up.sql
+-- Description: Inserts seed data into the exchange_rates table
+
+INSERT INTO exchange_rates (id, base_currency, quote_currency, rate, rate_date, created_at, updated_at) VALUES
+('333e4567-e89b-12d3-a456-426614174001', 'USD', 'EUR', 0.92150000, '2025-01-01', NOW(), NOW()),
+('333e4567-e89b-12d3-a456-426614174002', 'GBP', 'EUR', 1.18900000, '2025-01-01', NOW(), NOW()),
+('333e4567-e89b-12d3-a456-426614174003', 'USD', 'EUR', 0.89800000, '2025-04-01', NOW(), NOW()),
+('333e4567-e89b-12d3-a456-426614174004', 'GBP', 'EUR', 1.16700000, '2025-04-01', NOW(), NOW());
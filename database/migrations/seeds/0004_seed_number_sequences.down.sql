@@ -0,0 +1,4 @@
+-- Filename: 0004_seed_number_sequences.down.sql
+-- Description: Removes the seeded numbering series
+
+DELETE FROM number_sequences WHERE series = 'FAC' AND year = 2025;
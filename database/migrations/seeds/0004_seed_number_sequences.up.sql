@@ -0,0 +1,6 @@
+-- Filename: 0004_seed_number_sequences.up.sql
+-- Description: Continues the invoice numbering series after the seeded invoices
+
+INSERT INTO number_sequences (series, year, last_number) VALUES
+('FAC', 2025, 4)
+ON CONFLICT (series, year) DO UPDATE SET last_number = GREATEST(number_sequences.last_number, EXCLUDED.last_number);
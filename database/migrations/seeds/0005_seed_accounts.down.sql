@@ -0,0 +1,4 @@
+-- Filename: 0005_seed_accounts.down.sql
+-- Description: Removes the seeded billing profiles
+
+DELETE FROM accounts WHERE id IN ('account_mock_A', 'account_mock_B', 'account_mock_C');
@@ -0,0 +1,8 @@
+-- Filename: 0005_seed_accounts.up.sql
+-- Description: Seeds the billing profiles of the accounts of the seeded invoices and movements
+
+INSERT INTO accounts (id, legal_name, tax_id_type, tax_id, street, postal_code, town, province, country, billing_email, language, payment_method, billing_cycle_day, created_at, updated_at) VALUES
+('account_mock_A', 'Acme Telecom S.L.', 'CIF', 'B12345674', 'Calle Mayor 1', '28013', 'Madrid', 'Madrid', 'ES', 'billing@acme.example', 'es', 'DIRECT_DEBIT', 1, NOW(), NOW()),
+('account_mock_B', 'Lucía Fernández García', 'NIF', '12345678Z', 'Avenida de la Constitución 10', '41004', 'Sevilla', 'Sevilla', 'ES', 'lucia.fernandez@example.com', 'es', 'CARD', 15, NOW(), NOW()),
+('account_mock_C', 'Beispiel GmbH', 'VAT', 'DE136695976', 'Unter den Linden 1', '10117', 'Berlin', '', 'DE', 'rechnung@beispiel.example', 'en', 'TRANSFER', 1, NOW(), NOW())
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,8 @@
+-- Filename: 0006_renumber_seed_invoices.down.sql
+-- Description: Gives the seeded invoices their mock numbers back
+
+UPDATE invoices SET invoice_number = 'INV-MOCK-001' WHERE id = '123e4567-e89b-12d3-a456-426614174001' AND invoice_number = 'FAC-2025-000001';
+UPDATE invoices SET invoice_number = 'INV-MOCK-002' WHERE id = '123e4567-e89b-12d3-a456-426614174002' AND invoice_number = 'FAC-2025-000002';
+UPDATE invoices SET invoice_number = 'INV-MOCK-003' WHERE id = '123e4567-e89b-12d3-a456-426614174003' AND invoice_number = 'FAC-2025-000003';
+UPDATE invoices SET invoice_number = 'INV-MOCK-004' WHERE id = '123e4567-e89b-12d3-a456-426614174004' AND invoice_number IS NULL;
+UPDATE invoices SET invoice_number = 'INV-MOCK-005' WHERE id = '123e4567-e89b-12d3-a456-426614174005' AND invoice_number = 'FAC-2025-000004';
@@ -0,0 +1,8 @@
+-- Filename: 0006_renumber_seed_invoices.up.sql
+-- Description: Numbers the seeded invoices in the FAC series and leaves the seeded draft unnumbered
+
+UPDATE invoices SET invoice_number = 'FAC-2025-000001' WHERE id = '123e4567-e89b-12d3-a456-426614174001' AND invoice_number = 'INV-MOCK-001';
+UPDATE invoices SET invoice_number = 'FAC-2025-000002' WHERE id = '123e4567-e89b-12d3-a456-426614174002' AND invoice_number = 'INV-MOCK-002';
+UPDATE invoices SET invoice_number = 'FAC-2025-000003' WHERE id = '123e4567-e89b-12d3-a456-426614174003' AND invoice_number = 'INV-MOCK-003';
+UPDATE invoices SET invoice_number = NULL WHERE id = '123e4567-e89b-12d3-a456-426614174004' AND status = 'DRAFT';
+UPDATE invoices SET invoice_number = 'FAC-2025-000004' WHERE id = '123e4567-e89b-12d3-a456-426614174005' AND invoice_number = 'INV-MOCK-005';
@@ -0,0 +1,271 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+)
+
+var (
+	ErrAccountNotFound        = errors.New("account not found")
+	ErrAccountIDEmpty         = errors.New("account ID cannot be empty")
+	ErrInvalidBillingProfile  = errors.New("invalid billing profile")
+	ErrNoProfileChanges       = errors.New("no billing profile field to change")
+	ErrUnknownLanguage        = errors.New("unknown language")
+	ErrUnknownPaymentMethod   = errors.New("unknown payment method")
+	ErrInvalidBillingCycleDay = errors.New("invalid billing cycle day")
+)
+
+// Language is the ISO 639-1 code of the language the account is addressed in.
+type Language string
+
+const (
+	LanguageSpanish Language = "es"
+	LanguageEnglish Language = "en"
+)
+
+// ParseLanguage parses a language, case insensitively.
+func ParseLanguage(value string) (Language, error) {
+	switch language := Language(strings.ToLower(strings.TrimSpace(value))); language {
+	case LanguageSpanish, LanguageEnglish:
+		return language, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownLanguage, value)
+	}
+}
+
+// PaymentMethod is the way the account prefers to pay its invoices.
+type PaymentMethod string
+
+const (
+	PaymentMethodTransfer    PaymentMethod = "TRANSFER"
+	PaymentMethodCard        PaymentMethod = "CARD"
+	PaymentMethodDirectDebit PaymentMethod = "DIRECT_DEBIT"
+)
+
+// ParsePaymentMethod parses a payment method, case insensitively.
+func ParsePaymentMethod(value string) (PaymentMethod, error) {
+	switch method := PaymentMethod(strings.ToUpper(strings.TrimSpace(value))); method {
+	case PaymentMethodTransfer, PaymentMethodCard, PaymentMethodDirectDebit:
+		return method, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownPaymentMethod, value)
+	}
+}
+
+// MaxBillingCycleDay is the last day of the month an account can be billed on, so every month has it.
+const MaxBillingCycleDay = 28
+
+// Defaults of the billing profiles that do not set these fields
+const (
+	DefaultLanguage        = LanguageSpanish
+	DefaultPaymentMethod   = PaymentMethodTransfer
+	DefaultBillingCycleDay = 1
+)
+
+// Address is the fiscal address of an account.
+type Address struct {
+	Street     string
+	PostalCode string
+	Town       string
+	Province   string
+	Country    string // ISO 3166-1 alpha-2 code, e.g. ES
+}
+
+// BillingProfile is who an account is invoiced to and how.
+type BillingProfile struct {
+	LegalName       string // Legal name of a company, or full name of a natural person
+	TaxID           TaxID
+	FiscalAddress   Address
+	BillingEmail    string
+	Language        Language
+	PaymentMethod   PaymentMethod
+	BillingCycleDay int // Day of the month the account is billed on
+}
+
+// BillingDetails are the fields of a billing profile as given by a client, before they are validated.
+type BillingDetails struct {
+	LegalName       string
+	TaxID           string
+	FiscalAddress   Address
+	BillingEmail    string
+	Language        string
+	PaymentMethod   string
+	BillingCycleDay int
+}
+
+// NewBillingProfile validates and normalizes the details of a billing profile, reporting every invalid field.
+// The language, the payment method and the billing cycle day take their defaults when not set.
+func NewBillingProfile(details BillingDetails) (BillingProfile, error) {
+	var errs []error
+	profile := BillingProfile{
+		LegalName:       strings.TrimSpace(details.LegalName),
+		Language:        DefaultLanguage,
+		PaymentMethod:   DefaultPaymentMethod,
+		BillingCycleDay: DefaultBillingCycleDay,
+	}
+	if profile.LegalName == "" {
+		errs = append(errs, fmt.Errorf("%w: legal name cannot be empty", ErrInvalidBillingProfile))
+	}
+
+	address, location, err := newAddress(details.FiscalAddress)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%w: fiscal address: %w", ErrInvalidBillingProfile, err))
+	}
+	profile.FiscalAddress = address
+
+	// The tax ID is checked against the country of the address as soon as it is known
+	if location.Country != "" {
+		if profile.TaxID, err = ParseTaxID(details.TaxID, location.Country); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidBillingProfile, err))
+		}
+	}
+
+	email, err := mail.ParseAddress(strings.TrimSpace(details.BillingEmail))
+	if err != nil || email.Name != "" {
+		errs = append(errs, fmt.Errorf("%w: billing email %q is not an email address", ErrInvalidBillingProfile, details.BillingEmail))
+	} else {
+		profile.BillingEmail = strings.ToLower(email.Address)
+	}
+
+	if details.Language != "" {
+		if profile.Language, err = ParseLanguage(details.Language); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidBillingProfile, err))
+		}
+	}
+	if details.PaymentMethod != "" {
+		if profile.PaymentMethod, err = ParsePaymentMethod(details.PaymentMethod); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidBillingProfile, err))
+		}
+	}
+	if details.BillingCycleDay != 0 {
+		if details.BillingCycleDay < 1 || details.BillingCycleDay > MaxBillingCycleDay {
+			errs = append(errs, fmt.Errorf("%w: %w: %d is not between 1 and %d",
+				ErrInvalidBillingProfile, ErrInvalidBillingCycleDay, details.BillingCycleDay, MaxBillingCycleDay))
+		}
+		profile.BillingCycleDay = details.BillingCycleDay
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return BillingProfile{}, err
+	}
+	return profile, nil
+}
+
+// newAddress validates a fiscal address: it needs a street and a town, and a postal code in Spain, where it decides
+// the tax territory. The location is returned whenever the country is valid, even if other fields are missing.
+func newAddress(address Address) (Address, taxes.Location, error) {
+	address.Street = strings.TrimSpace(address.Street)
+	address.Town = strings.TrimSpace(address.Town)
+	address.Province = strings.TrimSpace(address.Province)
+	location, err := taxes.NewLocation(address.Country, address.PostalCode)
+	if err != nil {
+		return address, taxes.Location{}, err
+	}
+	address.Country, address.PostalCode = location.Country, location.PostalCode
+
+	var missing []string
+	if address.Street == "" {
+		missing = append(missing, "street")
+	}
+	if address.PostalCode == "" && location.Country == "ES" {
+		missing = append(missing, "postal code")
+	}
+	if address.Town == "" {
+		missing = append(missing, "town")
+	}
+	if len(missing) > 0 {
+		return address, location, fmt.Errorf("%s cannot be empty", strings.Join(missing, ", "))
+	}
+	return address, location, nil
+}
+
+// Details returns the fields of the profile, to change some of them.
+func (p BillingProfile) Details() BillingDetails {
+	return BillingDetails{
+		LegalName:       p.LegalName,
+		TaxID:           p.TaxID.Value,
+		FiscalAddress:   p.FiscalAddress,
+		BillingEmail:    p.BillingEmail,
+		Language:        string(p.Language),
+		PaymentMethod:   string(p.PaymentMethod),
+		BillingCycleDay: p.BillingCycleDay,
+	}
+}
+
+// Location is where the account is established for tax purposes, from its fiscal address.
+func (p BillingProfile) Location() taxes.Location {
+	return taxes.Location{Country: p.FiscalAddress.Country, PostalCode: p.FiscalAddress.PostalCode}
+}
+
+// ProfileChanges are the fields of a billing profile to change, nil fields keep their value.
+type ProfileChanges struct {
+	LegalName       *string
+	TaxID           *string
+	Street          *string
+	PostalCode      *string
+	Town            *string
+	Province        *string
+	Country         *string
+	BillingEmail    *string
+	Language        *string
+	PaymentMethod   *string
+	BillingCycleDay *int
+}
+
+// IsEmpty reports whether the changes leave every field as it is.
+func (c ProfileChanges) IsEmpty() bool {
+	return c == ProfileChanges{}
+}
+
+// Apply returns the details with the changes applied.
+func (d BillingDetails) Apply(c ProfileChanges) BillingDetails {
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+		}
+	}
+	set(&d.LegalName, c.LegalName)
+	set(&d.TaxID, c.TaxID)
+	set(&d.FiscalAddress.Street, c.Street)
+	set(&d.FiscalAddress.PostalCode, c.PostalCode)
+	set(&d.FiscalAddress.Town, c.Town)
+	set(&d.FiscalAddress.Province, c.Province)
+	set(&d.FiscalAddress.Country, c.Country)
+	set(&d.BillingEmail, c.BillingEmail)
+	set(&d.Language, c.Language)
+	set(&d.PaymentMethod, c.PaymentMethod)
+	if c.BillingCycleDay != nil {
+		d.BillingCycleDay = *c.BillingCycleDay
+	}
+	return d
+}
+
+// Account is a customer that is billed, identified by the account ID its movements and invoices refer to.
+type Account struct {
+	ID        string
+	Profile   BillingProfile
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// UpdateBillingProfile applies the changes to the billing profile of the account. An account without profile gets
+// one, which needs all the required fields.
+func (a *Account) UpdateBillingProfile(changes ProfileChanges) error {
+	if a.ID == "" {
+		return ErrAccountIDEmpty
+	}
+	if changes.IsEmpty() {
+		return ErrNoProfileChanges
+	}
+	profile, err := NewBillingProfile(a.Profile.Details().Apply(changes))
+	if err != nil {
+		return err
+	}
+	a.Profile = profile
+	return nil
+}
@@ -0,0 +1,102 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func details() model.BillingDetails {
+	return model.BillingDetails{
+		LegalName:     " Acme Telecom S.L. ",
+		TaxID:         "b12345674",
+		FiscalAddress: model.Address{Street: "Calle Mayor 1", PostalCode: "35001", Town: "Las Palmas", Country: "es"},
+		BillingEmail:  "Billing@Acme.example",
+	}
+}
+
+func TestNewBillingProfile(t *testing.T) {
+	profile, err := model.NewBillingProfile(details())
+	require.NoError(t, err)
+
+	assert.Equal(t, model.BillingProfile{
+		LegalName:       "Acme Telecom S.L.",
+		TaxID:           model.TaxID{Type: model.TaxIDTypeCIF, Value: "B12345674"},
+		FiscalAddress:   model.Address{Street: "Calle Mayor 1", PostalCode: "35001", Town: "Las Palmas", Country: "ES"},
+		BillingEmail:    "billing@acme.example",
+		Language:        model.DefaultLanguage,
+		PaymentMethod:   model.DefaultPaymentMethod,
+		BillingCycleDay: model.DefaultBillingCycleDay,
+	}, profile)
+	assert.Equal(t, taxes.TerritoryCanaryIslands, profile.Location().Territory(), "the fiscal address decides the tax territory")
+}
+
+func TestNewBillingProfile_ReportsEveryInvalidField(t *testing.T) {
+	_, err := model.NewBillingProfile(model.BillingDetails{
+		TaxID:           "B12345674",
+		FiscalAddress:   model.Address{Street: "Rue de Rivoli 1", Country: "FR"},
+		BillingEmail:    "Acme <billing@acme.example>",
+		Language:        "fr",
+		PaymentMethod:   "CASH",
+		BillingCycleDay: 31,
+	})
+
+	assert.ErrorIs(t, err, model.ErrInvalidBillingProfile)
+	assert.ErrorIs(t, err, model.ErrInvalidTaxID)
+	assert.ErrorIs(t, err, model.ErrUnknownLanguage)
+	assert.ErrorIs(t, err, model.ErrUnknownPaymentMethod)
+	assert.ErrorIs(t, err, model.ErrInvalidBillingCycleDay)
+	assert.ErrorContains(t, err, "legal name cannot be empty")
+	assert.ErrorContains(t, err, "fiscal address: town cannot be empty")
+	assert.ErrorContains(t, err, `"B12345674" is not a VAT number of FR`, "the tax ID must be one of the country of the fiscal address")
+	assert.ErrorContains(t, err, "is not an email address")
+}
+
+func TestNewBillingProfile_SpanishAddressNeedsPostalCode(t *testing.T) {
+	invalid := details()
+	invalid.FiscalAddress.PostalCode = ""
+	_, err := model.NewBillingProfile(invalid)
+	assert.ErrorContains(t, err, "fiscal address: postal code cannot be empty")
+
+	invalid.FiscalAddress.PostalCode = "3500"
+	_, err = model.NewBillingProfile(invalid)
+	assert.ErrorIs(t, err, taxes.ErrInvalidLocation)
+}
+
+func TestAccount_UpdateBillingProfile(t *testing.T) {
+	account := model.Account{ID: "account_A"}
+
+	email := "invoices@acme.example"
+	assert.ErrorIs(t, account.UpdateBillingProfile(model.ProfileChanges{BillingEmail: &email}), model.ErrInvalidBillingProfile,
+		"the first profile needs all the required fields")
+
+	first := details()
+	require.NoError(t, account.UpdateBillingProfile(model.ProfileChanges{
+		LegalName:    &first.LegalName,
+		TaxID:        &first.TaxID,
+		Street:       &first.FiscalAddress.Street,
+		PostalCode:   &first.FiscalAddress.PostalCode,
+		Town:         &first.FiscalAddress.Town,
+		Country:      &first.FiscalAddress.Country,
+		BillingEmail: &first.BillingEmail,
+	}))
+
+	day, language := 15, "EN"
+	require.NoError(t, account.UpdateBillingProfile(model.ProfileChanges{BillingEmail: &email, BillingCycleDay: &day, Language: &language}))
+	assert.Equal(t, "invoices@acme.example", account.Profile.BillingEmail)
+	assert.Equal(t, 15, account.Profile.BillingCycleDay)
+	assert.Equal(t, model.LanguageEnglish, account.Profile.Language)
+	assert.Equal(t, "Acme Telecom S.L.", account.Profile.LegalName, "fields without changes keep their value")
+
+	// Moving to another country needs a tax ID of that country
+	country := "DE"
+	err := account.UpdateBillingProfile(model.ProfileChanges{Country: &country})
+	assert.ErrorIs(t, err, model.ErrInvalidTaxID)
+	assert.Equal(t, "ES", account.Profile.FiscalAddress.Country, "an invalid change leaves the profile as it was")
+
+	assert.ErrorIs(t, account.UpdateBillingProfile(model.ProfileChanges{}), model.ErrNoProfileChanges)
+	assert.ErrorIs(t, (&model.Account{}).UpdateBillingProfile(model.ProfileChanges{BillingEmail: &email}), model.ErrAccountIDEmpty)
+}
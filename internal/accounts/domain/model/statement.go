@@ -0,0 +1,146 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var ErrInvalidStatementPeriod = errors.New("invalid statement period")
+
+// EntryType is the kind of document a statement entry comes from.
+type EntryType string
+
+const (
+	EntryTypeInvoice    EntryType = "INVOICE"     // An issued invoice, charged to the account
+	EntryTypeCreditNote EntryType = "CREDIT_NOTE" // An issued credit note, credited to the account
+	EntryTypePayment    EntryType = "PAYMENT"     // A payment received from the account
+)
+
+// StatementEntry is a document that changes what an account owes. Amount is positive when it increases the debt
+// of the account, as invoices do, and negative when it decreases it, as credit notes and payments do.
+type StatementEntry struct {
+	Type      EntryType
+	ID        uuid.UUID
+	Date      time.Time
+	Reference string // Number of the invoice or credit note, reference of the payment
+	Amount    money.Money
+	Balance   money.Money // What the account owes once the entry is applied
+}
+
+// PendingMovement is a movement of an account not invoiced yet, so it is not owed but will be.
+type PendingMovement struct {
+	ID          uuid.UUID
+	Date        time.Time
+	Description string
+	Amount      money.Money // Amount including tax, negative for the movements that credit the account
+}
+
+// StatementPeriod is the range of days a statement covers, both included.
+type StatementPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewStatementPeriod returns the period between two days, which are truncated to the day.
+func NewStatementPeriod(from, to time.Time) (StatementPeriod, error) {
+	from, to = from.Truncate(24*time.Hour), to.Truncate(24*time.Hour)
+	if from.IsZero() || to.IsZero() {
+		return StatementPeriod{}, fmt.Errorf("%w: both ends are required", ErrInvalidStatementPeriod)
+	}
+	if from.After(to) {
+		return StatementPeriod{}, fmt.Errorf("%w: %s is after %s", ErrInvalidStatementPeriod,
+			from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	return StatementPeriod{From: from, To: to}, nil
+}
+
+// End returns the first instant after the period.
+func (p StatementPeriod) End() time.Time {
+	return p.To.AddDate(0, 0, 1)
+}
+
+// Statement is what an account owes in a currency over a period: the balance it opened with, the documents that
+// changed it with the running balance after each of them, and the balance it closed with.
+type Statement struct {
+	AccountID      string
+	Currency       money.Currency
+	Period         StatementPeriod
+	OpeningBalance money.Money
+	Entries        []StatementEntry
+	Charged        money.Money // Sum of the invoices of the period
+	Credited       money.Money // Sum of the credit notes and payments of the period, as a positive amount
+	ClosingBalance money.Money
+	// OwedSince is the day since which the account has owed something without interruption, zero when the
+	// closing balance is not positive
+	OwedSince    time.Time
+	Pending      []PendingMovement
+	PendingTotal money.Money
+}
+
+// NewStatement builds the statement of an account for a period from its history, the entries dated up to the end
+// of the period, and the movements still pending at that time. The entries before the period make up the opening
+// balance; the whole history is needed to know since when the account owes its closing balance.
+func NewStatement(accountID string, currency money.Currency, period StatementPeriod, history []StatementEntry, pending []PendingMovement) (Statement, error) {
+	if accountID == "" {
+		return Statement{}, ErrAccountIDEmpty
+	}
+
+	statement := Statement{
+		AccountID:      accountID,
+		Currency:       currency,
+		Period:         period,
+		OpeningBalance: money.Zero(currency),
+		Entries:        []StatementEntry{},
+		Charged:        money.Zero(currency),
+		Credited:       money.Zero(currency),
+		Pending:        pending,
+		PendingTotal:   money.Zero(currency),
+	}
+
+	history = append([]StatementEntry(nil), history...)
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Date.Before(history[j].Date) })
+
+	balance := money.Zero(currency)
+	for _, entry := range history {
+		if !entry.Date.Before(period.End()) {
+			continue
+		}
+		previous := balance
+		var err error
+		if balance, err = balance.Add(entry.Amount); err != nil {
+			return Statement{}, fmt.Errorf("entry %s %s: %w", entry.Type, entry.ID, err)
+		}
+		if balance.IsPositive() && !previous.IsPositive() {
+			statement.OwedSince = entry.Date
+		}
+
+		if entry.Date.Before(period.From) {
+			statement.OpeningBalance = balance
+			continue
+		}
+		entry.Balance = balance
+		statement.Entries = append(statement.Entries, entry)
+		if entry.Amount.IsPositive() {
+			statement.Charged, _ = statement.Charged.Add(entry.Amount)
+		} else {
+			statement.Credited, _ = statement.Credited.Sub(entry.Amount)
+		}
+	}
+	statement.ClosingBalance = balance
+	if !balance.IsPositive() {
+		statement.OwedSince = time.Time{}
+	}
+
+	for _, movement := range pending {
+		var err error
+		if statement.PendingTotal, err = statement.PendingTotal.Add(movement.Amount); err != nil {
+			return Statement{}, fmt.Errorf("pending movement %s: %w", movement.ID, err)
+		}
+	}
+	return statement, nil
+}
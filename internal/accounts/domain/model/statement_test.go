@@ -0,0 +1,106 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(value string) time.Time {
+	date, _ := time.Parse(time.DateOnly, value)
+	return date
+}
+
+func eur(minor int64) money.Money {
+	return money.New(minor, "EUR")
+}
+
+func entry(entryType model.EntryType, date string, minor int64) model.StatementEntry {
+	return model.StatementEntry{Type: entryType, ID: uuid.New(), Date: day(date), Amount: eur(minor)}
+}
+
+func statementPeriod(t *testing.T, from, to string) model.StatementPeriod {
+	period, err := model.NewStatementPeriod(day(from), day(to))
+	require.NoError(t, err)
+	return period
+}
+
+func TestNewStatement(t *testing.T) {
+	history := []model.StatementEntry{
+		entry(model.EntryTypeInvoice, "2025-05-10", 12100),
+		entry(model.EntryTypePayment, "2025-05-20", -12100),
+		entry(model.EntryTypeInvoice, "2025-06-10", 24200),
+		entry(model.EntryTypeCreditNote, "2025-07-02", -4200),
+		entry(model.EntryTypeInvoice, "2025-07-10", 12100),
+		entry(model.EntryTypePayment, "2025-07-15", -10000),
+	}
+	pending := []model.PendingMovement{
+		{ID: uuid.New(), Date: day("2025-07-20"), Amount: eur(5000)},
+		{ID: uuid.New(), Date: day("2025-07-25"), Amount: eur(-1000)},
+	}
+
+	statement, err := model.NewStatement("account_A", "EUR", statementPeriod(t, "2025-07-01", "2025-07-31"), history, pending)
+	require.NoError(t, err)
+
+	assert.Equal(t, eur(24200), statement.OpeningBalance)
+	require.Len(t, statement.Entries, 3)
+	assert.Equal(t, eur(20000), statement.Entries[0].Balance)
+	assert.Equal(t, eur(32100), statement.Entries[1].Balance)
+	assert.Equal(t, eur(22100), statement.Entries[2].Balance)
+	assert.Equal(t, eur(12100), statement.Charged)
+	assert.Equal(t, eur(14200), statement.Credited)
+	assert.Equal(t, eur(22100), statement.ClosingBalance)
+	assert.Equal(t, day("2025-06-10"), statement.OwedSince, "the account has owed since the June invoice")
+	assert.Equal(t, eur(4000), statement.PendingTotal)
+}
+
+func TestNewStatement_LeavesOutEntriesAfterThePeriod(t *testing.T) {
+	history := []model.StatementEntry{
+		entry(model.EntryTypeInvoice, "2025-07-10", 12100),
+		entry(model.EntryTypePayment, "2025-08-01", -12100),
+	}
+
+	statement, err := model.NewStatement("account_A", "EUR", statementPeriod(t, "2025-07-01", "2025-07-31"), history, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, statement.Entries, 1)
+	assert.Equal(t, eur(12100), statement.ClosingBalance)
+	assert.Equal(t, eur(0), statement.PendingTotal)
+}
+
+func TestNewStatement_SettledAccountOwesNothing(t *testing.T) {
+	history := []model.StatementEntry{
+		entry(model.EntryTypeInvoice, "2025-07-10", 12100),
+		entry(model.EntryTypePayment, "2025-07-15", -20000),
+	}
+
+	statement, err := model.NewStatement("account_A", "EUR", statementPeriod(t, "2025-07-01", "2025-07-31"), history, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, eur(-7900), statement.ClosingBalance, "the overpayment stays as a credit of the account")
+	assert.True(t, statement.OwedSince.IsZero())
+}
+
+func TestNewStatement_RejectsOtherCurrencies(t *testing.T) {
+	history := []model.StatementEntry{{Type: model.EntryTypeInvoice, ID: uuid.New(), Date: day("2025-07-10"), Amount: money.New(100, "USD")}}
+
+	_, err := model.NewStatement("account_A", "EUR", statementPeriod(t, "2025-07-01", "2025-07-31"), history, nil)
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+}
+
+func TestNewStatementPeriod(t *testing.T) {
+	_, err := model.NewStatementPeriod(day("2025-08-01"), day("2025-07-31"))
+	assert.ErrorIs(t, err, model.ErrInvalidStatementPeriod)
+
+	_, err = model.NewStatementPeriod(time.Time{}, day("2025-07-31"))
+	assert.ErrorIs(t, err, model.ErrInvalidStatementPeriod)
+
+	period, err := model.NewStatementPeriod(day("2025-07-31"), day("2025-07-31"))
+	require.NoError(t, err)
+	assert.Equal(t, day("2025-08-01"), period.End())
+}
@@ -0,0 +1,265 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+)
+
+var ErrInvalidTaxID = errors.New("invalid tax ID")
+
+// TaxIDType is the kind of tax identifier of a party.
+type TaxIDType string
+
+const (
+	TaxIDTypeNIF     TaxIDType = "NIF"     // Spanish natural persons: DNI, NIE and the K, L and M NIFs
+	TaxIDTypeCIF     TaxIDType = "CIF"     // Spanish legal entities
+	TaxIDTypeVAT     TaxIDType = "VAT"     // VAT number of a party established in another member state of the European Union
+	TaxIDTypeForeign TaxIDType = "FOREIGN" // Tax identifier of a party established outside the European Union
+)
+
+// TaxID is the tax identifier of a party, normalized to uppercase without spaces, dots or dashes.
+type TaxID struct {
+	Type  TaxIDType
+	Value string // e.g. 12345678Z, B12345674 or DE136695976
+}
+
+func (t TaxID) String() string {
+	return t.Value
+}
+
+// ParseTaxID validates the tax ID of a party established in the given country. Spanish parties are identified by
+// their NIF or CIF, optionally with the ES prefix of their VAT number, and parties of other member states by their
+// VAT number, with or without its country prefix. The check digits of Spanish, Belgian, German, French, Italian,
+// Polish and Portuguese identifiers are verified, other VAT numbers are checked against the format of their country.
+// Tax IDs of parties outside the European Union are only normalized.
+func ParseTaxID(value, country string) (TaxID, error) {
+	normalized := strings.ToUpper(taxIDSeparators.Replace(value))
+	if normalized == "" {
+		return TaxID{}, fmt.Errorf("%w: it cannot be empty", ErrInvalidTaxID)
+	}
+
+	location, err := taxes.NewLocation(country, "")
+	if err != nil {
+		return TaxID{}, err
+	}
+	switch territory := location.Territory(); {
+	case territory.IsSpanish():
+		return parseSpanishTaxID(strings.TrimPrefix(normalized, "ES"), value)
+	case territory == taxes.TerritoryEU:
+		return parseVATNumber(normalized, location.Country, value)
+	default:
+		return TaxID{Type: TaxIDTypeForeign, Value: normalized}, nil
+	}
+}
+
+var taxIDSeparators = strings.NewReplacer(" ", "", ".", "", "-", "")
+
+var (
+	dniPattern = regexp.MustCompile(`^[0-9]{8}[A-Z]$`)
+	niePattern = regexp.MustCompile(`^[XYZ][0-9]{7}[A-Z]$`)
+	cifPattern = regexp.MustCompile(`^[ABCDEFGHJKLMNPQRSUVW][0-9]{7}[0-9A-J]$`)
+)
+
+// dniLetters are the control letters of DNIs and NIEs, indexed by the number modulo 23.
+const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+// cifLetters are the control letters of CIFs, indexed by the control digit.
+const cifLetters = "JABCDEFGHI"
+
+func parseSpanishTaxID(normalized, value string) (TaxID, error) {
+	var number string
+	switch {
+	case dniPattern.MatchString(normalized):
+		number = normalized[:8]
+	case niePattern.MatchString(normalized):
+		// The leading X, Y and Z of NIEs stand for 0, 1 and 2
+		number = strconv.Itoa(strings.IndexByte("XYZ", normalized[0])) + normalized[1:8]
+	case cifPattern.MatchString(normalized):
+		return checkCIF(normalized, value)
+	default:
+		return TaxID{}, fmt.Errorf("%w: %q is not a Spanish NIF or CIF", ErrInvalidTaxID, value)
+	}
+
+	n, _ := strconv.Atoi(number)
+	if dniLetters[n%23] != normalized[8] {
+		return TaxID{}, fmt.Errorf("%w: %q fails its control letter", ErrInvalidTaxID, value)
+	}
+	return TaxID{Type: TaxIDTypeNIF, Value: normalized}, nil
+}
+
+// checkCIF verifies the control character of a CIF, or of the NIF of a K, L or M natural person, computed from its
+// seven digits. Depending on the kind of entity, the control is a digit, a letter or either of them.
+func checkCIF(normalized, value string) (TaxID, error) {
+	sum := 0
+	for i, r := range normalized[1:8] {
+		digit := int(r - '0')
+		if i%2 == 0 {
+			digit *= 2
+			digit = digit/10 + digit%10
+		}
+		sum += digit
+	}
+	controlDigit := (10 - sum%10) % 10
+	digit, letter := byte('0'+controlDigit), cifLetters[controlDigit]
+
+	taxIDType := TaxIDTypeCIF
+	control := normalized[8]
+	switch normalized[0] {
+	case 'K', 'L', 'M':
+		taxIDType = TaxIDTypeNIF
+		fallthrough
+	case 'N', 'P', 'Q', 'R', 'S', 'W':
+		if control != letter {
+			return TaxID{}, fmt.Errorf("%w: %q fails its control letter", ErrInvalidTaxID, value)
+		}
+	case 'A', 'B', 'E', 'H':
+		if control != digit {
+			return TaxID{}, fmt.Errorf("%w: %q fails its control digit", ErrInvalidTaxID, value)
+		}
+	default:
+		if control != digit && control != letter {
+			return TaxID{}, fmt.Errorf("%w: %q fails its control character", ErrInvalidTaxID, value)
+		}
+	}
+	return TaxID{Type: taxIDType, Value: normalized}, nil
+}
+
+// vatFormat is the format of the VAT numbers of a member state, and the check of their control digits when known.
+type vatFormat struct {
+	prefix  string // Greece uses EL instead of its ISO code
+	pattern *regexp.Regexp
+	check   func(number string) bool
+}
+
+// vatFormats are the VAT number formats of the member states of the European Union other than Spain, by ISO code.
+var vatFormats = map[string]vatFormat{
+	"AT": {prefix: "AT", pattern: regexp.MustCompile(`^U[0-9]{8}$`)},
+	"BE": {prefix: "BE", pattern: regexp.MustCompile(`^[01][0-9]{9}$`), check: checkBelgianVAT},
+	"BG": {prefix: "BG", pattern: regexp.MustCompile(`^[0-9]{9,10}$`)},
+	"CY": {prefix: "CY", pattern: regexp.MustCompile(`^[0-9]{8}[A-Z]$`)},
+	"CZ": {prefix: "CZ", pattern: regexp.MustCompile(`^[0-9]{8,10}$`)},
+	"DE": {prefix: "DE", pattern: regexp.MustCompile(`^[0-9]{9}$`), check: checkGermanVAT},
+	"DK": {prefix: "DK", pattern: regexp.MustCompile(`^[0-9]{8}$`)},
+	"EE": {prefix: "EE", pattern: regexp.MustCompile(`^[0-9]{9}$`)},
+	"FI": {prefix: "FI", pattern: regexp.MustCompile(`^[0-9]{8}$`)},
+	"FR": {prefix: "FR", pattern: regexp.MustCompile(`^[0-9A-HJ-NP-Z]{2}[0-9]{9}$`), check: checkFrenchVAT},
+	"GR": {prefix: "EL", pattern: regexp.MustCompile(`^[0-9]{9}$`)},
+	"HR": {prefix: "HR", pattern: regexp.MustCompile(`^[0-9]{11}$`)},
+	"HU": {prefix: "HU", pattern: regexp.MustCompile(`^[0-9]{8}$`)},
+	"IE": {prefix: "IE", pattern: regexp.MustCompile(`^([0-9]{7}[A-W][A-I]?|[0-9][A-Z+*][0-9]{5}[A-W])$`)},
+	"IT": {prefix: "IT", pattern: regexp.MustCompile(`^[0-9]{11}$`), check: checkLuhn},
+	"LT": {prefix: "LT", pattern: regexp.MustCompile(`^([0-9]{9}|[0-9]{12})$`)},
+	"LU": {prefix: "LU", pattern: regexp.MustCompile(`^[0-9]{8}$`)},
+	"LV": {prefix: "LV", pattern: regexp.MustCompile(`^[0-9]{11}$`)},
+	"MT": {prefix: "MT", pattern: regexp.MustCompile(`^[0-9]{8}$`)},
+	"NL": {prefix: "NL", pattern: regexp.MustCompile(`^[0-9]{9}B[0-9]{2}$`)},
+	"PL": {prefix: "PL", pattern: regexp.MustCompile(`^[0-9]{10}$`), check: checkPolishVAT},
+	"PT": {prefix: "PT", pattern: regexp.MustCompile(`^[0-9]{9}$`), check: checkPortugueseVAT},
+	"RO": {prefix: "RO", pattern: regexp.MustCompile(`^[1-9][0-9]{1,9}$`)},
+	"SE": {prefix: "SE", pattern: regexp.MustCompile(`^[0-9]{10}01$`)},
+	"SI": {prefix: "SI", pattern: regexp.MustCompile(`^[0-9]{8}$`)},
+	"SK": {prefix: "SK", pattern: regexp.MustCompile(`^[0-9]{10}$`)},
+}
+
+// parseVATNumber validates the VAT number of a party of another member state, adding its country prefix if missing.
+func parseVATNumber(normalized, country, value string) (TaxID, error) {
+	format, ok := vatFormats[country]
+	if !ok {
+		return TaxID{}, fmt.Errorf("%w: VAT numbers of %s are not supported", ErrInvalidTaxID, country)
+	}
+	number := strings.TrimPrefix(normalized, format.prefix)
+	if !format.pattern.MatchString(number) {
+		return TaxID{}, fmt.Errorf("%w: %q is not a VAT number of %s", ErrInvalidTaxID, value, country)
+	}
+	if format.check != nil && !format.check(number) {
+		return TaxID{}, fmt.Errorf("%w: %q fails its check digits", ErrInvalidTaxID, value)
+	}
+	return TaxID{Type: TaxIDTypeVAT, Value: format.prefix + number}, nil
+}
+
+func digits(number string) []int {
+	result := make([]int, len(number))
+	for i, r := range number {
+		result[i] = int(r - '0')
+	}
+	return result
+}
+
+// checkBelgianVAT verifies that the last two digits are 97 minus the first eight modulo 97.
+func checkBelgianVAT(number string) bool {
+	base, _ := strconv.Atoi(number[:8])
+	check, _ := strconv.Atoi(number[8:])
+	return 97-base%97 == check
+}
+
+// checkGermanVAT verifies the last digit with ISO 7064 MOD 11,10.
+func checkGermanVAT(number string) bool {
+	d := digits(number)
+	product := 10
+	for _, digit := range d[:8] {
+		sum := (digit + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = 2 * sum % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == d[8]
+}
+
+// checkFrenchVAT verifies the numeric key that precedes the SIREN. Alphanumeric keys have no public check.
+func checkFrenchVAT(number string) bool {
+	key, err := strconv.Atoi(number[:2])
+	if err != nil {
+		return true
+	}
+	siren, _ := strconv.Atoi(number[2:])
+	return key == (12+3*(siren%97))%97
+}
+
+// checkLuhn verifies the last digit with the Luhn algorithm, used by Italian VAT numbers.
+func checkLuhn(number string) bool {
+	sum := 0
+	for i, digit := range digits(number) {
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// checkPolishVAT verifies the last digit as the weighted sum of the others modulo 11.
+func checkPolishVAT(number string) bool {
+	d := digits(number)
+	sum := 0
+	for i, weight := range []int{6, 5, 7, 2, 3, 4, 5, 6, 7} {
+		sum += d[i] * weight
+	}
+	return sum%11 == d[9]
+}
+
+// checkPortugueseVAT verifies the last digit as 11 minus the weighted sum of the others modulo 11.
+func checkPortugueseVAT(number string) bool {
+	d := digits(number)
+	sum := 0
+	for i := range 8 {
+		sum += d[i] * (9 - i)
+	}
+	check := 11 - sum%11
+	if check >= 10 {
+		check = 0
+	}
+	return check == d[8]
+}
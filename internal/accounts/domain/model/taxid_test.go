@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaxID(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		country  string
+		expected model.TaxID
+	}{
+		{"DNI", "12345678-Z", "ES", model.TaxID{Type: model.TaxIDTypeNIF, Value: "12345678Z"}},
+		{"NIE", "x1234567l", "ES", model.TaxID{Type: model.TaxIDTypeNIF, Value: "X1234567L"}},
+		{"NIF of a K, L or M person", "K1234567D", "ES", model.TaxID{Type: model.TaxIDTypeNIF, Value: "K1234567D"}},
+		{"CIF with control digit", "B 1234567 4", "ES", model.TaxID{Type: model.TaxIDTypeCIF, Value: "B12345674"}},
+		{"CIF with control letter", "Q2826000H", "ES", model.TaxID{Type: model.TaxIDTypeCIF, Value: "Q2826000H"}},
+		{"Spanish VAT number", "ESB12345674", "ES", model.TaxID{Type: model.TaxIDTypeCIF, Value: "B12345674"}},
+		{"German VAT number", "DE136695976", "DE", model.TaxID{Type: model.TaxIDTypeVAT, Value: "DE136695976"}},
+		{"French VAT number", "FR 40 303265045", "FR", model.TaxID{Type: model.TaxIDTypeVAT, Value: "FR40303265045"}},
+		{"Italian VAT number", "IT00743110157", "IT", model.TaxID{Type: model.TaxIDTypeVAT, Value: "IT00743110157"}},
+		{"Portuguese VAT number without prefix", "501964843", "PT", model.TaxID{Type: model.TaxIDTypeVAT, Value: "PT501964843"}},
+		{"Belgian VAT number", "BE0403.170.701", "BE", model.TaxID{Type: model.TaxIDTypeVAT, Value: "BE0403170701"}},
+		{"Polish VAT number", "PL5260001246", "PL", model.TaxID{Type: model.TaxIDTypeVAT, Value: "PL5260001246"}},
+		{"Greek VAT number", "094259216", "GR", model.TaxID{Type: model.TaxIDTypeVAT, Value: "EL094259216"}},
+		{"Dutch VAT number", "NL004495445B01", "NL", model.TaxID{Type: model.TaxIDTypeVAT, Value: "NL004495445B01"}},
+		{"outside the European Union", "12-3456789", "us", model.TaxID{Type: model.TaxIDTypeForeign, Value: "123456789"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxID, err := model.ParseTaxID(tt.value, tt.country)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, taxID)
+		})
+	}
+}
+
+func TestParseTaxID_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		country  string
+		expected string
+	}{
+		{"empty", " ", "ES", "cannot be empty"},
+		{"DNI with wrong letter", "12345678A", "ES", "fails its control letter"},
+		{"NIE with wrong letter", "X1234567A", "ES", "fails its control letter"},
+		{"CIF with wrong digit", "B12345675", "ES", "fails its control digit"},
+		{"CIF with a digit instead of a letter", "Q28260008", "ES", "fails its control letter"},
+		{"not a Spanish identifier", "DE136695976", "ES", "is not a Spanish NIF or CIF"},
+		{"VAT number of another country", "FR40303265045", "DE", "is not a VAT number of DE"},
+		{"German VAT number with wrong check digit", "DE136695977", "DE", "fails its check digits"},
+		{"French VAT number with wrong key", "FR41303265045", "FR", "fails its check digits"},
+		{"Italian VAT number with wrong check digit", "IT00743110158", "IT", "fails its check digits"},
+		{"Portuguese VAT number with wrong check digit", "PT501964844", "PT", "fails its check digits"},
+		{"Belgian VAT number with wrong check digits", "BE0403170702", "BE", "fails its check digits"},
+		{"Polish VAT number with wrong check digit", "PL5260001247", "PL", "fails its check digits"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := model.ParseTaxID(tt.value, tt.country)
+			assert.ErrorIs(t, err, model.ErrInvalidTaxID)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
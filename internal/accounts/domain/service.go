@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Repository defines the persistence needed by accounts.
+// It's implemented by an adapter in the infrastructure layer.
+type Repository interface {
+	// GetAccount returns an account, or model.ErrAccountNotFound.
+	GetAccount(ctx context.Context, id string) (model.Account, error)
+	// LockAccount returns an account, locking it until the transaction ends, or model.ErrAccountNotFound.
+	LockAccount(ctx context.Context, id string) (model.Account, error)
+	// SaveAccount creates the account, or updates it when it exists.
+	SaveAccount(ctx context.Context, account model.Account) error
+	// GetStatementEntries returns the issued invoices and credit notes and the payments of an account in a currency
+	// dated before the given time, oldest first.
+	GetStatementEntries(ctx context.Context, accountID string, currency money.Currency, before time.Time) ([]model.StatementEntry, error)
+	// GetPendingMovements returns the movements of an account in a currency dated before the given time that are not
+	// invoiced yet, oldest first.
+	GetPendingMovements(ctx context.Context, accountID string, currency money.Currency, before time.Time) ([]model.PendingMovement, error)
+}
+
+// Transactor runs a unit of work in a single database transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	repo       Repository
+	transactor Transactor
+	logger     zerolog.Logger
+}
+
+func NewService(repo Repository, transactor Transactor) Service {
+	return Service{
+		repo:       repo,
+		transactor: transactor,
+		logger:     log.With().Str("module", "accountsService").Logger(),
+	}
+}
+
+// GetAccount returns an account with its billing profile.
+func (s Service) GetAccount(ctx context.Context, id string) (model.Account, error) {
+	s.logger.Info().Str("account_id", id).Msg("Fetching account")
+
+	account, err := s.repo.GetAccount(ctx, id)
+	if err != nil {
+		s.logger.Error().Err(err).Str("account_id", id).Msg("Failed to fetch account")
+		return model.Account{}, err
+	}
+	return account, nil
+}
+
+// UpdateBillingProfile changes the given fields of the billing profile of an account. The account is created with
+// its first profile, which needs all the required fields.
+func (s Service) UpdateBillingProfile(ctx context.Context, id string, changes model.ProfileChanges) (model.Account, error) {
+	logger := s.logger.With().Str("account_id", id).Logger()
+	logger.Info().Msg("Updating billing profile")
+
+	var account model.Account
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		account, err = s.repo.LockAccount(ctx, id)
+		if errors.Is(err, model.ErrAccountNotFound) {
+			account = model.Account{ID: id}
+		} else if err != nil {
+			return err
+		}
+
+		if err := account.UpdateBillingProfile(changes); err != nil {
+			return err
+		}
+		if err := s.repo.SaveAccount(ctx, account); err != nil {
+			return fmt.Errorf("failed to save account: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to update billing profile")
+		return model.Account{}, err
+	}
+
+	// Read the account back for the timestamps set by the database
+	return s.GetAccount(ctx, id)
+}
@@ -0,0 +1,115 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newService(ctrl *gomock.Controller) (domain.Service, *domain.MockRepository) {
+	repo := domain.NewMockRepository(ctrl)
+	transactor := domain.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+	).AnyTimes()
+	return domain.NewService(repo, transactor), repo
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func existingAccount(t *testing.T) model.Account {
+	profile, err := model.NewBillingProfile(model.BillingDetails{
+		LegalName:     "Acme Telecom S.L.",
+		TaxID:         "B12345674",
+		FiscalAddress: model.Address{Street: "Calle Mayor 1", PostalCode: "28013", Town: "Madrid", Country: "ES"},
+		BillingEmail:  "billing@acme.example",
+	})
+	require.NoError(t, err)
+	return model.Account{ID: "account_A", Profile: profile}
+}
+
+func TestService_UpdateBillingProfile_ChangesTheGivenFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+
+	account := existingAccount(t)
+	repo.EXPECT().LockAccount(ctx, "account_A").Return(account, nil)
+	repo.EXPECT().SaveAccount(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, saved model.Account) error {
+		assert.Equal(t, model.PaymentMethodDirectDebit, saved.Profile.PaymentMethod)
+		assert.Equal(t, account.Profile.TaxID, saved.Profile.TaxID)
+		account = saved
+		return nil
+	})
+	repo.EXPECT().GetAccount(ctx, "account_A").DoAndReturn(func(context.Context, string) (model.Account, error) { return account, nil })
+
+	updated, err := service.UpdateBillingProfile(ctx, "account_A", model.ProfileChanges{PaymentMethod: ptr("direct_debit")})
+	require.NoError(t, err)
+	assert.Equal(t, model.PaymentMethodDirectDebit, updated.Profile.PaymentMethod)
+}
+
+func TestService_UpdateBillingProfile_CreatesTheAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+
+	repo.EXPECT().LockAccount(ctx, "account_B").Return(model.Account{}, model.ErrAccountNotFound)
+	repo.EXPECT().SaveAccount(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, saved model.Account) error {
+		assert.Equal(t, "account_B", saved.ID)
+		assert.Equal(t, model.TaxID{Type: model.TaxIDTypeVAT, Value: "DE136695976"}, saved.Profile.TaxID)
+		return nil
+	})
+	repo.EXPECT().GetAccount(ctx, "account_B").Return(model.Account{ID: "account_B"}, nil)
+
+	_, err := service.UpdateBillingProfile(ctx, "account_B", model.ProfileChanges{
+		LegalName:    ptr("Beispiel GmbH"),
+		TaxID:        ptr("136695976"),
+		Street:       ptr("Unter den Linden 1"),
+		Town:         ptr("Berlin"),
+		Country:      ptr("DE"),
+		BillingEmail: ptr("rechnung@beispiel.example"),
+	})
+	require.NoError(t, err)
+}
+
+func TestService_UpdateBillingProfile_InvalidProfileIsNotSaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+
+	repo.EXPECT().LockAccount(ctx, "account_A").Return(existingAccount(t), nil)
+
+	_, err := service.UpdateBillingProfile(ctx, "account_A", model.ProfileChanges{TaxID: ptr("B12345675")})
+	assert.ErrorIs(t, err, model.ErrInvalidTaxID)
+}
+
+func TestService_UpdateBillingProfile_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+
+	dbErr := errors.New("connection refused")
+	repo.EXPECT().LockAccount(ctx, "account_A").Return(model.Account{}, dbErr)
+
+	_, err := service.UpdateBillingProfile(ctx, "account_A", model.ProfileChanges{BillingEmail: ptr("billing@acme.example")})
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestService_GetAccount_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+
+	repo.EXPECT().GetAccount(ctx, "account_Z").Return(model.Account{}, model.ErrAccountNotFound)
+
+	_, err := service.GetAccount(ctx, "account_Z")
+	assert.ErrorIs(t, err, model.ErrAccountNotFound)
+}
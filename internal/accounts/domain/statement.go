@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+// GetAccountStatement returns what an account owes in a currency over a period, from its issued invoices, credit
+// notes and payments, together with the movements not invoiced yet by the end of the period. Documents in other
+// currencies are left out. Accounts without a billing profile have a statement too.
+func (s Service) GetAccountStatement(ctx context.Context, accountID string, currency money.Currency, period model.StatementPeriod) (model.Statement, error) {
+	logger := s.logger.With().Str("account_id", accountID).Str("currency", currency.String()).Logger()
+	logger.Info().Time("from", period.From).Time("to", period.To).Msg("Building account statement")
+
+	history, err := s.repo.GetStatementEntries(ctx, accountID, currency, period.End())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to fetch statement entries")
+		return model.Statement{}, fmt.Errorf("failed to fetch statement entries: %w", err)
+	}
+	pending, err := s.repo.GetPendingMovements(ctx, accountID, currency, period.End())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to fetch pending movements")
+		return model.Statement{}, fmt.Errorf("failed to fetch pending movements: %w", err)
+	}
+
+	statement, err := model.NewStatement(accountID, currency, period, history, pending)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to build account statement")
+		return model.Statement{}, err
+	}
+	return statement, nil
+}
@@ -0,0 +1,55 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func julyPeriod(t *testing.T) model.StatementPeriod {
+	period, err := model.NewStatementPeriod(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return period
+}
+
+func TestService_GetAccountStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+	period := julyPeriod(t)
+
+	repo.EXPECT().GetStatementEntries(ctx, "account_A", money.Currency("EUR"), period.End()).Return([]model.StatementEntry{
+		{Type: model.EntryTypeInvoice, ID: uuid.New(), Date: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), Amount: money.New(12100, "EUR")},
+		{Type: model.EntryTypePayment, ID: uuid.New(), Date: time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC), Amount: money.New(-2100, "EUR")},
+	}, nil)
+	repo.EXPECT().GetPendingMovements(ctx, "account_A", money.Currency("EUR"), period.End()).Return([]model.PendingMovement{
+		{ID: uuid.New(), Date: time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC), Amount: money.New(5000, "EUR")},
+	}, nil)
+
+	statement, err := service.GetAccountStatement(ctx, "account_A", "EUR", period)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(12100, "EUR"), statement.OpeningBalance)
+	assert.Len(t, statement.Entries, 1)
+	assert.Equal(t, money.New(10000, "EUR"), statement.ClosingBalance)
+	assert.Equal(t, money.New(5000, "EUR"), statement.PendingTotal)
+}
+
+func TestService_GetAccountStatement_PropagatesRepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, repo := newService(ctrl)
+	ctx := context.Background()
+	dbErr := errors.New("connection refused")
+
+	repo.EXPECT().GetStatementEntries(ctx, "account_A", money.Currency("EUR"), gomock.Any()).Return(nil, dbErr)
+
+	_, err := service.GetAccountStatement(ctx, "account_A", "EUR", julyPeriod(t))
+	assert.ErrorIs(t, err, dbErr)
+}
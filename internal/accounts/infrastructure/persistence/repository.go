@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/infrastructure/persistence/sql"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	client    sql.AccountSqlClient
+	converter sql.AccountSqlConverter
+	logger    zerolog.Logger
+}
+
+func NewRepository(client sql.AccountSqlClient, converter sql.AccountSqlConverter) Repository {
+	return Repository{
+		client:    client,
+		converter: converter,
+		logger:    log.With().Str("component", "AccountsPersistenceRepository").Logger(),
+	}
+}
+
+func (r Repository) GetAccount(ctx context.Context, id string) (model.Account, error) {
+	return r.getAccount(ctx, id, false)
+}
+
+func (r Repository) LockAccount(ctx context.Context, id string) (model.Account, error) {
+	return r.getAccount(ctx, id, true)
+}
+
+func (r Repository) getAccount(ctx context.Context, id string, lock bool) (model.Account, error) {
+	account, err := r.client.GetAccount(ctx, id, lock)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Account{}, model.ErrAccountNotFound
+		}
+		r.logger.Error().Err(err).Str("account_id", id).Msg("Failed to fetch account")
+		return model.Account{}, err
+	}
+	return r.converter.AccountToDomain(account), nil
+}
+
+func (r Repository) SaveAccount(ctx context.Context, account model.Account) error {
+	r.logger.Info().Str("account_id", account.ID).Msg("Saving account")
+
+	if err := r.client.SaveAccount(ctx, r.converter.AccountToSql(account)); err != nil {
+		r.logger.Error().Err(err).Str("account_id", account.ID).Msg("Failed to save account")
+		return err
+	}
+	return nil
+}
+
+func (r Repository) GetStatementEntries(ctx context.Context, accountID string, currency money.Currency, before time.Time) ([]model.StatementEntry, error) {
+	sqlEntries, err := r.client.GetStatementEntries(ctx, accountID, currency.String(), before)
+	if err != nil {
+		r.logger.Error().Err(err).Str("account_id", accountID).Msg("Failed to fetch statement entries")
+		return nil, err
+	}
+
+	entries := make([]model.StatementEntry, len(sqlEntries))
+	for i, entry := range sqlEntries {
+		entries[i] = r.converter.StatementEntryToDomain(entry)
+	}
+	return entries, nil
+}
+
+func (r Repository) GetPendingMovements(ctx context.Context, accountID string, currency money.Currency, before time.Time) ([]model.PendingMovement, error) {
+	sqlMovements, err := r.client.GetPendingMovements(ctx, accountID, currency.String(), before)
+	if err != nil {
+		r.logger.Error().Err(err).Str("account_id", accountID).Msg("Failed to fetch pending movements")
+		return nil, err
+	}
+
+	movements := make([]model.PendingMovement, len(sqlMovements))
+	for i, movement := range sqlMovements {
+		movements[i] = r.converter.PendingMovementToDomain(movement)
+	}
+	return movements, nil
+}
@@ -0,0 +1,73 @@
+package sql
+
+import (
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+type AccountSqlConverter struct{}
+
+func NewAccountSqlConverter() AccountSqlConverter {
+	return AccountSqlConverter{}
+}
+
+func (c AccountSqlConverter) AccountToSql(account model.Account) Account {
+	profile := account.Profile
+	return Account{
+		ID:              account.ID,
+		LegalName:       profile.LegalName,
+		TaxIDType:       string(profile.TaxID.Type),
+		TaxID:           profile.TaxID.Value,
+		Street:          profile.FiscalAddress.Street,
+		PostalCode:      profile.FiscalAddress.PostalCode,
+		Town:            profile.FiscalAddress.Town,
+		Province:        profile.FiscalAddress.Province,
+		Country:         profile.FiscalAddress.Country,
+		BillingEmail:    profile.BillingEmail,
+		Language:        string(profile.Language),
+		PaymentMethod:   string(profile.PaymentMethod),
+		BillingCycleDay: profile.BillingCycleDay,
+	}
+}
+
+func (c AccountSqlConverter) AccountToDomain(account Account) model.Account {
+	return model.Account{
+		ID: account.ID,
+		Profile: model.BillingProfile{
+			LegalName: account.LegalName,
+			TaxID:     model.TaxID{Type: model.TaxIDType(account.TaxIDType), Value: account.TaxID},
+			FiscalAddress: model.Address{
+				Street:     account.Street,
+				PostalCode: account.PostalCode,
+				Town:       account.Town,
+				Province:   account.Province,
+				Country:    account.Country,
+			},
+			BillingEmail:    account.BillingEmail,
+			Language:        model.Language(account.Language),
+			PaymentMethod:   model.PaymentMethod(account.PaymentMethod),
+			BillingCycleDay: account.BillingCycleDay,
+		},
+		CreatedAt: account.CreatedAt,
+		UpdatedAt: account.UpdatedAt,
+	}
+}
+
+func (c AccountSqlConverter) StatementEntryToDomain(entry StatementEntry) model.StatementEntry {
+	return model.StatementEntry{
+		Type:      model.EntryType(entry.EntryType),
+		ID:        entry.ID,
+		Date:      entry.EntryDate,
+		Reference: entry.Reference,
+		Amount:    money.New(int64(entry.Amount), money.Currency(entry.Currency)),
+	}
+}
+
+func (c AccountSqlConverter) PendingMovementToDomain(movement PendingMovement) model.PendingMovement {
+	return model.PendingMovement{
+		ID:          movement.ID,
+		Date:        movement.TransactionDate,
+		Description: movement.Description,
+		Amount:      money.New(int64(movement.Amount), money.Currency(movement.Currency)),
+	}
+}
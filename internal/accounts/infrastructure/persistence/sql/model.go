@@ -0,0 +1,52 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+// Account represents the billing profile of an account. Accounts are identified by the ID their movements and
+// invoices refer to.
+type Account struct {
+	ID              string `gorm:"type:varchar(255);primaryKey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	LegalName       string `gorm:"type:varchar(255);not null"`
+	TaxIDType       string `gorm:"column:tax_id_type;type:varchar(10);not null"`
+	TaxID           string `gorm:"column:tax_id;type:varchar(20);not null"`
+	Street          string `gorm:"type:varchar(255);not null"`
+	PostalCode      string `gorm:"type:varchar(20);not null"`
+	Town            string `gorm:"type:varchar(100);not null"`
+	Province        string `gorm:"type:varchar(100);not null"`
+	Country         string `gorm:"type:char(2);not null"`
+	BillingEmail    string `gorm:"type:varchar(255);not null"`
+	Language        string `gorm:"type:char(2);not null"`
+	PaymentMethod   string `gorm:"type:varchar(20);not null"`
+	BillingCycleDay int    `gorm:"type:smallint;not null"`
+}
+
+// TableName specifies the table name for Account in the database.
+func (Account) TableName() string {
+	return "accounts"
+}
+
+// StatementEntry is an issued invoice or credit note, or a payment, as it changes what the account owes.
+type StatementEntry struct {
+	EntryType string
+	ID        uuid.UUID
+	EntryDate time.Time
+	Reference string
+	Currency  string
+	Amount    commons.Decimal
+}
+
+// PendingMovement is a movement not invoiced yet.
+type PendingMovement struct {
+	ID              uuid.UUID
+	TransactionDate time.Time
+	Description     string
+	Currency        string
+	Amount          commons.Decimal
+}
@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Invoices left out of statements: drafts were never issued and void invoices are not owed
+var unissuedInvoiceStatuses = []string{"DRAFT", "VOID"}
+
+// pendingMovementStatus is the status of the movements not invoiced yet
+const pendingMovementStatus = "PENDING"
+
+// statementEntriesQuery reads the issued invoices and credit notes and the payments of an account in a currency, with
+// the sign of what they add to the debt of the account: credit notes are stored with negative totals already.
+const statementEntriesQuery = `
+	SELECT entry_type, id, entry_date, reference, currency, amount FROM (
+		SELECT CASE WHEN invoice_type = 'CREDIT_NOTE' THEN 'CREDIT_NOTE' ELSE 'INVOICE' END AS entry_type,
+			id, issue_date AS entry_date, COALESCE(invoice_number, '') AS reference, currency,
+			total_amount_with_tax AS amount
+		FROM invoices
+		WHERE account_id = @account AND currency = @currency AND issue_date < @before
+			AND status NOT IN @unissued AND deleted_at IS NULL
+		UNION ALL
+		SELECT 'PAYMENT', id, payment_date, COALESCE(reference, ''), currency, -amount
+		FROM payments
+		WHERE account_id = @account AND currency = @currency AND payment_date < @before AND deleted_at IS NULL
+	) entries
+	ORDER BY entry_date, entry_type, id`
+
+// AccountSqlClient runs the accounts queries. Every method joins the transaction carried by the context, if any.
+type AccountSqlClient struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewAccountSqlClient(db *gorm.DB) AccountSqlClient {
+	return AccountSqlClient{
+		db:     db,
+		logger: log.With().Str("component", "AccountSqlClient").Logger(),
+	}
+}
+
+// GetAccount retrieves an account by its ID, locking it when lock is set
+func (c AccountSqlClient) GetAccount(ctx context.Context, id string, lock bool) (account Account, err error) {
+	query := commons.Conn(ctx, c.db).Where("id = ?", id)
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	err = query.Take(&account).Error
+	return
+}
+
+// SaveAccount inserts the account, or updates every field but its creation time when it exists
+func (c AccountSqlClient) SaveAccount(ctx context.Context, account Account) error {
+	return commons.Conn(ctx, c.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true}).
+		Create(&account).Error
+}
+
+// GetStatementEntries returns the issued invoices and credit notes and the payments of an account in a currency dated
+// before the given time, oldest first
+func (c AccountSqlClient) GetStatementEntries(ctx context.Context, accountID, currency string, before time.Time) (entries []StatementEntry, err error) {
+	err = commons.Conn(ctx, c.db).Raw(statementEntriesQuery, map[string]any{
+		"account":  accountID,
+		"currency": currency,
+		"before":   before,
+		"unissued": unissuedInvoiceStatuses,
+	}).Scan(&entries).Error
+	return
+}
+
+// GetPendingMovements returns the movements of an account in a currency dated before the given time that are not
+// invoiced yet, oldest first
+func (c AccountSqlClient) GetPendingMovements(ctx context.Context, accountID, currency string, before time.Time) (movements []PendingMovement, err error) {
+	err = commons.Conn(ctx, c.db).Table("movements").
+		Select("id, transaction_date, COALESCE(description, '') AS description, currency, amount").
+		Where("account_id = ? AND currency = ? AND status = ? AND transaction_date < ?", accountID, currency, pendingMovementStatus, before).
+		Where("deleted_at IS NULL").
+		Order("transaction_date, id").
+		Scan(&movements).Error
+	return
+}
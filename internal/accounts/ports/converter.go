@@ -0,0 +1,239 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrInvalidAccount             = errors.New("invalid account")
+	ErrInvalidStatement           = errors.New("invalid account statement")
+	ErrInvalidDate                = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrUnsupportedStatementFormat = errors.New("unsupported statement format")
+)
+
+// StatementFormat is a format account statements can be returned in.
+type StatementFormat string
+
+const (
+	StatementFormatJSON StatementFormat = "json"
+	StatementFormatCSV  StatementFormat = "csv" // A row per entry between the opening and closing balances
+)
+
+type Converter struct{}
+
+func NewConverter() Converter {
+	return Converter{}
+}
+
+// ConvertRequestArgsToChanges reads the fields of the billing profile to change from the UpdateBillingProfile tool
+// arguments. Omitted arguments keep their value.
+func (c Converter) ConvertRequestArgsToChanges(args map[string]any) (model.ProfileChanges, error) {
+	var changes model.ProfileChanges
+	var err error
+	for _, arg := range []struct {
+		name  string
+		field **string
+	}{
+		{"legalName", &changes.LegalName},
+		{"taxId", &changes.TaxID},
+		{"street", &changes.Street},
+		{"postalCode", &changes.PostalCode},
+		{"town", &changes.Town},
+		{"province", &changes.Province},
+		{"country", &changes.Country},
+		{"billingEmail", &changes.BillingEmail},
+		{"preferredLanguage", &changes.Language},
+		{"paymentMethod", &changes.PaymentMethod},
+	} {
+		if *arg.field, err = stringArg(args, arg.name); err != nil {
+			return model.ProfileChanges{}, err
+		}
+	}
+
+	if raw, ok := args["billingCycleDay"]; ok && raw != nil {
+		day, ok := raw.(float64)
+		if !ok || day != math.Trunc(day) || day < 1 || day > model.MaxBillingCycleDay {
+			return model.ProfileChanges{}, fmt.Errorf("%w: billingCycleDay must be a day between 1 and %d",
+				model.ErrInvalidBillingCycleDay, model.MaxBillingCycleDay)
+		}
+		billingCycleDay := int(day)
+		changes.BillingCycleDay = &billingCycleDay
+	}
+	return changes, nil
+}
+
+// stringArg reads an optional string argument, nil when it is omitted
+func stringArg(args map[string]any, name string) (*string, error) {
+	raw, ok := args[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s must be a string", model.ErrInvalidBillingProfile, name)
+	}
+	return &value, nil
+}
+
+func (c Converter) ConvertAccountToJson(account model.Account) ([]byte, error) {
+	profile := account.Profile
+	dto := Account{
+		ID:        account.ID,
+		LegalName: profile.LegalName,
+		TaxID:     profile.TaxID.Value,
+		TaxIDType: string(profile.TaxID.Type),
+		FiscalAddress: Address{
+			Street:     profile.FiscalAddress.Street,
+			PostalCode: profile.FiscalAddress.PostalCode,
+			Town:       profile.FiscalAddress.Town,
+			Province:   profile.FiscalAddress.Province,
+			Country:    profile.FiscalAddress.Country,
+		},
+		BillingEmail:      profile.BillingEmail,
+		PreferredLanguage: string(profile.Language),
+		PaymentMethod:     string(profile.PaymentMethod),
+		BillingCycleDay:   profile.BillingCycleDay,
+		CreatedAt:         account.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         account.UpdatedAt.Format(time.RFC3339),
+	}
+
+	jsonData, err := json.Marshal(dto)
+	if err != nil {
+		return nil, ErrInvalidAccount
+	}
+	return jsonData, nil
+}
+
+// StatementRequest is what the GetAccountStatement tool asks for.
+type StatementRequest struct {
+	Currency money.Currency
+	Period   model.StatementPeriod
+	Format   StatementFormat
+}
+
+// ConvertRequestArgsToStatementRequest reads the GetAccountStatement tool arguments. The period ends today and starts
+// on the first day of the month it ends in, unless given; the currency defaults to the default currency and the
+// format to JSON.
+func (c Converter) ConvertRequestArgsToStatementRequest(args map[string]any) (StatementRequest, error) {
+	request := StatementRequest{Currency: money.DefaultCurrency, Format: StatementFormatJSON}
+	if value, ok := args["currency"].(string); ok && value != "" {
+		currency, err := money.ParseCurrency(value)
+		if err != nil {
+			return StatementRequest{}, err
+		}
+		request.Currency = currency
+	}
+	if value, ok := args["format"].(string); ok && value != "" {
+		switch format := StatementFormat(value); format {
+		case StatementFormatJSON, StatementFormatCSV:
+			request.Format = format
+		default:
+			return StatementRequest{}, fmt.Errorf("%w: %q", ErrUnsupportedStatementFormat, value)
+		}
+	}
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if value, ok := args["to"].(string); ok && value != "" {
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return StatementRequest{}, fmt.Errorf("%w: to %q", ErrInvalidDate, value)
+		}
+		to = date
+	}
+	from := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if value, ok := args["from"].(string); ok && value != "" {
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return StatementRequest{}, fmt.Errorf("%w: from %q", ErrInvalidDate, value)
+		}
+		from = date
+	}
+
+	period, err := model.NewStatementPeriod(from, to)
+	if err != nil {
+		return StatementRequest{}, err
+	}
+	request.Period = period
+	return request, nil
+}
+
+func (c Converter) ConvertStatementToJson(statement model.Statement) ([]byte, error) {
+	dto := Statement{
+		AccountID:        statement.AccountID,
+		Currency:         statement.Currency.String(),
+		From:             statement.Period.From.Format(time.DateOnly),
+		To:               statement.Period.To.Format(time.DateOnly),
+		OpeningBalance:   statement.OpeningBalance.Amount(),
+		Charged:          statement.Charged.Amount(),
+		Credited:         statement.Credited.Amount(),
+		ClosingBalance:   statement.ClosingBalance.Amount(),
+		Entries:          make([]StatementEntry, len(statement.Entries)),
+		PendingMovements: make([]PendingMovement, len(statement.Pending)),
+		PendingTotal:     statement.PendingTotal.Amount(),
+	}
+	if !statement.OwedSince.IsZero() {
+		dto.OwedSince = statement.OwedSince.Format(time.DateOnly)
+	}
+	for i, entry := range statement.Entries {
+		dto.Entries[i] = StatementEntry{
+			Date:      entry.Date.Format(time.DateOnly),
+			Type:      string(entry.Type),
+			ID:        entry.ID.String(),
+			Reference: entry.Reference,
+			Amount:    entry.Amount.Amount(),
+			Balance:   entry.Balance.Amount(),
+		}
+	}
+	for i, movement := range statement.Pending {
+		dto.PendingMovements[i] = PendingMovement{
+			Date:        movement.Date.Format(time.DateOnly),
+			ID:          movement.ID.String(),
+			Description: movement.Description,
+			Amount:      movement.Amount.Amount(),
+		}
+	}
+
+	jsonData, err := json.Marshal(dto)
+	if err != nil {
+		return nil, ErrInvalidStatement
+	}
+	return jsonData, nil
+}
+
+// statementCSVHeader are the columns of the CSV statements
+var statementCSVHeader = []string{"date", "type", "id", "reference", "amount", "balance", "currency"}
+
+// ConvertStatementToCSV writes the statement as CSV: the opening balance, a row per entry with the running balance,
+// the closing balance, and then the pending movements, which have no balance as they are not owed yet.
+func (c Converter) ConvertStatementToCSV(statement model.Statement) ([]byte, error) {
+	currency := statement.Currency.String()
+	rows := [][]string{
+		statementCSVHeader,
+		{statement.Period.From.Format(time.DateOnly), "OPENING_BALANCE", "", "", "", statement.OpeningBalance.Amount(), currency},
+	}
+	for _, entry := range statement.Entries {
+		rows = append(rows, []string{entry.Date.Format(time.DateOnly), string(entry.Type), entry.ID.String(),
+			entry.Reference, entry.Amount.Amount(), entry.Balance.Amount(), currency})
+	}
+	rows = append(rows, []string{statement.Period.To.Format(time.DateOnly), "CLOSING_BALANCE", "", "", "", statement.ClosingBalance.Amount(), currency})
+	for _, movement := range statement.Pending {
+		rows = append(rows, []string{movement.Date.Format(time.DateOnly), "PENDING_MOVEMENT", movement.ID.String(),
+			movement.Description, movement.Amount.Amount(), "", currency})
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, ErrInvalidStatement
+	}
+	return buf.Bytes(), nil
+}
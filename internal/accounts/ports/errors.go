@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+)
+
+// errorCode classifies the errors of the accounts context
+func errorCode(err error) toolerror.Code {
+	switch {
+	case errors.Is(err, model.ErrAccountNotFound):
+		return toolerror.CodeNotFound
+	case errors.Is(err, ErrMissingAccountId),
+		errors.Is(err, model.ErrAccountIDEmpty),
+		errors.Is(err, model.ErrInvalidBillingProfile),
+		errors.Is(err, model.ErrNoProfileChanges),
+		errors.Is(err, model.ErrInvalidTaxID),
+		errors.Is(err, model.ErrUnknownLanguage),
+		errors.Is(err, model.ErrUnknownPaymentMethod),
+		errors.Is(err, model.ErrInvalidBillingCycleDay),
+		errors.Is(err, model.ErrInvalidStatementPeriod),
+		errors.Is(err, ErrInvalidDate),
+		errors.Is(err, ErrUnsupportedStatementFormat),
+		errors.Is(err, taxes.ErrInvalidLocation):
+		return toolerror.CodeInvalidArgument
+	default:
+		return ""
+	}
+}
+
+// toolError is the structured tool result of a failure of the accounts context
+func toolError(message string, err error) *mcp.CallToolResult {
+	return toolerror.Result(toolerror.Classify(err, errorCode), message, err)
+}
@@ -0,0 +1,133 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var ErrMissingAccountId = errors.New("account_id is required")
+
+type AccountService interface {
+	GetAccount(ctx context.Context, id string) (model.Account, error)
+	UpdateBillingProfile(ctx context.Context, id string, changes model.ProfileChanges) (model.Account, error)
+	GetAccountStatement(ctx context.Context, accountID string, currency money.Currency, period model.StatementPeriod) (model.Statement, error)
+}
+
+type controller struct {
+	service   AccountService
+	converter Converter
+	logger    zerolog.Logger
+}
+
+func NewController(service AccountService) controller {
+	return controller{
+		service:   service,
+		converter: NewConverter(),
+		logger:    log.With().Str("module", "accountsMcpController").Logger(),
+	}
+}
+
+func (c controller) GetAccount(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in GetAccount tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
+		c.logger.Error().Msg("Account ID is required")
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
+	}
+
+	account, err := c.service.GetAccount(ctx, accountId)
+	if err != nil {
+		c.logger.Error().Err(err).Str("accountId", accountId).Msg("Failed to get account")
+		return toolError("Failed to get account", err), nil
+	}
+	return c.accountResult(account)
+}
+
+func (c controller) UpdateBillingProfile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in UpdateBillingProfile tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
+		c.logger.Error().Msg("Account ID is required")
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
+	}
+
+	changes, err := c.converter.ConvertRequestArgsToChanges(args)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid billing profile changes")
+		return toolerror.InvalidArgument("Invalid billing profile", err), nil
+	}
+
+	account, err := c.service.UpdateBillingProfile(ctx, accountId, changes)
+	if err != nil {
+		c.logger.Error().Err(err).Str("accountId", accountId).Msg("Failed to update billing profile")
+		return toolError("Failed to update billing profile", err), nil
+	}
+	return c.accountResult(account)
+}
+
+func (c controller) GetAccountStatement(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in GetAccountStatement tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
+		c.logger.Error().Msg("Account ID is required")
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
+	}
+
+	statementRequest, err := c.converter.ConvertRequestArgsToStatementRequest(args)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid statement request")
+		return toolerror.InvalidArgument("Invalid statement request", err), nil
+	}
+
+	statement, err := c.service.GetAccountStatement(ctx, accountId, statementRequest.Currency, statementRequest.Period)
+	if err != nil {
+		c.logger.Error().Err(err).Str("accountId", accountId).Msg("Failed to get account statement")
+		return toolError("Failed to get account statement", err), nil
+	}
+
+	var output []byte
+	if statementRequest.Format == StatementFormatCSV {
+		output, err = c.converter.ConvertStatementToCSV(statement)
+	} else {
+		output, err = c.converter.ConvertStatementToJson(statement)
+	}
+	if err != nil {
+		c.logger.Error().Err(err).Str("format", string(statementRequest.Format)).Msg("Failed to convert account statement")
+		return toolError("Failed to convert account statement", err), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
+
+func (c controller) accountResult(account model.Account) (*mcp.CallToolResult, error) {
+	jsonData, err := c.converter.ConvertAccountToJson(account)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert account to JSON")
+		return toolError("Failed to convert account to JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
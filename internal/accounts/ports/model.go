@@ -0,0 +1,60 @@
+package ports
+
+// Account represents an account and its billing profile as returned by the MCP API.
+type Account struct {
+	ID                string  `json:"id"`
+	LegalName         string  `json:"legal_name"`
+	TaxID             string  `json:"tax_id"`
+	TaxIDType         string  `json:"tax_id_type"`
+	FiscalAddress     Address `json:"fiscal_address"`
+	BillingEmail      string  `json:"billing_email"`
+	PreferredLanguage string  `json:"preferred_language"`
+	PaymentMethod     string  `json:"payment_method"`
+	BillingCycleDay   int     `json:"billing_cycle_day"`
+	CreatedAt         string  `json:"created_at"`
+	UpdatedAt         string  `json:"updated_at"`
+}
+
+// Address represents the fiscal address of an account.
+type Address struct {
+	Street     string `json:"street"`
+	PostalCode string `json:"postal_code"`
+	Town       string `json:"town"`
+	Province   string `json:"province,omitempty"`
+	Country    string `json:"country"`
+}
+
+// Statement represents what an account owes over a period as returned by the MCP API.
+// Amounts are exact decimal strings, e.g. "100.50", and positive amounts increase what the account owes.
+type Statement struct {
+	AccountID        string            `json:"account_id"`
+	Currency         string            `json:"currency"`
+	From             string            `json:"from"`
+	To               string            `json:"to"`
+	OpeningBalance   string            `json:"opening_balance"`
+	Charged          string            `json:"charged"`
+	Credited         string            `json:"credited"`
+	ClosingBalance   string            `json:"closing_balance"`
+	OwedSince        string            `json:"owed_since,omitempty"`
+	Entries          []StatementEntry  `json:"entries"`
+	PendingMovements []PendingMovement `json:"pending_movements"`
+	PendingTotal     string            `json:"pending_total"`
+}
+
+// StatementEntry represents an invoice, credit note or payment of a statement.
+type StatementEntry struct {
+	Date      string `json:"date"`
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Reference string `json:"reference,omitempty"`
+	Amount    string `json:"amount"`
+	Balance   string `json:"balance"`
+}
+
+// PendingMovement represents a movement of a statement not invoiced yet.
+type PendingMovement struct {
+	Date        string `json:"date"`
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Amount      string `json:"amount"`
+}
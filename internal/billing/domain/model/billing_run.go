@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrInvalidPeriod      = errors.New("billing period start must not be after its end")
+	ErrBillingRunNotFound = errors.New("billing run not found")
+)
+
+// Period is a billing period made of whole days, both ends included.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewPeriod creates the period between two dates. Times of day are ignored.
+func NewPeriod(start, end time.Time) (Period, error) {
+	start = truncateToDay(start)
+	end = truncateToDay(end)
+	if start.After(end) {
+		return Period{}, ErrInvalidPeriod
+	}
+	return Period{Start: start, End: end}, nil
+}
+
+// EndExclusive returns the first instant after the period.
+func (p Period) EndExclusive() time.Time {
+	return p.End.AddDate(0, 0, 1)
+}
+
+// String returns the period as "YYYY-MM-DD/YYYY-MM-DD".
+func (p Period) String() string {
+	return p.Start.Format(time.DateOnly) + "/" + p.End.Format(time.DateOnly)
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// RunStatus is the status of a billing run.
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "RUNNING"
+	RunStatusCompleted RunStatus = "COMPLETED"
+	RunStatusFailed    RunStatus = "FAILED" // At least one account could not be billed, re-running the period retries it
+)
+
+// BillingRun groups the pending movements of a period into one draft invoice per account.
+// There is a single run per period; running the period again reuses it.
+type BillingRun struct {
+	ID                uuid.UUID
+	Period            Period
+	Status            RunStatus
+	InvoicesCreated   int
+	MovementsInvoiced int
+	StartedAt         time.Time
+	FinishedAt        time.Time
+}
+
+// NewBillingRun creates a running billing run for the period.
+func NewBillingRun(period Period) BillingRun {
+	return BillingRun{
+		ID:        uuid.New(),
+		Period:    period,
+		Status:    RunStatusRunning,
+		StartedAt: time.Now(),
+	}
+}
+
+// Restart marks a previous run of the same period as running again.
+func (r *BillingRun) Restart() {
+	r.Status = RunStatusRunning
+	r.StartedAt = time.Now()
+	r.FinishedAt = time.Time{}
+}
+
+// Finish records the outcome of an execution, adding its counts to those of previous executions.
+func (r *BillingRun) Finish(results []AccountResult) {
+	r.Status = RunStatusCompleted
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			r.Status = RunStatusFailed
+		case result.InvoiceCreated:
+			r.InvoicesCreated++
+		}
+		r.MovementsInvoiced += result.MovementsInvoiced
+	}
+	r.FinishedAt = time.Now()
+}
+
+// PendingMovement is a movement waiting to be invoiced by a billing run.
+type PendingMovement struct {
+	ID              uuid.UUID
+	AccountID       string
+	Amount          money.Money       // Amount including tax
+	TaxPercentage   *money.Percentage // Nil when the tax is resolved from the product category
+	ProductCategory taxes.Category    // When set, the tax is resolved from it for the customer location
+	MovementType    string
+	Description     string
+	TransactionDate time.Time
+}
+
+// AccountResult is the outcome of billing one account in a run.
+type AccountResult struct {
+	AccountID          string
+	InvoiceID          uuid.UUID
+	InvoiceCreated     bool // False when the movements were added to the draft invoice of a previous execution
+	MovementsInvoiced  int
+	TotalAmountWithTax money.Money
+	Error              string
+}
+
+// RunResult is the outcome of executing a billing run.
+type RunResult struct {
+	Run      BillingRun
+	Accounts []AccountResult
+}
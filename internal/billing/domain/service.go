@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	invoicesDomain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Repository defines the persistence needed by billing runs.
+// It's implemented by an adapter in the infrastructure layer.
+type Repository interface {
+	// GetRun returns the run of the period, or model.ErrBillingRunNotFound.
+	GetRun(ctx context.Context, period model.Period) (model.BillingRun, error)
+	CreateRun(ctx context.Context, run model.BillingRun) error
+	UpdateRun(ctx context.Context, run model.BillingRun) error
+	// GetAccountsToBill returns the accounts with pending movements without invoice in the period.
+	GetAccountsToBill(ctx context.Context, period model.Period) ([]string, error)
+	// GetPendingMovements returns and locks the pending movements without invoice of an account in the period.
+	GetPendingMovements(ctx context.Context, accountID string, period model.Period) ([]model.PendingMovement, error)
+	// GetRunDraftInvoice returns the invoice created for the account by the run while it is still a draft, if any.
+	GetRunDraftInvoice(ctx context.Context, runID uuid.UUID, accountID string) (invoices.Invoice, bool, error)
+	CreateRunInvoice(ctx context.Context, runID uuid.UUID, invoice invoices.Invoice) error
+	UpdateInvoiceTotals(ctx context.Context, invoice invoices.Invoice) error
+	// InvoiceMovements attaches the movements backing the lines to the invoice and flips them to INVOICED.
+	InvoiceMovements(ctx context.Context, invoiceID invoices.InvoiceID, lines []invoices.InvoiceLine) error
+}
+
+// Transactor runs a unit of work in a single database transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Settings are the billing defaults applied to the generated invoices.
+type Settings struct {
+	PaymentTermDays int
+}
+
+type Service struct {
+	repo       Repository
+	transactor Transactor
+	converter  invoicesDomain.CurrencyConverter
+	taxes      invoicesDomain.TaxResolver
+	accounts   invoicesDomain.AccountRegistry
+	settings   Settings
+	logger     zerolog.Logger
+}
+
+func NewService(repo Repository, transactor Transactor, converter invoicesDomain.CurrencyConverter, taxResolver invoicesDomain.TaxResolver, accounts invoicesDomain.AccountRegistry, settings Settings) Service {
+	return Service{
+		repo:       repo,
+		transactor: transactor,
+		converter:  converter,
+		taxes:      taxResolver,
+		accounts:   accounts,
+		settings:   settings,
+		logger:     log.With().Str("module", "billingService").Logger(),
+	}
+}
+
+// Run invoices the pending movements of the period, creating one draft invoice per account.
+// Each account is billed in its own transaction. Running a period again only picks up the movements
+// left pending, adding them to the draft invoice the account already has in the run, or to a new draft
+// when the invoice of the previous execution has been issued since.
+func (s Service) Run(ctx context.Context, period model.Period) (model.RunResult, error) {
+	logger := s.logger.With().Str("period", period.String()).Logger()
+	logger.Info().Msg("Starting billing run")
+
+	run, err := s.startRun(ctx, period)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to start billing run")
+		return model.RunResult{}, err
+	}
+
+	accounts, err := s.repo.GetAccountsToBill(ctx, period)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to fetch accounts to bill")
+		return model.RunResult{}, err
+	}
+
+	results := make([]model.AccountResult, 0, len(accounts))
+	for _, accountID := range accounts {
+		var result model.AccountResult
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			result, err = s.billAccount(ctx, run, accountID)
+			return err
+		})
+		if err != nil {
+			logger.Error().Err(err).Str("account_id", accountID).Msg("Failed to bill account")
+			result = model.AccountResult{AccountID: accountID, Error: err.Error()}
+		}
+		if result.MovementsInvoiced > 0 || result.Error != "" {
+			results = append(results, result)
+		}
+	}
+
+	run.Finish(results)
+	if err := s.repo.UpdateRun(ctx, run); err != nil {
+		logger.Error().Err(err).Msg("Failed to record billing run outcome")
+		return model.RunResult{}, err
+	}
+
+	logger.Info().Str("status", string(run.Status)).Int("accounts", len(results)).Msg("Finished billing run")
+	return model.RunResult{Run: run, Accounts: results}, nil
+}
+
+// startRun reuses the run of the period when it already exists
+func (s Service) startRun(ctx context.Context, period model.Period) (model.BillingRun, error) {
+	run, err := s.repo.GetRun(ctx, period)
+	if errors.Is(err, model.ErrBillingRunNotFound) {
+		run = model.NewBillingRun(period)
+		return run, s.repo.CreateRun(ctx, run)
+	}
+	if err != nil {
+		return model.BillingRun{}, err
+	}
+
+	run.Restart()
+	return run, s.repo.UpdateRun(ctx, run)
+}
+
+func (s Service) billAccount(ctx context.Context, run model.BillingRun, accountID string) (model.AccountResult, error) {
+	movements, err := s.repo.GetPendingMovements(ctx, accountID, run.Period)
+	if err != nil || len(movements) == 0 {
+		return model.AccountResult{AccountID: accountID}, err
+	}
+
+	account, err := s.accounts.GetAccount(ctx, accountID)
+	if err != nil {
+		return model.AccountResult{}, err
+	}
+
+	invoice, found, err := s.repo.GetRunDraftInvoice(ctx, run.ID, accountID)
+	if err != nil {
+		return model.AccountResult{}, err
+	}
+	if !found {
+		if invoice, err = s.newInvoice(run, accountID, movements); err != nil {
+			return model.AccountResult{}, err
+		}
+		invoice.CustomerLocation = account.Profile.Location()
+	}
+
+	lines := make([]invoices.InvoiceLine, 0, len(movements))
+	for _, movement := range movements {
+		line, err := s.invoiceLine(ctx, movement, invoice)
+		if err != nil {
+			return model.AccountResult{}, fmt.Errorf("movement %s: %w", movement.ID, err)
+		}
+		if err := invoice.AddLine(line); err != nil {
+			return model.AccountResult{}, fmt.Errorf("movement %s: %w", movement.ID, err)
+		}
+		lines = append(lines, line)
+	}
+
+	if found {
+		err = s.repo.UpdateInvoiceTotals(ctx, invoice)
+	} else {
+		err = s.repo.CreateRunInvoice(ctx, run.ID, invoice)
+	}
+	if err != nil {
+		return model.AccountResult{}, err
+	}
+	if err := s.repo.InvoiceMovements(ctx, invoice.ID, lines); err != nil {
+		return model.AccountResult{}, err
+	}
+
+	s.logger.Info().Str("account_id", accountID).Str("invoice_id", invoice.ID.String()).Int("movements", len(lines)).Msg("Billed account")
+	return model.AccountResult{
+		AccountID:          accountID,
+		InvoiceID:          uuid.UUID(invoice.ID),
+		InvoiceCreated:     !found,
+		MovementsInvoiced:  len(lines),
+		TotalAmountWithTax: invoice.TotalAmountWithTax,
+	}, nil
+}
+
+// newInvoice creates the draft invoice of an account, dated on the last day of the period until it is sent.
+// It is expressed in the currency of the movements when they all share one, otherwise in the default currency.
+func (s Service) newInvoice(run model.BillingRun, accountID string, movements []model.PendingMovement) (invoices.Invoice, error) {
+	currency := movements[0].Amount.Currency()
+	for _, movement := range movements[1:] {
+		if movement.Amount.Currency() != currency {
+			currency = money.DefaultCurrency
+			break
+		}
+	}
+
+	issueDate := run.Period.End
+	dueDate := issueDate.AddDate(0, 0, s.settings.PaymentTermDays)
+	return invoices.NewInvoice(accountID, currency, issueDate, dueDate)
+}
+
+// invoiceLine turns a pending movement into a line in the invoice currency, keeping the movement ID.
+// The amount of the movement includes the tax of the customer of the invoice on its transaction date.
+func (s Service) invoiceLine(ctx context.Context, movement model.PendingMovement, invoice invoices.Invoice) (invoices.InvoiceLine, error) {
+	tax, err := s.movementTax(ctx, movement, invoice.CustomerLocation)
+	if err != nil {
+		return invoices.InvoiceLine{}, err
+	}
+
+	description := movement.Description
+	if description == "" {
+		description = fmt.Sprintf("%s movement of %s", movement.MovementType, movement.TransactionDate.Format(time.DateOnly))
+	}
+
+	line, err := invoices.NewInvoiceLineWithTax(description, movement.Amount, tax.Percentage, movement.MovementType)
+	if err != nil {
+		return invoices.InvoiceLine{}, err
+	}
+	line.MovementID = movement.ID
+	line.TransactionDate = movement.TransactionDate
+	line.TaxRegime = tax.Regime
+	line.ExemptionCause = tax.ExemptionCause
+	line.ProductCategory = movement.ProductCategory
+
+	return s.converter.ConvertLine(ctx, line, invoice.Currency)
+}
+
+// movementTax resolves the tax of a movement from its product category. A movement recorded with a tax
+// percentage and no category keeps that percentage under the regime of the customer, and one with
+// neither is taxed at the general rate.
+func (s Service) movementTax(ctx context.Context, movement model.PendingMovement, location taxes.Location) (taxes.Tax, error) {
+	if movement.ProductCategory == "" && movement.TaxPercentage != nil {
+		return taxes.Tax{Regime: location.Territory().Regime(), Percentage: *movement.TaxPercentage}, nil
+	}
+
+	category := movement.ProductCategory
+	if category == "" {
+		category = taxes.CategoryGeneral
+	}
+	return s.taxes.Resolve(ctx, category, location, movement.TransactionDate)
+}
@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/billing/domain/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/billing/domain/service.go -destination=internal/billing/domain/service_mock.go -package=domain Repository,Transactor
+//
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	model "github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	model0 "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRun mocks base method.
+func (m *MockRepository) CreateRun(ctx context.Context, run model.BillingRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRepositoryMockRecorder) CreateRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRepository)(nil).CreateRun), ctx, run)
+}
+
+// CreateRunInvoice mocks base method.
+func (m *MockRepository) CreateRunInvoice(ctx context.Context, runID uuid.UUID, invoice model0.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRunInvoice", ctx, runID, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRunInvoice indicates an expected call of CreateRunInvoice.
+func (mr *MockRepositoryMockRecorder) CreateRunInvoice(ctx, runID, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRunInvoice", reflect.TypeOf((*MockRepository)(nil).CreateRunInvoice), ctx, runID, invoice)
+}
+
+// GetAccountsToBill mocks base method.
+func (m *MockRepository) GetAccountsToBill(ctx context.Context, period model.Period) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountsToBill", ctx, period)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountsToBill indicates an expected call of GetAccountsToBill.
+func (mr *MockRepositoryMockRecorder) GetAccountsToBill(ctx, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsToBill", reflect.TypeOf((*MockRepository)(nil).GetAccountsToBill), ctx, period)
+}
+
+// GetPendingMovements mocks base method.
+func (m *MockRepository) GetPendingMovements(ctx context.Context, accountID string, period model.Period) ([]model.PendingMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingMovements", ctx, accountID, period)
+	ret0, _ := ret[0].([]model.PendingMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingMovements indicates an expected call of GetPendingMovements.
+func (mr *MockRepositoryMockRecorder) GetPendingMovements(ctx, accountID, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingMovements", reflect.TypeOf((*MockRepository)(nil).GetPendingMovements), ctx, accountID, period)
+}
+
+// GetRun mocks base method.
+func (m *MockRepository) GetRun(ctx context.Context, period model.Period) (model.BillingRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, period)
+	ret0, _ := ret[0].(model.BillingRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockRepositoryMockRecorder) GetRun(ctx, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockRepository)(nil).GetRun), ctx, period)
+}
+
+// GetRunDraftInvoice mocks base method.
+func (m *MockRepository) GetRunDraftInvoice(ctx context.Context, runID uuid.UUID, accountID string) (model0.Invoice, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunDraftInvoice", ctx, runID, accountID)
+	ret0, _ := ret[0].(model0.Invoice)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRunDraftInvoice indicates an expected call of GetRunDraftInvoice.
+func (mr *MockRepositoryMockRecorder) GetRunDraftInvoice(ctx, runID, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunDraftInvoice", reflect.TypeOf((*MockRepository)(nil).GetRunDraftInvoice), ctx, runID, accountID)
+}
+
+// InvoiceMovements mocks base method.
+func (m *MockRepository) InvoiceMovements(ctx context.Context, invoiceID model0.InvoiceID, lines []model0.InvoiceLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvoiceMovements", ctx, invoiceID, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvoiceMovements indicates an expected call of InvoiceMovements.
+func (mr *MockRepositoryMockRecorder) InvoiceMovements(ctx, invoiceID, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvoiceMovements", reflect.TypeOf((*MockRepository)(nil).InvoiceMovements), ctx, invoiceID, lines)
+}
+
+// UpdateInvoiceTotals mocks base method.
+func (m *MockRepository) UpdateInvoiceTotals(ctx context.Context, invoice model0.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvoiceTotals", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInvoiceTotals indicates an expected call of UpdateInvoiceTotals.
+func (mr *MockRepositoryMockRecorder) UpdateInvoiceTotals(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceTotals", reflect.TypeOf((*MockRepository)(nil).UpdateInvoiceTotals), ctx, invoice)
+}
+
+// UpdateRun mocks base method.
+func (m *MockRepository) UpdateRun(ctx context.Context, run model.BillingRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockRepositoryMockRecorder) UpdateRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*MockRepository)(nil).UpdateRun), ctx, run)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
@@ -0,0 +1,248 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	accounts "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	invoicesDomain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var settings = domain.Settings{PaymentTermDays: 30}
+
+var mainlandIVA = taxes.Tax{Regime: taxes.RegimeIVA, RateType: taxes.RateTypeGeneral, Percentage: 2100}
+
+type mocks struct {
+	repo     *domain.MockRepository
+	taxes    *invoicesDomain.MockTaxResolver
+	accounts *invoicesDomain.MockAccountRegistry
+}
+
+func newService(ctrl *gomock.Controller) (domain.Service, mocks) {
+	m := mocks{
+		repo:     domain.NewMockRepository(ctrl),
+		taxes:    invoicesDomain.NewMockTaxResolver(ctrl),
+		accounts: invoicesDomain.NewMockAccountRegistry(ctrl),
+	}
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	mockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+	).AnyTimes()
+
+	// Every movement in these tests is in the invoice currency, so no rate is ever looked up
+	converter := invoicesDomain.NewCurrencyConverter(nil)
+	return domain.NewService(m.repo, mockTransactor, converter, m.taxes, m.accounts, settings), m
+}
+
+func account(id, postalCode string) accounts.Account {
+	return accounts.Account{ID: id, Profile: accounts.BillingProfile{
+		LegalName:     "Acme S.L.",
+		TaxID:         accounts.TaxID{Type: accounts.TaxIDTypeCIF, Value: "B12345674"},
+		FiscalAddress: accounts.Address{Street: "Calle Mayor 1", PostalCode: postalCode, Town: "Town", Country: "ES"},
+	}}
+}
+
+func januaryPeriod(t *testing.T) model.Period {
+	period, err := model.NewPeriod(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return period
+}
+
+func pendingMovements(accountID string) []model.PendingMovement {
+	reducedTax := money.Percentage(1000)
+	return []model.PendingMovement{
+		{ID: uuid.New(), AccountID: accountID, Amount: money.New(12100, "EUR"), MovementType: "CREDIT", Description: "Monthly fee", TransactionDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{ID: uuid.New(), AccountID: accountID, Amount: money.New(5025, "EUR"), TaxPercentage: &reducedTax, MovementType: "CREDIT", Description: "Support", TransactionDate: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestService_Run_CreatesDraftInvoicePerAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+	period := januaryPeriod(t)
+	movements := pendingMovements("account_A")
+
+	var runID uuid.UUID
+	m.repo.EXPECT().GetRun(ctx, period).Return(model.BillingRun{}, model.ErrBillingRunNotFound)
+	m.repo.EXPECT().CreateRun(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, run model.BillingRun) error {
+		runID = run.ID
+		assert.Equal(t, model.RunStatusRunning, run.Status)
+		return nil
+	})
+	m.repo.EXPECT().GetAccountsToBill(ctx, period).Return([]string{"account_A"}, nil)
+	m.repo.EXPECT().GetPendingMovements(ctx, "account_A", period).Return(movements, nil)
+	m.accounts.EXPECT().GetAccount(ctx, "account_A").Return(account("account_A", "28013"), nil)
+	m.repo.EXPECT().GetRunDraftInvoice(ctx, gomock.Any(), "account_A").Return(invoices.Invoice{}, false, nil)
+	m.taxes.EXPECT().Resolve(ctx, taxes.CategoryGeneral, taxes.Location{Country: "ES", PostalCode: "28013"}, movements[0].TransactionDate).Return(mainlandIVA, nil)
+	m.repo.EXPECT().CreateRunInvoice(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID, invoice invoices.Invoice) error {
+		assert.Equal(t, runID, id)
+		assert.Equal(t, taxes.Location{Country: "ES", PostalCode: "28013"}, invoice.CustomerLocation, "the customer is located at the fiscal address of the account")
+		assert.Equal(t, invoices.InvoiceStatusDraft, invoice.Status)
+		assert.Equal(t, period.End, invoice.IssueDate)
+		assert.Equal(t, period.End.AddDate(0, 0, 30), invoice.DueDate)
+		assert.Equal(t, "171.25", invoice.TotalAmountWithTax.Amount())
+		assert.Equal(t, "145.68", invoice.TotalAmountWithoutTax.Amount(), "100.00 at 21% plus 45.68 at 10%")
+		assert.Equal(t, "25.57", invoice.TaxAmount.Amount())
+		return nil
+	})
+	m.repo.EXPECT().InvoiceMovements(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ invoices.InvoiceID, lines []invoices.InvoiceLine) error {
+		require.Len(t, lines, 2)
+		assert.Equal(t, movements[0].ID, lines[0].MovementID)
+		assert.Equal(t, money.Percentage(2100), lines[0].TaxPercentage, "the general rate applies without category nor percentage")
+		assert.Equal(t, money.Percentage(1000), lines[1].TaxPercentage)
+		assert.Equal(t, taxes.RegimeIVA, lines[1].TaxRegime, "an explicit percentage is taken under the regime of the customer")
+		return nil
+	})
+	m.repo.EXPECT().UpdateRun(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.Run(ctx, period)
+	require.NoError(t, err)
+	assert.Equal(t, model.RunStatusCompleted, result.Run.Status)
+	assert.Equal(t, 1, result.Run.InvoicesCreated)
+	assert.Equal(t, 2, result.Run.MovementsInvoiced)
+	require.Len(t, result.Accounts, 1)
+	assert.True(t, result.Accounts[0].InvoiceCreated)
+}
+
+func TestService_Run_RerunAddsToDraftInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+	period := januaryPeriod(t)
+
+	previous := model.NewBillingRun(period)
+	previous.Finish([]model.AccountResult{{AccountID: "account_A", InvoiceCreated: true, MovementsInvoiced: 3}})
+	draft, err := invoices.NewInvoice("account_A", "EUR", period.End, period.End.AddDate(0, 0, 30))
+	require.NoError(t, err)
+
+	m.repo.EXPECT().GetRun(ctx, period).Return(previous, nil)
+	m.repo.EXPECT().UpdateRun(ctx, gomock.Any()).Return(nil).Times(2)
+	m.repo.EXPECT().GetAccountsToBill(ctx, period).Return([]string{"account_A"}, nil)
+	m.repo.EXPECT().GetPendingMovements(ctx, "account_A", period).Return(pendingMovements("account_A")[:1], nil)
+	m.accounts.EXPECT().GetAccount(ctx, "account_A").Return(account("account_A", "28013"), nil)
+	m.repo.EXPECT().GetRunDraftInvoice(ctx, previous.ID, "account_A").Return(draft, true, nil)
+	m.taxes.EXPECT().Resolve(ctx, taxes.CategoryGeneral, draft.CustomerLocation, gomock.Any()).Return(mainlandIVA, nil)
+	m.repo.EXPECT().UpdateInvoiceTotals(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, invoice invoices.Invoice) error {
+		assert.Equal(t, draft.ID, invoice.ID)
+		assert.Equal(t, "121.00", invoice.TotalAmountWithTax.Amount())
+		return nil
+	})
+	m.repo.EXPECT().InvoiceMovements(ctx, draft.ID, gomock.Any()).Return(nil)
+
+	result, err := service.Run(ctx, period)
+	require.NoError(t, err)
+	assert.Equal(t, previous.ID, result.Run.ID)
+	assert.Equal(t, 1, result.Run.InvoicesCreated, "no new invoice is created")
+	assert.Equal(t, 4, result.Run.MovementsInvoiced)
+	assert.False(t, result.Accounts[0].InvoiceCreated)
+}
+
+func TestService_Run_BillsLateMovementsOnNewDraftOnceTheRunInvoiceIsIssued(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+	period := januaryPeriod(t)
+
+	previous := model.NewBillingRun(period)
+	previous.Finish([]model.AccountResult{{AccountID: "account_A", InvoiceCreated: true, MovementsInvoiced: 2}})
+	late := pendingMovements("account_A")[:1]
+
+	m.repo.EXPECT().GetRun(ctx, period).Return(previous, nil)
+	m.repo.EXPECT().UpdateRun(ctx, gomock.Any()).Return(nil).Times(2)
+	m.repo.EXPECT().GetAccountsToBill(ctx, period).Return([]string{"account_A"}, nil)
+	m.repo.EXPECT().GetPendingMovements(ctx, "account_A", period).Return(late, nil)
+	m.accounts.EXPECT().GetAccount(ctx, "account_A").Return(account("account_A", "28013"), nil)
+	m.repo.EXPECT().GetRunDraftInvoice(ctx, previous.ID, "account_A").Return(invoices.Invoice{}, false, nil)
+	m.taxes.EXPECT().Resolve(ctx, taxes.CategoryGeneral, gomock.Any(), gomock.Any()).Return(mainlandIVA, nil)
+	m.repo.EXPECT().CreateRunInvoice(ctx, previous.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, invoice invoices.Invoice) error {
+		assert.Equal(t, invoices.InvoiceStatusDraft, invoice.Status)
+		assert.Equal(t, "121.00", invoice.TotalAmountWithTax.Amount())
+		return nil
+	})
+	m.repo.EXPECT().InvoiceMovements(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := service.Run(ctx, period)
+	require.NoError(t, err)
+	assert.Equal(t, model.RunStatusCompleted, result.Run.Status)
+	assert.Equal(t, 2, result.Run.InvoicesCreated, "the late movements get their own draft")
+	assert.Equal(t, 3, result.Run.MovementsInvoiced)
+	require.Len(t, result.Accounts, 1)
+	assert.True(t, result.Accounts[0].InvoiceCreated)
+	assert.Empty(t, result.Accounts[0].Error)
+}
+
+func TestService_Run_TaxesLinesForTheFiscalAddressOfTheAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+	period := januaryPeriod(t)
+
+	canary := taxes.Location{Country: "ES", PostalCode: "35002"}
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	movements := []model.PendingMovement{
+		{ID: uuid.New(), AccountID: "account_A", Amount: money.New(10700, "EUR"), ProductCategory: taxes.CategoryGeneral, MovementType: "CREDIT", Description: "Monthly fee", TransactionDate: date},
+		{ID: uuid.New(), AccountID: "account_A", Amount: money.New(5000, "EUR"), ProductCategory: taxes.CategoryHealthcare, MovementType: "CREDIT", Description: "Check-up", TransactionDate: date},
+	}
+
+	m.repo.EXPECT().GetRun(ctx, period).Return(model.BillingRun{}, model.ErrBillingRunNotFound)
+	m.repo.EXPECT().CreateRun(ctx, gomock.Any()).Return(nil)
+	m.repo.EXPECT().GetAccountsToBill(ctx, period).Return([]string{"account_A"}, nil)
+	m.repo.EXPECT().GetPendingMovements(ctx, "account_A", period).Return(movements, nil)
+	m.accounts.EXPECT().GetAccount(ctx, "account_A").Return(account("account_A", "35002"), nil)
+	m.repo.EXPECT().GetRunDraftInvoice(ctx, gomock.Any(), "account_A").Return(invoices.Invoice{}, false, nil)
+	m.taxes.EXPECT().Resolve(ctx, taxes.CategoryGeneral, canary, date).
+		Return(taxes.Tax{Regime: taxes.RegimeIGIC, RateType: taxes.RateTypeGeneral, Percentage: 700}, nil)
+	m.taxes.EXPECT().Resolve(ctx, taxes.CategoryHealthcare, canary, date).
+		Return(taxes.Exempt(taxes.RegimeIGIC, taxes.ExemptionArticle20), nil)
+	m.repo.EXPECT().CreateRunInvoice(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, invoice invoices.Invoice) error {
+		assert.Equal(t, canary, invoice.CustomerLocation)
+		assert.Equal(t, "157.00", invoice.TotalAmountWithTax.Amount())
+		assert.Equal(t, "7.00", invoice.TaxAmount.Amount(), "100.00 at 7% IGIC and 50.00 exempt")
+		return nil
+	})
+	m.repo.EXPECT().InvoiceMovements(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ invoices.InvoiceID, lines []invoices.InvoiceLine) error {
+		require.Len(t, lines, 2)
+		assert.Equal(t, taxes.RegimeIGIC, lines[0].TaxRegime)
+		assert.Equal(t, money.Percentage(700), lines[0].TaxPercentage)
+		assert.Equal(t, taxes.CategoryGeneral, lines[0].ProductCategory)
+		assert.Equal(t, taxes.ExemptionArticle20, lines[1].ExemptionCause)
+		assert.Equal(t, money.Percentage(0), lines[1].TaxPercentage)
+		return nil
+	})
+	m.repo.EXPECT().UpdateRun(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.Run(ctx, period)
+	require.NoError(t, err)
+	assert.Equal(t, model.RunStatusCompleted, result.Run.Status)
+}
+
+func TestService_Run_FailsAccountThatIsNotRegistered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+	period := januaryPeriod(t)
+
+	m.repo.EXPECT().GetRun(ctx, period).Return(model.BillingRun{}, model.ErrBillingRunNotFound)
+	m.repo.EXPECT().CreateRun(ctx, gomock.Any()).Return(nil)
+	m.repo.EXPECT().GetAccountsToBill(ctx, period).Return([]string{"account_Z"}, nil)
+	m.repo.EXPECT().GetPendingMovements(ctx, "account_Z", period).Return(pendingMovements("account_Z"), nil)
+	m.accounts.EXPECT().GetAccount(ctx, "account_Z").Return(accounts.Account{}, accounts.ErrAccountNotFound)
+	m.repo.EXPECT().UpdateRun(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.Run(ctx, period)
+	require.NoError(t, err)
+	assert.Equal(t, model.RunStatusFailed, result.Run.Status)
+	require.Len(t, result.Accounts, 1)
+	assert.Equal(t, accounts.ErrAccountNotFound.Error(), result.Accounts[0].Error)
+}
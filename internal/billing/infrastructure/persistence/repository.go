@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/infrastructure/persistence/sql"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	client    sql.BillingSqlClient
+	converter sql.BillingSqlConverter
+	logger    zerolog.Logger
+}
+
+func NewRepository(client sql.BillingSqlClient, converter sql.BillingSqlConverter) Repository {
+	return Repository{
+		client:    client,
+		converter: converter,
+		logger:    log.With().Str("component", "BillingPersistenceRepository").Logger(),
+	}
+}
+
+func (r Repository) GetRun(ctx context.Context, period model.Period) (model.BillingRun, error) {
+	run, err := r.client.GetRunByPeriod(ctx, period.Start, period.End)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.BillingRun{}, model.ErrBillingRunNotFound
+		}
+		r.logger.Error().Err(err).Str("period", period.String()).Msg("Failed to fetch billing run")
+		return model.BillingRun{}, err
+	}
+	return r.converter.RunToDomain(run), nil
+}
+
+func (r Repository) CreateRun(ctx context.Context, run model.BillingRun) error {
+	r.logger.Info().Str("id", run.ID.String()).Str("period", run.Period.String()).Msg("Creating billing run")
+
+	if err := r.client.CreateRun(ctx, r.converter.RunToSql(run)); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to create billing run")
+		return err
+	}
+	return nil
+}
+
+func (r Repository) UpdateRun(ctx context.Context, run model.BillingRun) error {
+	r.logger.Info().Str("id", run.ID.String()).Str("status", string(run.Status)).Msg("Updating billing run")
+
+	if err := r.client.UpdateRun(ctx, r.converter.RunToSql(run)); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to update billing run")
+		return err
+	}
+	return nil
+}
+
+func (r Repository) GetAccountsToBill(ctx context.Context, period model.Period) ([]string, error) {
+	accounts, err := r.client.GetAccountsToBill(ctx, period.Start, period.EndExclusive())
+	if err != nil {
+		r.logger.Error().Err(err).Str("period", period.String()).Msg("Failed to fetch accounts to bill")
+		return nil, err
+	}
+
+	r.logger.Info().Str("period", period.String()).Int("count", len(accounts)).Msg("Fetched accounts to bill")
+	return accounts, nil
+}
+
+func (r Repository) GetPendingMovements(ctx context.Context, accountID string, period model.Period) ([]model.PendingMovement, error) {
+	sqlMovements, err := r.client.GetPendingMovements(ctx, accountID, period.Start, period.EndExclusive())
+	if err != nil {
+		r.logger.Error().Err(err).Str("account_id", accountID).Msg("Failed to fetch pending movements")
+		return nil, err
+	}
+
+	movements := make([]model.PendingMovement, len(sqlMovements))
+	for i, sqlMovement := range sqlMovements {
+		movements[i] = r.converter.MovementToDomain(sqlMovement)
+	}
+	return movements, nil
+}
+
+func (r Repository) GetRunDraftInvoice(ctx context.Context, runID uuid.UUID, accountID string) (invoices.Invoice, bool, error) {
+	sqlInvoice, err := r.client.GetRunDraftInvoice(ctx, runID, accountID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invoices.Invoice{}, false, nil
+		}
+		r.logger.Error().Err(err).Str("account_id", accountID).Msg("Failed to fetch billing run draft invoice")
+		return invoices.Invoice{}, false, err
+	}
+
+	invoice, err := r.converter.InvoiceToDomain(sqlInvoice)
+	if err != nil {
+		return invoices.Invoice{}, false, err
+	}
+	return invoice, true, nil
+}
+
+func (r Repository) CreateRunInvoice(ctx context.Context, runID uuid.UUID, invoice invoices.Invoice) error {
+	r.logger.Info().Str("id", invoice.ID.String()).Str("account_id", invoice.AccountID).Msg("Creating billing run invoice")
+
+	if err := r.client.CreateInvoice(ctx, r.converter.InvoiceToSql(runID, invoice)); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to create billing run invoice")
+		return err
+	}
+	return nil
+}
+
+func (r Repository) UpdateInvoiceTotals(ctx context.Context, invoice invoices.Invoice) error {
+	r.logger.Info().Str("id", invoice.ID.String()).Msg("Updating billing run invoice totals")
+
+	if err := r.client.UpdateInvoiceTotals(ctx, r.converter.InvoiceToSql(uuid.Nil, invoice)); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to update billing run invoice totals")
+		return err
+	}
+	return nil
+}
+
+func (r Repository) InvoiceMovements(ctx context.Context, invoiceID invoices.InvoiceID, lines []invoices.InvoiceLine) error {
+	for _, line := range lines {
+		if err := r.client.InvoiceMovement(ctx, line.MovementID, r.converter.InvoicedMovementColumns(invoiceID, line)); err != nil {
+			r.logger.Error().Err(err).Str("movement_id", line.MovementID.String()).Msg("Failed to invoice movement")
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+type BillingSqlConverter struct {
+}
+
+func NewBillingSqlConverter() BillingSqlConverter {
+	return BillingSqlConverter{}
+}
+
+func (c BillingSqlConverter) RunToDomain(run BillingRun) model.BillingRun {
+	domainRun := model.BillingRun{
+		ID:                run.ID,
+		Period:            model.Period{Start: run.PeriodStart, End: run.PeriodEnd},
+		Status:            model.RunStatus(run.Status),
+		InvoicesCreated:   run.InvoicesCreated,
+		MovementsInvoiced: run.MovementsInvoiced,
+		StartedAt:         run.StartedAt,
+	}
+	if run.FinishedAt != nil {
+		domainRun.FinishedAt = *run.FinishedAt
+	}
+	return domainRun
+}
+
+func (c BillingSqlConverter) RunToSql(run model.BillingRun) BillingRun {
+	sqlRun := BillingRun{
+		BaseModel:         commons.BaseModel{ID: run.ID},
+		PeriodStart:       run.Period.Start,
+		PeriodEnd:         run.Period.End,
+		Status:            string(run.Status),
+		InvoicesCreated:   run.InvoicesCreated,
+		MovementsInvoiced: run.MovementsInvoiced,
+		StartedAt:         run.StartedAt,
+	}
+	if !run.FinishedAt.IsZero() {
+		finishedAt := run.FinishedAt
+		sqlRun.FinishedAt = &finishedAt
+	}
+	return sqlRun
+}
+
+func (c BillingSqlConverter) InvoiceToDomain(invoice Invoice) (invoices.Invoice, error) {
+	status, err := invoices.GetStatusFromString(invoice.Status)
+	if err != nil {
+		return invoices.Invoice{}, err
+	}
+
+	currency := money.Currency(invoice.Currency)
+	domainInvoice := invoices.Invoice{
+		ID:                    invoices.InvoiceID(invoice.ID),
+		AccountID:             invoice.AccountID,
+		IssueDate:             invoice.IssueDate,
+		DueDate:               invoice.DueDate,
+		TaxAmount:             money.New(int64(invoice.TaxAmount), currency),
+		TotalAmountWithoutTax: money.New(int64(invoice.TotalAmountWithoutTax), currency),
+		TotalAmountWithTax:    money.New(int64(invoice.TotalAmountWithTax), currency),
+		Status:                status,
+		Currency:              currency,
+		Type:                  invoices.InvoiceTypeStandard,
+		CustomerLocation:      taxes.Location{Country: invoice.CustomerCountry, PostalCode: invoice.CustomerPostalCode},
+		TaxBreakdown:          make(invoices.TaxBreakdown, len(invoice.TaxBreakdown.Data)),
+	}
+	if domainInvoice.CustomerLocation.Country == "" {
+		domainInvoice.CustomerLocation = taxes.DefaultLocation
+	}
+	for i, summary := range invoice.TaxBreakdown.Data {
+		domainInvoice.TaxBreakdown[i] = invoices.TaxSummary{
+			Regime:         taxes.Regime(summary.Regime),
+			Percentage:     money.Percentage(summary.Percentage),
+			ExemptionCause: taxes.ExemptionCause(summary.ExemptionCause),
+			TaxableBase:    money.New(int64(summary.TaxableBase), currency),
+			TaxAmount:      money.New(int64(summary.TaxAmount), currency),
+		}
+	}
+	if invoice.InvoiceNumber != nil {
+		domainInvoice.InvoiceNumber = *invoice.InvoiceNumber
+	}
+	return domainInvoice, nil
+}
+
+func (c BillingSqlConverter) InvoiceToSql(runID uuid.UUID, invoice invoices.Invoice) Invoice {
+	breakdown := make([]TaxSummary, len(invoice.TaxBreakdown))
+	for i, summary := range invoice.TaxBreakdown {
+		breakdown[i] = TaxSummary{
+			Regime:         string(summary.Regime),
+			Percentage:     commons.Decimal(summary.Percentage),
+			ExemptionCause: string(summary.ExemptionCause),
+			TaxableBase:    commons.Decimal(summary.TaxableBase.Minor()),
+			TaxAmount:      commons.Decimal(summary.TaxAmount.Minor()),
+		}
+	}
+
+	return Invoice{
+		BaseModel:             commons.BaseModel{ID: uuid.UUID(invoice.ID)},
+		AccountID:             invoice.AccountID,
+		IssueDate:             invoice.IssueDate,
+		DueDate:               invoice.DueDate,
+		TaxAmount:             commons.Decimal(invoice.TaxAmount.Minor()),
+		TotalAmountWithoutTax: commons.Decimal(invoice.TotalAmountWithoutTax.Minor()),
+		TotalAmountWithTax:    commons.Decimal(invoice.TotalAmountWithTax.Minor()),
+		Status:                string(invoice.Status),
+		Currency:              invoice.Currency.String(),
+		CustomerCountry:       invoice.CustomerLocation.Country,
+		CustomerPostalCode:    invoice.CustomerLocation.PostalCode,
+		BillingRunID:          &runID,
+		TaxBreakdown:          commons.JSON[[]TaxSummary]{Data: breakdown},
+	}
+}
+
+func (c BillingSqlConverter) MovementToDomain(movement Movement) model.PendingMovement {
+	pending := model.PendingMovement{
+		ID:              movement.ID,
+		AccountID:       movement.AccountID,
+		Amount:          money.New(int64(movement.Amount), money.Currency(movement.Currency)),
+		ProductCategory: taxes.Category(movement.ProductCategory),
+		MovementType:    movement.MovementType,
+		Description:     movement.Description,
+		TransactionDate: movement.TransactionDate,
+	}
+	if movement.TaxPercentage != nil {
+		taxPercentage := money.Percentage(*movement.TaxPercentage)
+		pending.TaxPercentage = &taxPercentage
+	}
+	return pending
+}
+
+// InvoicedMovementColumns returns the columns written when a line's movement is invoiced
+func (c BillingSqlConverter) InvoicedMovementColumns(invoiceID invoices.InvoiceID, line invoices.InvoiceLine) map[string]interface{} {
+	columns := map[string]interface{}{
+		"invoice_id":          uuid.UUID(invoiceID),
+		"status":              invoicedMovementStatus,
+		"amount_without_tax":  commons.Decimal(line.AmountWithoutTax.Minor()),
+		"amount_with_tax":     commons.Decimal(line.AmountWithTax.Minor()),
+		"tax_percentage":      commons.Decimal(line.TaxPercentage),
+		"tax_regime":          string(line.TaxRegime),
+		"tax_exemption_cause": nil,
+		"product_category":    nil,
+		"operation_type":      line.OperationType,
+		"exchange_rate":       nil,
+		"updated_at":          time.Now(),
+	}
+	if line.ExemptionCause != "" {
+		columns["tax_exemption_cause"] = string(line.ExemptionCause)
+	}
+	if line.ProductCategory != "" {
+		columns["product_category"] = string(line.ProductCategory)
+	}
+	if line.ExchangeRate.From != line.ExchangeRate.To {
+		columns["exchange_rate"] = line.ExchangeRate.String()
+	}
+	return columns
+}
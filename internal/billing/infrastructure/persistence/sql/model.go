@@ -0,0 +1,77 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+// BillingRun represents the execution of the billing of a period in the database.
+type BillingRun struct {
+	commons.BaseModel
+	PeriodStart       time.Time `gorm:"type:date;not null"`
+	PeriodEnd         time.Time `gorm:"type:date;not null"`
+	Status            string    `gorm:"type:varchar(50);not null"`
+	InvoicesCreated   int       `gorm:"not null"`
+	MovementsInvoiced int       `gorm:"not null"`
+	StartedAt         time.Time `gorm:"not null"`
+	FinishedAt        *time.Time
+}
+
+// TableName specifies the table name for BillingRun in the database.
+func (BillingRun) TableName() string {
+	return "billing_runs"
+}
+
+// Invoice is the view of an invoice created by a billing run.
+type Invoice struct {
+	commons.BaseModel
+	AccountID             string `gorm:"index"`
+	IssueDate             time.Time
+	DueDate               time.Time
+	TaxAmount             commons.Decimal `gorm:"type:decimal(12,2)"`
+	TotalAmountWithoutTax commons.Decimal `gorm:"type:decimal(12,2)"`
+	TotalAmountWithTax    commons.Decimal `gorm:"type:decimal(12,2)"`
+	Status                string
+	InvoiceNumber         *string                    `gorm:"index;unique"`
+	Currency              string                     `gorm:"type:char(3);not null"`
+	CustomerCountry       string                     `gorm:"type:char(2);not null;default:ES"`
+	CustomerPostalCode    string                     `gorm:"type:varchar(10)"`
+	BillingRunID          *uuid.UUID                 `gorm:"type:uuid"`
+	TaxBreakdown          commons.JSON[[]TaxSummary] `gorm:"type:jsonb;not null;default:'[]'"`
+}
+
+// TaxSummary is an entry of the tax breakdown stored as JSON with the invoice.
+type TaxSummary struct {
+	Regime         string          `json:"regime"`
+	Percentage     commons.Decimal `json:"percentage"`
+	ExemptionCause string          `json:"exemption_cause,omitempty"`
+	TaxableBase    commons.Decimal `json:"taxable_base"`
+	TaxAmount      commons.Decimal `json:"tax_amount"`
+}
+
+// TableName specifies the table name for Invoice in the database.
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// Movement is the view of a movement waiting to be billed.
+type Movement struct {
+	ID              uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	AccountID       string           `gorm:"type:varchar(255)"`
+	InvoiceID       *uuid.UUID       `gorm:"type:uuid"`
+	Amount          commons.Decimal  `gorm:"type:decimal(12,2);not null"`
+	Currency        string           `gorm:"type:char(3);not null"`
+	TaxPercentage   *commons.Decimal `gorm:"type:decimal(5,2)"`
+	ProductCategory string           `gorm:"type:varchar(50)"`
+	MovementType    string           `gorm:"type:varchar(50);not null"`
+	Description     string           `gorm:"type:text"`
+	TransactionDate time.Time        `gorm:"not null"`
+	Status          string           `gorm:"type:varchar(50);not null"`
+}
+
+// TableName specifies the table name for Movement in the database.
+func (Movement) TableName() string {
+	return "movements"
+}
@@ -0,0 +1,129 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pendingMovementStatus  = "PENDING"
+	invoicedMovementStatus = "INVOICED"
+	draftInvoiceStatus     = "DRAFT"
+)
+
+// BillingSqlClient runs the billing queries. Every method joins the transaction carried by the context, if any.
+type BillingSqlClient struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewBillingSqlClient(db *gorm.DB) BillingSqlClient {
+	return BillingSqlClient{
+		db:     db,
+		logger: log.With().Str("component", "BillingSqlClient").Logger(),
+	}
+}
+
+func (c BillingSqlClient) GetRunByPeriod(ctx context.Context, start, end time.Time) (run BillingRun, err error) {
+	err = commons.Conn(ctx, c.db).
+		Where("period_start = ? AND period_end = ?", start, end).
+		First(&run).Error
+	return
+}
+
+func (c BillingSqlClient) CreateRun(ctx context.Context, run BillingRun) error {
+	return commons.Conn(ctx, c.db).Create(&run).Error
+}
+
+func (c BillingSqlClient) UpdateRun(ctx context.Context, run BillingRun) error {
+	result := commons.Conn(ctx, c.db).Model(&BillingRun{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+		"status":             run.Status,
+		"invoices_created":   run.InvoicesCreated,
+		"movements_invoiced": run.MovementsInvoiced,
+		"started_at":         run.StartedAt,
+		"finished_at":        run.FinishedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetAccountsToBill returns the accounts with pending movements not attached to an invoice in [start, end)
+func (c BillingSqlClient) GetAccountsToBill(ctx context.Context, start, end time.Time) ([]string, error) {
+	var accounts []string
+	err := commons.Conn(ctx, c.db).Model(&Movement{}).
+		Distinct("account_id").
+		Where("status = ? AND invoice_id IS NULL AND account_id IS NOT NULL", pendingMovementStatus).
+		Where("transaction_date >= ? AND transaction_date < ?", start, end).
+		Where("deleted_at IS NULL").
+		Order("account_id").
+		Pluck("account_id", &accounts).Error
+	return accounts, err
+}
+
+// GetPendingMovements locks the pending movements of an account in [start, end).
+// Rows locked by a concurrent run are skipped so both runs never invoice the same movement.
+func (c BillingSqlClient) GetPendingMovements(ctx context.Context, accountID string, start, end time.Time) ([]Movement, error) {
+	var movements []Movement
+	err := commons.Conn(ctx, c.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("account_id = ? AND status = ? AND invoice_id IS NULL", accountID, pendingMovementStatus).
+		Where("transaction_date >= ? AND transaction_date < ?", start, end).
+		Where("deleted_at IS NULL").
+		Order("transaction_date, id").
+		Find(&movements).Error
+	return movements, err
+}
+
+func (c BillingSqlClient) GetRunDraftInvoice(ctx context.Context, runID uuid.UUID, accountID string) (invoice Invoice, err error) {
+	err = commons.Conn(ctx, c.db).
+		Where("billing_run_id = ? AND account_id = ? AND status = ?", runID, accountID, draftInvoiceStatus).
+		First(&invoice).Error
+	return
+}
+
+func (c BillingSqlClient) CreateInvoice(ctx context.Context, invoice Invoice) error {
+	return commons.Conn(ctx, c.db).Create(&invoice).Error
+}
+
+func (c BillingSqlClient) UpdateInvoiceTotals(ctx context.Context, invoice Invoice) error {
+	result := commons.Conn(ctx, c.db).Model(&Invoice{}).Where("id = ?", invoice.ID).Updates(map[string]interface{}{
+		"tax_amount":               invoice.TaxAmount,
+		"total_amount_without_tax": invoice.TotalAmountWithoutTax,
+		"total_amount_with_tax":    invoice.TotalAmountWithTax,
+		"tax_breakdown":            invoice.TaxBreakdown,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// InvoiceMovement flips a pending movement to INVOICED, failing if it is no longer pending
+func (c BillingSqlClient) InvoiceMovement(ctx context.Context, movementID uuid.UUID, columns map[string]interface{}) error {
+	result := commons.Conn(ctx, c.db).Model(&Movement{}).
+		Where("id = ? AND status = ? AND invoice_id IS NULL", movementID, pendingMovementStatus).
+		Updates(columns)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("movement %s is no longer pending: %w", movementID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
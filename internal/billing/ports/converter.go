@@ -0,0 +1,86 @@
+package ports
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+)
+
+var (
+	ErrInvalidDate      = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidRunResult = errors.New("invalid billing run result")
+)
+
+type Converter struct{}
+
+func NewConverter() Converter {
+	return Converter{}
+}
+
+// ConvertRequestArgsToPeriod builds the billing period from the periodStart and periodEnd arguments
+func (c Converter) ConvertRequestArgsToPeriod(args map[string]any) (model.Period, error) {
+	start, err := parseDate(args["periodStart"])
+	if err != nil {
+		return model.Period{}, err
+	}
+	end, err := parseDate(args["periodEnd"])
+	if err != nil {
+		return model.Period{}, err
+	}
+	return model.NewPeriod(start, end)
+}
+
+func (c Converter) ConvertRunResultToJson(result model.RunResult) ([]byte, error) {
+	jsonData, err := json.Marshal(c.ConvertRunResult(result))
+	if err != nil {
+		return nil, ErrInvalidRunResult
+	}
+	return jsonData, nil
+}
+
+func (c Converter) ConvertRunResult(result model.RunResult) BillingRun {
+	run := result.Run
+	dto := BillingRun{
+		ID:                run.ID.String(),
+		PeriodStart:       run.Period.Start.Format(time.DateOnly),
+		PeriodEnd:         run.Period.End.Format(time.DateOnly),
+		Status:            string(run.Status),
+		InvoicesCreated:   run.InvoicesCreated,
+		MovementsInvoiced: run.MovementsInvoiced,
+		StartedAt:         run.StartedAt.Format(time.RFC3339),
+		Accounts:          make([]AccountBilling, len(result.Accounts)),
+	}
+	if !run.FinishedAt.IsZero() {
+		dto.FinishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+
+	for i, account := range result.Accounts {
+		dto.Accounts[i] = AccountBilling{
+			AccountID:         account.AccountID,
+			InvoiceCreated:    account.InvoiceCreated,
+			MovementsInvoiced: account.MovementsInvoiced,
+			Error:             account.Error,
+		}
+		if account.InvoiceID != uuid.Nil {
+			dto.Accounts[i].InvoiceID = account.InvoiceID.String()
+			dto.Accounts[i].AmountWithTax = account.TotalAmountWithTax.Amount()
+			dto.Accounts[i].Currency = account.TotalAmountWithTax.Currency().String()
+		}
+	}
+	return dto
+}
+
+func parseDate(value any) (time.Time, error) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, ErrInvalidDate
+	}
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return date, nil
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+)
+
+// errorCode classifies the errors of the billing context
+func errorCode(err error) toolerror.Code {
+	switch {
+	case errors.Is(err, model.ErrBillingRunNotFound):
+		return toolerror.CodeNotFound
+	case errors.Is(err, model.ErrInvalidPeriod),
+		errors.Is(err, ErrInvalidDate):
+		return toolerror.CodeInvalidArgument
+	default:
+		return ""
+	}
+}
+
+// toolError is the structured tool result of a failure of the billing context
+func toolError(message string, err error) *mcp.CallToolResult {
+	return toolerror.Result(toolerror.Classify(err, errorCode), message, err)
+}
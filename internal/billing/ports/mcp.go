@@ -0,0 +1,59 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/billing/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type BillingService interface {
+	Run(ctx context.Context, period model.Period) (model.RunResult, error)
+}
+
+type controller struct {
+	service   BillingService
+	converter Converter
+	logger    zerolog.Logger
+}
+
+func NewController(service BillingService) controller {
+	return controller{
+		service:   service,
+		converter: NewConverter(),
+		logger:    log.With().Str("module", "billingMcpController").Logger(),
+	}
+}
+
+func (c controller) RunBilling(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in RunBilling tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+
+	period, err := c.converter.ConvertRequestArgsToPeriod(args)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid billing period")
+		return toolerror.InvalidArgument("Invalid billing period", err), nil
+	}
+
+	result, err := c.service.Run(ctx, period)
+	if err != nil {
+		c.logger.Error().Err(err).Str("period", period.String()).Msg("Failed to run billing")
+		return toolError("Failed to run billing", err), nil
+	}
+
+	jsonData, err := c.converter.ConvertRunResultToJson(result)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert billing run to JSON")
+		return toolError("Failed to convert billing run to JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
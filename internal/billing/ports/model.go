@@ -0,0 +1,25 @@
+package ports
+
+// BillingRun represents the outcome of a billing run as returned by the MCP API.
+type BillingRun struct {
+	ID                string           `json:"id"`
+	PeriodStart       string           `json:"period_start"`
+	PeriodEnd         string           `json:"period_end"`
+	Status            string           `json:"status"`
+	InvoicesCreated   int              `json:"invoices_created"`
+	MovementsInvoiced int              `json:"movements_invoiced"`
+	StartedAt         string           `json:"started_at"`
+	FinishedAt        string           `json:"finished_at,omitempty"`
+	Accounts          []AccountBilling `json:"accounts"`
+}
+
+// AccountBilling represents the billing of one account in the run being reported.
+type AccountBilling struct {
+	AccountID         string `json:"account_id"`
+	InvoiceID         string `json:"invoice_id,omitempty"`
+	InvoiceCreated    bool   `json:"invoice_created"`
+	MovementsInvoiced int    `json:"movements_invoiced"`
+	AmountWithTax     string `json:"amount_with_tax,omitempty"`
+	Currency          string `json:"currency,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
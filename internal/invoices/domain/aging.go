@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+)
+
+// GetAgingReport returns the accounts receivable aging of an account, or of every account when the query has none:
+// the outstanding amount of its issued invoices still to be collected, grouped by the days passed since their due
+// date. What is outstanding of an invoice is its total less the payments allocated to it and its credit notes.
+func (s Service) GetAgingReport(ctx context.Context, query model.AgingQuery) (model.AgingReport, error) {
+	logger := s.logger.With().Str("account_id", query.AccountID).Str("currency", query.Currency.String()).Logger()
+	logger.Info().Time("as_of", query.AsOf).Msg("Building aging report")
+
+	totals, err := s.repo.GetAgingTotals(ctx, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to fetch aging totals")
+		return model.AgingReport{}, fmt.Errorf("failed to fetch aging totals: %w", err)
+	}
+
+	report, err := model.NewAgingReport(query, totals)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to build aging report")
+		return model.AgingReport{}, err
+	}
+	return report, nil
+}
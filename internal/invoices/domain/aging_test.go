@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_GetAgingReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	query := model.AgingQuery{AccountID: "account_A", Currency: "EUR", AsOf: time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)}
+	mockRepo.EXPECT().GetAgingTotals(ctx, query).Return([]model.AgingTotal{
+		{AccountID: "account_A", Status: model.InvoiceStatusOverdue, Bucket: model.AgingBucket61To90, Invoices: 2, Outstanding: money.New(30000, "EUR")},
+	}, nil)
+
+	report, err := service.GetAgingReport(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, query, report.AgingQuery)
+	assert.Equal(t, money.New(30000, "EUR"), report.Totals.Buckets[model.AgingBucket61To90])
+	require.Len(t, report.Accounts, 1)
+	assert.Equal(t, 2, report.Accounts[0].Invoices)
+}
+
+func TestService_GetAgingReport_PropagatesRepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+	dbErr := errors.New("connection refused")
+
+	mockRepo.EXPECT().GetAgingTotals(ctx, gomock.Any()).Return(nil, dbErr)
+
+	_, err := service.GetAgingReport(ctx, model.AgingQuery{Currency: "EUR"})
+	assert.ErrorIs(t, err, dbErr)
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+// IssueCreditNote issues a credit note rectifying the given invoice, numbered in the credit note series.
+// Its lines cancel the requested lines of the invoice, or every line for what is left of it when none is requested.
+// An open invoice left with nothing to collect is settled as PAID in the same transaction.
+func (s Service) IssueCreditNote(ctx context.Context, id model.InvoiceID, request model.CreditNoteRequest) (model.Invoice, error) {
+	logger := s.logger.With().Str("invoice_id", id.String()).Logger()
+	logger.Info().Int("lines", len(request.Lines)).Msg("Issuing credit note")
+
+	issueDate := request.IssueDate
+	if issueDate.IsZero() {
+		issueDate = time.Now()
+	}
+
+	var creditNote model.Invoice
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// The invoice stays locked until commit, so concurrent credit notes of it are serialized and the
+		// credited amounts read below cannot change before this one is stored.
+		original, err := s.repo.LockInvoice(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := original.CanBeCredited(); err != nil {
+			return err
+		}
+		lines, err := s.repo.GetInvoiceLines(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch invoice lines: %w", err)
+		}
+
+		creditNote, err = model.NewCreditNote(original, issueDate, request.Reason)
+		if err != nil {
+			return err
+		}
+		number, err := s.numbering.Next(ctx, creditNote)
+		if err != nil {
+			return err
+		}
+
+		credited, err := s.repo.GetCreditedAmounts(ctx, original)
+		if err != nil {
+			return fmt.Errorf("failed to fetch credited amounts: %w", err)
+		}
+		creditLines, err := rectifyLines(lines, credited, request.Lines)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.CreateInvoice(ctx, creditNote); err != nil {
+			return fmt.Errorf("failed to create credit note: %w", err)
+		}
+		for _, line := range creditLines {
+			if err := creditNote.AddLine(line); err != nil {
+				return err
+			}
+			if err := s.repo.AddInvoiceLine(ctx, creditNote, line); err != nil {
+				return fmt.Errorf("failed to add credit note line: %w", err)
+			}
+		}
+
+		from := creditNote.Status
+		if err := creditNote.Issue(number); err != nil {
+			return err
+		}
+		if err := s.repo.ChangeInvoiceStatus(ctx, creditNote, model.NewStatusChange(creditNote.ID, from, creditNote.Status)); err != nil {
+			return err
+		}
+		return s.settleCredited(ctx, original)
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to issue credit note")
+		return model.Invoice{}, err
+	}
+
+	logger.Info().Str("credit_note_id", creditNote.ID.String()).Str("number", creditNote.InvoiceNumber).Msg("Issued credit note")
+	return creditNote, nil
+}
+
+// settleCredited marks an open invoice as PAID once its credit notes and payments leave nothing to collect of it, so it
+// no longer takes payments nor becomes overdue.
+func (s Service) settleCredited(ctx context.Context, invoice model.Invoice) error {
+	if !invoice.Status.CanTransitionTo(model.InvoiceStatusPaid) {
+		return nil
+	}
+	outstanding, err := s.repo.GetOutstandingAmount(ctx, invoice)
+	if err != nil {
+		return fmt.Errorf("failed to fetch outstanding amount: %w", err)
+	}
+	if outstanding.IsPositive() {
+		return nil
+	}
+
+	from := invoice.Status
+	if err := invoice.MarkAsPaid(); err != nil {
+		return err
+	}
+	return s.repo.ChangeInvoiceStatus(ctx, invoice, model.NewStatusChange(invoice.ID, from, invoice.Status))
+}
+
+// rectifyLines builds the credit note lines for the selected lines of an invoice, given what was already credited on each.
+func rectifyLines(lines []model.InvoiceLine, credited map[uuid.UUID]money.Money, selected []model.CreditedLine) ([]model.InvoiceLine, error) {
+	remaining := func(line model.InvoiceLine) (money.Money, error) {
+		done, ok := credited[line.MovementID]
+		if !ok {
+			return line.AmountWithoutTax, nil
+		}
+		return line.AmountWithoutTax.Sub(done)
+	}
+
+	var creditLines []model.InvoiceLine
+	if len(selected) == 0 {
+		for _, line := range lines {
+			left, err := remaining(line)
+			if err != nil {
+				return nil, err
+			}
+			if !left.IsPositive() {
+				continue
+			}
+			credit, err := line.Rectify(left)
+			if err != nil {
+				return nil, err
+			}
+			creditLines = append(creditLines, credit)
+		}
+	} else {
+		byID := make(map[uuid.UUID]model.InvoiceLine, len(lines))
+		for _, line := range lines {
+			byID[line.MovementID] = line
+		}
+		seen := make(map[uuid.UUID]bool, len(selected))
+		for _, selection := range selected {
+			line, ok := byID[selection.MovementID]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", model.ErrLineNotInInvoice, selection.MovementID)
+			}
+			if seen[selection.MovementID] {
+				return nil, fmt.Errorf("%w: %s", model.ErrLineCreditedTwice, selection.MovementID)
+			}
+			seen[selection.MovementID] = true
+			left, err := remaining(line)
+			if err != nil {
+				return nil, err
+			}
+			amount := left
+			if selection.AmountWithoutTax != nil {
+				amount = *selection.AmountWithoutTax
+			}
+			if cmp, err := amount.Cmp(left); err != nil {
+				return nil, err
+			} else if cmp > 0 || !amount.IsPositive() {
+				return nil, fmt.Errorf("%w: %s requested, %s left on line %s", model.ErrCreditExceedsLine, amount, left, line.MovementID)
+			}
+			credit, err := line.Rectify(amount)
+			if err != nil {
+				return nil, err
+			}
+			creditLines = append(creditLines, credit)
+		}
+	}
+
+	if len(creditLines) == 0 {
+		return nil, model.ErrNothingToCredit
+	}
+	return creditLines, nil
+}
@@ -0,0 +1,185 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func runInTransaction(transactor *domain.MockTransactor) {
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func invoiceLines(t *testing.T, amounts ...int64) []model.InvoiceLine {
+	lines := make([]model.InvoiceLine, len(amounts))
+	for i, amount := range amounts {
+		line, err := model.NewInvoiceLine("Line", money.New(amount, money.DefaultCurrency), 2100, "CREDIT")
+		require.NoError(t, err)
+		lines[i] = line
+	}
+	return lines
+}
+
+func TestService_IssueCreditNote_CreditsWhatIsLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	invoice := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	lines := invoiceLines(t, 10000, 5000)
+	issueDate := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, invoice.ID).Return(invoice, nil)
+	mockRepo.EXPECT().GetInvoiceLines(ctx, invoice.ID).Return(lines, nil)
+	mockRepo.EXPECT().NextSequenceNumber(ctx, "R", 2025).Return(int64(7), nil)
+	// The second line was already credited in full by a previous credit note
+	mockRepo.EXPECT().GetCreditedAmounts(ctx, invoice).Return(map[uuid.UUID]money.Money{
+		lines[0].MovementID: money.New(2500, money.DefaultCurrency),
+		lines[1].MovementID: money.New(5000, money.DefaultCurrency),
+	}, nil)
+	mockRepo.EXPECT().CreateInvoice(ctx, gomock.Any()).Return(nil)
+	mockRepo.EXPECT().AddInvoiceLine(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ model.Invoice, line model.InvoiceLine) error {
+			assert.Equal(t, lines[0].MovementID, line.CorrectedMovementID)
+			assert.Equal(t, "-75.00", line.AmountWithoutTax.Amount())
+			return nil
+		})
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ model.Invoice, change model.StatusChange) error {
+			assert.Equal(t, model.InvoiceStatusDraft, change.From)
+			assert.Equal(t, model.InvoiceStatusSent, change.To)
+			return nil
+		})
+	// Something is still to be collected, so the invoice stays open
+	mockRepo.EXPECT().GetOutstandingAmount(ctx, invoice).Return(money.New(9075, money.DefaultCurrency), nil)
+
+	creditNote, err := service.IssueCreditNote(ctx, invoice.ID, model.CreditNoteRequest{Reason: "Wrong tariff", IssueDate: issueDate})
+	require.NoError(t, err)
+	assert.Equal(t, "R-2025-000007", creditNote.InvoiceNumber)
+	assert.Equal(t, model.InvoiceTypeCreditNote, creditNote.Type)
+	assert.Equal(t, invoice.ID, creditNote.CorrectedInvoiceID)
+	assert.Equal(t, model.InvoiceStatusSent, creditNote.Status)
+	assert.Equal(t, "-75.00", creditNote.TotalAmountWithoutTax.Amount())
+	assert.Equal(t, "-90.75", creditNote.TotalAmountWithTax.Amount())
+}
+
+func TestService_IssueCreditNote_SettlesFullyCreditedInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	invoice := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	lines := invoiceLines(t, 10000)
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, invoice.ID).Return(invoice, nil)
+	mockRepo.EXPECT().GetInvoiceLines(ctx, invoice.ID).Return(lines, nil)
+	mockRepo.EXPECT().NextSequenceNumber(ctx, "R", gomock.Any()).Return(int64(3), nil)
+	mockRepo.EXPECT().GetCreditedAmounts(ctx, invoice).Return(map[uuid.UUID]money.Money{}, nil)
+	mockRepo.EXPECT().CreateInvoice(ctx, gomock.Any()).Return(nil)
+	mockRepo.EXPECT().AddInvoiceLine(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	issued := mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetOutstandingAmount(ctx, invoice).Return(money.Zero(money.DefaultCurrency), nil).After(issued)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, settled model.Invoice, change model.StatusChange) error {
+			assert.Equal(t, invoice.ID, settled.ID)
+			assert.Equal(t, model.InvoiceStatusSent, change.From)
+			assert.Equal(t, model.InvoiceStatusPaid, change.To)
+			return nil
+		})
+
+	_, err := service.IssueCreditNote(ctx, invoice.ID, model.CreditNoteRequest{Reason: "Cancelled order"})
+	require.NoError(t, err)
+}
+
+func TestService_IssueCreditNote_RejectsInvalidLines(t *testing.T) {
+	invoice := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	lines := invoiceLines(t, 10000)
+	credited := map[uuid.UUID]money.Money{lines[0].MovementID: money.New(9000, money.DefaultCurrency)}
+	tooMuch := money.New(1001, money.DefaultCurrency)
+
+	tests := []struct {
+		name     string
+		lines    []model.CreditedLine
+		expected error
+	}{
+		{"line of another invoice", []model.CreditedLine{{MovementID: uuid.New()}}, model.ErrLineNotInInvoice},
+		{"more than what is left", []model.CreditedLine{{MovementID: lines[0].MovementID, AmountWithoutTax: &tooMuch}}, model.ErrCreditExceedsLine},
+		{"same line twice", []model.CreditedLine{{MovementID: lines[0].MovementID}, {MovementID: lines[0].MovementID}}, model.ErrLineCreditedTwice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockRepository(ctrl)
+			mockTransactor := domain.NewMockTransactor(ctrl)
+			service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+			ctx := context.Background()
+
+			runInTransaction(mockTransactor)
+			mockRepo.EXPECT().LockInvoice(ctx, invoice.ID).Return(invoice, nil)
+			mockRepo.EXPECT().GetInvoiceLines(ctx, invoice.ID).Return(lines, nil)
+			mockRepo.EXPECT().NextSequenceNumber(ctx, "R", gomock.Any()).Return(int64(1), nil)
+			mockRepo.EXPECT().GetCreditedAmounts(ctx, invoice).Return(credited, nil)
+
+			_, err := service.IssueCreditNote(ctx, invoice.ID, model.CreditNoteRequest{Reason: "Wrong tariff", Lines: tt.lines})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestService_IssueCreditNote_NothingLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	invoice := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	lines := invoiceLines(t, 10000)
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, invoice.ID).Return(invoice, nil)
+	mockRepo.EXPECT().GetInvoiceLines(ctx, invoice.ID).Return(lines, nil)
+	mockRepo.EXPECT().NextSequenceNumber(ctx, "R", gomock.Any()).Return(int64(2), nil)
+	mockRepo.EXPECT().GetCreditedAmounts(ctx, invoice).Return(map[uuid.UUID]money.Money{
+		lines[0].MovementID: money.New(10000, money.DefaultCurrency),
+	}, nil)
+
+	_, err := service.IssueCreditNote(ctx, invoice.ID, model.CreditNoteRequest{Reason: "Duplicated"})
+	assert.ErrorIs(t, err, model.ErrNothingToCredit)
+}
+
+func TestService_IssueCreditNote_RejectsDrafts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	// The draft is read under lock and rejected before a number of the series is taken
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, draft.ID).Return(draft, nil)
+
+	_, err = service.IssueCreditNote(ctx, draft.ID, model.CreditNoteRequest{Reason: "Wrong tariff"})
+	assert.ErrorIs(t, err, model.ErrInvoiceNotCreditable)
+}
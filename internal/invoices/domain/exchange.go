@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// ExchangeRateRepository provides the exchange rates used to invoice movements recorded in other currencies.
+type ExchangeRateRepository interface {
+	// GetExchangeRate returns the most recent rate from one currency to another published on or before the given date.
+	// It returns model.ErrExchangeRateNotFound when no such rate exists.
+	GetExchangeRate(ctx context.Context, from, to money.Currency, date time.Time) (money.ExchangeRate, error)
+}
+
+// CurrencyConverter converts invoice lines into the invoice currency using the rate of their transaction date.
+type CurrencyConverter struct {
+	rates  ExchangeRateRepository
+	logger zerolog.Logger
+}
+
+func NewCurrencyConverter(rates ExchangeRateRepository) CurrencyConverter {
+	return CurrencyConverter{
+		rates:  rates,
+		logger: log.With().Str("module", "currencyConverter").Logger(),
+	}
+}
+
+// ConvertLine expresses the line in the target currency. Lines already in that currency are returned unchanged.
+func (c CurrencyConverter) ConvertLine(ctx context.Context, line model.InvoiceLine, to money.Currency) (model.InvoiceLine, error) {
+	from := line.AmountWithoutTax.Currency()
+	if from == to {
+		return line, nil
+	}
+
+	rate, err := c.rateAt(ctx, from, to, line.TransactionDate)
+	if err != nil {
+		c.logger.Error().Err(err).Str("from", from.String()).Str("to", to.String()).Time("date", line.TransactionDate).Msg("Failed to find exchange rate")
+		return model.InvoiceLine{}, err
+	}
+
+	c.logger.Info().Str("from", from.String()).Str("to", to.String()).Str("rate", rate.String()).Msg("Converting invoice line")
+	return line.Convert(rate)
+}
+
+// rateAt looks the rate up in both directions, inverting it when only the opposite pair is published.
+func (c CurrencyConverter) rateAt(ctx context.Context, from, to money.Currency, date time.Time) (money.ExchangeRate, error) {
+	rate, err := c.rates.GetExchangeRate(ctx, from, to, date)
+	if err == nil {
+		return rate, nil
+	}
+	if !errors.Is(err, model.ErrExchangeRateNotFound) {
+		return money.ExchangeRate{}, err
+	}
+
+	inverse, err := c.rates.GetExchangeRate(ctx, to, from, date)
+	if err != nil {
+		if errors.Is(err, model.ErrExchangeRateNotFound) {
+			return money.ExchangeRate{}, fmt.Errorf("%w: %s to %s on %s", model.ErrExchangeRateNotFound, from, to, date.Format(time.DateOnly))
+		}
+		return money.ExchangeRate{}, err
+	}
+	return inverse.Invert(), nil
+}
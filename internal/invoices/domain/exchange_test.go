@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRates publishes a single rate per currency pair
+type stubRates map[money.Currency]map[money.Currency]string
+
+func (s stubRates) GetExchangeRate(_ context.Context, from, to money.Currency, _ time.Time) (money.ExchangeRate, error) {
+	rate, ok := s[from][to]
+	if !ok {
+		return money.ExchangeRate{}, model.ErrExchangeRateNotFound
+	}
+	return money.NewExchangeRate(from, to, rate)
+}
+
+func TestCurrencyConverter_ConvertLine(t *testing.T) {
+	converter := domain.NewCurrencyConverter(stubRates{"EUR": {"USD": "1.25"}})
+	ctx := context.Background()
+
+	usdLine, err := model.NewInvoiceLine("Roaming", money.New(12500, "USD"), 0, "CREDIT")
+	require.NoError(t, err)
+
+	converted, err := converter.ConvertLine(ctx, usdLine, "EUR")
+	require.NoError(t, err, "the EUR/USD rate is inverted")
+	assert.Equal(t, "100.00 EUR", converted.AmountWithTax.String())
+	assert.Equal(t, "125.00 USD", converted.OriginalAmount.String())
+
+	unchanged, err := converter.ConvertLine(ctx, usdLine, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, usdLine, unchanged)
+
+	gbpLine, err := model.NewInvoiceLine("Roaming", money.New(100, "GBP"), 0, "CREDIT")
+	require.NoError(t, err)
+	_, err = converter.ConvertLine(ctx, gbpLine, "EUR")
+	assert.ErrorIs(t, err, model.ErrExchangeRateNotFound)
+}
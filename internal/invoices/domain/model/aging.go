@@ -0,0 +1,151 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+// AgingBucket groups the receivable invoices by the days passed since their due date.
+type AgingBucket string
+
+const (
+	AgingBucketCurrent AgingBucket = "CURRENT" // Not past due
+	AgingBucket1To30   AgingBucket = "1_30"
+	AgingBucket31To60  AgingBucket = "31_60"
+	AgingBucket61To90  AgingBucket = "61_90"
+	AgingBucketOver90  AgingBucket = "90_PLUS"
+)
+
+// AgingBucketLimit is the most days past due of the invoices of a bucket.
+type AgingBucketLimit struct {
+	Bucket         AgingBucket
+	MaxDaysPastDue int
+}
+
+// AgingBuckets are the aging buckets in order, each one holding the invoices past due more days than the one before.
+var AgingBuckets = []AgingBucketLimit{
+	{AgingBucketCurrent, 0},
+	{AgingBucket1To30, 30},
+	{AgingBucket31To60, 60},
+	{AgingBucket61To90, 90},
+	{AgingBucketOver90, math.MaxInt32},
+}
+
+// ReceivableStatuses are the statuses of the issued invoices still to be collected.
+var ReceivableStatuses = []InvoiceStatus{InvoiceStatusSent, InvoiceStatusOverdue, InvoiceStatusUnpaid}
+
+// AgingQuery selects the receivables of an aging report: those of an account, or of every account when AccountID is
+// empty, in a currency, aged on a day.
+type AgingQuery struct {
+	AccountID string
+	Currency  money.Currency
+	AsOf      time.Time
+}
+
+// AgingTotal is the outstanding amount of the receivable invoices of an account with a status in an aging bucket.
+type AgingTotal struct {
+	AccountID   string
+	Status      InvoiceStatus
+	Bucket      AgingBucket
+	Invoices    int
+	Outstanding money.Money
+}
+
+// AgingAmounts are outstanding amounts by aging bucket, with their total and the number of invoices they add up.
+type AgingAmounts struct {
+	Buckets  map[AgingBucket]money.Money
+	Total    money.Money
+	Invoices int
+}
+
+func newAgingAmounts(currency money.Currency) AgingAmounts {
+	amounts := AgingAmounts{Buckets: make(map[AgingBucket]money.Money, len(AgingBuckets)), Total: money.Zero(currency)}
+	for _, limit := range AgingBuckets {
+		amounts.Buckets[limit.Bucket] = money.Zero(currency)
+	}
+	return amounts
+}
+
+func (a *AgingAmounts) add(total AgingTotal) error {
+	bucket, ok := a.Buckets[total.Bucket]
+	if !ok {
+		return fmt.Errorf("unknown aging bucket %q", total.Bucket)
+	}
+	var err error
+	if a.Buckets[total.Bucket], err = bucket.Add(total.Outstanding); err != nil {
+		return err
+	}
+	a.Total, _ = a.Total.Add(total.Outstanding)
+	a.Invoices += total.Invoices
+	return nil
+}
+
+// AccountAging are the aging amounts of an account.
+type AccountAging struct {
+	AccountID string
+	AgingAmounts
+}
+
+// StatusAging are the aging amounts of the invoices with a status.
+type StatusAging struct {
+	Status InvoiceStatus
+	AgingAmounts
+}
+
+// AgingReport is the accounts receivable aging: the outstanding amount of the receivable invoices by aging bucket, per
+// account, per status and in total. Accounts owing the most come first; every status and bucket is reported, even
+// when nothing is outstanding in it.
+type AgingReport struct {
+	AgingQuery
+	Accounts []AccountAging
+	Statuses []StatusAging
+	Totals   AgingAmounts
+}
+
+// NewAgingReport adds up the aging totals of the query into a report.
+func NewAgingReport(query AgingQuery, totals []AgingTotal) (AgingReport, error) {
+	report := AgingReport{
+		AgingQuery: query,
+		Accounts:   []AccountAging{},
+		Statuses:   make([]StatusAging, len(ReceivableStatuses)),
+		Totals:     newAgingAmounts(query.Currency),
+	}
+	statuses := make(map[InvoiceStatus]int, len(ReceivableStatuses))
+	for i, status := range ReceivableStatuses {
+		report.Statuses[i] = StatusAging{Status: status, AgingAmounts: newAgingAmounts(query.Currency)}
+		statuses[status] = i
+	}
+
+	accounts := make(map[string]int)
+	for _, total := range totals {
+		status, ok := statuses[total.Status]
+		if !ok {
+			return AgingReport{}, fmt.Errorf("invoices %s are not receivable", total.Status)
+		}
+		account, ok := accounts[total.AccountID]
+		if !ok {
+			account = len(report.Accounts)
+			accounts[total.AccountID] = account
+			report.Accounts = append(report.Accounts, AccountAging{AccountID: total.AccountID, AgingAmounts: newAgingAmounts(query.Currency)})
+		}
+
+		if err := report.Totals.add(total); err != nil {
+			return AgingReport{}, fmt.Errorf("account %s: %w", total.AccountID, err)
+		}
+		_ = report.Statuses[status].add(total)
+		_ = report.Accounts[account].add(total)
+	}
+
+	sort.SliceStable(report.Accounts, func(i, j int) bool {
+		a, b := report.Accounts[i], report.Accounts[j]
+		if cmp, _ := a.Total.Cmp(b.Total); cmp != 0 {
+			return cmp > 0
+		}
+		return a.AccountID < b.AccountID
+	})
+	return report, nil
+}
@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAgingReport(t *testing.T) {
+	query := model.AgingQuery{Currency: "EUR", AsOf: time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)}
+	totals := []model.AgingTotal{
+		{AccountID: "account_A", Status: model.InvoiceStatusSent, Bucket: model.AgingBucketCurrent, Invoices: 2, Outstanding: money.New(24200, "EUR")},
+		{AccountID: "account_A", Status: model.InvoiceStatusOverdue, Bucket: model.AgingBucket1To30, Invoices: 1, Outstanding: money.New(5000, "EUR")},
+		{AccountID: "account_B", Status: model.InvoiceStatusUnpaid, Bucket: model.AgingBucketOver90, Invoices: 1, Outstanding: money.New(50000, "EUR")},
+		{AccountID: "account_C", Status: model.InvoiceStatusOverdue, Bucket: model.AgingBucket31To60, Invoices: 1, Outstanding: money.New(1000, "EUR")},
+	}
+
+	report, err := model.NewAgingReport(query, totals)
+	require.NoError(t, err)
+
+	assert.Equal(t, money.New(80200, "EUR"), report.Totals.Total)
+	assert.Equal(t, 5, report.Totals.Invoices)
+	assert.Equal(t, money.New(24200, "EUR"), report.Totals.Buckets[model.AgingBucketCurrent])
+	assert.Equal(t, money.New(0, "EUR"), report.Totals.Buckets[model.AgingBucket61To90], "every bucket is reported")
+
+	require.Len(t, report.Statuses, len(model.ReceivableStatuses))
+	assert.Equal(t, model.InvoiceStatusOverdue, report.Statuses[1].Status)
+	assert.Equal(t, money.New(6000, "EUR"), report.Statuses[1].Total)
+	assert.Equal(t, money.New(1000, "EUR"), report.Statuses[1].Buckets[model.AgingBucket31To60])
+
+	require.Len(t, report.Accounts, 3)
+	assert.Equal(t, "account_B", report.Accounts[0].AccountID, "accounts owing the most come first")
+	assert.Equal(t, "account_A", report.Accounts[1].AccountID)
+	assert.Equal(t, money.New(29200, "EUR"), report.Accounts[1].Total)
+	assert.Equal(t, 3, report.Accounts[1].Invoices)
+	assert.Equal(t, "account_C", report.Accounts[2].AccountID)
+}
+
+func TestNewAgingReport_Empty(t *testing.T) {
+	report, err := model.NewAgingReport(model.AgingQuery{AccountID: "account_A", Currency: "USD"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Accounts)
+	assert.Equal(t, money.Zero("USD"), report.Totals.Total)
+	assert.Len(t, report.Totals.Buckets, len(model.AgingBuckets))
+}
+
+func TestNewAgingReport_RejectsUnexpectedTotals(t *testing.T) {
+	query := model.AgingQuery{Currency: "EUR"}
+
+	_, err := model.NewAgingReport(query, []model.AgingTotal{
+		{AccountID: "account_A", Status: model.InvoiceStatusPaid, Bucket: model.AgingBucketCurrent, Invoices: 1, Outstanding: money.New(100, "EUR")},
+	})
+	assert.Error(t, err, "paid invoices are not receivable")
+
+	_, err = model.NewAgingReport(query, []model.AgingTotal{
+		{AccountID: "account_A", Status: model.InvoiceStatusSent, Bucket: "120_PLUS", Invoices: 1, Outstanding: money.New(100, "EUR")},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrInvoiceNotCreditable  = errors.New("only issued invoices can be rectified by a credit note")
+	ErrCorrectionReasonEmpty = errors.New("credit note reason cannot be empty")
+	ErrLineNotInInvoice      = errors.New("line does not belong to the rectified invoice")
+	ErrCreditExceedsLine     = errors.New("credited amount exceeds what is left to credit on the line")
+	ErrLineCreditedTwice     = errors.New("line is selected more than once in the credit note")
+	ErrNothingToCredit       = errors.New("nothing left to credit on the invoice")
+)
+
+// InvoiceType tells ordinary invoices apart from the credit notes that rectify them.
+type InvoiceType string
+
+const (
+	InvoiceTypeStandard   InvoiceType = "STANDARD"
+	InvoiceTypeCreditNote InvoiceType = "CREDIT_NOTE"
+)
+
+// creditableStatuses are the statuses of an issued invoice that can still be rectified.
+var creditableStatuses = map[InvoiceStatus]bool{
+	InvoiceStatusSent:    true,
+	InvoiceStatusOverdue: true,
+	InvoiceStatusUnpaid:  true,
+	InvoiceStatusPaid:    true,
+}
+
+// CreditNoteRequest describes the credit note to issue for an invoice.
+// Without lines every line of the invoice is credited for what is left of it.
+type CreditNoteRequest struct {
+	Reason    string
+	IssueDate time.Time
+	Lines     []CreditedLine
+}
+
+// CreditedLine selects a line of the rectified invoice. A nil AmountWithoutTax credits what is left of the line.
+type CreditedLine struct {
+	MovementID       uuid.UUID
+	AmountWithoutTax *money.Money
+}
+
+// CanBeCredited reports whether a credit note can rectify the invoice.
+func (inv Invoice) CanBeCredited() error {
+	if inv.Type == InvoiceTypeCreditNote || !creditableStatuses[inv.Status] {
+		return fmt.Errorf("%w: invoice %s is a %s %s", ErrInvoiceNotCreditable, inv.ID, inv.Status, inv.Type)
+	}
+	return nil
+}
+
+// NewCreditNote creates the draft credit note that rectifies the given invoice.
+// It is due on its issue date and shares the account and currency of the invoice.
+func NewCreditNote(original Invoice, issueDate time.Time, reason string) (Invoice, error) {
+	if err := original.CanBeCredited(); err != nil {
+		return Invoice{}, err
+	}
+	if reason == "" {
+		return Invoice{}, ErrCorrectionReasonEmpty
+	}
+
+	creditNote, err := NewInvoice(original.AccountID, original.Currency, issueDate, issueDate)
+	if err != nil {
+		return Invoice{}, err
+	}
+	creditNote.Type = InvoiceTypeCreditNote
+	creditNote.CustomerLocation = original.CustomerLocation
+	creditNote.CorrectedInvoiceID = original.ID
+	creditNote.CorrectionReason = reason
+	return creditNote, nil
+}
+
+// Rectify returns the credit note line cancelling the given base amount of this line.
+// Its amounts are negative and it keeps the tax of the line; crediting the whole line negates it exactly.
+func (l InvoiceLine) Rectify(amountWithoutTax money.Money) (InvoiceLine, error) {
+	cmp, err := amountWithoutTax.Cmp(l.AmountWithoutTax)
+	if err != nil {
+		return InvoiceLine{}, err
+	}
+	if cmp > 0 || !amountWithoutTax.IsPositive() {
+		return InvoiceLine{}, fmt.Errorf("%w: %s of %s", ErrCreditExceedsLine, amountWithoutTax, l.AmountWithoutTax)
+	}
+
+	description := "Rectification: " + l.Description
+	var credit InvoiceLine
+	if cmp == 0 {
+		credit = l
+		credit.MovementID = uuid.New()
+		credit.Description = description
+		credit.AmountWithoutTax = l.AmountWithoutTax.Neg()
+		credit.AmountWithTax = l.AmountWithTax.Neg()
+		credit.OriginalAmount = l.OriginalAmount.Neg()
+	} else {
+		credit, err = NewInvoiceLine(description, amountWithoutTax.Neg(), l.TaxPercentage, l.OperationType)
+		if err != nil {
+			return InvoiceLine{}, err
+		}
+		credit.TaxRegime = l.TaxRegime
+		credit.ExemptionCause = l.ExemptionCause
+		credit.ProductCategory = l.ProductCategory
+	}
+	credit.TransactionDate = time.Now()
+	credit.CorrectedMovementID = l.MovementID
+	return credit, nil
+}
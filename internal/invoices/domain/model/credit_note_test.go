@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreditNote(t *testing.T) {
+	invoice, err := model.NewInvoice("account_A", "USD", time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	_, err = model.NewCreditNote(invoice, time.Now(), "Wrong tariff")
+	assert.ErrorIs(t, err, model.ErrInvoiceNotCreditable, "a draft can still be edited")
+
+	require.NoError(t, invoice.Issue("FAC-2025-000001"))
+	_, err = model.NewCreditNote(invoice, time.Now(), "")
+	assert.ErrorIs(t, err, model.ErrCorrectionReasonEmpty)
+
+	issueDate := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	creditNote, err := model.NewCreditNote(invoice, issueDate, "Wrong tariff")
+	require.NoError(t, err)
+	assert.Equal(t, model.InvoiceTypeCreditNote, creditNote.Type)
+	assert.Equal(t, invoice.ID, creditNote.CorrectedInvoiceID)
+	assert.Equal(t, invoice.AccountID, creditNote.AccountID)
+	assert.Equal(t, money.Currency("USD"), creditNote.Currency)
+	assert.Equal(t, issueDate, creditNote.DueDate)
+	assert.Equal(t, model.InvoiceStatusDraft, creditNote.Status)
+
+	require.NoError(t, creditNote.Issue("R-2025-000001"))
+	_, err = model.NewCreditNote(creditNote, issueDate, "Wrong tariff")
+	assert.ErrorIs(t, err, model.ErrInvoiceNotCreditable, "credit notes cannot be rectified themselves")
+}
+
+func TestInvoiceLine_Rectify(t *testing.T) {
+	line, err := model.NewInvoiceLine("Monthly subscription", money.New(10050, "EUR"), 2100, "CREDIT")
+	require.NoError(t, err)
+
+	full, err := line.Rectify(line.AmountWithoutTax)
+	require.NoError(t, err)
+	assert.Equal(t, "-100.50", full.AmountWithoutTax.Amount())
+	assert.Equal(t, "-121.61", full.AmountWithTax.Amount(), "a full credit negates the line exactly")
+	assert.Equal(t, line.MovementID, full.CorrectedMovementID)
+	assert.NotEqual(t, line.MovementID, full.MovementID)
+
+	partial, err := line.Rectify(money.New(2550, "EUR"))
+	require.NoError(t, err)
+	assert.Equal(t, "-25.50", partial.AmountWithoutTax.Amount())
+	assert.Equal(t, "-30.86", partial.AmountWithTax.Amount(), "25.50 + 21% rounded to the cent")
+	assert.Equal(t, line.TaxPercentage, partial.TaxPercentage)
+	assert.Equal(t, line.MovementID, partial.CorrectedMovementID)
+
+	_, err = line.Rectify(money.New(10051, "EUR"))
+	assert.ErrorIs(t, err, model.ErrCreditExceedsLine)
+	_, err = line.Rectify(money.Zero("EUR"))
+	assert.ErrorIs(t, err, model.ErrCreditExceedsLine)
+	_, err = line.Rectify(money.New(100, "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+}
@@ -1,9 +1,80 @@
 package model
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+)
+
+// MinLineTextLength is the shortest text searched in the descriptions of the lines
+const MinLineTextLength = 3
+
+var ErrInvalidCriteria = errors.New("invalid search criteria")
+
+// Criteria filters the invoices of an account. Every filter is optional, and an invoice must match all the ones set.
 type Criteria struct {
-	Status        InvoiceStatus
+	Statuses      []InvoiceStatus // Any of them
 	IssueDateFrom time.Time
 	IssueDateTo   time.Time
+	DueDateFrom   time.Time
+	DueDateTo     time.Time
+	// TotalFrom and TotalTo bound the total with taxes, only matching the invoices in their currency
+	TotalFrom *money.Money
+	TotalTo   *money.Money
+	// NumberPrefix matches the start of the invoice number, e.g. FAC-2025-
+	NumberPrefix string
+	// LineText is searched in the descriptions of the lines, ignoring case
+	LineText string
+	// HasOverdueBalance only matches the issued invoices past their due date that are not fully paid
+	HasOverdueBalance bool
+}
+
+// Validate checks the filters make sense together, reporting all the ones that do not.
+func (c Criteria) Validate() error {
+	var errs []error
+	for _, status := range c.Statuses {
+		if _, err := GetStatusFromString(string(status)); err != nil {
+			errs = append(errs, fmt.Errorf("%w: status %q", err, status))
+		}
+	}
+	if !c.IssueDateFrom.IsZero() && !c.IssueDateTo.IsZero() && c.IssueDateFrom.After(c.IssueDateTo) {
+		errs = append(errs, fmt.Errorf("%w: issueDateFrom is after issueDateTo", ErrInvalidCriteria))
+	}
+	if !c.DueDateFrom.IsZero() && !c.DueDateTo.IsZero() && c.DueDateFrom.After(c.DueDateTo) {
+		errs = append(errs, fmt.Errorf("%w: dueDateFrom is after dueDateTo", ErrInvalidCriteria))
+	}
+	if c.TotalFrom != nil && c.TotalTo != nil {
+		if cmp, err := c.TotalFrom.Cmp(*c.TotalTo); err != nil {
+			errs = append(errs, fmt.Errorf("%w: totalFrom and totalTo: %w", ErrInvalidCriteria, err))
+		} else if cmp > 0 {
+			errs = append(errs, fmt.Errorf("%w: totalFrom is greater than totalTo", ErrInvalidCriteria))
+		}
+	}
+	if c.LineText != "" && len([]rune(c.LineText)) < MinLineTextLength {
+		errs = append(errs, fmt.Errorf("%w: lineText must have at least %d characters", ErrInvalidCriteria, MinLineTextLength))
+	}
+	return errors.Join(errs...)
 }
+
+// Fields the invoices of an account can be sorted by
+const (
+	SortByIssueDate   = "issueDate"
+	SortByDueDate     = "dueDate"
+	SortByTotalAmount = "totalAmount"
+)
+
+// Fields the lines of an invoice can be sorted by
+const (
+	SortByTransactionDate = "transactionDate"
+	SortByAmount          = "amount"
+)
+
+var (
+	InvoiceSortFields  = []string{SortByIssueDate, SortByDueDate, SortByTotalAmount}
+	InvoiceDefaultSort = pagination.Sort{Field: SortByIssueDate, Direction: pagination.Descending}
+	LineSortFields     = []string{SortByTransactionDate, SortByAmount}
+	LineDefaultSort    = pagination.Sort{Field: SortByTransactionDate, Direction: pagination.Ascending}
+)
@@ -0,0 +1,68 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func amount(minor int64, currency money.Currency) *money.Money {
+	m := money.New(minor, currency)
+	return &m
+}
+
+func TestCriteria_Validate(t *testing.T) {
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	valid := model.Criteria{
+		Statuses:          []model.InvoiceStatus{model.InvoiceStatusSent, model.InvoiceStatusOverdue},
+		IssueDateFrom:     march,
+		IssueDateTo:       april,
+		DueDateFrom:       march,
+		DueDateTo:         march,
+		TotalFrom:         amount(10000, money.DefaultCurrency),
+		TotalTo:           amount(10000, money.DefaultCurrency),
+		NumberPrefix:      "FAC-2025-",
+		LineText:          "fibra",
+		HasOverdueBalance: true,
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, model.Criteria{}.Validate(), "every filter is optional")
+}
+
+func TestCriteria_Validate_ReportsEveryInvalidFilter(t *testing.T) {
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	criteria := model.Criteria{
+		Statuses:      []model.InvoiceStatus{"LOST"},
+		IssueDateFrom: april,
+		IssueDateTo:   march,
+		DueDateFrom:   april,
+		DueDateTo:     march,
+		TotalFrom:     amount(50000, money.DefaultCurrency),
+		TotalTo:       amount(10000, money.DefaultCurrency),
+		LineText:      "tv",
+	}
+
+	err := criteria.Validate()
+	assert.ErrorIs(t, err, model.ErrStatusUnknown)
+	assert.ErrorIs(t, err, model.ErrInvalidCriteria)
+	assert.ErrorContains(t, err, `status "LOST"`)
+	assert.ErrorContains(t, err, "issueDateFrom is after issueDateTo")
+	assert.ErrorContains(t, err, "dueDateFrom is after dueDateTo")
+	assert.ErrorContains(t, err, "totalFrom is greater than totalTo")
+	assert.ErrorContains(t, err, "lineText must have at least 3 characters")
+}
+
+func TestCriteria_Validate_TotalsInDifferentCurrencies(t *testing.T) {
+	usd, err := money.ParseCurrency("USD")
+	assert.NoError(t, err)
+
+	criteria := model.Criteria{TotalFrom: amount(10000, money.DefaultCurrency), TotalTo: amount(50000, usd)}
+	assert.ErrorIs(t, criteria.Validate(), money.ErrCurrencyMismatch)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrPartyTaxIDEmpty  = errors.New("party tax ID cannot be empty")
+	ErrPartyNameEmpty   = errors.New("party name cannot be empty")
+	ErrInvoiceNotIssued = errors.New("invoice has not been issued yet")
+)
+
+// Address is the postal address of a party.
+type Address struct {
+	Street     string
+	PostalCode string
+	Town       string
+	Province   string
+	Country    string // ISO 3166-1 alpha-2 code, e.g. ES
+}
+
+// Party is the seller or the buyer of an invoice.
+type Party struct {
+	TaxID   string // NIF of Spanish parties, VAT number of foreign ones
+	Name    string // Legal name of a company, or full name of a natural person
+	Address Address
+}
+
+// NewParty validates a party, its country defaults to Spain.
+func NewParty(taxID, name string, address Address) (Party, error) {
+	taxID = strings.ToUpper(strings.TrimSpace(taxID))
+	name = strings.TrimSpace(name)
+	if taxID == "" {
+		return Party{}, ErrPartyTaxIDEmpty
+	}
+	if name == "" {
+		return Party{}, ErrPartyNameEmpty
+	}
+
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	if address.Country == "" {
+		address.Country = "ES"
+	}
+	return Party{TaxID: taxID, Name: name, Address: address}, nil
+}
+
+// Document is an issued invoice together with everything that is rendered on it.
+type Document struct {
+	Invoice Invoice
+	Lines   []InvoiceLine
+	Buyer   Party
+	// CorrectedInvoice is the invoice a credit note rectifies, nil for other invoices
+	CorrectedInvoice *Invoice
+}
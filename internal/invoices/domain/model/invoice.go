@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid" // Import for UUID
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
 )
 
 // Predefined domain errors
@@ -17,6 +19,16 @@ var (
 	ErrVoidInvoiceCannotBePaid   = errors.New("void invoice cannot be marked as paid")
 	ErrPaidInvoiceCannotBeVoided = errors.New("paid invoice cannot be voided")
 	ErrInvoiceNotFound           = errors.New("invoice not found") // Added
+	ErrInvoiceNumberEmpty        = errors.New("invoice number cannot be empty")
+	ErrDueDateBeforeIssueDate    = errors.New("due date cannot be before issue date")
+	ErrLineDescriptionEmpty      = errors.New("invoice line description cannot be empty")
+	ErrLineCurrencyMismatch      = errors.New("invoice line currency does not match the invoice currency")
+	ErrCurrencyEmpty             = errors.New("currency cannot be empty")
+	ErrExchangeRateNotFound      = errors.New("exchange rate not found")
+	ErrInvoiceNotEditable        = errors.New("lines can only be added to draft invoices")
+	ErrStatusChangedConcurrently = errors.New("invoice status was changed concurrently")
+	ErrCustomerLocationMismatch  = errors.New("customer location does not match the fiscal address of the account")
+	ErrInvoiceSettled            = errors.New("invoice is settled by its payments and credit notes")
 )
 
 // InvoiceID represents the unique identifier for an Invoice.
@@ -49,13 +61,97 @@ func ParseInvoiceID(s string) (InvoiceID, error) {
 
 // InvoiceLine represents a single line item on an invoice.
 // It provides a simplified view of a Movement that's included in an invoice.
+// Amounts are expressed in the invoice currency; OriginalAmount keeps the
+// movement amount (with tax) in the currency it was recorded in.
 type InvoiceLine struct {
 	MovementID       uuid.UUID // Reference to the corresponding movement
 	Description      string
-	AmountWithoutTax float64
-	AmountWithTax    float64
-	TaxPercentage    float64
-	OperationType    string // Corresponds to MovementType ("CREDIT" or "DEBIT")
+	AmountWithoutTax money.Money
+	AmountWithTax    money.Money
+	TaxPercentage    money.Percentage
+	TaxRegime        taxes.Regime
+	ExemptionCause   taxes.ExemptionCause // Only set for exempt lines
+	ProductCategory  taxes.Category       // When set, the tax of the line is resolved from it
+	OperationType    string               // Corresponds to MovementType ("CREDIT" or "DEBIT")
+	TransactionDate  time.Time
+	OriginalAmount   money.Money
+	ExchangeRate     money.ExchangeRate // Rate applied from the original currency to the invoice currency
+	// CorrectedMovementID is the line of the original invoice a credit note line rectifies
+	CorrectedMovementID uuid.UUID
+}
+
+// NewInvoiceLine creates a line for the given amount before tax, computing the
+// amount with tax from the tax percentage rounded to the cent.
+func NewInvoiceLine(description string, amountWithoutTax money.Money, taxPercentage money.Percentage, operationType string) (InvoiceLine, error) {
+	if description == "" {
+		return InvoiceLine{}, ErrLineDescriptionEmpty
+	}
+
+	amountWithTax, err := amountWithoutTax.Add(amountWithoutTax.ApplyPercentage(taxPercentage))
+	if err != nil {
+		return InvoiceLine{}, err
+	}
+
+	return InvoiceLine{
+		MovementID:       uuid.New(),
+		Description:      description,
+		AmountWithoutTax: amountWithoutTax,
+		AmountWithTax:    amountWithTax,
+		TaxPercentage:    taxPercentage,
+		TaxRegime:        taxes.RegimeIVA,
+		OperationType:    operationType,
+		TransactionDate:  time.Now(),
+		OriginalAmount:   amountWithTax,
+		ExchangeRate:     money.IdentityRate(amountWithoutTax.Currency()),
+	}, nil
+}
+
+// NewInvoiceLineWithTax creates an invoice line from an amount that already includes the tax.
+// The tax is the difference between that amount and its taxable base, so the amount is kept to the cent.
+func NewInvoiceLineWithTax(description string, amountWithTax money.Money, taxPercentage money.Percentage, operationType string) (InvoiceLine, error) {
+	line, err := NewInvoiceLine(description, amountWithTax.RemovePercentage(taxPercentage), taxPercentage, operationType)
+	if err != nil {
+		return InvoiceLine{}, err
+	}
+	line.AmountWithTax = amountWithTax
+	line.OriginalAmount = amountWithTax
+	return line, nil
+}
+
+// WithTax returns the line taxed with the given tax, recomputing its amount with tax on the same base.
+// It must be applied before the line is converted, as the original amount is recomputed too.
+func (l InvoiceLine) WithTax(tax taxes.Tax) (InvoiceLine, error) {
+	amountWithTax, err := l.AmountWithoutTax.Add(l.AmountWithoutTax.ApplyPercentage(tax.Percentage))
+	if err != nil {
+		return InvoiceLine{}, err
+	}
+
+	taxed := l
+	taxed.TaxPercentage = tax.Percentage
+	taxed.TaxRegime = tax.Regime
+	taxed.ExemptionCause = tax.ExemptionCause
+	taxed.AmountWithTax = amountWithTax
+	taxed.OriginalAmount = amountWithTax
+	return taxed, nil
+}
+
+// Convert returns the line expressed in the target currency of the given rate.
+// The tax is recomputed on the converted base, and the original amount is kept.
+func (l InvoiceLine) Convert(rate money.ExchangeRate) (InvoiceLine, error) {
+	amountWithoutTax, err := rate.Convert(l.AmountWithoutTax)
+	if err != nil {
+		return InvoiceLine{}, err
+	}
+	amountWithTax, err := amountWithoutTax.Add(amountWithoutTax.ApplyPercentage(l.TaxPercentage))
+	if err != nil {
+		return InvoiceLine{}, err
+	}
+
+	converted := l
+	converted.AmountWithoutTax = amountWithoutTax
+	converted.AmountWithTax = amountWithTax
+	converted.ExchangeRate = rate
+	return converted, nil
 }
 
 type Invoices = []Invoice
@@ -67,46 +163,130 @@ type Invoice struct {
 	IssueDate             time.Time
 	DueDate               time.Time
 	Lines                 []InvoiceLine
-	TaxAmount             float64
-	TotalAmountWithoutTax float64
-	TotalAmountWithTax    float64
+	TaxAmount             money.Money
+	TotalAmountWithoutTax money.Money
+	TotalAmountWithTax    money.Money
 	Status                InvoiceStatus
 	InvoiceNumber         string
+	Currency              money.Currency
+	Type                  InvoiceType
+	CustomerLocation      taxes.Location // Decides the tax territory of the lines
+	TaxBreakdown          TaxBreakdown
+	// A credit note references the invoice it rectifies and the reason for it
+	CorrectedInvoiceID InvoiceID
+	CorrectionReason   string
+}
+
+// NewInvoice creates an empty draft invoice for the given account and currency.
+// Drafts are not numbered, they get their number from their series when they are issued.
+func NewInvoice(accountID string, currency money.Currency, issueDate, dueDate time.Time) (Invoice, error) {
+	if accountID == "" {
+		return Invoice{}, ErrAccountIDEmpty
+	}
+	if currency == "" {
+		return Invoice{}, ErrCurrencyEmpty
+	}
+	if dueDate.Before(issueDate) {
+		return Invoice{}, ErrDueDateBeforeIssueDate
+	}
+
+	return Invoice{
+		ID:                    NewInvoiceID(),
+		AccountID:             accountID,
+		IssueDate:             issueDate,
+		DueDate:               dueDate,
+		TaxAmount:             money.Zero(currency),
+		TotalAmountWithoutTax: money.Zero(currency),
+		TotalAmountWithTax:    money.Zero(currency),
+		Status:                InvoiceStatusDraft,
+		Currency:              currency,
+		Type:                  InvoiceTypeStandard,
+		CustomerLocation:      taxes.DefaultLocation,
+	}, nil
 }
 
-// AddLine adds a new line item to the invoice.
+// AddLine adds a new line item to the invoice and updates its totals.
 func (inv *Invoice) AddLine(invoiceLine InvoiceLine) error {
 	if inv.Status != InvoiceStatusDraft {
-		return ErrInvoiceAlreadyPaid
+		return ErrInvoiceNotEditable
+	}
+	if invoiceLine.AmountWithTax.Currency() != inv.Currency {
+		return ErrLineCurrencyMismatch
+	}
+
+	totalWithoutTax, err := inv.TotalAmountWithoutTax.Add(invoiceLine.AmountWithoutTax)
+	if err != nil {
+		return ErrLineCurrencyMismatch
+	}
+	breakdown, err := inv.TaxBreakdown.add(invoiceLine)
+	if err != nil {
+		return ErrLineCurrencyMismatch
+	}
+	taxAmount, err := breakdown.TaxAmount(inv.Currency)
+	if err != nil {
+		return err
+	}
+	totalWithTax, err := totalWithoutTax.Add(taxAmount)
+	if err != nil {
+		return err
 	}
 
 	inv.Lines = append(inv.Lines, invoiceLine)
+	inv.TaxBreakdown = breakdown
+	inv.TotalAmountWithoutTax = totalWithoutTax
+	inv.TotalAmountWithTax = totalWithTax
+	inv.TaxAmount = taxAmount
 	return nil
 }
 
-func (inv *Invoice) MarkAsSent() error {
-	if inv.Status != InvoiceStatusDraft {
-		return ErrInvoiceNotDraft
+// TransitionTo moves the invoice to the given status when the transition table allows it.
+func (inv *Invoice) TransitionTo(status InvoiceStatus) error {
+	if !inv.Status.CanTransitionTo(status) {
+		return &TransitionError{From: inv.Status, To: status}
 	}
 
-	inv.Status = InvoiceStatusSent
+	inv.Status = status
 	return nil
 }
 
-func (inv *Invoice) MarkAsPaid() error {
-	if inv.Status == InvoiceStatusVoid {
-		return ErrVoidInvoiceCannotBePaid
+// SetIssueDate dates a draft invoice on the day it is issued, moving its due date so it keeps its payment term.
+// It must be called before the invoice is numbered, as the period of its series is that of its issue date.
+func (inv *Invoice) SetIssueDate(issueDate time.Time) error {
+	if inv.Status != InvoiceStatusDraft {
+		return ErrInvoiceNotDraft
 	}
+	paymentTerm := inv.DueDate.Sub(inv.IssueDate)
+	inv.IssueDate = issueDate
+	inv.DueDate = issueDate.Add(paymentTerm)
+	return nil
+}
 
-	inv.Status = InvoiceStatusPaid
+// Issue numbers a draft invoice and sends it. The number must be the next one of the series of the invoice.
+func (inv *Invoice) Issue(invoiceNumber string) error {
+	if invoiceNumber == "" {
+		return ErrInvoiceNumberEmpty
+	}
+	if err := inv.TransitionTo(InvoiceStatusSent); err != nil {
+		return err
+	}
+	inv.InvoiceNumber = invoiceNumber
 	return nil
 }
 
+func (inv *Invoice) MarkAsPaid() error {
+	return inv.TransitionTo(InvoiceStatusPaid)
+}
+
 func (inv *Invoice) MarkAsVoid() error {
-	if inv.Status == InvoiceStatusPaid {
-		return ErrPaidInvoiceCannotBeVoided
-	}
+	return inv.TransitionTo(InvoiceStatusVoid)
+}
 
-	inv.Status = InvoiceStatusVoid
-	return nil
+// MarkAsOverdue flags an issued invoice whose due date has passed without being paid.
+func (inv *Invoice) MarkAsOverdue() error {
+	return inv.TransitionTo(InvoiceStatusOverdue)
+}
+
+// MarkAsUnpaid flags an invoice whose payment was not collected or was returned.
+func (inv *Invoice) MarkAsUnpaid() error {
+	return inv.TransitionTo(InvoiceStatusUnpaid)
 }
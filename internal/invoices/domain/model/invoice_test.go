@@ -0,0 +1,109 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvoice(t *testing.T) {
+	issueDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	dueDate := issueDate.AddDate(0, 1, 0)
+
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, issueDate, dueDate)
+	require.NoError(t, err)
+	assert.False(t, invoice.ID.IsNil())
+	assert.Equal(t, model.InvoiceStatusDraft, invoice.Status)
+	assert.Empty(t, invoice.InvoiceNumber, "drafts are numbered when they are issued")
+
+	_, err = model.NewInvoice("", money.DefaultCurrency, issueDate, dueDate)
+	assert.ErrorIs(t, err, model.ErrAccountIDEmpty)
+
+	_, err = model.NewInvoice("account_A", money.DefaultCurrency, dueDate, issueDate)
+	assert.ErrorIs(t, err, model.ErrDueDateBeforeIssueDate)
+}
+
+func TestInvoice_AddLine(t *testing.T) {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	line, err := model.NewInvoiceLine("Monthly subscription", money.New(10050, money.DefaultCurrency), 2100, "CREDIT")
+	require.NoError(t, err)
+	require.NoError(t, invoice.AddLine(line))
+	require.NoError(t, invoice.AddLine(line))
+
+	assert.Len(t, invoice.Lines, 2)
+	assert.Equal(t, "121.61", line.AmountWithTax.Amount(), "100.50 + 21% rounded to the cent")
+	assert.Equal(t, "201.00", invoice.TotalAmountWithoutTax.Amount())
+	assert.Equal(t, "243.21", invoice.TotalAmountWithTax.Amount(), "the tax is computed on the whole base of the rate")
+	assert.Equal(t, "42.21", invoice.TaxAmount.Amount())
+
+	otherCurrencyLine, err := model.NewInvoiceLine("Roaming", money.New(500, "USD"), 2100, "CREDIT")
+	require.NoError(t, err)
+	assert.ErrorIs(t, invoice.AddLine(otherCurrencyLine), model.ErrLineCurrencyMismatch)
+
+	require.NoError(t, invoice.Issue("FAC-2025-000001"))
+	assert.ErrorIs(t, invoice.AddLine(line), model.ErrInvoiceNotEditable)
+}
+
+func TestInvoice_SetIssueDate_KeepsPaymentTerm(t *testing.T) {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	require.NoError(t, invoice.SetIssueDate(time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), invoice.IssueDate)
+	assert.Equal(t, time.Date(2025, 2, 7, 0, 0, 0, 0, time.UTC), invoice.DueDate, "30 days after the issue date")
+
+	require.NoError(t, invoice.Issue("FAC-2025-000001"))
+	assert.ErrorIs(t, invoice.SetIssueDate(time.Now()), model.ErrInvoiceNotDraft)
+}
+
+func TestInvoice_StatusTransitions(t *testing.T) {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, invoice.Issue(""), model.ErrInvoiceNumberEmpty)
+	require.NoError(t, invoice.Issue("FAC-2025-000001"))
+	assert.Equal(t, "FAC-2025-000001", invoice.InvoiceNumber)
+	assert.ErrorIs(t, invoice.Issue("FAC-2025-000002"), model.ErrInvoiceNotDraft)
+	assert.Equal(t, "FAC-2025-000001", invoice.InvoiceNumber, "an issued invoice keeps its number")
+
+	require.NoError(t, invoice.MarkAsPaid())
+	assert.ErrorIs(t, invoice.MarkAsVoid(), model.ErrPaidInvoiceCannotBeVoided)
+
+	require.NoError(t, invoice.MarkAsUnpaid())
+	assert.Equal(t, model.InvoiceStatusUnpaid, invoice.Status)
+	require.NoError(t, invoice.MarkAsVoid())
+	assert.ErrorIs(t, invoice.MarkAsPaid(), model.ErrVoidInvoiceCannotBePaid)
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	err = draft.MarkAsPaid()
+	assert.ErrorIs(t, err, model.ErrInvalidTransition)
+	assert.Equal(t, model.InvoiceStatusDraft, draft.Status, "a rejected transition must not change the status")
+}
+
+func TestInvoiceLine_Convert(t *testing.T) {
+	line, err := model.NewInvoiceLine("US roaming", money.New(10000, "USD"), 2100, "CREDIT")
+	require.NoError(t, err)
+
+	rate, err := money.NewExchangeRate("USD", "EUR", "0.9")
+	require.NoError(t, err)
+
+	converted, err := line.Convert(rate)
+	require.NoError(t, err)
+	assert.Equal(t, "90.00 EUR", converted.AmountWithoutTax.String())
+	assert.Equal(t, "108.90 EUR", converted.AmountWithTax.String())
+	assert.Equal(t, "121.00 USD", converted.OriginalAmount.String())
+	assert.Equal(t, "0.90000000", converted.ExchangeRate.String())
+
+	invoice, err := model.NewInvoice("account_A", "EUR", time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.ErrorIs(t, invoice.AddLine(line), model.ErrLineCurrencyMismatch)
+	require.NoError(t, invoice.AddLine(converted))
+	assert.Equal(t, "108.90", invoice.TotalAmountWithTax.Amount())
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSeriesPrefix  = errors.New("numbering series prefix must have between 1 and 20 characters")
+	ErrInvalidSeriesPadding = errors.New("numbering series padding must be between 1 and 18 digits")
+	ErrInvalidSeriesReset   = errors.New("numbering series reset must be YEARLY or NEVER")
+)
+
+// SeriesReset tells when the numbers of a series start over from 1.
+type SeriesReset string
+
+const (
+	SeriesResetYearly SeriesReset = "YEARLY"
+	SeriesResetNever  SeriesReset = "NEVER"
+)
+
+// ParseSeriesReset parses a series reset, case insensitively.
+func ParseSeriesReset(value string) (SeriesReset, error) {
+	switch reset := SeriesReset(strings.ToUpper(value)); reset {
+	case SeriesResetYearly, SeriesResetNever:
+		return reset, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidSeriesReset, value)
+	}
+}
+
+// NumberingSeries describes how the invoices of a series are numbered,
+// e.g. FAC-2025-000042 for a yearly series or FAC-000042 for one that never resets.
+type NumberingSeries struct {
+	Prefix  string
+	Reset   SeriesReset
+	Padding int
+}
+
+// NewNumberingSeries validates and creates a numbering series.
+func NewNumberingSeries(prefix string, reset SeriesReset, padding int) (NumberingSeries, error) {
+	if prefix == "" || len(prefix) > 20 {
+		return NumberingSeries{}, fmt.Errorf("%w: %q", ErrInvalidSeriesPrefix, prefix)
+	}
+	if reset != SeriesResetYearly && reset != SeriesResetNever {
+		return NumberingSeries{}, fmt.Errorf("%w: %q", ErrInvalidSeriesReset, reset)
+	}
+	if padding < 1 || padding > 18 {
+		return NumberingSeries{}, fmt.Errorf("%w: %d", ErrInvalidSeriesPadding, padding)
+	}
+	return NumberingSeries{Prefix: prefix, Reset: reset, Padding: padding}, nil
+}
+
+// Period returns the period whose sequence numbers an invoice issued on the given date:
+// its year for yearly series, 0 for series that never reset.
+func (s NumberingSeries) Period(issueDate time.Time) int {
+	if s.Reset == SeriesResetNever {
+		return 0
+	}
+	return issueDate.Year()
+}
+
+// Format renders the number of an invoice from its position in the period of the series.
+func (s NumberingSeries) Format(period int, sequence int64) string {
+	if s.Reset == SeriesResetNever {
+		return fmt.Sprintf("%s-%0*d", s.Prefix, s.Padding, sequence)
+	}
+	return fmt.Sprintf("%s-%d-%0*d", s.Prefix, period, s.Padding, sequence)
+}
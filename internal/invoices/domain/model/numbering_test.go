@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberingSeries_Format(t *testing.T) {
+	issueDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	yearly, err := model.NewNumberingSeries("FAC", model.SeriesResetYearly, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 2025, yearly.Period(issueDate))
+	assert.Equal(t, "FAC-2025-000042", yearly.Format(yearly.Period(issueDate), 42))
+
+	continuous, err := model.NewNumberingSeries("A", model.SeriesResetNever, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 0, continuous.Period(issueDate), "series that never reset share a single sequence")
+	assert.Equal(t, "A-0042", continuous.Format(continuous.Period(issueDate), 42))
+	assert.Equal(t, "A-12345", continuous.Format(0, 12345), "numbers beyond the padding are not truncated")
+}
+
+func TestNewNumberingSeries(t *testing.T) {
+	_, err := model.NewNumberingSeries("", model.SeriesResetYearly, 6)
+	assert.ErrorIs(t, err, model.ErrInvalidSeriesPrefix)
+
+	_, err = model.NewNumberingSeries("FAC", model.SeriesResetYearly, 0)
+	assert.ErrorIs(t, err, model.ErrInvalidSeriesPadding)
+
+	_, err = model.NewNumberingSeries("FAC", "MONTHLY", 6)
+	assert.ErrorIs(t, err, model.ErrInvalidSeriesReset)
+
+	reset, err := model.ParseSeriesReset("yearly")
+	require.NoError(t, err)
+	assert.Equal(t, model.SeriesResetYearly, reset)
+}
@@ -2,9 +2,13 @@ package model
 
 import (
 	"errors"
+	"fmt"
 )
 
-var ErrStatusUnknown = errors.New("unknown status")
+var (
+	ErrStatusUnknown     = errors.New("unknown status")
+	ErrInvalidTransition = errors.New("invalid invoice status transition")
+)
 
 // InvoiceStatus represents the status of an invoice.
 type InvoiceStatus string
@@ -32,4 +36,62 @@ func GetStatusFromString(status string) (InvoiceStatus, error) {
 		return s, nil
 	}
 	return "", ErrStatusUnknown
-}
\ No newline at end of file
+}
+
+// transitions lists, for every status, the statuses an invoice can move to.
+// VOID is final; a PAID invoice only goes back to UNPAID when its payment is returned.
+var transitions = map[InvoiceStatus][]InvoiceStatus{
+	InvoiceStatusDraft:   {InvoiceStatusSent, InvoiceStatusVoid},
+	InvoiceStatusSent:    {InvoiceStatusPaid, InvoiceStatusOverdue, InvoiceStatusUnpaid, InvoiceStatusVoid},
+	InvoiceStatusOverdue: {InvoiceStatusPaid, InvoiceStatusUnpaid, InvoiceStatusVoid},
+	InvoiceStatusUnpaid:  {InvoiceStatusPaid, InvoiceStatusVoid},
+	InvoiceStatusPaid:    {InvoiceStatusUnpaid},
+	InvoiceStatusVoid:    {},
+}
+
+// CanTransitionTo reports whether the transition table allows moving from s to the given status.
+func (s InvoiceStatus) CanTransitionTo(to InvoiceStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError is returned for a transition the table does not allow.
+// Each pair of statuses is a distinct error: errors.Is matches a TransitionError with the same
+// statuses, ErrInvalidTransition, and the legacy sentinel of the pair if it has one.
+type TransitionError struct {
+	From InvoiceStatus
+	To   InvoiceStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invoice cannot transition from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	if target == ErrInvalidTransition {
+		return true
+	}
+	if t, ok := target.(*TransitionError); ok {
+		return *t == *e
+	}
+	return target == e.legacyError()
+}
+
+// legacyError maps the pairs that had a dedicated error before the transition table existed
+func (e *TransitionError) legacyError() error {
+	switch {
+	case e.To == InvoiceStatusSent:
+		return ErrInvoiceNotDraft
+	case e.From == InvoiceStatusVoid && e.To == InvoiceStatusPaid:
+		return ErrVoidInvoiceCannotBePaid
+	case e.From == InvoiceStatusPaid && e.To == InvoiceStatusVoid:
+		return ErrPaidInvoiceCannotBeVoided
+	case e.From == InvoiceStatusPaid && e.To == InvoiceStatusPaid:
+		return ErrInvoiceAlreadyPaid
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusChange records a transition of an invoice from one status to another.
+type StatusChange struct {
+	ID        uuid.UUID
+	InvoiceID InvoiceID
+	From      InvoiceStatus
+	To        InvoiceStatus
+	ChangedAt time.Time
+}
+
+// NewStatusChange records the transition of the invoice that has just happened.
+func NewStatusChange(invoiceID InvoiceID, from, to InvoiceStatus) StatusChange {
+	return StatusChange{
+		ID:        uuid.New(),
+		InvoiceID: invoiceID,
+		From:      from,
+		To:        to,
+		ChangedAt: time.Now(),
+	}
+}
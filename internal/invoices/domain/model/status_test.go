@@ -0,0 +1,55 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoiceStatus_CanTransitionTo(t *testing.T) {
+	statuses := []model.InvoiceStatus{
+		model.InvoiceStatusDraft,
+		model.InvoiceStatusSent,
+		model.InvoiceStatusPaid,
+		model.InvoiceStatusOverdue,
+		model.InvoiceStatusVoid,
+		model.InvoiceStatusUnpaid,
+	}
+	allowed := map[[2]model.InvoiceStatus]bool{
+		{model.InvoiceStatusDraft, model.InvoiceStatusSent}:     true,
+		{model.InvoiceStatusDraft, model.InvoiceStatusVoid}:     true,
+		{model.InvoiceStatusSent, model.InvoiceStatusPaid}:      true,
+		{model.InvoiceStatusSent, model.InvoiceStatusOverdue}:   true,
+		{model.InvoiceStatusSent, model.InvoiceStatusUnpaid}:    true,
+		{model.InvoiceStatusSent, model.InvoiceStatusVoid}:      true,
+		{model.InvoiceStatusOverdue, model.InvoiceStatusPaid}:   true,
+		{model.InvoiceStatusOverdue, model.InvoiceStatusUnpaid}: true,
+		{model.InvoiceStatusOverdue, model.InvoiceStatusVoid}:   true,
+		{model.InvoiceStatusUnpaid, model.InvoiceStatusPaid}:    true,
+		{model.InvoiceStatusUnpaid, model.InvoiceStatusVoid}:    true,
+		{model.InvoiceStatusPaid, model.InvoiceStatusUnpaid}:    true,
+	}
+
+	messages := map[string]bool{}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			pair := [2]model.InvoiceStatus{from, to}
+			assert.Equal(t, allowed[pair], from.CanTransitionTo(to), "%s -> %s", from, to)
+			if allowed[pair] {
+				continue
+			}
+
+			err := error(&model.TransitionError{From: from, To: to})
+			assert.ErrorIs(t, err, model.ErrInvalidTransition)
+			assert.False(t, messages[err.Error()], "duplicated error for %s -> %s", from, to)
+			messages[err.Error()] = true
+		}
+	}
+
+	sentToSent := &model.TransitionError{From: model.InvoiceStatusSent, To: model.InvoiceStatusSent}
+	assert.True(t, errors.Is(sentToSent, &model.TransitionError{From: model.InvoiceStatusSent, To: model.InvoiceStatusSent}))
+	assert.False(t, errors.Is(sentToSent, &model.TransitionError{From: model.InvoiceStatusVoid, To: model.InvoiceStatusSent}))
+	assert.ErrorIs(t, sentToSent, model.ErrInvoiceNotDraft)
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+// TaxSummary holds the taxable base and the tax quota of the lines of an invoice that are taxed alike.
+type TaxSummary struct {
+	Regime         taxes.Regime
+	Percentage     money.Percentage
+	ExemptionCause taxes.ExemptionCause
+	TaxableBase    money.Money
+	TaxAmount      money.Money
+}
+
+// TaxBreakdown splits the tax of an invoice per regime and rate. Each quota is computed on the whole base of
+// its rate rather than added up line by line, as the Spanish invoicing regulation requires.
+type TaxBreakdown []TaxSummary
+
+// add returns the breakdown with the base of the line added to the summary of its tax, recomputing its quota.
+func (b TaxBreakdown) add(line InvoiceLine) (TaxBreakdown, error) {
+	breakdown := slices.Clone(b)
+	i := slices.IndexFunc(breakdown, func(summary TaxSummary) bool {
+		return summary.Regime == line.TaxRegime && summary.Percentage == line.TaxPercentage && summary.ExemptionCause == line.ExemptionCause
+	})
+	if i < 0 {
+		breakdown = append(breakdown, TaxSummary{
+			Regime:         line.TaxRegime,
+			Percentage:     line.TaxPercentage,
+			ExemptionCause: line.ExemptionCause,
+			TaxableBase:    money.Zero(line.AmountWithoutTax.Currency()),
+		})
+		i = len(breakdown) - 1
+	}
+
+	base, err := breakdown[i].TaxableBase.Add(line.AmountWithoutTax)
+	if err != nil {
+		return nil, err
+	}
+	breakdown[i].TaxableBase = base
+	breakdown[i].TaxAmount = base.ApplyPercentage(breakdown[i].Percentage)
+
+	slices.SortStableFunc(breakdown, func(a, b TaxSummary) int {
+		return cmp.Or(
+			cmp.Compare(a.Regime, b.Regime),
+			cmp.Compare(a.ExemptionCause, b.ExemptionCause),
+			cmp.Compare(b.Percentage, a.Percentage),
+		)
+	})
+	return breakdown, nil
+}
+
+// TaxAmount adds up the quotas of every rate.
+func (b TaxBreakdown) TaxAmount(currency money.Currency) (money.Money, error) {
+	total := money.Zero(currency)
+	for _, summary := range b {
+		var err error
+		if total, err = total.Add(summary.TaxAmount); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,62 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoice_TaxBreakdown(t *testing.T) {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	addLine := func(amount int64, tax taxes.Tax) {
+		line, err := model.NewInvoiceLine("Line", money.New(amount, money.DefaultCurrency), 0, "CREDIT")
+		require.NoError(t, err)
+		line, err = line.WithTax(tax)
+		require.NoError(t, err)
+		require.NoError(t, invoice.AddLine(line))
+	}
+	general := taxes.Tax{Regime: taxes.RegimeIVA, RateType: taxes.RateTypeGeneral, Percentage: 2100}
+	reduced := taxes.Tax{Regime: taxes.RegimeIVA, RateType: taxes.RateTypeReduced, Percentage: 1000}
+
+	addLine(1250, reduced)
+	addLine(1250, general)
+	addLine(1250, general)
+	addLine(5000, taxes.Exempt(taxes.RegimeIVA, taxes.ExemptionArticle20))
+
+	require.Len(t, invoice.TaxBreakdown, 3)
+	assert.Equal(t, model.TaxSummary{
+		Regime:      taxes.RegimeIVA,
+		Percentage:  2100,
+		TaxableBase: money.New(2500, money.DefaultCurrency),
+		TaxAmount:   money.New(525, money.DefaultCurrency),
+	}, invoice.TaxBreakdown[0], "12.50 at 21% is 2.63 per line but 5.25 on the whole base")
+	assert.Equal(t, money.Percentage(1000), invoice.TaxBreakdown[1].Percentage)
+	assert.Equal(t, "1.25", invoice.TaxBreakdown[1].TaxAmount.Amount())
+	assert.Equal(t, taxes.ExemptionArticle20, invoice.TaxBreakdown[2].ExemptionCause)
+	assert.Equal(t, "50.00", invoice.TaxBreakdown[2].TaxableBase.Amount())
+	assert.True(t, invoice.TaxBreakdown[2].TaxAmount.IsZero())
+
+	assert.Equal(t, "87.50", invoice.TotalAmountWithoutTax.Amount())
+	assert.Equal(t, "6.50", invoice.TaxAmount.Amount())
+	assert.Equal(t, "94.00", invoice.TotalAmountWithTax.Amount())
+}
+
+func TestInvoiceLine_WithTax(t *testing.T) {
+	line, err := model.NewInvoiceLine("Canary Islands subscription", money.New(10000, money.DefaultCurrency), 2100, "CREDIT")
+	require.NoError(t, err)
+
+	taxed, err := line.WithTax(taxes.Tax{Regime: taxes.RegimeIGIC, RateType: taxes.RateTypeGeneral, Percentage: 700})
+	require.NoError(t, err)
+	assert.Equal(t, taxes.RegimeIGIC, taxed.TaxRegime)
+	assert.Equal(t, money.Percentage(700), taxed.TaxPercentage)
+	assert.Equal(t, "107.00", taxed.AmountWithTax.Amount())
+	assert.Equal(t, "107.00", taxed.OriginalAmount.Amount())
+	assert.Equal(t, taxes.RegimeIVA, line.TaxRegime, "the original line is left untouched")
+}
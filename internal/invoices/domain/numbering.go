@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+)
+
+// SequenceRepository hands out the numbers of the numbering series.
+type SequenceRepository interface {
+	// NextSequenceNumber returns the next number of a series in a period. The sequence stays locked until
+	// the transaction carried by the context ends, so a number is only consumed if that transaction commits.
+	NextSequenceNumber(ctx context.Context, series string, period int) (int64, error)
+}
+
+// Numbering assigns gapless sequential numbers to invoices when they are issued.
+// Ordinary invoices and credit notes are numbered in separate series.
+type Numbering struct {
+	sequences SequenceRepository
+	series    map[model.InvoiceType]model.NumberingSeries
+}
+
+func NewNumbering(sequences SequenceRepository, invoices, creditNotes model.NumberingSeries) Numbering {
+	return Numbering{
+		sequences: sequences,
+		series: map[model.InvoiceType]model.NumberingSeries{
+			model.InvoiceTypeStandard:   invoices,
+			model.InvoiceTypeCreditNote: creditNotes,
+		},
+	}
+}
+
+// Next returns the number of the invoice in its series, according to its type and issue date.
+// It must be called within the transaction that issues the invoice for numbers to stay gapless.
+func (n Numbering) Next(ctx context.Context, invoice model.Invoice) (string, error) {
+	series, ok := n.series[invoice.Type]
+	if !ok {
+		return "", fmt.Errorf("no numbering series for %s invoices", invoice.Type)
+	}
+
+	period := series.Period(invoice.IssueDate)
+	sequence, err := n.sequences.NextSequenceNumber(ctx, series.Prefix, period)
+	if err != nil {
+		return "", fmt.Errorf("failed to number invoice in series %s: %w", series.Prefix, err)
+	}
+	return series.Format(period, sequence), nil
+}
@@ -2,27 +2,83 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	accounts "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 type Repository interface {
 	GetInvoiceByID(id model.InvoiceID) (model.Invoice, error)
+	// LockInvoice returns an invoice, locking it until the transaction carried by the context ends, or model.ErrInvoiceNotFound.
+	LockInvoice(ctx context.Context, id model.InvoiceID) (model.Invoice, error)
 	GetInvoicesByAccountId(accountId string, criteria model.Criteria) (model.Invoices, error)
+	GetInvoicesPageByAccountId(accountId string, criteria model.Criteria, page pagination.Request) (pagination.Page[model.Invoice], error)
 	GetInvoiceLines(ctx context.Context, id model.InvoiceID) ([]model.InvoiceLine, error)
+	GetInvoiceLinesPage(ctx context.Context, id model.InvoiceID, page pagination.Request) (pagination.Page[model.InvoiceLine], error)
+	CreateInvoice(ctx context.Context, invoice model.Invoice) error
+	// AddInvoiceLine stores the line with the new totals of the invoice, or fails with model.ErrInvoiceNotEditable
+	// when the invoice is no longer a draft.
+	AddInvoiceLine(ctx context.Context, invoice model.Invoice, line model.InvoiceLine) error
+	// ChangeInvoiceStatus persists the invoice together with the status change that was applied to it.
+	ChangeInvoiceStatus(ctx context.Context, invoice model.Invoice, change model.StatusChange) error
+	GetInvoiceStatusHistory(ctx context.Context, id model.InvoiceID) ([]model.StatusChange, error)
+	// GetPastDueInvoices returns the SENT invoices whose due date is before the given time.
+	GetPastDueInvoices(ctx context.Context, before time.Time) (model.Invoices, error)
+	SequenceRepository
+	// GetCreditedAmounts returns the base amount credited so far on each line of the invoice by its credit notes.
+	GetCreditedAmounts(ctx context.Context, invoice model.Invoice) (map[uuid.UUID]money.Money, error)
+	// GetOutstandingAmount returns what is left to collect of an issued invoice: its total less its issued credit
+	// notes and the payments allocated to it.
+	GetOutstandingAmount(ctx context.Context, invoice model.Invoice) (money.Money, error)
+	// GetAgingTotals sums the outstanding amount of the receivable invoices of the query by account, status and aging
+	// bucket, leaving out the invoices with nothing outstanding.
+	GetAgingTotals(ctx context.Context, query model.AgingQuery) ([]model.AgingTotal, error)
+}
+
+// TaxResolver resolves the tax of a line from the product category sold, the customer location and the date.
+type TaxResolver interface {
+	Resolve(ctx context.Context, category taxes.Category, location taxes.Location, date time.Time) (taxes.Tax, error)
+}
+
+// AccountRegistry returns the accounts invoices are issued to, with their billing profile.
+type AccountRegistry interface {
+	// GetAccount returns an account, or accounts.ErrAccountNotFound.
+	GetAccount(ctx context.Context, id string) (accounts.Account, error)
+}
+
+// Transactor runs a unit of work in a single database transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Service struct {
-	repo   Repository
-	logger zerolog.Logger
+	repo       Repository
+	transactor Transactor
+	numbering  Numbering
+	taxes      TaxResolver
+	accounts   AccountRegistry
+	converter  CurrencyConverter
+	logger     zerolog.Logger
 }
 
-func NewService(repo Repository) Service {
+func NewService(repo Repository, rates ExchangeRateRepository, transactor Transactor, numbering Numbering, taxes TaxResolver, accounts AccountRegistry) Service {
 	return Service{
-		repo:   repo,
-		logger: log.With().Str("module", "invoicesService").Logger(),
+		repo:       repo,
+		transactor: transactor,
+		numbering:  numbering,
+		taxes:      taxes,
+		accounts:   accounts,
+		converter:  NewCurrencyConverter(rates),
+		logger:     log.With().Str("module", "invoicesService").Logger(),
 	}
 }
 
@@ -40,6 +96,9 @@ func (s Service) GetInvoiceByID(id model.InvoiceID) (model.Invoice, error) {
 func (s Service) GetInvoicesByCriteria(accountId string, criteria model.Criteria) (model.Invoices, error) {
 	s.logger.Info().Str("account_id", accountId).Interface("criteria", criteria).Msg("Fetching invoices by criteria")
 
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
 	invoices, err := s.repo.GetInvoicesByAccountId(accountId, criteria)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to fetch invoices by criteria")
@@ -48,6 +107,21 @@ func (s Service) GetInvoicesByCriteria(accountId string, criteria model.Criteria
 	return invoices, nil
 }
 
+// GetInvoicesPageByCriteria returns a page of the invoices of the account matching the criteria
+func (s Service) GetInvoicesPageByCriteria(accountId string, criteria model.Criteria, page pagination.Request) (pagination.Page[model.Invoice], error) {
+	s.logger.Info().Str("account_id", accountId).Interface("criteria", criteria).Int("page_size", page.Size).Msg("Fetching page of invoices by criteria")
+
+	if err := criteria.Validate(); err != nil {
+		return pagination.Page[model.Invoice]{}, err
+	}
+	invoices, err := s.repo.GetInvoicesPageByAccountId(accountId, criteria, page)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch page of invoices by criteria")
+		return pagination.Page[model.Invoice]{}, err
+	}
+	return invoices, nil
+}
+
 func (s Service) GetInvoiceLines(ctx context.Context, id model.InvoiceID) ([]model.InvoiceLine, error) {
 	s.logger.Info().Str("id", id.String()).Msg("Fetching invoice lines by invoice ID")
 
@@ -58,3 +132,283 @@ func (s Service) GetInvoiceLines(ctx context.Context, id model.InvoiceID) ([]mod
 	}
 	return lines, nil
 }
+
+// GetInvoiceLinesPage returns a page of the lines of an invoice
+func (s Service) GetInvoiceLinesPage(ctx context.Context, id model.InvoiceID, page pagination.Request) (pagination.Page[model.InvoiceLine], error) {
+	s.logger.Info().Str("id", id.String()).Int("page_size", page.Size).Msg("Fetching page of invoice lines by invoice ID")
+
+	lines, err := s.repo.GetInvoiceLinesPage(ctx, id, page)
+	if err != nil {
+		s.logger.Error().Err(err).Str("invoice_id", id.String()).Msg("Failed to fetch page of invoice lines")
+		return pagination.Page[model.InvoiceLine]{}, err
+	}
+	return lines, nil
+}
+
+// CreateInvoice creates an unnumbered draft invoice, it is numbered when it is sent.
+// Invoices are only created for accounts with a billing profile. The fiscal address of the account is the customer
+// location, which decides the taxes of its lines. A location given with the request must match it.
+func (s Service) CreateInvoice(ctx context.Context, accountId string, currency money.Currency, location taxes.Location, issueDate, dueDate time.Time) (model.Invoice, error) {
+	s.logger.Info().Str("account_id", accountId).Str("currency", currency.String()).Str("country", location.Country).Msg("Creating invoice")
+
+	invoice, err := model.NewInvoice(accountId, currency, issueDate, dueDate)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Invalid invoice data")
+		return model.Invoice{}, err
+	}
+
+	account, err := s.accounts.GetAccount(ctx, accountId)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch the account to invoice")
+		return model.Invoice{}, err
+	}
+	fiscalLocation := account.Profile.Location()
+	if !matchesLocation(location, fiscalLocation) {
+		return model.Invoice{}, fmt.Errorf("%w: the fiscal address of account %s is in %s %s",
+			model.ErrCustomerLocationMismatch, accountId, fiscalLocation.Country, fiscalLocation.PostalCode)
+	}
+	invoice.CustomerLocation = fiscalLocation
+
+	if err := s.repo.CreateInvoice(ctx, invoice); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to create invoice")
+		return model.Invoice{}, fmt.Errorf("failed to create invoice: %w", err)
+	}
+	return invoice, nil
+}
+
+// matchesLocation reports whether a requested customer location agrees with the fiscal location of the account.
+// An empty request matches any location, and a request without postal code only has to match the country.
+func matchesLocation(requested, fiscal taxes.Location) bool {
+	if requested.Country != "" && requested.Country != fiscal.Country {
+		return false
+	}
+	return requested.PostalCode == "" || requested.PostalCode == fiscal.PostalCode
+}
+
+// AddInvoiceLine adds a line to a draft invoice. The invoice stays locked until its new totals are stored,
+// so concurrent lines add up and the invoice cannot be issued in between.
+func (s Service) AddInvoiceLine(ctx context.Context, id model.InvoiceID, line model.InvoiceLine) (model.Invoice, error) {
+	s.logger.Info().Str("id", id.String()).Str("movement_id", line.MovementID.String()).Msg("Adding line to invoice")
+
+	var invoice model.Invoice
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invoice, err = s.repo.LockInvoice(ctx, id)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to fetch invoice by ID")
+			return err
+		}
+
+		line, err = s.applyTax(ctx, invoice, line)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to resolve the tax of the invoice line")
+			return err
+		}
+
+		// Movements recorded in another currency are invoiced at the rate of their transaction date
+		line, err = s.converter.ConvertLine(ctx, line, invoice.Currency)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to convert invoice line to the invoice currency")
+			return err
+		}
+
+		if err := invoice.AddLine(line); err != nil {
+			s.logger.Error().Err(err).Msg("Invoice does not accept new lines")
+			return err
+		}
+
+		if err := s.repo.AddInvoiceLine(ctx, invoice, line); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to add invoice line")
+			return fmt.Errorf("failed to add invoice line: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Invoice{}, err
+	}
+	return invoice, nil
+}
+
+// applyTax taxes lines with a product category at the rate in force on their transaction date for the
+// customer of the invoice. Lines without one keep the percentage they were given, under the regime of the customer.
+func (s Service) applyTax(ctx context.Context, invoice model.Invoice, line model.InvoiceLine) (model.InvoiceLine, error) {
+	if line.ProductCategory == "" {
+		line.TaxRegime = invoice.CustomerLocation.Territory().Regime()
+		return line, nil
+	}
+
+	tax, err := s.taxes.Resolve(ctx, line.ProductCategory, invoice.CustomerLocation, line.TransactionDate)
+	if err != nil {
+		return model.InvoiceLine{}, err
+	}
+	return line.WithTax(tax)
+}
+
+// SendInvoice issues a draft invoice on the current day, giving it the next number of the series of that day so
+// numbers follow the issue dates. The number is handed out in the same transaction that sends the invoice, so it
+// is released if sending fails.
+func (s Service) SendInvoice(ctx context.Context, id model.InvoiceID) (model.Invoice, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var invoice model.Invoice
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		invoice, err = s.transition(ctx, id, "send", func(invoice *model.Invoice) error {
+			if !invoice.Status.CanTransitionTo(model.InvoiceStatusSent) {
+				return &model.TransitionError{From: invoice.Status, To: model.InvoiceStatusSent}
+			}
+			if err := invoice.SetIssueDate(today); err != nil {
+				return err
+			}
+			number, err := s.numbering.Next(ctx, *invoice)
+			if err != nil {
+				return err
+			}
+			return invoice.Issue(number)
+		})
+		return err
+	})
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	s.logger.Info().Str("id", id.String()).Str("invoice_number", invoice.InvoiceNumber).Msg("Issued invoice")
+	return invoice, nil
+}
+
+func (s Service) MarkInvoicePaid(ctx context.Context, id model.InvoiceID) (model.Invoice, error) {
+	return s.transition(ctx, id, "mark as paid", (*model.Invoice).MarkAsPaid)
+}
+
+func (s Service) VoidInvoice(ctx context.Context, id model.InvoiceID) (model.Invoice, error) {
+	return s.transition(ctx, id, "void", (*model.Invoice).MarkAsVoid)
+}
+
+func (s Service) MarkInvoiceOverdue(ctx context.Context, id model.InvoiceID) (model.Invoice, error) {
+	return s.transition(ctx, id, "mark as overdue", (*model.Invoice).MarkAsOverdue)
+}
+
+// MarkInvoiceUnpaid flags an issued invoice whose payment was not collected. A PAID invoice is only reopened once its
+// payments and credit notes no longer settle it, as marking it unpaid does not reverse their allocations.
+func (s Service) MarkInvoiceUnpaid(ctx context.Context, id model.InvoiceID) (model.Invoice, error) {
+	return s.transition(ctx, id, "mark as unpaid", func(invoice *model.Invoice) error {
+		if invoice.Status != model.InvoiceStatusPaid {
+			return invoice.MarkAsUnpaid()
+		}
+		outstanding, err := s.repo.GetOutstandingAmount(ctx, *invoice)
+		if err != nil {
+			return fmt.Errorf("failed to fetch outstanding amount: %w", err)
+		}
+		if !outstanding.IsPositive() {
+			return fmt.Errorf("%w: invoice %s", model.ErrInvoiceSettled, invoice.ID)
+		}
+		return invoice.MarkAsUnpaid()
+	})
+}
+
+// MarkOverdueInvoices moves to OVERDUE every sent invoice whose due date is before the day of now,
+// returning how many were marked. Invoices that changed status in the meantime are skipped.
+func (s Service) MarkOverdueInvoices(ctx context.Context, now time.Time) (int, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	s.logger.Info().Time("today", today).Msg("Marking past due invoices as overdue")
+
+	invoices, err := s.repo.GetPastDueInvoices(ctx, today)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch past due invoices")
+		return 0, err
+	}
+
+	marked := 0
+	var errs []error
+	for _, invoice := range invoices {
+		if err := ctx.Err(); err != nil {
+			return marked, err
+		}
+		_, err := s.MarkInvoiceOverdue(ctx, invoice.ID)
+		switch {
+		case err == nil:
+			marked++
+		case errors.Is(err, model.ErrInvalidTransition), errors.Is(err, model.ErrStatusChangedConcurrently):
+			s.logger.Info().Str("id", invoice.ID.String()).Msg("Invoice changed status before being marked as overdue")
+		default:
+			errs = append(errs, fmt.Errorf("invoice %s: %w", invoice.ID, err))
+		}
+	}
+
+	s.logger.Info().Int("count", len(invoices)).Int("marked", marked).Msg("Marked past due invoices as overdue")
+	return marked, errors.Join(errs...)
+}
+
+// GetInvoiceStatusHistory returns the status changes of an invoice, oldest first.
+func (s Service) GetInvoiceStatusHistory(ctx context.Context, id model.InvoiceID) ([]model.StatusChange, error) {
+	s.logger.Info().Str("id", id.String()).Msg("Fetching invoice status history")
+
+	if _, err := s.repo.GetInvoiceByID(id); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch invoice by ID")
+		return nil, err
+	}
+
+	history, err := s.repo.GetInvoiceStatusHistory(ctx, id)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch invoice status history")
+		return nil, err
+	}
+	return history, nil
+}
+
+// GetInvoiceDocument gathers an issued invoice with its lines, and the invoice it rectifies when it is a credit note,
+// so it can be rendered for the given buyer.
+func (s Service) GetInvoiceDocument(ctx context.Context, id model.InvoiceID, buyer model.Party) (model.Document, error) {
+	s.logger.Info().Str("id", id.String()).Msg("Fetching invoice document")
+
+	invoice, err := s.repo.GetInvoiceByID(id)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch invoice by ID")
+		return model.Document{}, err
+	}
+	if invoice.InvoiceNumber == "" {
+		return model.Document{}, fmt.Errorf("%w: invoice %s is a draft", model.ErrInvoiceNotIssued, id)
+	}
+
+	lines, err := s.repo.GetInvoiceLines(ctx, id)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch invoice lines")
+		return model.Document{}, err
+	}
+
+	document := model.Document{Invoice: invoice, Lines: lines, Buyer: buyer}
+	if invoice.Type == model.InvoiceTypeCreditNote {
+		corrected, err := s.repo.GetInvoiceByID(invoice.CorrectedInvoiceID)
+		if err != nil {
+			s.logger.Error().Err(err).Str("corrected_invoice_id", invoice.CorrectedInvoiceID.String()).Msg("Failed to fetch rectified invoice")
+			return model.Document{}, err
+		}
+		document.CorrectedInvoice = &corrected
+	}
+	return document, nil
+}
+
+// transition loads the invoice, applies the given status change and persists the result.
+func (s Service) transition(ctx context.Context, id model.InvoiceID, action string, apply func(*model.Invoice) error) (model.Invoice, error) {
+	s.logger.Info().Str("id", id.String()).Str("action", action).Msg("Changing invoice status")
+
+	invoice, err := s.repo.GetInvoiceByID(id)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch invoice by ID")
+		return model.Invoice{}, err
+	}
+
+	from := invoice.Status
+	if err := apply(&invoice); err != nil {
+		s.logger.Error().Err(err).Str("status", string(invoice.Status)).Msg("Invoice status change rejected")
+		return model.Invoice{}, err
+	}
+
+	change := model.NewStatusChange(invoice.ID, from, invoice.Status)
+	if err := s.repo.ChangeInvoiceStatus(ctx, invoice, change); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to update invoice")
+		return model.Invoice{}, fmt.Errorf("failed to %s invoice: %w", action, err)
+	}
+	return invoice, nil
+}
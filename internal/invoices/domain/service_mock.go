@@ -0,0 +1,386 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/invoices/domain/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/invoices/domain/service.go -destination=internal/invoices/domain/service_mock.go -package=domain Repository,Transactor,TaxResolver,AccountRegistry
+//
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	model "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	model0 "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	model1 "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	money "github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	pagination "github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddInvoiceLine mocks base method.
+func (m *MockRepository) AddInvoiceLine(ctx context.Context, invoice model0.Invoice, line model0.InvoiceLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInvoiceLine", ctx, invoice, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInvoiceLine indicates an expected call of AddInvoiceLine.
+func (mr *MockRepositoryMockRecorder) AddInvoiceLine(ctx, invoice, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInvoiceLine", reflect.TypeOf((*MockRepository)(nil).AddInvoiceLine), ctx, invoice, line)
+}
+
+// ChangeInvoiceStatus mocks base method.
+func (m *MockRepository) ChangeInvoiceStatus(ctx context.Context, invoice model0.Invoice, change model0.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeInvoiceStatus", ctx, invoice, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeInvoiceStatus indicates an expected call of ChangeInvoiceStatus.
+func (mr *MockRepositoryMockRecorder) ChangeInvoiceStatus(ctx, invoice, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeInvoiceStatus", reflect.TypeOf((*MockRepository)(nil).ChangeInvoiceStatus), ctx, invoice, change)
+}
+
+// CreateInvoice mocks base method.
+func (m *MockRepository) CreateInvoice(ctx context.Context, invoice model0.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoice", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvoice indicates an expected call of CreateInvoice.
+func (mr *MockRepositoryMockRecorder) CreateInvoice(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockRepository)(nil).CreateInvoice), ctx, invoice)
+}
+
+// GetAgingTotals mocks base method.
+func (m *MockRepository) GetAgingTotals(ctx context.Context, query model0.AgingQuery) ([]model0.AgingTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgingTotals", ctx, query)
+	ret0, _ := ret[0].([]model0.AgingTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgingTotals indicates an expected call of GetAgingTotals.
+func (mr *MockRepositoryMockRecorder) GetAgingTotals(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgingTotals", reflect.TypeOf((*MockRepository)(nil).GetAgingTotals), ctx, query)
+}
+
+// GetCreditedAmounts mocks base method.
+func (m *MockRepository) GetCreditedAmounts(ctx context.Context, invoice model0.Invoice) (map[uuid.UUID]money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditedAmounts", ctx, invoice)
+	ret0, _ := ret[0].(map[uuid.UUID]money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditedAmounts indicates an expected call of GetCreditedAmounts.
+func (mr *MockRepositoryMockRecorder) GetCreditedAmounts(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditedAmounts", reflect.TypeOf((*MockRepository)(nil).GetCreditedAmounts), ctx, invoice)
+}
+
+// GetInvoiceByID mocks base method.
+func (m *MockRepository) GetInvoiceByID(id model0.InvoiceID) (model0.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceByID", id)
+	ret0, _ := ret[0].(model0.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceByID indicates an expected call of GetInvoiceByID.
+func (mr *MockRepositoryMockRecorder) GetInvoiceByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByID", reflect.TypeOf((*MockRepository)(nil).GetInvoiceByID), id)
+}
+
+// GetInvoiceLines mocks base method.
+func (m *MockRepository) GetInvoiceLines(ctx context.Context, id model0.InvoiceID) ([]model0.InvoiceLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceLines", ctx, id)
+	ret0, _ := ret[0].([]model0.InvoiceLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceLines indicates an expected call of GetInvoiceLines.
+func (mr *MockRepositoryMockRecorder) GetInvoiceLines(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceLines", reflect.TypeOf((*MockRepository)(nil).GetInvoiceLines), ctx, id)
+}
+
+// GetInvoiceLinesPage mocks base method.
+func (m *MockRepository) GetInvoiceLinesPage(ctx context.Context, id model0.InvoiceID, page pagination.Request) (pagination.Page[model0.InvoiceLine], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceLinesPage", ctx, id, page)
+	ret0, _ := ret[0].(pagination.Page[model0.InvoiceLine])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceLinesPage indicates an expected call of GetInvoiceLinesPage.
+func (mr *MockRepositoryMockRecorder) GetInvoiceLinesPage(ctx, id, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceLinesPage", reflect.TypeOf((*MockRepository)(nil).GetInvoiceLinesPage), ctx, id, page)
+}
+
+// GetInvoiceStatusHistory mocks base method.
+func (m *MockRepository) GetInvoiceStatusHistory(ctx context.Context, id model0.InvoiceID) ([]model0.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceStatusHistory", ctx, id)
+	ret0, _ := ret[0].([]model0.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceStatusHistory indicates an expected call of GetInvoiceStatusHistory.
+func (mr *MockRepositoryMockRecorder) GetInvoiceStatusHistory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceStatusHistory", reflect.TypeOf((*MockRepository)(nil).GetInvoiceStatusHistory), ctx, id)
+}
+
+// GetInvoicesByAccountId mocks base method.
+func (m *MockRepository) GetInvoicesByAccountId(accountId string, criteria model0.Criteria) (model0.Invoices, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicesByAccountId", accountId, criteria)
+	ret0, _ := ret[0].(model0.Invoices)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicesByAccountId indicates an expected call of GetInvoicesByAccountId.
+func (mr *MockRepositoryMockRecorder) GetInvoicesByAccountId(accountId, criteria any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesByAccountId", reflect.TypeOf((*MockRepository)(nil).GetInvoicesByAccountId), accountId, criteria)
+}
+
+// GetInvoicesPageByAccountId mocks base method.
+func (m *MockRepository) GetInvoicesPageByAccountId(accountId string, criteria model0.Criteria, page pagination.Request) (pagination.Page[model0.Invoice], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicesPageByAccountId", accountId, criteria, page)
+	ret0, _ := ret[0].(pagination.Page[model0.Invoice])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicesPageByAccountId indicates an expected call of GetInvoicesPageByAccountId.
+func (mr *MockRepositoryMockRecorder) GetInvoicesPageByAccountId(accountId, criteria, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesPageByAccountId", reflect.TypeOf((*MockRepository)(nil).GetInvoicesPageByAccountId), accountId, criteria, page)
+}
+
+// GetOutstandingAmount mocks base method.
+func (m *MockRepository) GetOutstandingAmount(ctx context.Context, invoice model0.Invoice) (money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutstandingAmount", ctx, invoice)
+	ret0, _ := ret[0].(money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutstandingAmount indicates an expected call of GetOutstandingAmount.
+func (mr *MockRepositoryMockRecorder) GetOutstandingAmount(ctx, invoice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutstandingAmount", reflect.TypeOf((*MockRepository)(nil).GetOutstandingAmount), ctx, invoice)
+}
+
+// GetPastDueInvoices mocks base method.
+func (m *MockRepository) GetPastDueInvoices(ctx context.Context, before time.Time) (model0.Invoices, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPastDueInvoices", ctx, before)
+	ret0, _ := ret[0].(model0.Invoices)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPastDueInvoices indicates an expected call of GetPastDueInvoices.
+func (mr *MockRepositoryMockRecorder) GetPastDueInvoices(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPastDueInvoices", reflect.TypeOf((*MockRepository)(nil).GetPastDueInvoices), ctx, before)
+}
+
+// LockInvoice mocks base method.
+func (m *MockRepository) LockInvoice(ctx context.Context, id model0.InvoiceID) (model0.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockInvoice", ctx, id)
+	ret0, _ := ret[0].(model0.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockInvoice indicates an expected call of LockInvoice.
+func (mr *MockRepositoryMockRecorder) LockInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockInvoice", reflect.TypeOf((*MockRepository)(nil).LockInvoice), ctx, id)
+}
+
+// NextSequenceNumber mocks base method.
+func (m *MockRepository) NextSequenceNumber(ctx context.Context, series string, period int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextSequenceNumber", ctx, series, period)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextSequenceNumber indicates an expected call of NextSequenceNumber.
+func (mr *MockRepositoryMockRecorder) NextSequenceNumber(ctx, series, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextSequenceNumber", reflect.TypeOf((*MockRepository)(nil).NextSequenceNumber), ctx, series, period)
+}
+
+// MockTaxResolver is a mock of TaxResolver interface.
+type MockTaxResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxResolverMockRecorder is the mock recorder for MockTaxResolver.
+type MockTaxResolverMockRecorder struct {
+	mock *MockTaxResolver
+}
+
+// NewMockTaxResolver creates a new mock instance.
+func NewMockTaxResolver(ctrl *gomock.Controller) *MockTaxResolver {
+	mock := &MockTaxResolver{ctrl: ctrl}
+	mock.recorder = &MockTaxResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxResolver) EXPECT() *MockTaxResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockTaxResolver) Resolve(ctx context.Context, category model1.Category, location model1.Location, date time.Time) (model1.Tax, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, category, location, date)
+	ret0, _ := ret[0].(model1.Tax)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockTaxResolverMockRecorder) Resolve(ctx, category, location, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockTaxResolver)(nil).Resolve), ctx, category, location, date)
+}
+
+// MockAccountRegistry is a mock of AccountRegistry interface.
+type MockAccountRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountRegistryMockRecorder is the mock recorder for MockAccountRegistry.
+type MockAccountRegistryMockRecorder struct {
+	mock *MockAccountRegistry
+}
+
+// NewMockAccountRegistry creates a new mock instance.
+func NewMockAccountRegistry(ctrl *gomock.Controller) *MockAccountRegistry {
+	mock := &MockAccountRegistry{ctrl: ctrl}
+	mock.recorder = &MockAccountRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRegistry) EXPECT() *MockAccountRegistryMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountRegistry) GetAccount(ctx context.Context, id string) (model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
+	ret0, _ := ret[0].(model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountRegistryMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountRegistry)(nil).GetAccount), ctx, id)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
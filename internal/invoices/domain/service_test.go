@@ -0,0 +1,374 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	accounts "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func sentInvoice(t *testing.T, dueDate time.Time) model.Invoice {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, dueDate.AddDate(0, -1, 0), dueDate)
+	require.NoError(t, err)
+	require.NoError(t, invoice.Issue("FAC-2025-000001"))
+	return invoice
+}
+
+// newNumbering numbers invoices in the yearly FAC series and credit notes in the yearly R series
+func newNumbering(t *testing.T, sequences domain.SequenceRepository) domain.Numbering {
+	invoices, err := model.NewNumberingSeries("FAC", model.SeriesResetYearly, 6)
+	require.NoError(t, err)
+	creditNotes, err := model.NewNumberingSeries("R", model.SeriesResetYearly, 6)
+	require.NoError(t, err)
+	return domain.NewNumbering(sequences, invoices, creditNotes)
+}
+
+func TestService_GetInvoicesPageByCriteria(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+
+	criteria := model.Criteria{Statuses: []model.InvoiceStatus{model.InvoiceStatusSent}}
+	page := pagination.Request{Size: 1, Sort: model.InvoiceDefaultSort}
+	expectedPage := pagination.Page[model.Invoice]{Items: model.Invoices{sentInvoice(t, time.Now())}, NextCursor: "next"}
+	mockRepo.EXPECT().GetInvoicesPageByAccountId("account_A", criteria, page).Return(expectedPage, nil)
+
+	result, err := service.GetInvoicesPageByCriteria("account_A", criteria, page)
+	require.NoError(t, err)
+	assert.Equal(t, expectedPage, result)
+}
+
+func TestService_MarkOverdueInvoices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+	pastDue := sentInvoice(t, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+	paidMeanwhile := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	concurrentlyChanged := sentInvoice(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	mockRepo.EXPECT().GetPastDueInvoices(ctx, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)).
+		Return(model.Invoices{pastDue, paidMeanwhile, concurrentlyChanged}, nil)
+
+	mockRepo.EXPECT().GetInvoiceByID(pastDue.ID).Return(pastDue, nil)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, invoice model.Invoice, change model.StatusChange) error {
+			assert.Equal(t, model.InvoiceStatusOverdue, invoice.Status)
+			assert.Equal(t, model.InvoiceStatusSent, change.From)
+			assert.Equal(t, model.InvoiceStatusOverdue, change.To)
+			return nil
+		})
+
+	paid := paidMeanwhile
+	require.NoError(t, paid.MarkAsPaid())
+	mockRepo.EXPECT().GetInvoiceByID(paidMeanwhile.ID).Return(paid, nil)
+
+	mockRepo.EXPECT().GetInvoiceByID(concurrentlyChanged.ID).Return(concurrentlyChanged, nil)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).Return(model.ErrStatusChangedConcurrently)
+
+	marked, err := service.MarkOverdueInvoices(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, marked)
+}
+
+func TestService_MarkOverdueInvoices_ReportsFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	invoice := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	dbErr := errors.New("connection reset")
+
+	mockRepo.EXPECT().GetPastDueInvoices(ctx, gomock.Any()).Return(model.Invoices{invoice}, nil)
+	mockRepo.EXPECT().GetInvoiceByID(invoice.ID).Return(invoice, nil)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).Return(dbErr)
+
+	marked, err := service.MarkOverdueInvoices(ctx, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, dbErr)
+	assert.Zero(t, marked)
+}
+
+func TestService_SendInvoice_NumbersOnIssue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	// A draft from a previous year is numbered in the series of the day it is sent
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	number := fmt.Sprintf("FAC-%d-000042", today.Year())
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().GetInvoiceByID(draft.ID).Return(draft, nil)
+	mockRepo.EXPECT().NextSequenceNumber(ctx, "FAC", today.Year()).Return(int64(42), nil)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, invoice model.Invoice, change model.StatusChange) error {
+			assert.Equal(t, number, invoice.InvoiceNumber)
+			assert.Equal(t, today, invoice.IssueDate, "the issue date is stored with the number")
+			assert.Equal(t, today.AddDate(0, 0, 30), invoice.DueDate)
+			assert.Equal(t, model.InvoiceStatusSent, change.To)
+			return nil
+		})
+
+	sent, err := service.SendInvoice(ctx, draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, number, sent.InvoiceNumber)
+	assert.Equal(t, model.InvoiceStatusSent, sent.Status)
+}
+
+func TestService_SendInvoice_DoesNotNumberIssuedInvoices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	invoice := sentInvoice(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().GetInvoiceByID(invoice.ID).Return(invoice, nil)
+
+	_, err := service.SendInvoice(ctx, invoice.ID)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotDraft)
+}
+
+func TestService_SendInvoice_ReleasesNumberOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	// The status change and the number are handed to the same transaction, which is rolled back as a whole
+	mockTransactor.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			assert.ErrorIs(t, err, model.ErrStatusChangedConcurrently)
+			return err
+		})
+	mockRepo.EXPECT().GetInvoiceByID(draft.ID).Return(draft, nil)
+	mockRepo.EXPECT().NextSequenceNumber(ctx, "FAC", gomock.Any()).Return(int64(1), nil)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).Return(model.ErrStatusChangedConcurrently)
+
+	_, err = service.SendInvoice(ctx, draft.ID)
+	assert.ErrorIs(t, err, model.ErrStatusChangedConcurrently)
+}
+
+func canaryAccount() accounts.Account {
+	return accounts.Account{ID: "account_A", Profile: accounts.BillingProfile{
+		LegalName:     "Acme Canarias S.L.",
+		TaxID:         accounts.TaxID{Type: accounts.TaxIDTypeCIF, Value: "B12345674"},
+		FiscalAddress: accounts.Address{Street: "Calle Triana 1", PostalCode: "35002", Town: "Las Palmas", Country: "ES"},
+	}}
+}
+
+func TestService_MarkInvoiceUnpaid_RejectsSettledInvoices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	paid := sentInvoice(t, time.Now())
+	require.NoError(t, paid.MarkAsPaid())
+	mockRepo.EXPECT().GetInvoiceByID(paid.ID).Return(paid, nil)
+	mockRepo.EXPECT().GetOutstandingAmount(ctx, paid).Return(money.Zero(money.DefaultCurrency), nil)
+
+	_, err := service.MarkInvoiceUnpaid(ctx, paid.ID)
+	assert.ErrorIs(t, err, model.ErrInvoiceSettled, "its payments are still allocated to it")
+}
+
+func TestService_MarkInvoiceUnpaid_ReopensInvoicesLeftOutstanding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	paid := sentInvoice(t, time.Now())
+	require.NoError(t, paid.MarkAsPaid())
+	mockRepo.EXPECT().GetInvoiceByID(paid.ID).Return(paid, nil)
+	mockRepo.EXPECT().GetOutstandingAmount(ctx, paid).Return(money.New(9075, money.DefaultCurrency), nil)
+	mockRepo.EXPECT().ChangeInvoiceStatus(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, invoice model.Invoice, change model.StatusChange) error {
+			assert.Equal(t, model.InvoiceStatusPaid, change.From)
+			assert.Equal(t, model.InvoiceStatusUnpaid, change.To)
+			return nil
+		})
+
+	unpaid, err := service.MarkInvoiceUnpaid(ctx, paid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.InvoiceStatusUnpaid, unpaid.Status)
+}
+
+func TestService_CreateInvoice_LocatesTheCustomerAtItsFiscalAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockAccounts := domain.NewMockAccountRegistry(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, mockAccounts)
+	ctx := context.Background()
+
+	mockAccounts.EXPECT().GetAccount(ctx, "account_A").Return(canaryAccount(), nil).Times(2)
+	mockRepo.EXPECT().CreateInvoice(ctx, gomock.Any()).Return(nil).Times(2)
+
+	invoice, err := service.CreateInvoice(ctx, "account_A", money.DefaultCurrency, taxes.Location{}, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, taxes.Location{Country: "ES", PostalCode: "35002"}, invoice.CustomerLocation)
+
+	invoice, err = service.CreateInvoice(ctx, "account_A", money.DefaultCurrency, taxes.Location{Country: "ES"}, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, taxes.TerritoryCanaryIslands, invoice.CustomerLocation.Territory(), "a location without postal code only has to match the country")
+}
+
+func TestService_CreateInvoice_ValidatesAgainstTheAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockAccounts := domain.NewMockAccountRegistry(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, mockAccounts)
+	ctx := context.Background()
+
+	mockAccounts.EXPECT().GetAccount(ctx, "account_Z").Return(accounts.Account{}, accounts.ErrAccountNotFound)
+	_, err := service.CreateInvoice(ctx, "account_Z", money.DefaultCurrency, taxes.Location{}, time.Now(), time.Now())
+	assert.ErrorIs(t, err, accounts.ErrAccountNotFound, "accounts without billing profile cannot be invoiced")
+
+	mockAccounts.EXPECT().GetAccount(ctx, "account_A").Return(canaryAccount(), nil)
+	_, err = service.CreateInvoice(ctx, "account_A", money.DefaultCurrency, taxes.Location{Country: "ES", PostalCode: "28013"}, time.Now(), time.Now())
+	assert.ErrorIs(t, err, model.ErrCustomerLocationMismatch)
+	assert.ErrorContains(t, err, "ES 35002")
+}
+
+func TestService_AddInvoiceLine_ResolvesTax(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTaxes := domain.NewMockTaxResolver(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), mockTaxes, nil)
+	ctx := context.Background()
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	draft.CustomerLocation = taxes.Location{Country: "ES", PostalCode: "38001"}
+
+	line, err := model.NewInvoiceLine("Bottled water", money.New(10000, money.DefaultCurrency), 0, "CREDIT")
+	require.NoError(t, err)
+	line.ProductCategory = taxes.CategoryWater
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, draft.ID).Return(draft, nil)
+	mockTaxes.EXPECT().Resolve(ctx, taxes.CategoryWater, draft.CustomerLocation, line.TransactionDate).
+		Return(taxes.Tax{Regime: taxes.RegimeIGIC, RateType: taxes.RateTypeReduced, Percentage: 300}, nil)
+	mockRepo.EXPECT().AddInvoiceLine(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, invoice model.Invoice, line model.InvoiceLine) error {
+			assert.Equal(t, taxes.RegimeIGIC, line.TaxRegime)
+			assert.Equal(t, "103.00", line.AmountWithTax.Amount())
+			return nil
+		})
+
+	invoice, err := service.AddInvoiceLine(ctx, draft.ID, line)
+	require.NoError(t, err)
+	require.Len(t, invoice.TaxBreakdown, 1)
+	assert.Equal(t, taxes.RegimeIGIC, invoice.TaxBreakdown[0].Regime)
+	assert.Equal(t, "3.00", invoice.TaxAmount.Amount())
+}
+
+func TestService_AddInvoiceLine_KeepsExplicitPercentage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), domain.NewMockTaxResolver(ctrl), nil)
+	ctx := context.Background()
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	draft.CustomerLocation = taxes.Location{Country: "ES", PostalCode: "51001"}
+
+	line, err := model.NewInvoiceLine("Consulting", money.New(10000, money.DefaultCurrency), 400, "CREDIT")
+	require.NoError(t, err)
+
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, draft.ID).Return(draft, nil)
+	mockRepo.EXPECT().AddInvoiceLine(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+	invoice, err := service.AddInvoiceLine(ctx, draft.ID, line)
+	require.NoError(t, err)
+	assert.Equal(t, taxes.RegimeIPSI, invoice.Lines[0].TaxRegime, "lines are taxed under the regime of the customer")
+	assert.Equal(t, money.Percentage(400), invoice.Lines[0].TaxPercentage)
+}
+
+func TestService_AddInvoiceLine_RejectsIssuedInvoices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	mockTransactor := domain.NewMockTransactor(ctrl)
+	service := domain.NewService(mockRepo, nil, mockTransactor, newNumbering(t, mockRepo), domain.NewMockTaxResolver(ctrl), nil)
+	ctx := context.Background()
+
+	line, err := model.NewInvoiceLine("Consulting", money.New(10000, money.DefaultCurrency), 2100, "CREDIT")
+	require.NoError(t, err)
+
+	sent := sentInvoice(t, time.Now())
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, sent.ID).Return(sent, nil)
+	_, err = service.AddInvoiceLine(ctx, sent.ID, line)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotEditable)
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now())
+	require.NoError(t, err)
+	runInTransaction(mockTransactor)
+	mockRepo.EXPECT().LockInvoice(ctx, draft.ID).Return(draft, nil)
+	mockRepo.EXPECT().AddInvoiceLine(ctx, gomock.Any(), gomock.Any()).Return(model.ErrInvoiceNotEditable)
+	_, err = service.AddInvoiceLine(ctx, draft.ID, line)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotEditable, "an invoice issued since it was read is not rewritten")
+}
+
+func TestService_GetInvoiceDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo, nil, nil, newNumbering(t, mockRepo), nil, nil)
+	ctx := context.Background()
+
+	buyer, err := model.NewParty("B87654321", "Cliente S.L.", model.Address{Street: "Calle Sol 2", PostalCode: "41001", Town: "Sevilla", Province: "Sevilla"})
+	require.NoError(t, err)
+
+	draft, err := model.NewInvoice("account_A", money.DefaultCurrency, time.Now(), time.Now())
+	require.NoError(t, err)
+	mockRepo.EXPECT().GetInvoiceByID(draft.ID).Return(draft, nil)
+	_, err = service.GetInvoiceDocument(ctx, draft.ID, buyer)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotIssued)
+
+	original := sentInvoice(t, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+	creditNote, err := model.NewCreditNote(original, time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC), "Duplicated charge")
+	require.NoError(t, err)
+	require.NoError(t, creditNote.Issue("R-2025-000001"))
+	lines := []model.InvoiceLine{{Description: "Rectification: Monthly subscription"}}
+
+	mockRepo.EXPECT().GetInvoiceByID(creditNote.ID).Return(creditNote, nil)
+	mockRepo.EXPECT().GetInvoiceLines(ctx, creditNote.ID).Return(lines, nil)
+	mockRepo.EXPECT().GetInvoiceByID(original.ID).Return(original, nil)
+
+	document, err := service.GetInvoiceDocument(ctx, creditNote.ID, buyer)
+	require.NoError(t, err)
+	assert.Equal(t, creditNote.ID, document.Invoice.ID)
+	assert.Equal(t, lines, document.Lines)
+	assert.Equal(t, buyer, document.Buyer)
+	require.NotNil(t, document.CorrectedInvoice, "a credit note is rendered with the invoice it rectifies")
+	assert.Equal(t, "FAC-2025-000001", document.CorrectedInvoice.InvoiceNumber)
+}
@@ -0,0 +1,16 @@
+package facturae
+
+// alpha3 maps the ISO 3166-1 alpha-2 codes parties are recorded with to the alpha-3 codes Facturae uses.
+// It covers the European Economic Area and the countries customers are usually established in.
+var alpha3 = map[string]string{
+	// European Union
+	"AT": "AUT", "BE": "BEL", "BG": "BGR", "CY": "CYP", "CZ": "CZE", "DE": "DEU", "DK": "DNK",
+	"EE": "EST", "ES": "ESP", "FI": "FIN", "FR": "FRA", "GR": "GRC", "HR": "HRV", "HU": "HUN",
+	"IE": "IRL", "IT": "ITA", "LT": "LTU", "LU": "LUX", "LV": "LVA", "MT": "MLT", "NL": "NLD",
+	"PL": "POL", "PT": "PRT", "RO": "ROU", "SE": "SWE", "SI": "SVN", "SK": "SVK",
+	// Rest of Europe
+	"AD": "AND", "CH": "CHE", "GB": "GBR", "IS": "ISL", "LI": "LIE", "MC": "MCO", "NO": "NOR", "SM": "SMR",
+	// Rest of the world
+	"AR": "ARG", "AU": "AUS", "BR": "BRA", "CA": "CAN", "CL": "CHL", "CN": "CHN", "CO": "COL",
+	"IN": "IND", "JP": "JPN", "MA": "MAR", "MX": "MEX", "PE": "PER", "US": "USA",
+}
@@ -0,0 +1,305 @@
+// Package facturae renders issued invoices as Facturae 3.2.2 documents, the XML format required by
+// Spanish public administrations and accepted by most B2B customers.
+//
+// Documents are produced unsigned; signing them with XAdES is left to the tool that submits them.
+package facturae
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrUnsupportedCountry     = errors.New("country is not supported by the Facturae export")
+	ErrIncompleteAddress      = errors.New("address is incomplete for a Facturae document")
+	ErrCorrectedInvoiceNeeded = errors.New("credit note is exported without the invoice it rectifies")
+)
+
+// taxTypeCodes are the Facturae codes of the indirect taxes.
+var taxTypeCodes = map[taxes.Regime]string{
+	taxes.RegimeIVA:  "01",
+	taxes.RegimeIPSI: "02",
+	taxes.RegimeIGIC: "03",
+}
+
+// exemptionReasons are the legal grounds written on exempt lines.
+var exemptionReasons = map[taxes.ExemptionCause]string{
+	taxes.ExemptionArticle20: "Operación exenta por el artículo 20 de la Ley 37/1992",
+	taxes.ExemptionExport:    "Operación exenta por el artículo 21 de la Ley 37/1992",
+	taxes.ExemptionIntraEU:   "Operación exenta por el artículo 25 de la Ley 37/1992",
+}
+
+// legalEntityLetters are the first letters of the NIF of Spanish legal entities, natural persons hold a DNI or NIE.
+// Foreign parties are identified by their VAT number and taken as legal entities.
+const legalEntityLetters = "ABCDEFGHJNPQRSUVW"
+
+// Exporter renders the documents of invoices issued by a given seller.
+type Exporter struct {
+	seller model.Party
+}
+
+// NewExporter creates an exporter for the invoices issued by the given seller.
+func NewExporter(seller model.Party) Exporter {
+	return Exporter{seller: seller}
+}
+
+// Export renders the document as an unsigned Facturae 3.2.2 file with a single invoice.
+func (e Exporter) Export(document model.Document) ([]byte, error) {
+	facturae, err := e.convert(document)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := xml.MarshalIndent(facturae, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Facturae document: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+func (e Exporter) convert(document model.Document) (Facturae, error) {
+	invoice := document.Invoice
+
+	seller, err := business(e.seller)
+	if err != nil {
+		return Facturae{}, fmt.Errorf("seller: %w", err)
+	}
+	buyer, err := business(document.Buyer)
+	if err != nil {
+		return Facturae{}, fmt.Errorf("buyer: %w", err)
+	}
+	header, err := invoiceHeader(document)
+	if err != nil {
+		return Facturae{}, err
+	}
+
+	total := amount(invoice.TotalAmountWithTax)
+	return Facturae{
+		XMLNSFe: Namespace,
+		XMLNSDs: DSigNamespace,
+		FileHeader: FileHeader{
+			SchemaVersion:     SchemaVersion,
+			Modality:          "I",
+			InvoiceIssuerType: "EM",
+			Batch: Batch{
+				BatchIdentifier:        e.seller.TaxID + invoice.InvoiceNumber,
+				InvoicesCount:          1,
+				TotalInvoicesAmount:    Amount{TotalAmount: total},
+				TotalOutstandingAmount: Amount{TotalAmount: total},
+				TotalExecutableAmount:  Amount{TotalAmount: total},
+				InvoiceCurrencyCode:    invoice.Currency.String(),
+			},
+		},
+		Parties: Parties{SellerParty: seller, BuyerParty: buyer},
+		Invoices: []Invoice{{
+			InvoiceHeader: header,
+			InvoiceIssueData: InvoiceIssueData{
+				IssueDate:           date(invoice.IssueDate),
+				InvoiceCurrencyCode: invoice.Currency.String(),
+				TaxCurrencyCode:     invoice.Currency.String(),
+				LanguageName:        language,
+			},
+			TaxesOutputs: taxesOutputs(invoice.TaxBreakdown),
+			InvoiceTotals: InvoiceTotals{
+				TotalGrossAmount:            amount(invoice.TotalAmountWithoutTax),
+				TotalGrossAmountBeforeTaxes: amount(invoice.TotalAmountWithoutTax),
+				TotalTaxOutputs:             amount(invoice.TaxAmount),
+				TotalTaxesWithheld:          amount(money.Zero(invoice.Currency)),
+				InvoiceTotal:                total,
+				TotalOutstandingAmount:      total,
+				TotalExecutableAmount:       total,
+			},
+			Items: items(document.Lines),
+		}},
+	}, nil
+}
+
+func invoiceHeader(document model.Document) (InvoiceHeader, error) {
+	series, number := splitInvoiceNumber(document.Invoice.InvoiceNumber)
+	header := InvoiceHeader{
+		InvoiceNumber:       number,
+		InvoiceSeriesCode:   series,
+		InvoiceDocumentType: "FC",
+		InvoiceClass:        "OO",
+	}
+	if document.Invoice.Type != model.InvoiceTypeCreditNote {
+		return header, nil
+	}
+
+	corrected := document.CorrectedInvoice
+	if corrected == nil {
+		return InvoiceHeader{}, fmt.Errorf("%w: %s", ErrCorrectedInvoiceNeeded, document.Invoice.InvoiceNumber)
+	}
+	correctedSeries, correctedNumber := splitInvoiceNumber(corrected.InvoiceNumber)
+	start := time.Date(corrected.IssueDate.Year(), corrected.IssueDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// Credit notes only rectify the taxable base, by the difference between the original invoice and the right one
+	header.InvoiceClass = "OR"
+	header.Corrective = &Corrective{
+		InvoiceNumber:               correctedNumber,
+		InvoiceSeriesCode:           correctedSeries,
+		ReasonCode:                  "16",
+		ReasonDescription:           "Base imponible",
+		TaxPeriod:                   Period{StartDate: date(start), EndDate: date(start.AddDate(0, 1, -1))},
+		CorrectionMethod:            "02",
+		CorrectionMethodDescription: "Rectificación por diferencias",
+		AdditionalReasonDescription: document.Invoice.CorrectionReason,
+	}
+	return header, nil
+}
+
+// splitInvoiceNumber splits a number such as FAC-2025-000042 into its series, FAC-2025, and its number, 000042.
+func splitInvoiceNumber(invoiceNumber string) (string, string) {
+	i := strings.LastIndex(invoiceNumber, "-")
+	if i < 0 {
+		return "", invoiceNumber
+	}
+	return invoiceNumber[:i], invoiceNumber[i+1:]
+}
+
+func business(party model.Party) (Business, error) {
+	if party.TaxID == "" {
+		return Business{}, model.ErrPartyTaxIDEmpty
+	}
+	identification := TaxIdentification{
+		PersonTypeCode:          "F",
+		ResidenceTypeCode:       residenceTypeCode(party.Address.Country),
+		TaxIdentificationNumber: party.TaxID,
+	}
+	spanish, overseas, err := address(party.Address)
+	if err != nil {
+		return Business{}, err
+	}
+
+	if party.Address.Country != "ES" || strings.ContainsRune(legalEntityLetters, rune(party.TaxID[0])) {
+		identification.PersonTypeCode = "J"
+		return Business{
+			TaxIdentification: identification,
+			LegalEntity:       &LegalEntity{CorporateName: text(party.Name, 80), AddressInSpain: spanish, OverseasAddress: overseas},
+		}, nil
+	}
+
+	name, surnames, _ := strings.Cut(party.Name, " ")
+	return Business{
+		TaxIdentification: identification,
+		Individual: &Individual{
+			Name:            text(name, 40),
+			FirstSurname:    text(strings.TrimSpace(surnames), 40),
+			AddressInSpain:  spanish,
+			OverseasAddress: overseas,
+		},
+	}, nil
+}
+
+// residenceTypeCode tells residents in Spain (R) from those in other member states (U) and elsewhere (E).
+func residenceTypeCode(country string) string {
+	switch territory := (taxes.Location{Country: country}).Territory(); {
+	case territory.IsSpanish():
+		return "R"
+	case territory == taxes.TerritoryEU:
+		return "U"
+	default:
+		return "E"
+	}
+}
+
+func address(address model.Address) (*AddressInSpain, *OverseasAddress, error) {
+	countryCode, ok := alpha3[address.Country]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedCountry, address.Country)
+	}
+	if address.Street == "" || address.Town == "" || address.Province == "" {
+		return nil, nil, fmt.Errorf("%w: street, town and province are required", ErrIncompleteAddress)
+	}
+
+	if address.Country != "ES" {
+		postCodeAndTown := strings.TrimSpace(address.PostalCode + " " + address.Town)
+		return nil, &OverseasAddress{
+			Address:         text(address.Street, 80),
+			PostCodeAndTown: text(postCodeAndTown, 50),
+			Province:        text(address.Province, 20),
+			CountryCode:     countryCode,
+		}, nil
+	}
+
+	if _, err := taxes.NewLocation(address.Country, address.PostalCode); err != nil || address.PostalCode == "" {
+		return nil, nil, fmt.Errorf("%w: %q is not a Spanish postal code", ErrIncompleteAddress, address.PostalCode)
+	}
+	return &AddressInSpain{
+		Address:     text(address.Street, 80),
+		PostCode:    address.PostalCode,
+		Town:        text(address.Town, 50),
+		Province:    text(address.Province, 20),
+		CountryCode: countryCode,
+	}, nil, nil
+}
+
+func taxesOutputs(breakdown model.TaxBreakdown) []Tax {
+	outputs := make([]Tax, 0, len(breakdown))
+	for _, summary := range breakdown {
+		outputs = append(outputs, Tax{
+			TaxTypeCode: taxTypeCodes[summary.Regime],
+			TaxRate:     summary.Percentage.String(),
+			TaxableBase: Amount{TotalAmount: amount(summary.TaxableBase)},
+			TaxAmount:   Amount{TotalAmount: amount(summary.TaxAmount)},
+		})
+	}
+	return outputs
+}
+
+// items renders every line as a single unit priced at its base, Facturae asks for six decimals on line amounts.
+func items(lines []model.InvoiceLine) []InvoiceLine {
+	items := make([]InvoiceLine, 0, len(lines))
+	for _, line := range lines {
+		price := amount(line.AmountWithoutTax) + "0000"
+		item := InvoiceLine{
+			ItemDescription:     text(line.Description, 2500),
+			Quantity:            "1.0",
+			UnitPriceWithoutTax: price,
+			TotalCost:           price,
+			GrossAmount:         price,
+			TaxesOutputs: []Tax{{
+				TaxTypeCode: taxTypeCodes[line.TaxRegime],
+				TaxRate:     line.TaxPercentage.String(),
+				TaxableBase: Amount{TotalAmount: amount(line.AmountWithoutTax)},
+				TaxAmount:   Amount{TotalAmount: amount(line.AmountWithoutTax.ApplyPercentage(line.TaxPercentage))},
+			}},
+		}
+		if !line.TransactionDate.IsZero() {
+			item.TransactionDate = date(line.TransactionDate)
+		}
+		if line.ExemptionCause != "" {
+			reason, ok := exemptionReasons[line.ExemptionCause]
+			if !ok {
+				reason = fmt.Sprintf("Operación exenta (%s)", line.ExemptionCause)
+			}
+			item.SpecialTaxableEvent = &SpecialTaxableEvent{SpecialTaxableEventCode: "01", SpecialTaxableEventReason: reason}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func amount(m money.Money) string {
+	return m.Amount()
+}
+
+func date(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+// text cuts a value to the length the schema allows for it.
+func text(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit])
+}
@@ -0,0 +1,159 @@
+package facturae_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/facturae"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var issueDate = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+func newParty(t *testing.T, taxID, name string, address model.Address) model.Party {
+	party, err := model.NewParty(taxID, name, address)
+	require.NoError(t, err)
+	return party
+}
+
+func newExporter(t *testing.T) facturae.Exporter {
+	return facturae.NewExporter(newParty(t, "B12345674", "Billing MCP S.L.", model.Address{
+		Street: "Calle Mayor 1", PostalCode: "28013", Town: "Madrid", Province: "Madrid",
+	}))
+}
+
+func newInvoice(t *testing.T, lines ...model.InvoiceLine) model.Invoice {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, issueDate, issueDate.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	for _, line := range lines {
+		require.NoError(t, invoice.AddLine(line))
+	}
+	require.NoError(t, invoice.Issue("FAC-2025-000042"))
+	return invoice
+}
+
+func newLine(t *testing.T, description string, minor int64, percentage money.Percentage) model.InvoiceLine {
+	line, err := model.NewInvoiceLine(description, money.New(minor, money.DefaultCurrency), percentage, "CREDIT")
+	require.NoError(t, err)
+	line.TransactionDate = issueDate
+	return line
+}
+
+// validate checks the document against the bundled schema with xmllint, when it is installed.
+func validate(t *testing.T, document []byte) {
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint is not installed, skipping schema validation")
+	}
+
+	path := filepath.Join(t.TempDir(), "invoice.xml")
+	require.NoError(t, os.WriteFile(path, document, 0o600))
+	output, err := exec.Command(xmllint, "--noout", "--schema", filepath.Join("schema", "Facturaev3_2_2.xsd"), path).CombinedOutput()
+	require.NoError(t, err, "document does not validate against the Facturae schema:\n%s\n%s", output, document)
+}
+
+func TestExporter_Export(t *testing.T) {
+	lines := []model.InvoiceLine{
+		newLine(t, "Monthly subscription", 10050, 2100),
+		newLine(t, "Monthly subscription", 10050, 2100),
+		newLine(t, "Printed manual", 2000, 400),
+	}
+	invoice := newInvoice(t, lines...)
+	buyer := newParty(t, "12345678z", "Ana García López", model.Address{
+		Street: "Calle Real 5", PostalCode: "08001", Town: "Barcelona", Province: "Barcelona",
+	})
+
+	document, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: lines, Buyer: buyer})
+	require.NoError(t, err)
+
+	xml := string(document)
+	assert.Contains(t, xml, `<fe:Facturae xmlns:fe="`+facturae.Namespace+`"`)
+	assert.Contains(t, xml, "<BatchIdentifier>B12345674FAC-2025-000042</BatchIdentifier>")
+	assert.Contains(t, xml, "<InvoiceNumber>000042</InvoiceNumber>")
+	assert.Contains(t, xml, "<InvoiceSeriesCode>FAC-2025</InvoiceSeriesCode>")
+	assert.Contains(t, xml, "<InvoiceClass>OO</InvoiceClass>")
+	assert.Contains(t, xml, "<PersonTypeCode>J</PersonTypeCode>", "the seller holds a CIF")
+	assert.Contains(t, xml, "<Name>Ana</Name>")
+	assert.Contains(t, xml, "<FirstSurname>García López</FirstSurname>")
+	assert.Contains(t, xml, "<TaxRate>21.00</TaxRate>\n          <TaxableBase>\n            <TotalAmount>201.00</TotalAmount>")
+	assert.Contains(t, xml, "<TotalTaxOutputs>43.01</TotalTaxOutputs>", "42.21 at 21% plus 0.80 at 4%")
+	assert.Contains(t, xml, "<InvoiceTotal>264.01</InvoiceTotal>")
+	assert.Contains(t, xml, "<UnitPriceWithoutTax>100.500000</UnitPriceWithoutTax>")
+	assert.NotContains(t, xml, "<Corrective>")
+	validate(t, document)
+}
+
+func TestExporter_Export_CreditNote(t *testing.T) {
+	lines := []model.InvoiceLine{newLine(t, "Monthly subscription", 10050, 2100)}
+	original := newInvoice(t, lines...)
+
+	creditNote, err := model.NewCreditNote(original, issueDate.AddDate(0, 0, 10), "Duplicated charge")
+	require.NoError(t, err)
+	credit, err := lines[0].Rectify(money.New(5000, money.DefaultCurrency))
+	require.NoError(t, err)
+	require.NoError(t, creditNote.AddLine(credit))
+	require.NoError(t, creditNote.Issue("RECT-2025-000001"))
+
+	buyer := newParty(t, "DE123456789", "Kunde GmbH", model.Address{
+		Street: "Hauptstraße 1", PostalCode: "10115", Town: "Berlin", Province: "Berlin", Country: "de",
+	})
+	document := model.Document{Invoice: creditNote, Lines: []model.InvoiceLine{credit}, Buyer: buyer}
+
+	_, err = newExporter(t).Export(document)
+	assert.ErrorIs(t, err, facturae.ErrCorrectedInvoiceNeeded)
+
+	document.CorrectedInvoice = &original
+	output, err := newExporter(t).Export(document)
+	require.NoError(t, err)
+
+	xml := string(output)
+	assert.Contains(t, xml, "<InvoiceClass>OR</InvoiceClass>")
+	assert.Contains(t, xml, "<InvoiceSeriesCode>FAC-2025</InvoiceSeriesCode>\n          <ReasonCode>16</ReasonCode>")
+	assert.Contains(t, xml, "<StartDate>2025-03-01</StartDate>")
+	assert.Contains(t, xml, "<EndDate>2025-03-31</EndDate>")
+	assert.Contains(t, xml, "<AdditionalReasonDescription>Duplicated charge</AdditionalReasonDescription>")
+	assert.Contains(t, xml, "<ResidenceTypeCode>U</ResidenceTypeCode>")
+	assert.Contains(t, xml, "<PostCodeAndTown>10115 Berlin</PostCodeAndTown>")
+	assert.Contains(t, xml, "<CountryCode>DEU</CountryCode>")
+	assert.Contains(t, xml, "<InvoiceTotal>-60.50</InvoiceTotal>")
+	validate(t, output)
+}
+
+func TestExporter_Export_ExemptLine(t *testing.T) {
+	line, err := newLine(t, "Training course", 30000, 2100).WithTax(taxes.Exempt(taxes.RegimeIVA, taxes.ExemptionArticle20))
+	require.NoError(t, err)
+	invoice := newInvoice(t, line)
+	buyer := newParty(t, "A58818501", "Academia Canaria S.A.", model.Address{
+		Street: "Calle Triana 10", PostalCode: "35002", Town: "Las Palmas de Gran Canaria", Province: "Las Palmas",
+	})
+
+	output, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: []model.InvoiceLine{line}, Buyer: buyer})
+	require.NoError(t, err)
+
+	xml := string(output)
+	assert.Contains(t, xml, "<TaxRate>0.00</TaxRate>")
+	assert.Contains(t, xml, "<SpecialTaxableEventCode>01</SpecialTaxableEventCode>")
+	assert.Contains(t, xml, "artículo 20 de la Ley 37/1992")
+	assert.Contains(t, xml, "<InvoiceTotal>300.00</InvoiceTotal>")
+	validate(t, output)
+}
+
+func TestExporter_Export_InvalidParties(t *testing.T) {
+	lines := []model.InvoiceLine{newLine(t, "Monthly subscription", 10050, 2100)}
+	invoice := newInvoice(t, lines...)
+
+	buyer := newParty(t, "B87654321", "Cliente S.L.", model.Address{Street: "Calle Sol 2", Town: "Sevilla", Province: "Sevilla"})
+	_, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: lines, Buyer: buyer})
+	assert.ErrorIs(t, err, facturae.ErrIncompleteAddress, "Spanish addresses need a postal code")
+
+	buyer = newParty(t, "XX123", "Faraway Ltd", model.Address{Street: "Main St 1", Town: "Nowhere", Province: "Nowhere", Country: "XX"})
+	_, err = newExporter(t).Export(model.Document{Invoice: invoice, Lines: lines, Buyer: buyer})
+	assert.ErrorIs(t, err, facturae.ErrUnsupportedCountry)
+}
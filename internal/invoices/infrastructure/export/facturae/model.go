@@ -0,0 +1,162 @@
+package facturae
+
+import "encoding/xml"
+
+const (
+	Namespace     = "http://www.facturae.gob.es/formato/Versiones/Facturaev3_2_2.xml"
+	DSigNamespace = "http://www.w3.org/2000/09/xmldsig#"
+	SchemaVersion = "3.2.2"
+	dateLayout    = "2006-01-02"
+	language      = "es"
+)
+
+// Facturae is the root element of a Facturae 3.2.2 document. Only the root element is qualified,
+// its children belong to no namespace as the schema declares them unqualified.
+type Facturae struct {
+	XMLName    xml.Name   `xml:"fe:Facturae"`
+	XMLNSFe    string     `xml:"xmlns:fe,attr"`
+	XMLNSDs    string     `xml:"xmlns:ds,attr"`
+	FileHeader FileHeader `xml:"FileHeader"`
+	Parties    Parties    `xml:"Parties"`
+	Invoices   []Invoice  `xml:"Invoices>Invoice"`
+}
+
+type FileHeader struct {
+	SchemaVersion     string `xml:"SchemaVersion"`
+	Modality          string `xml:"Modality"`
+	InvoiceIssuerType string `xml:"InvoiceIssuerType"`
+	Batch             Batch  `xml:"Batch"`
+}
+
+type Batch struct {
+	BatchIdentifier        string `xml:"BatchIdentifier"`
+	InvoicesCount          int    `xml:"InvoicesCount"`
+	TotalInvoicesAmount    Amount `xml:"TotalInvoicesAmount"`
+	TotalOutstandingAmount Amount `xml:"TotalOutstandingAmount"`
+	TotalExecutableAmount  Amount `xml:"TotalExecutableAmount"`
+	InvoiceCurrencyCode    string `xml:"InvoiceCurrencyCode"`
+}
+
+type Parties struct {
+	SellerParty Business `xml:"SellerParty"`
+	BuyerParty  Business `xml:"BuyerParty"`
+}
+
+// Business is a party, either a legal entity or an individual.
+type Business struct {
+	TaxIdentification TaxIdentification `xml:"TaxIdentification"`
+	LegalEntity       *LegalEntity      `xml:"LegalEntity,omitempty"`
+	Individual        *Individual       `xml:"Individual,omitempty"`
+}
+
+type TaxIdentification struct {
+	PersonTypeCode          string `xml:"PersonTypeCode"`
+	ResidenceTypeCode       string `xml:"ResidenceTypeCode"`
+	TaxIdentificationNumber string `xml:"TaxIdentificationNumber"`
+}
+
+type LegalEntity struct {
+	CorporateName   string           `xml:"CorporateName"`
+	AddressInSpain  *AddressInSpain  `xml:"AddressInSpain,omitempty"`
+	OverseasAddress *OverseasAddress `xml:"OverseasAddress,omitempty"`
+}
+
+type Individual struct {
+	Name            string           `xml:"Name"`
+	FirstSurname    string           `xml:"FirstSurname"`
+	AddressInSpain  *AddressInSpain  `xml:"AddressInSpain,omitempty"`
+	OverseasAddress *OverseasAddress `xml:"OverseasAddress,omitempty"`
+}
+
+type AddressInSpain struct {
+	Address     string `xml:"Address"`
+	PostCode    string `xml:"PostCode"`
+	Town        string `xml:"Town"`
+	Province    string `xml:"Province"`
+	CountryCode string `xml:"CountryCode"`
+}
+
+type OverseasAddress struct {
+	Address         string `xml:"Address"`
+	PostCodeAndTown string `xml:"PostCodeAndTown"`
+	Province        string `xml:"Province"`
+	CountryCode     string `xml:"CountryCode"`
+}
+
+type Invoice struct {
+	InvoiceHeader    InvoiceHeader    `xml:"InvoiceHeader"`
+	InvoiceIssueData InvoiceIssueData `xml:"InvoiceIssueData"`
+	TaxesOutputs     []Tax            `xml:"TaxesOutputs>Tax"`
+	InvoiceTotals    InvoiceTotals    `xml:"InvoiceTotals"`
+	Items            []InvoiceLine    `xml:"Items>InvoiceLine"`
+}
+
+type InvoiceHeader struct {
+	InvoiceNumber       string      `xml:"InvoiceNumber"`
+	InvoiceSeriesCode   string      `xml:"InvoiceSeriesCode,omitempty"`
+	InvoiceDocumentType string      `xml:"InvoiceDocumentType"`
+	InvoiceClass        string      `xml:"InvoiceClass"`
+	Corrective          *Corrective `xml:"Corrective,omitempty"`
+}
+
+// Corrective identifies the invoice a corrective invoice rectifies and how.
+type Corrective struct {
+	InvoiceNumber               string `xml:"InvoiceNumber"`
+	InvoiceSeriesCode           string `xml:"InvoiceSeriesCode,omitempty"`
+	ReasonCode                  string `xml:"ReasonCode"`
+	ReasonDescription           string `xml:"ReasonDescription"`
+	TaxPeriod                   Period `xml:"TaxPeriod"`
+	CorrectionMethod            string `xml:"CorrectionMethod"`
+	CorrectionMethodDescription string `xml:"CorrectionMethodDescription"`
+	AdditionalReasonDescription string `xml:"AdditionalReasonDescription,omitempty"`
+}
+
+type Period struct {
+	StartDate string `xml:"StartDate"`
+	EndDate   string `xml:"EndDate"`
+}
+
+type InvoiceIssueData struct {
+	IssueDate           string `xml:"IssueDate"`
+	InvoiceCurrencyCode string `xml:"InvoiceCurrencyCode"`
+	TaxCurrencyCode     string `xml:"TaxCurrencyCode"`
+	LanguageName        string `xml:"LanguageName"`
+}
+
+type Tax struct {
+	TaxTypeCode string `xml:"TaxTypeCode"`
+	TaxRate     string `xml:"TaxRate"`
+	TaxableBase Amount `xml:"TaxableBase"`
+	TaxAmount   Amount `xml:"TaxAmount"`
+}
+
+type Amount struct {
+	TotalAmount string `xml:"TotalAmount"`
+}
+
+type InvoiceTotals struct {
+	TotalGrossAmount            string `xml:"TotalGrossAmount"`
+	TotalGrossAmountBeforeTaxes string `xml:"TotalGrossAmountBeforeTaxes"`
+	TotalTaxOutputs             string `xml:"TotalTaxOutputs"`
+	TotalTaxesWithheld          string `xml:"TotalTaxesWithheld"`
+	InvoiceTotal                string `xml:"InvoiceTotal"`
+	TotalOutstandingAmount      string `xml:"TotalOutstandingAmount"`
+	TotalExecutableAmount       string `xml:"TotalExecutableAmount"`
+}
+
+type InvoiceLine struct {
+	ItemDescription     string               `xml:"ItemDescription"`
+	Quantity            string               `xml:"Quantity"`
+	UnitPriceWithoutTax string               `xml:"UnitPriceWithoutTax"`
+	TotalCost           string               `xml:"TotalCost"`
+	GrossAmount         string               `xml:"GrossAmount"`
+	TaxesOutputs        []Tax                `xml:"TaxesOutputs>Tax"`
+	TransactionDate     string               `xml:"TransactionDate,omitempty"`
+	SpecialTaxableEvent *SpecialTaxableEvent `xml:"SpecialTaxableEvent,omitempty"`
+}
+
+// SpecialTaxableEvent explains why a line bears no tax.
+type SpecialTaxableEvent struct {
+	SpecialTaxableEventCode   string `xml:"SpecialTaxableEventCode"`
+	SpecialTaxableEventReason string `xml:"SpecialTaxableEventReason"`
+}
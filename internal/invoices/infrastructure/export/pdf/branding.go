@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownTemplate = errors.New("unknown PDF template")
+	ErrUnknownLanguage = errors.New("unknown PDF language")
+	ErrInvalidColor    = errors.New("invalid color, expected a hex RGB color such as #1F4E79")
+)
+
+// Templates and languages of the documents.
+const (
+	TemplateClassic = "classic"
+	TemplateCompact = "compact"
+
+	LanguageSpanish = "es"
+	LanguageEnglish = "en"
+
+	DefaultAccentColor = "#1F4E79"
+)
+
+// Branding customizes the documents of a company.
+type Branding struct {
+	Template    string // Layout of the documents, TemplateClassic or TemplateCompact
+	Language    string // Language of the labels, dates and amounts, LanguageSpanish or LanguageEnglish
+	AccentColor string // Hex RGB color of the title, the table headings and the total
+	Logo        []byte // JPEG image printed on the top left corner of the first page, optional
+	Footer      string // Text printed at the bottom of every page, e.g. the registry details of the company
+}
+
+// template sets the layout of a document.
+type template struct {
+	margin       float64
+	fontSize     float64
+	titleSize    float64
+	rowHeight    float64
+	logoHeight   float64
+	headingBand  bool // Table headings are printed white on an accent band, or in the accent color over a rule
+	stripedRows  bool
+	sectionSpace float64
+}
+
+var templates = map[string]template{
+	TemplateClassic: {
+		margin: 50, fontSize: 9.5, titleSize: 22, rowHeight: 18, logoHeight: 48,
+		headingBand: true, stripedRows: true, sectionSpace: 26,
+	},
+	TemplateCompact: {
+		margin: 36, fontSize: 8, titleSize: 16, rowHeight: 13, logoHeight: 32,
+		sectionSpace: 16,
+	},
+}
+
+// labels are the texts of a document in a language.
+type labels struct {
+	invoice, creditNote                                    string
+	number, issueDate, dueDate, rectifies, reason          string
+	seller, buyer, account, taxID, location                string
+	date, description, taxRate, base, total                string
+	taxSummary, tax, exempt, totalBase, totalTax, totalDue string
+	page                                                   string // Format of the page numbers, given the page and the page count
+	dateLayout                                             string
+	decimal, thousands                                     string
+}
+
+var languages = map[string]labels{
+	LanguageSpanish: {
+		invoice: "FACTURA", creditNote: "FACTURA RECTIFICATIVA",
+		number: "Número", issueDate: "Fecha de emisión", dueDate: "Vencimiento", rectifies: "Rectifica a", reason: "Motivo",
+		seller: "Emisor", buyer: "Cliente", account: "Cuenta", taxID: "NIF", location: "Ubicación",
+		date: "Fecha", description: "Concepto", taxRate: "Impuesto", base: "Base imponible", total: "Total",
+		taxSummary: "Resumen de impuestos", tax: "Cuota", exempt: "Exenta", totalBase: "Base imponible",
+		totalTax: "Impuestos", totalDue: "Total factura",
+		page: "Página %d de %d", dateLayout: "02/01/2006", decimal: ",", thousands: ".",
+	},
+	LanguageEnglish: {
+		invoice: "INVOICE", creditNote: "CREDIT NOTE",
+		number: "Number", issueDate: "Issue date", dueDate: "Due date", rectifies: "Rectifies", reason: "Reason",
+		seller: "Seller", buyer: "Bill to", account: "Account", taxID: "Tax ID", location: "Location",
+		date: "Date", description: "Description", taxRate: "Tax", base: "Net amount", total: "Total",
+		taxSummary: "Tax summary", tax: "Tax amount", exempt: "Exempt", totalBase: "Net total",
+		totalTax: "Taxes", totalDue: "Total due",
+		page: "Page %d of %d", dateLayout: "2 Jan 2006", decimal: ".", thousands: ",",
+	},
+}
+
+// formatAmount writes a decimal amount such as -1234.50 with the separators of the language.
+func (l labels) formatAmount(amount, currency string) string {
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+	integer, fraction, _ := strings.Cut(amount, ".")
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(l.thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction != "" {
+		fraction = l.decimal + fraction
+	}
+	return sign + grouped.String() + fraction + " " + currency
+}
+
+// formatPercentage writes a percentage such as 21.00 with the decimal separator of the language.
+func (l labels) formatPercentage(percentage string) string {
+	return strings.Replace(percentage, ".", l.decimal, 1) + " %"
+}
+
+// parseColor parses a hex RGB color such as #1F4E79.
+func parseColor(hex string) (rgb, error) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return rgb{}, fmt.Errorf("%w: %q", ErrInvalidColor, hex)
+	}
+	return rgb{
+		r: float64(value>>16&0xff) / 255,
+		g: float64(value>>8&0xff) / 255,
+		b: float64(value&0xff) / 255,
+	}, nil
+}
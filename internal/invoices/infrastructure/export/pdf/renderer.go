@@ -0,0 +1,369 @@
+// Package pdf renders invoices as printable PDF documents branded with the logo, colors and texts of the
+// company that issues them. Documents are written directly in PDF with the standard Helvetica fonts,
+// so rendering needs no fonts, external tools or libraries.
+package pdf
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+// footerHeight is the space kept at the bottom of every page for the footer.
+const footerHeight = 28
+
+// Renderer renders the invoices issued by a company.
+type Renderer struct {
+	seller   model.Party
+	template template
+	labels   labels
+	accent   rgb
+	logo     *jpegImage
+	footer   string
+}
+
+// NewRenderer creates a renderer for the invoices issued by the given seller. The branding defaults to
+// the classic template in Spanish with the default accent color and no logo.
+func NewRenderer(seller model.Party, branding Branding) (Renderer, error) {
+	if branding.Template == "" {
+		branding.Template = TemplateClassic
+	}
+	if branding.Language == "" {
+		branding.Language = LanguageSpanish
+	}
+	if branding.AccentColor == "" {
+		branding.AccentColor = DefaultAccentColor
+	}
+
+	r := Renderer{seller: seller, footer: branding.Footer}
+	var ok bool
+	if r.template, ok = templates[branding.Template]; !ok {
+		return Renderer{}, fmt.Errorf("%w: %q", ErrUnknownTemplate, branding.Template)
+	}
+	if r.labels, ok = languages[branding.Language]; !ok {
+		return Renderer{}, fmt.Errorf("%w: %q", ErrUnknownLanguage, branding.Language)
+	}
+	var err error
+	if r.accent, err = parseColor(branding.AccentColor); err != nil {
+		return Renderer{}, err
+	}
+	if len(branding.Logo) > 0 {
+		if r.logo, err = newJPEGImage(branding.Logo); err != nil {
+			return Renderer{}, fmt.Errorf("invalid logo: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Render renders the document as a PDF: a header with the number and dates of the invoice, the seller and
+// the buyer, the table of its lines, its tax summary and its totals. Without a buyer name, the account and
+// the customer location of the invoice identify the buyer.
+func (r Renderer) Render(document model.Document) ([]byte, error) {
+	invoice := document.Invoice
+	title := r.labels.invoice
+	if invoice.Type == model.InvoiceTypeCreditNote {
+		title = r.labels.creditNote
+	}
+
+	l := &layout{Renderer: r, writer: &writer{title: title + " " + invoice.InvoiceNumber, logo: r.logo}}
+	l.newPage()
+	l.header(title, document)
+	l.parties(document)
+	l.lines(document.Lines, invoice.Currency.String())
+	l.taxSummary(invoice.TaxBreakdown, invoice.Currency.String())
+	l.totals(invoice)
+	l.footers()
+	return l.writer.bytes(), nil
+}
+
+// layout places the content of a document on its pages, top to bottom.
+type layout struct {
+	Renderer
+	writer *writer
+	page   *page
+	y      float64 // Top of the space left on the current page
+}
+
+func (l *layout) newPage() {
+	l.page = l.writer.addPage()
+	l.y = pageHeight - l.template.margin
+}
+
+// ensure starts a new page when the current one has not the given height left, calling continued on it.
+func (l *layout) ensure(height float64, continued func()) {
+	if l.y-height >= l.template.margin+footerHeight {
+		return
+	}
+	l.newPage()
+	if continued != nil {
+		continued()
+	}
+}
+
+func (l *layout) left() float64  { return l.template.margin }
+func (l *layout) right() float64 { return pageWidth - l.template.margin }
+
+func (l *layout) lineHeight() float64 { return l.template.fontSize * 1.45 }
+
+func (l *layout) header(title string, document model.Document) {
+	invoice := document.Invoice
+	top := l.y
+	logoBottom := top
+	if l.logo != nil {
+		height := l.template.logoHeight
+		width := height * float64(l.logo.width) / float64(l.logo.height)
+		l.page.image(l.left(), top-height, width, height)
+		logoBottom = top - height
+	}
+
+	l.page.textRight(l.right(), top-l.template.titleSize, bold, l.template.titleSize, l.accent, title)
+	l.y = top - l.template.titleSize - l.template.sectionSpace/2
+
+	fields := [][2]string{
+		{l.labels.number, invoice.InvoiceNumber},
+		{l.labels.issueDate, l.date(invoice.IssueDate)},
+	}
+	if corrected := document.CorrectedInvoice; corrected != nil {
+		fields = append(fields,
+			[2]string{l.labels.rectifies, corrected.InvoiceNumber + " (" + l.date(corrected.IssueDate) + ")"},
+			[2]string{l.labels.reason, invoice.CorrectionReason},
+		)
+	} else if invoice.Type != model.InvoiceTypeCreditNote {
+		fields = append(fields, [2]string{l.labels.dueDate, l.date(invoice.DueDate)})
+	}
+	for _, field := range fields {
+		l.y -= l.lineHeight()
+		l.page.text(l.right()-230, l.y, regular, l.template.fontSize, grey, field[0])
+		l.page.textRight(l.right(), l.y, bold, l.template.fontSize, black, truncate(field[1], bold, l.template.fontSize, 150))
+	}
+
+	l.y = min(l.y, logoBottom) - l.template.sectionSpace
+}
+
+func (l *layout) parties(document model.Document) {
+	seller := l.partyLines(l.seller)
+	buyer := l.partyLines(document.Buyer)
+	if document.Buyer.Name == "" {
+		location := document.Invoice.CustomerLocation
+		buyer = []string{l.labels.account + ": " + document.Invoice.AccountID}
+		if location.Country != "" {
+			buyer = append(buyer, l.labels.location+": "+joinNonEmpty(" ", location.PostalCode, location.Country))
+		}
+	}
+
+	columnWidth := (l.right()-l.left())/2 - 10
+	top := l.y
+	for i, column := range []struct {
+		heading string
+		lines   []string
+	}{{l.labels.seller, seller}, {l.labels.buyer, buyer}} {
+		x := l.left() + float64(i)*(columnWidth+20)
+		y := top - l.template.fontSize
+		l.page.text(x, y, bold, l.template.fontSize, l.accent, column.heading)
+		l.page.line(x, y-4, x+columnWidth, y-4, 0.5, l.accent)
+		y -= 4
+		for j, text := range column.lines {
+			y -= l.lineHeight()
+			f := regular
+			if j == 0 {
+				f = bold
+			}
+			l.page.text(x, y, f, l.template.fontSize, black, truncate(text, f, l.template.fontSize, columnWidth))
+		}
+		l.y = min(l.y, y)
+	}
+	l.y -= l.template.sectionSpace
+}
+
+// partyLines returns the name, the tax ID and the address of a party, one line each.
+func (l *layout) partyLines(party model.Party) []string {
+	if party.Name == "" {
+		return nil
+	}
+	address := party.Address
+	return nonEmpty(
+		party.Name,
+		l.labels.taxID+": "+party.TaxID,
+		address.Street,
+		joinNonEmpty(" ", address.PostalCode, address.Town),
+		joinNonEmpty(", ", address.Province, address.Country),
+	)
+}
+
+// column of a table, right aligned columns are aligned on their right edge.
+type column struct {
+	heading    string
+	width      float64 // Zero for the column taking the width left by the others
+	rightAlign bool
+}
+
+// table draws the heading and the rows of a table, repeating the heading on every page it spans.
+func (l *layout) table(columns []column, rows [][]string) {
+	flexible := l.right() - l.left()
+	for _, c := range columns {
+		flexible -= c.width
+	}
+	widths := make([]float64, len(columns))
+	for i, c := range columns {
+		widths[i] = c.width
+		if c.width == 0 {
+			widths[i] = flexible
+		}
+	}
+
+	rowHeight := l.template.rowHeight
+	baseline := func(top float64) float64 { return top - rowHeight/2 - l.template.fontSize*0.35 }
+	row := func(cells []string, f font, textColor rgb) {
+		x := l.left()
+		for i, cell := range cells {
+			cell = truncate(cell, f, l.template.fontSize, widths[i]-8)
+			if columns[i].rightAlign {
+				l.page.textRight(x+widths[i]-4, baseline(l.y), f, l.template.fontSize, textColor, cell)
+			} else {
+				l.page.text(x+4, baseline(l.y), f, l.template.fontSize, textColor, cell)
+			}
+			x += widths[i]
+		}
+		l.y -= rowHeight
+	}
+	heading := func() {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = c.heading
+		}
+		if l.template.headingBand {
+			l.page.rect(l.left(), l.y-rowHeight, l.right()-l.left(), rowHeight, l.accent)
+			row(cells, bold, white)
+			return
+		}
+		row(cells, bold, l.accent)
+		l.page.line(l.left(), l.y, l.right(), l.y, 0.75, l.accent)
+	}
+
+	l.ensure(2*rowHeight, nil)
+	heading()
+	for i, cells := range rows {
+		l.ensure(rowHeight, heading)
+		if l.template.stripedRows && i%2 == 1 {
+			l.page.rect(l.left(), l.y-rowHeight, l.right()-l.left(), rowHeight, light)
+		}
+		row(cells, regular, black)
+	}
+	l.page.line(l.left(), l.y, l.right(), l.y, 0.5, grey)
+	l.y -= l.template.sectionSpace
+}
+
+func (l *layout) lines(lines []model.InvoiceLine, currency string) {
+	rows := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		rows = append(rows, []string{
+			l.date(line.TransactionDate),
+			line.Description,
+			l.taxName(string(line.TaxRegime), string(line.ExemptionCause), line.TaxPercentage),
+			l.labels.formatAmount(line.AmountWithoutTax.Amount(), currency),
+			l.labels.formatAmount(line.AmountWithTax.Amount(), currency),
+		})
+	}
+	l.table([]column{
+		{heading: l.labels.date, width: 62},
+		{heading: l.labels.description},
+		{heading: l.labels.taxRate, width: 92},
+		{heading: l.labels.base, width: 90, rightAlign: true},
+		{heading: l.labels.total, width: 90, rightAlign: true},
+	}, rows)
+}
+
+func (l *layout) taxSummary(breakdown model.TaxBreakdown, currency string) {
+	if len(breakdown) == 0 {
+		return
+	}
+	l.ensure(l.lineHeight()+3*l.template.rowHeight, nil)
+	l.y -= l.template.fontSize
+	l.page.text(l.left(), l.y, bold, l.template.fontSize, l.accent, l.labels.taxSummary)
+	l.y -= l.template.fontSize / 2
+
+	rows := make([][]string, 0, len(breakdown))
+	for _, summary := range breakdown {
+		rows = append(rows, []string{
+			l.taxName(string(summary.Regime), string(summary.ExemptionCause), summary.Percentage),
+			l.labels.formatAmount(summary.TaxableBase.Amount(), currency),
+			l.labels.formatAmount(summary.TaxAmount.Amount(), currency),
+		})
+	}
+	l.table([]column{
+		{heading: l.labels.taxRate},
+		{heading: l.labels.base, width: 120, rightAlign: true},
+		{heading: l.labels.tax, width: 120, rightAlign: true},
+	}, rows)
+}
+
+func (l *layout) totals(invoice model.Invoice) {
+	currency := invoice.Currency.String()
+	l.ensure(4*l.lineHeight(), nil)
+	labelX := l.right() - 230
+	for _, total := range []struct {
+		label  string
+		amount money.Money
+	}{
+		{l.labels.totalBase, invoice.TotalAmountWithoutTax},
+		{l.labels.totalTax, invoice.TaxAmount},
+	} {
+		l.y -= l.lineHeight()
+		l.page.text(labelX, l.y, regular, l.template.fontSize, grey, total.label)
+		l.page.textRight(l.right(), l.y, regular, l.template.fontSize, black, l.labels.formatAmount(total.amount.Amount(), currency))
+	}
+
+	size := l.template.fontSize + 2
+	l.y -= l.lineHeight() / 2
+	l.page.line(labelX, l.y, l.right(), l.y, 1, l.accent)
+	l.y -= size * 1.5
+	l.page.text(labelX, l.y, bold, size, l.accent, l.labels.totalDue)
+	l.page.textRight(l.right(), l.y, bold, size, l.accent, l.labels.formatAmount(invoice.TotalAmountWithTax.Amount(), currency))
+}
+
+// footers prints the footer text and the page number at the bottom of every page, once all pages are laid out.
+func (l *layout) footers() {
+	count := len(l.writer.pages)
+	y := l.template.margin
+	for i, p := range l.writer.pages {
+		number := fmt.Sprintf(l.labels.page, i+1, count)
+		p.line(l.left(), y+l.template.fontSize+2, l.right(), y+l.template.fontSize+2, 0.5, light)
+		p.textRight(l.right(), y, regular, l.template.fontSize-1, grey, number)
+		if l.footer != "" {
+			width := l.right() - l.left() - textWidth(number, regular, l.template.fontSize-1) - 20
+			p.text(l.left(), y, regular, l.template.fontSize-1, grey, truncate(l.footer, regular, l.template.fontSize-1, width))
+		}
+	}
+}
+
+// taxName names the tax of a line or summary, e.g. "IVA 21,00 %" or "IVA Exenta (E1)".
+func (l *layout) taxName(regime, exemptionCause string, percentage money.Percentage) string {
+	if exemptionCause != "" {
+		return joinNonEmpty(" ", regime, l.labels.exempt+" ("+exemptionCause+")")
+	}
+	return joinNonEmpty(" ", regime, l.labels.formatPercentage(percentage.String()))
+}
+
+func (l *layout) date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(l.labels.dateLayout)
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func joinNonEmpty(separator string, values ...string) string {
+	return strings.Join(nonEmpty(values...), separator)
+}
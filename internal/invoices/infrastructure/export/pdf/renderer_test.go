@@ -0,0 +1,230 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/pdf"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var issueDate = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+func newSeller(t *testing.T) model.Party {
+	seller, err := model.NewParty("B12345674", "Billing MCP S.L.", model.Address{
+		Street: "Calle Mayor 1", PostalCode: "28013", Town: "Madrid", Province: "Madrid",
+	})
+	require.NoError(t, err)
+	return seller
+}
+
+func newRenderer(t *testing.T, branding pdf.Branding) pdf.Renderer {
+	renderer, err := pdf.NewRenderer(newSeller(t), branding)
+	require.NoError(t, err)
+	return renderer
+}
+
+func newInvoice(t *testing.T, lines ...model.InvoiceLine) model.Invoice {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, issueDate, issueDate.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	for _, line := range lines {
+		require.NoError(t, invoice.AddLine(line))
+	}
+	require.NoError(t, invoice.Issue("FAC-2025-000042"))
+	return invoice
+}
+
+func newLine(t *testing.T, description string, minor int64, percentage money.Percentage) model.InvoiceLine {
+	line, err := model.NewInvoiceLine(description, money.New(minor, money.DefaultCurrency), percentage, "CREDIT")
+	require.NoError(t, err)
+	line.TransactionDate = issueDate
+	return line
+}
+
+var objectHeader = regexp.MustCompile(`^(\d+) 0 obj\n`)
+
+// checkStructure checks the output is a PDF file whose cross-reference table points at every object.
+func checkStructure(t *testing.T, output []byte) string {
+	document := string(output)
+	require.True(t, strings.HasPrefix(document, "%PDF-1.4\n"), "missing PDF header")
+	require.True(t, strings.HasSuffix(document, "%%EOF\n"), "missing end of file marker")
+
+	startxref := strings.LastIndex(document, "startxref\n")
+	require.Positive(t, startxref)
+	xref, err := strconv.Atoi(strings.Fields(document[startxref+len("startxref\n"):])[0])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(document[xref:], "xref\n"), "startxref does not point at the cross-reference table")
+
+	var size int
+	_, err = fmt.Sscanf(document[xref:], "xref\n0 %d\n", &size)
+	require.NoError(t, err)
+	entries := strings.Split(document[xref:], "\n")[3 : 3+size-1]
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		match := objectHeader.FindStringSubmatch(document[offset:])
+		require.NotNil(t, match, "entry %d does not point at an object", i+1)
+		assert.Equal(t, strconv.Itoa(i+1), match[1])
+	}
+	return document
+}
+
+func TestRenderer_Render(t *testing.T) {
+	lines := []model.InvoiceLine{
+		newLine(t, "Monthly subscription (March)", 123456, 2100),
+		newLine(t, "Printed manual", 2000, 400),
+	}
+	invoice := newInvoice(t, lines...)
+	buyer, err := model.NewParty("B87654321", "Cliente S.L.", model.Address{
+		Street: "Calle Sol 2", PostalCode: "41001", Town: "Sevilla", Province: "Sevilla",
+	})
+	require.NoError(t, err)
+
+	output, err := newRenderer(t, pdf.Branding{Language: pdf.LanguageEnglish, Footer: "Registered in Madrid"}).
+		Render(model.Document{Invoice: invoice, Lines: lines, Buyer: buyer})
+	require.NoError(t, err)
+
+	document := checkStructure(t, output)
+	assert.Contains(t, document, "/Count 1")
+	assert.Contains(t, document, "(INVOICE) Tj")
+	assert.Contains(t, document, "(FAC-2025-000042) Tj")
+	assert.Contains(t, document, "(14 Mar 2025) Tj")
+	assert.Contains(t, document, "(14 Apr 2025) Tj")
+	assert.Contains(t, document, "(Billing MCP S.L.) Tj")
+	assert.Contains(t, document, "(Cliente S.L.) Tj")
+	assert.Contains(t, document, `(Monthly subscription \(March\)) Tj`, "parentheses are escaped")
+	assert.Contains(t, document, "(1,234.56 EUR) Tj")
+	assert.Contains(t, document, "(IVA 21.00 %) Tj")
+	assert.Contains(t, document, "(1,493.82 EUR) Tj", "lines show their amount with tax")
+	assert.Contains(t, document, "(1,514.62 EUR) Tj")
+	assert.Contains(t, document, "(Registered in Madrid) Tj")
+	assert.Contains(t, document, "(Page 1 of 1) Tj")
+}
+
+func TestRenderer_Render_Spanish(t *testing.T) {
+	lines := []model.InvoiceLine{newLine(t, "Suscripción mensual", 10050, 2100)}
+	invoice := newInvoice(t, lines...)
+
+	output, err := newRenderer(t, pdf.Branding{}).Render(model.Document{Invoice: invoice, Lines: lines})
+	require.NoError(t, err)
+
+	document := checkStructure(t, output)
+	assert.Contains(t, document, "(FACTURA) Tj")
+	assert.Contains(t, document, "(14/03/2025) Tj")
+	assert.Contains(t, document, "(Suscripci\xf3n mensual) Tj", "text is encoded in WinAnsiEncoding")
+	assert.Contains(t, document, "(100,50 EUR) Tj")
+	assert.Contains(t, document, "(IVA 21,00 %) Tj")
+	assert.Contains(t, document, "(Cuenta: account_A) Tj", "without a buyer the account identifies it")
+	assert.Contains(t, document, "(P\xe1gina 1 de 1) Tj")
+}
+
+func TestRenderer_Render_CreditNote(t *testing.T) {
+	lines := []model.InvoiceLine{newLine(t, "Monthly subscription", 10050, 2100)}
+	original := newInvoice(t, lines...)
+	creditNote, err := model.NewCreditNote(original, issueDate.AddDate(0, 0, 10), "Duplicated charge")
+	require.NoError(t, err)
+	credit, err := lines[0].Rectify(money.New(5000, money.DefaultCurrency))
+	require.NoError(t, err)
+	require.NoError(t, creditNote.AddLine(credit))
+	require.NoError(t, creditNote.Issue("R-2025-000001"))
+
+	output, err := newRenderer(t, pdf.Branding{Language: pdf.LanguageEnglish}).Render(model.Document{
+		Invoice: creditNote, Lines: []model.InvoiceLine{credit}, CorrectedInvoice: &original,
+	})
+	require.NoError(t, err)
+
+	document := checkStructure(t, output)
+	assert.Contains(t, document, "(CREDIT NOTE) Tj")
+	assert.Contains(t, document, "(FAC-2025-000042 \\(14 Mar 2025\\)) Tj")
+	assert.Contains(t, document, "(Duplicated charge) Tj")
+	assert.Contains(t, document, "(-60.50 EUR) Tj")
+	assert.NotContains(t, document, "(Due date) Tj")
+}
+
+func TestRenderer_Render_Exempt(t *testing.T) {
+	line, err := newLine(t, "Consulting services", 30000, 2100).WithTax(taxes.Exempt(taxes.RegimeIVA, taxes.ExemptionIntraEU))
+	require.NoError(t, err)
+	invoice := newInvoice(t, line)
+
+	output, err := newRenderer(t, pdf.Branding{Language: pdf.LanguageEnglish}).
+		Render(model.Document{Invoice: invoice, Lines: []model.InvoiceLine{line}})
+	require.NoError(t, err)
+
+	assert.Contains(t, checkStructure(t, output), "(IVA Exempt \\(E5\\)) Tj")
+}
+
+func TestRenderer_Render_Pages(t *testing.T) {
+	var lines []model.InvoiceLine
+	for i := 0; i < 80; i++ {
+		lines = append(lines, newLine(t, fmt.Sprintf("Call %d", i+1), 100, 2100))
+	}
+	invoice := newInvoice(t, lines...)
+
+	for _, template := range []string{pdf.TemplateClassic, pdf.TemplateCompact} {
+		t.Run(template, func(t *testing.T) {
+			output, err := newRenderer(t, pdf.Branding{Template: template, Language: pdf.LanguageEnglish}).
+				Render(model.Document{Invoice: invoice, Lines: lines})
+			require.NoError(t, err)
+
+			document := checkStructure(t, output)
+			pages := strings.Count(document, "/Type /Page ")
+			assert.Greater(t, pages, 1)
+			assert.Contains(t, document, fmt.Sprintf("/Count %d", pages))
+			assert.Contains(t, document, fmt.Sprintf("(Page %d of %d) Tj", pages, pages))
+			assert.Contains(t, document, "(Call 80) Tj")
+			// Table headings are repeated on every page the lines span
+			assert.GreaterOrEqual(t, strings.Count(document, "(Description) Tj"), pages-1)
+		})
+	}
+}
+
+func TestRenderer_Render_Logo(t *testing.T) {
+	var logo bytes.Buffer
+	require.NoError(t, jpeg.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 120, 40)), nil))
+	lines := []model.InvoiceLine{newLine(t, "Monthly subscription", 10050, 2100)}
+
+	output, err := newRenderer(t, pdf.Branding{Logo: logo.Bytes(), AccentColor: "#AA0000"}).
+		Render(model.Document{Invoice: newInvoice(t, lines...), Lines: lines})
+	require.NoError(t, err)
+
+	document := checkStructure(t, output)
+	assert.Contains(t, document, "/Subtype /Image /Width 120 /Height 40 /ColorSpace /DeviceRGB")
+	assert.Contains(t, document, "/Im1 Do")
+	assert.Contains(t, document, "0.67 0 0 rg", "the accent color is applied")
+}
+
+func TestNewRenderer_InvalidBranding(t *testing.T) {
+	var logo bytes.Buffer
+	require.NoError(t, png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+
+	tests := []struct {
+		name     string
+		branding pdf.Branding
+		expected error
+	}{
+		{"unknown template", pdf.Branding{Template: "fancy"}, pdf.ErrUnknownTemplate},
+		{"unknown language", pdf.Branding{Language: "fr"}, pdf.ErrUnknownLanguage},
+		{"invalid color", pdf.Branding{AccentColor: "blue"}, pdf.ErrInvalidColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pdf.NewRenderer(newSeller(t), tt.branding)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	_, err := pdf.NewRenderer(newSeller(t), pdf.Branding{Logo: logo.Bytes()})
+	assert.ErrorContains(t, err, "only JPEG images are supported")
+}
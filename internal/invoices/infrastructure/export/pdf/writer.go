@@ -0,0 +1,177 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // Registers the JPEG format decoded by image.DecodeConfig
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// Fonts are the standard Helvetica fonts, which every PDF reader provides, so no font is embedded.
+type font string
+
+const (
+	regular font = "F1"
+	bold    font = "F2"
+)
+
+// rgb is a color with components between 0 and 1.
+type rgb struct{ r, g, b float64 }
+
+var (
+	black = rgb{0, 0, 0}
+	white = rgb{1, 1, 1}
+	grey  = rgb{0.45, 0.45, 0.45}
+	light = rgb{0.93, 0.93, 0.93}
+)
+
+// jpegImage is a JPEG file, embedded as is in the document.
+type jpegImage struct {
+	data       []byte
+	width      int
+	height     int
+	colorSpace string
+}
+
+func newJPEGImage(data []byte) (*jpegImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if format != "jpeg" {
+		return nil, fmt.Errorf("image is a %s, only JPEG images are supported", format)
+	}
+
+	colorSpace := "DeviceRGB"
+	switch config.ColorModel {
+	case color.GrayModel:
+		colorSpace = "DeviceGray"
+	case color.CMYKModel:
+		colorSpace = "DeviceCMYK"
+	}
+	return &jpegImage{data: data, width: config.Width, height: config.Height, colorSpace: colorSpace}, nil
+}
+
+// page is the content stream of a page. Coordinates are in points from the bottom left corner.
+type page struct {
+	content bytes.Buffer
+}
+
+func (p *page) text(x, y float64, f font, size float64, c rgb, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s rg %s %s Td (%s) Tj ET\n",
+		f, number(size), c.components(), number(x), number(y), escape(encode(s)))
+}
+
+// textRight writes text ending at x.
+func (p *page) textRight(x, y float64, f font, size float64, c rgb, s string) {
+	p.text(x-textWidth(s, f, size), y, f, size, c, s)
+}
+
+func (p *page) line(x1, y1, x2, y2, width float64, c rgb) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s m %s %s l S\n",
+		c.components(), number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+func (p *page) rect(x, y, width, height float64, c rgb) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		c.components(), number(x), number(y), number(width), number(height))
+}
+
+func (p *page) image(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im1 Do Q\n", number(width), number(height), number(x), number(y))
+}
+
+// writer assembles the pages into a PDF 1.4 file.
+type writer struct {
+	title string
+	logo  *jpegImage
+	pages []*page
+}
+
+func (w *writer) addPage() *page {
+	p := &page{}
+	w.pages = append(w.pages, p)
+	return p
+}
+
+// bytes writes the file: the catalog, the page tree, the fonts, the document information and the logo,
+// then every page followed by its content, and the cross-reference table with the offset of every object.
+func (w *writer) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			out.WriteString("stream\n")
+			out.Write(stream)
+			out.WriteString("\nendstream\n")
+		}
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	const catalogID, pagesID, infoID = 1, 2, 5
+	firstPageID := 6
+	if w.logo != nil {
+		firstPageID++
+	}
+	object(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID), nil)
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageID+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)), nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>", nil)
+	object("<< /Title "+literal(w.title)+" /Producer (billing-mcp) >>", nil)
+
+	resources := "<< /Font << /F1 3 0 R /F2 4 0 R >> >>"
+	if w.logo != nil {
+		object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>",
+			w.logo.width, w.logo.height, w.logo.colorSpace, len(w.logo.data)), w.logo.data)
+		resources = "<< /Font << /F1 3 0 R /F2 4 0 R >> /XObject << /Im1 6 0 R >> >>"
+	}
+
+	for i, p := range w.pages {
+		pageID := firstPageID + 2*i
+		object(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			pagesID, number(pageWidth), number(pageHeight), resources, pageID+1), nil)
+		object(fmt.Sprintf("<< /Length %d >>", p.content.Len()), p.content.Bytes())
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalogID, infoID, xref)
+	return out.Bytes()
+}
+
+func (c rgb) components() string {
+	return number(c.r) + " " + number(c.g) + " " + number(c.b)
+}
+
+// number writes a number with at most 2 decimals, as PDF readers expect them.
+func number(f float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", f), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func literal(s string) string {
+	return "(" + escape(encode(s)) + ")"
+}
+
+// escape escapes the characters of a PDF literal string.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", `\r`, "\n", `\n`).Replace(s)
+}
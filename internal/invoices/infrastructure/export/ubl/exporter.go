@@ -0,0 +1,282 @@
+// Package ubl renders issued invoices as UBL 2.1 invoices and credit notes following the
+// Peppol BIS Billing 3.0 profile of the European standard EN 16931.
+//
+// Every document is checked against the mandatory business rules of the profile before it is returned,
+// so a document that receivers would reject is never produced.
+package ubl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var ErrCorrectedInvoiceNeeded = errors.New("credit note is exported without the invoice it rectifies")
+
+// VAT categories of UNCL5305 used by the exporter.
+const (
+	CategoryStandard      = "S"
+	CategoryZeroRated     = "Z"
+	CategoryExempt        = "E"
+	CategoryIntraEU       = "K" // Intra-community supply
+	CategoryExport        = "G" // Export outside the European Union
+	CategoryCanaryIslands = "L" // IGIC
+	CategoryCeutaMelilla  = "M" // IPSI
+)
+
+// exemptions are the VATEX codes and reasons of each exemption cause.
+var exemptions = map[taxes.ExemptionCause]struct {
+	category string
+	code     string
+	reason   string
+}{
+	taxes.ExemptionArticle20: {CategoryExempt, "VATEX-EU-132", "Exempt based on article 132 of the Council Directive 2006/112/EC"},
+	taxes.ExemptionExport:    {CategoryExport, "VATEX-EU-G", "Export outside the EU"},
+	taxes.ExemptionIntraEU:   {CategoryIntraEU, "VATEX-EU-IC", "Intra-Community supply"},
+}
+
+// Exporter renders the documents of invoices issued by a given seller.
+type Exporter struct {
+	seller model.Party
+}
+
+// NewExporter creates an exporter for the invoices issued by the given seller.
+func NewExporter(seller model.Party) Exporter {
+	return Exporter{seller: seller}
+}
+
+// Export renders the document as a UBL invoice, or as a UBL credit note for credit notes,
+// failing with ErrBusinessRules when it breaks any of the rules checked by Validate.
+func (e Exporter) Export(document model.Document) ([]byte, error) {
+	ubl, err := e.convert(document)
+	if err != nil {
+		return nil, err
+	}
+	if violations := Validate(ubl); len(violations) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBusinessRules, violations)
+	}
+
+	output, err := xml.MarshalIndent(ubl, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal UBL document: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+func (e Exporter) convert(document model.Document) (Document, error) {
+	invoice := document.Invoice
+	currency := invoice.Currency.String()
+
+	ubl := Document{
+		XMLName:          xml.Name{Local: "Invoice"},
+		XMLNS:            InvoiceNamespace,
+		XMLNSCac:         CACNamespace,
+		XMLNSCbc:         CBCNamespace,
+		CustomizationID:  CustomizationID,
+		ProfileID:        ProfileID,
+		ID:               invoice.InvoiceNumber,
+		IssueDate:        date(invoice.IssueDate),
+		DocumentCurrency: currency,
+		BuyerReference:   invoice.AccountID,
+		Supplier:         PartyRole{Party: party(e.seller)},
+		Customer:         PartyRole{Party: party(document.Buyer)},
+	}
+
+	// UBL credit notes state positive amounts that are credited, where the domain keeps them negative
+	sign := int64(1)
+	if invoice.Type == model.InvoiceTypeCreditNote {
+		corrected := document.CorrectedInvoice
+		if corrected == nil {
+			return Document{}, fmt.Errorf("%w: %s", ErrCorrectedInvoiceNeeded, invoice.InvoiceNumber)
+		}
+		sign = -1
+		ubl.XMLName = xml.Name{Local: "CreditNote"}
+		ubl.XMLNS = CreditNoteNamespace
+		ubl.CreditNoteTypeCode = creditNoteTypeCode
+		ubl.Note = invoice.CorrectionReason
+		ubl.BillingReference = &BillingReference{InvoiceDocumentReference: DocumentReference{
+			ID:        corrected.InvoiceNumber,
+			IssueDate: date(corrected.IssueDate),
+		}}
+	} else {
+		ubl.DueDate = date(invoice.DueDate)
+		ubl.InvoiceTypeCode = invoiceTypeCode
+	}
+	signed := func(m money.Money) Amount {
+		return amount(money.New(sign*m.Minor(), m.Currency()))
+	}
+
+	subtotals, err := taxSubtotals(invoice.TaxBreakdown, invoice.Currency, sign)
+	if err != nil {
+		return Document{}, err
+	}
+	ubl.TaxTotal = TaxTotal{TaxAmount: signed(invoice.TaxAmount), TaxSubtotals: subtotals}
+	ubl.LegalMonetaryTotal = MonetaryTotal{
+		LineExtensionAmount: signed(invoice.TotalAmountWithoutTax),
+		TaxExclusiveAmount:  signed(invoice.TotalAmountWithoutTax),
+		TaxInclusiveAmount:  signed(invoice.TotalAmountWithTax),
+		PayableAmount:       signed(invoice.TotalAmountWithTax),
+	}
+
+	lines := make([]Line, 0, len(document.Lines))
+	var delivered time.Time
+	for i, domainLine := range document.Lines {
+		line := invoiceLine(i+1, domainLine, sign)
+		if ubl.IsCreditNote() {
+			line.CreditedQuantity, line.InvoicedQuantity = line.InvoicedQuantity, nil
+		}
+		lines = append(lines, line)
+		if domainLine.TransactionDate.After(delivered) {
+			delivered = domainLine.TransactionDate
+		}
+	}
+	if ubl.IsCreditNote() {
+		ubl.CreditNoteLines = lines
+	} else {
+		ubl.InvoiceLines = lines
+	}
+
+	// Intra-community supplies must state when and where the goods or services were delivered
+	if !delivered.IsZero() {
+		ubl.Delivery = &Delivery{ActualDeliveryDate: date(delivered)}
+		if slices.ContainsFunc(subtotals, func(subtotal TaxSubtotal) bool { return subtotal.TaxCategory.ID == CategoryIntraEU }) {
+			ubl.Delivery.DeliveryLocation = &DeliveryLocation{Address: ubl.Customer.Party.PostalAddress}
+		}
+	}
+	return ubl, nil
+}
+
+func party(party model.Party) Party {
+	country := party.Address.Country
+	vatID := vatIdentifier(party)
+	ubl := Party{
+		EndpointID: Identifier{Value: vatID, SchemeID: endpointSchemes[country]},
+		PostalAddress: Address{
+			StreetName:       party.Address.Street,
+			CityName:         party.Address.Town,
+			PostalZone:       party.Address.PostalCode,
+			CountrySubentity: party.Address.Province,
+			Country:          Country{IdentificationCode: country},
+		},
+		LegalEntity: LegalEntity{RegistrationName: party.Name},
+	}
+	if vatID != "" {
+		ubl.PartyTaxScheme = &PartyTaxScheme{CompanyID: vatID, TaxScheme: TaxScheme{ID: vatScheme}}
+	}
+	return ubl
+}
+
+// vatIdentifier returns the tax ID of the party prefixed with its country, as VAT identifiers are written.
+// Greek VAT identifiers use the EL prefix.
+func vatIdentifier(party model.Party) string {
+	if party.TaxID == "" {
+		return ""
+	}
+	prefix := party.Address.Country
+	if prefix == "GR" {
+		prefix = "EL"
+	}
+	if strings.HasPrefix(party.TaxID, prefix) {
+		return party.TaxID
+	}
+	return prefix + party.TaxID
+}
+
+// taxCategory returns the VAT category the operations of a regime, exemption cause and rate fall into.
+func taxCategory(regime taxes.Regime, cause taxes.ExemptionCause, percentage money.Percentage) TaxCategory {
+	category := TaxCategory{Percent: percentage.String(), TaxScheme: TaxScheme{ID: vatScheme}}
+	if exemption, ok := exemptions[cause]; ok {
+		category.ID = exemption.category
+		category.TaxExemptionReasonCode = exemption.code
+		category.TaxExemptionReason = exemption.reason
+		return category
+	}
+
+	switch {
+	case cause != "":
+		category.ID = CategoryExempt
+		category.TaxExemptionReason = fmt.Sprintf("Exempt (%s)", cause)
+	case regime == taxes.RegimeIGIC:
+		category.ID = CategoryCanaryIslands
+	case regime == taxes.RegimeIPSI:
+		category.ID = CategoryCeutaMelilla
+	case percentage == 0:
+		category.ID = CategoryZeroRated
+	default:
+		category.ID = CategoryStandard
+	}
+	return category
+}
+
+// taxSubtotals merges the tax breakdown of the invoice per VAT category and rate, recomputing each quota on its merged base.
+func taxSubtotals(breakdown model.TaxBreakdown, currency money.Currency, sign int64) ([]TaxSubtotal, error) {
+	type key struct{ category, percent string }
+	var keys []key
+	bases := map[key]money.Money{}
+	percentages := map[key]money.Percentage{}
+	categories := map[key]TaxCategory{}
+
+	for _, summary := range breakdown {
+		category := taxCategory(summary.Regime, summary.ExemptionCause, summary.Percentage)
+		k := key{category.ID, category.Percent}
+		base, ok := bases[k]
+		if !ok {
+			keys = append(keys, k)
+			base = money.Zero(currency)
+			categories[k] = category
+			percentages[k] = summary.Percentage
+		}
+		var err error
+		if bases[k], err = base.Add(summary.TaxableBase); err != nil {
+			return nil, err
+		}
+	}
+
+	subtotals := make([]TaxSubtotal, 0, len(keys))
+	for _, k := range keys {
+		base := money.New(sign*bases[k].Minor(), currency)
+		subtotals = append(subtotals, TaxSubtotal{
+			TaxableAmount: amount(base),
+			TaxAmount:     amount(base.ApplyPercentage(percentages[k])),
+			TaxCategory:   categories[k],
+		})
+	}
+	return subtotals, nil
+}
+
+// invoiceLine renders a line as a single unit priced at its base. The price of a line cannot be negative,
+// so negative lines of an invoice are rendered as a negative quantity.
+func invoiceLine(number int, line model.InvoiceLine, sign int64) Line {
+	net := money.New(sign*line.AmountWithoutTax.Minor(), line.AmountWithoutTax.Currency())
+	quantity := "1"
+	price := net
+	if net.IsNegative() {
+		quantity = "-1"
+		price = net.Neg()
+	}
+
+	category := taxCategory(line.TaxRegime, line.ExemptionCause, line.TaxPercentage)
+	category.TaxExemptionReasonCode, category.TaxExemptionReason = "", ""
+	return Line{
+		ID:                  fmt.Sprint(number),
+		InvoicedQuantity:    &Quantity{Value: quantity, UnitCode: unitCode},
+		LineExtensionAmount: amount(net),
+		Item:                Item{Name: line.Description, ClassifiedTaxCategory: category},
+		Price:               Price{PriceAmount: amount(price)},
+	}
+}
+
+func amount(m money.Money) Amount {
+	return Amount{Value: m.Amount(), CurrencyID: m.Currency().String()}
+}
+
+func date(t time.Time) string {
+	return t.Format(dateLayout)
+}
@@ -0,0 +1,170 @@
+package ubl_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/ubl"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var issueDate = time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+func newParty(t *testing.T, taxID, name string, address model.Address) model.Party {
+	party, err := model.NewParty(taxID, name, address)
+	require.NoError(t, err)
+	return party
+}
+
+func newExporter(t *testing.T) ubl.Exporter {
+	return ubl.NewExporter(newParty(t, "B12345674", "Billing MCP S.L.", model.Address{
+		Street: "Calle Mayor 1", PostalCode: "28013", Town: "Madrid", Province: "Madrid",
+	}))
+}
+
+func newBuyer(t *testing.T) model.Party {
+	return newParty(t, "DE123456789", "Kunde GmbH", model.Address{
+		Street: "Hauptstraße 1", PostalCode: "10115", Town: "Berlin", Province: "Berlin", Country: "DE",
+	})
+}
+
+func newInvoice(t *testing.T, lines ...model.InvoiceLine) model.Invoice {
+	invoice, err := model.NewInvoice("account_A", money.DefaultCurrency, issueDate, issueDate.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	for _, line := range lines {
+		require.NoError(t, invoice.AddLine(line))
+	}
+	require.NoError(t, invoice.Issue("FAC-2025-000042"))
+	return invoice
+}
+
+func newLine(t *testing.T, description string, minor int64, percentage money.Percentage) model.InvoiceLine {
+	line, err := model.NewInvoiceLine(description, money.New(minor, money.DefaultCurrency), percentage, "CREDIT")
+	require.NoError(t, err)
+	line.TransactionDate = issueDate
+	return line
+}
+
+// wellFormed checks the output is a well formed XML document and returns it as a string.
+func wellFormed(t *testing.T, output []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(output))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return string(output)
+		}
+		require.NoError(t, err, "document is not well formed:\n%s", output)
+	}
+}
+
+func TestExporter_Export_Invoice(t *testing.T) {
+	lines := []model.InvoiceLine{
+		newLine(t, "Monthly subscription", 10050, 2100),
+		newLine(t, "Monthly subscription", 10050, 2100),
+		newLine(t, "Printed manual", 2000, 400),
+	}
+	invoice := newInvoice(t, lines...)
+
+	output, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: lines, Buyer: newBuyer(t)})
+	require.NoError(t, err)
+
+	document := wellFormed(t, output)
+	assert.Contains(t, document, `<Invoice xmlns="`+ubl.InvoiceNamespace+`"`)
+	assert.Contains(t, document, "<cbc:CustomizationID>"+ubl.CustomizationID+"</cbc:CustomizationID>")
+	assert.Contains(t, document, "<cbc:ID>FAC-2025-000042</cbc:ID>")
+	assert.Contains(t, document, "<cbc:DueDate>2025-04-14</cbc:DueDate>")
+	assert.Contains(t, document, "<cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>")
+	assert.Contains(t, document, `<cbc:EndpointID schemeID="9920">ESB12345674</cbc:EndpointID>`)
+	assert.Contains(t, document, `<cbc:EndpointID schemeID="9930">DE123456789</cbc:EndpointID>`)
+	assert.Contains(t, document, `<cbc:TaxAmount currencyID="EUR">43.01</cbc:TaxAmount>`)
+	assert.Contains(t, document, `<cbc:PayableAmount currencyID="EUR">264.01</cbc:PayableAmount>`)
+	assert.Contains(t, document, `<cbc:InvoicedQuantity unitCode="C62">1</cbc:InvoicedQuantity>`)
+	assert.Contains(t, document, `<cbc:TaxableAmount currencyID="EUR">201.00</cbc:TaxableAmount>`)
+	assert.Contains(t, document, `<cbc:TaxAmount currencyID="EUR">42.21</cbc:TaxAmount>`)
+	assert.Contains(t, document, `<cbc:TaxableAmount currencyID="EUR">20.00</cbc:TaxableAmount>`)
+	assert.Equal(t, 3, strings.Count(document, "<cac:InvoiceLine>"))
+	assert.NotContains(t, document, "<cac:CreditNoteLine>")
+}
+
+func TestExporter_Export_CreditNote(t *testing.T) {
+	lines := []model.InvoiceLine{newLine(t, "Monthly subscription", 10050, 2100)}
+	original := newInvoice(t, lines...)
+
+	creditNote, err := model.NewCreditNote(original, issueDate.AddDate(0, 0, 10), "Duplicated charge")
+	require.NoError(t, err)
+	credit, err := lines[0].Rectify(money.New(5000, money.DefaultCurrency))
+	require.NoError(t, err)
+	require.NoError(t, creditNote.AddLine(credit))
+	require.NoError(t, creditNote.Issue("R-2025-000001"))
+
+	creditNoteDocument := model.Document{Invoice: creditNote, Lines: []model.InvoiceLine{credit}, Buyer: newBuyer(t)}
+	_, err = newExporter(t).Export(creditNoteDocument)
+	assert.ErrorIs(t, err, ubl.ErrCorrectedInvoiceNeeded)
+
+	creditNoteDocument.CorrectedInvoice = &original
+	output, err := newExporter(t).Export(creditNoteDocument)
+	require.NoError(t, err)
+
+	document := wellFormed(t, output)
+	assert.Contains(t, document, `<CreditNote xmlns="`+ubl.CreditNoteNamespace+`"`)
+	assert.NotContains(t, document, "<cbc:DueDate>", "UBL 2.1 credit notes have no due date")
+	assert.Contains(t, document, "<cbc:CreditNoteTypeCode>381</cbc:CreditNoteTypeCode>")
+	assert.Contains(t, document, "<cbc:Note>Duplicated charge</cbc:Note>")
+	assert.Contains(t, document, "<cac:InvoiceDocumentReference>\n      <cbc:ID>FAC-2025-000042</cbc:ID>\n      <cbc:IssueDate>2025-03-14</cbc:IssueDate>")
+	assert.Contains(t, document, `<cbc:LineExtensionAmount currencyID="EUR">50.00</cbc:LineExtensionAmount>`, "credited amounts are positive")
+	assert.Contains(t, document, `<cbc:CreditedQuantity unitCode="C62">1</cbc:CreditedQuantity>`)
+	assert.Contains(t, document, `<cbc:PayableAmount currencyID="EUR">60.50</cbc:PayableAmount>`)
+	assert.Equal(t, 1, strings.Count(document, "<cac:CreditNoteLine>"))
+}
+
+func TestExporter_Export_IntraCommunitySupply(t *testing.T) {
+	line, err := newLine(t, "Consulting services", 30000, 2100).WithTax(taxes.Exempt(taxes.RegimeIVA, taxes.ExemptionIntraEU))
+	require.NoError(t, err)
+	invoice := newInvoice(t, line)
+
+	output, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: []model.InvoiceLine{line}, Buyer: newBuyer(t)})
+	require.NoError(t, err)
+
+	document := wellFormed(t, output)
+	assert.Contains(t, document, "<cbc:ID>K</cbc:ID>\n        <cbc:Percent>0.00</cbc:Percent>\n        <cbc:TaxExemptionReasonCode>VATEX-EU-IC</cbc:TaxExemptionReasonCode>")
+	assert.Equal(t, 1, strings.Count(document, "<cbc:TaxExemptionReasonCode>"), "exemption reasons are only stated in the breakdown")
+	assert.Contains(t, document, "<cbc:ActualDeliveryDate>2025-03-14</cbc:ActualDeliveryDate>")
+	assert.Contains(t, document, "<cac:DeliveryLocation>")
+}
+
+func TestExporter_Export_NegativeLine(t *testing.T) {
+	lines := []model.InvoiceLine{
+		newLine(t, "Monthly subscription", 10000, 2100),
+		newLine(t, "Loyalty discount", -1000, 2100),
+	}
+	invoice := newInvoice(t, lines...)
+
+	output, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: lines, Buyer: newBuyer(t)})
+	require.NoError(t, err)
+
+	document := wellFormed(t, output)
+	assert.Contains(t, document, `<cbc:InvoicedQuantity unitCode="C62">-1</cbc:InvoicedQuantity>`)
+	assert.Contains(t, document, `<cbc:LineExtensionAmount currencyID="EUR">-10.00</cbc:LineExtensionAmount>`)
+	assert.Contains(t, document, `<cbc:PriceAmount currencyID="EUR">10.00</cbc:PriceAmount>`, "prices cannot be negative")
+}
+
+func TestExporter_Export_BreaksBusinessRules(t *testing.T) {
+	lines := []model.InvoiceLine{newLine(t, "Monthly subscription", 10050, 2100)}
+	invoice := newInvoice(t, lines...)
+	buyer := newParty(t, "123456789", "Customer Inc.", model.Address{
+		Street: "Main St 1", PostalCode: "10001", Town: "New York", Province: "NY", Country: "US",
+	})
+
+	_, err := newExporter(t).Export(model.Document{Invoice: invoice, Lines: lines, Buyer: buyer})
+	assert.ErrorIs(t, err, ubl.ErrBusinessRules)
+	assert.ErrorContains(t, err, "PEPPOL-EN16931-R010", "the buyer cannot be addressed through Peppol")
+}
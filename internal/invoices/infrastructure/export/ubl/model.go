@@ -0,0 +1,183 @@
+package ubl
+
+import "encoding/xml"
+
+const (
+	InvoiceNamespace    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	CreditNoteNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	CACNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	CBCNamespace        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+
+	// CustomizationID and ProfileID identify the Peppol BIS Billing 3.0 specification and its billing process.
+	CustomizationID = "urn:cen.eu:en16931:2017#compliant#urn:fdc:peppol.eu:2017:poacc:billing:3.0"
+	ProfileID       = "urn:fdc:peppol.eu:2017:poacc:billing:01:1.0"
+
+	invoiceTypeCode    = "380" // Commercial invoice
+	creditNoteTypeCode = "381" // Credit note
+	vatScheme          = "VAT"
+	unitCode           = "C62" // One, the lines of an invoice are single units
+	dateLayout         = "2006-01-02"
+)
+
+// Document is either a UBL Invoice or a UBL CreditNote. Both share their structure, only the names of the
+// root, the type code, the lines and their quantity change, so the elements of the other kind are left empty.
+// Elements keep the order of the UBL 2.1 schema.
+type Document struct {
+	XMLName            xml.Name          `xml:""`
+	XMLNS              string            `xml:"xmlns,attr"`
+	XMLNSCac           string            `xml:"xmlns:cac,attr"`
+	XMLNSCbc           string            `xml:"xmlns:cbc,attr"`
+	CustomizationID    string            `xml:"cbc:CustomizationID"`
+	ProfileID          string            `xml:"cbc:ProfileID"`
+	ID                 string            `xml:"cbc:ID"`
+	IssueDate          string            `xml:"cbc:IssueDate"`
+	DueDate            string            `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode    string            `xml:"cbc:InvoiceTypeCode,omitempty"`
+	CreditNoteTypeCode string            `xml:"cbc:CreditNoteTypeCode,omitempty"`
+	Note               string            `xml:"cbc:Note,omitempty"`
+	DocumentCurrency   string            `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference     string            `xml:"cbc:BuyerReference"`
+	BillingReference   *BillingReference `xml:"cac:BillingReference,omitempty"`
+	Supplier           PartyRole         `xml:"cac:AccountingSupplierParty"`
+	Customer           PartyRole         `xml:"cac:AccountingCustomerParty"`
+	Delivery           *Delivery         `xml:"cac:Delivery,omitempty"`
+	TaxTotal           TaxTotal          `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal MonetaryTotal     `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines       []Line            `xml:"cac:InvoiceLine,omitempty"`
+	CreditNoteLines    []Line            `xml:"cac:CreditNoteLine,omitempty"`
+}
+
+// IsCreditNote reports whether the document is a UBL CreditNote.
+func (d Document) IsCreditNote() bool {
+	return d.XMLName.Local == "CreditNote"
+}
+
+// Lines returns the lines of the document, whatever its kind.
+func (d Document) Lines() []Line {
+	if d.IsCreditNote() {
+		return d.CreditNoteLines
+	}
+	return d.InvoiceLines
+}
+
+type BillingReference struct {
+	InvoiceDocumentReference DocumentReference `xml:"cac:InvoiceDocumentReference"`
+}
+
+type DocumentReference struct {
+	ID        string `xml:"cbc:ID"`
+	IssueDate string `xml:"cbc:IssueDate,omitempty"`
+}
+
+type PartyRole struct {
+	Party Party `xml:"cac:Party"`
+}
+
+type Party struct {
+	EndpointID     Identifier      `xml:"cbc:EndpointID"`
+	PostalAddress  Address         `xml:"cac:PostalAddress"`
+	PartyTaxScheme *PartyTaxScheme `xml:"cac:PartyTaxScheme,omitempty"`
+	LegalEntity    LegalEntity     `xml:"cac:PartyLegalEntity"`
+}
+
+type Identifier struct {
+	Value    string `xml:",chardata"`
+	SchemeID string `xml:"schemeID,attr,omitempty"`
+}
+
+type Address struct {
+	StreetName       string  `xml:"cbc:StreetName,omitempty"`
+	CityName         string  `xml:"cbc:CityName,omitempty"`
+	PostalZone       string  `xml:"cbc:PostalZone,omitempty"`
+	CountrySubentity string  `xml:"cbc:CountrySubentity,omitempty"`
+	Country          Country `xml:"cac:Country"`
+}
+
+type Country struct {
+	IdentificationCode string `xml:"cbc:IdentificationCode"`
+}
+
+type PartyTaxScheme struct {
+	CompanyID string    `xml:"cbc:CompanyID"`
+	TaxScheme TaxScheme `xml:"cac:TaxScheme"`
+}
+
+type TaxScheme struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type LegalEntity struct {
+	RegistrationName string `xml:"cbc:RegistrationName"`
+}
+
+type Delivery struct {
+	ActualDeliveryDate string            `xml:"cbc:ActualDeliveryDate,omitempty"`
+	DeliveryLocation   *DeliveryLocation `xml:"cac:DeliveryLocation,omitempty"`
+}
+
+type DeliveryLocation struct {
+	Address Address `xml:"cac:Address"`
+}
+
+type TaxTotal struct {
+	TaxAmount    Amount        `xml:"cbc:TaxAmount"`
+	TaxSubtotals []TaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type TaxSubtotal struct {
+	TaxableAmount Amount      `xml:"cbc:TaxableAmount"`
+	TaxAmount     Amount      `xml:"cbc:TaxAmount"`
+	TaxCategory   TaxCategory `xml:"cac:TaxCategory"`
+}
+
+// TaxCategory is a VAT category of UNCL5305 with its rate. Exempt categories carry the reason of the exemption.
+type TaxCategory struct {
+	ID                     string    `xml:"cbc:ID"`
+	Percent                string    `xml:"cbc:Percent"`
+	TaxExemptionReasonCode string    `xml:"cbc:TaxExemptionReasonCode,omitempty"`
+	TaxExemptionReason     string    `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme              TaxScheme `xml:"cac:TaxScheme"`
+}
+
+type MonetaryTotal struct {
+	LineExtensionAmount Amount `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  Amount `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  Amount `xml:"cbc:TaxInclusiveAmount"`
+	PayableAmount       Amount `xml:"cbc:PayableAmount"`
+}
+
+type Amount struct {
+	Value      string `xml:",chardata"`
+	CurrencyID string `xml:"currencyID,attr"`
+}
+
+type Line struct {
+	ID                  string    `xml:"cbc:ID"`
+	InvoicedQuantity    *Quantity `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *Quantity `xml:"cbc:CreditedQuantity,omitempty"`
+	LineExtensionAmount Amount    `xml:"cbc:LineExtensionAmount"`
+	Item                Item      `xml:"cac:Item"`
+	Price               Price     `xml:"cac:Price"`
+}
+
+// Quantity returns the invoiced or credited quantity of the line.
+func (l Line) Quantity() *Quantity {
+	if l.CreditedQuantity != nil {
+		return l.CreditedQuantity
+	}
+	return l.InvoicedQuantity
+}
+
+type Quantity struct {
+	Value    string `xml:",chardata"`
+	UnitCode string `xml:"unitCode,attr"`
+}
+
+type Item struct {
+	Name                  string      `xml:"cbc:Name"`
+	ClassifiedTaxCategory TaxCategory `xml:"cac:ClassifiedTaxCategory"`
+}
+
+type Price struct {
+	PriceAmount Amount `xml:"cbc:PriceAmount"`
+}
@@ -0,0 +1,236 @@
+package ubl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var ErrBusinessRules = errors.New("document breaks the EN 16931 / Peppol BIS 3.0 business rules")
+
+// Violation is a business rule a document breaks, identified as in the EN 16931 and Peppol BIS 3.0 specifications.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Rule + " " + v.Message
+}
+
+type Violations []Violation
+
+func (v Violations) String() string {
+	messages := make([]string, 0, len(v))
+	for _, violation := range v {
+		messages = append(messages, violation.String())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks the document against the mandatory business rules of EN 16931 and Peppol BIS Billing 3.0
+// that apply to the documents the exporter produces: mandatory elements, party identification, VAT
+// categories and the consistency of the totals with the lines and the VAT breakdown.
+func Validate(document Document) Violations {
+	v := &validator{currency: document.DocumentCurrency}
+	v.header(document)
+	v.party("seller", "BR-06", "BR-09", "PEPPOL-EN16931-R020", document.Supplier.Party)
+	v.party("buyer", "BR-07", "BR-11", "PEPPOL-EN16931-R010", document.Customer.Party)
+	lineTotal, lineBases := v.lines(document.Lines())
+	v.totals(document, lineTotal)
+	v.taxes(document, lineBases)
+	return v.violations
+}
+
+type validator struct {
+	currency   string
+	violations Violations
+}
+
+func (v *validator) fail(rule, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(rule, value, name string) {
+	if strings.TrimSpace(value) == "" {
+		v.fail(rule, "%s is mandatory", name)
+	}
+}
+
+// amount parses an amount, checking it is expressed in the currency of the document.
+func (v *validator) amount(a Amount, name string) int64 {
+	if a.CurrencyID != v.currency {
+		v.fail("PEPPOL-EN16931-R051", "%s is in %q instead of the document currency %q", name, a.CurrencyID, v.currency)
+	}
+	minor, err := money.ParseMinor(a.Value)
+	if err != nil {
+		v.fail("BR-DEC", "%s %q is not an amount with at most 2 decimals", name, a.Value)
+	}
+	return minor
+}
+
+func (v *validator) header(document Document) {
+	v.required("BR-01", document.CustomizationID, "Specification identifier (BT-24)")
+	v.required("PEPPOL-EN16931-R001", document.ProfileID, "Business process (BT-23)")
+	v.required("BR-02", document.ID, "Invoice number (BT-1)")
+	v.required("BR-03", document.IssueDate, "Invoice issue date (BT-2)")
+	v.required("BR-04", document.InvoiceTypeCode+document.CreditNoteTypeCode, "Invoice type code (BT-3)")
+	v.required("BR-05", document.DocumentCurrency, "Invoice currency code (BT-5)")
+	v.required("PEPPOL-EN16931-R003", document.BuyerReference, "Buyer reference (BT-10)")
+	if document.BillingReference != nil {
+		v.required("BR-55", document.BillingReference.InvoiceDocumentReference.ID, "Preceding invoice reference (BT-25)")
+	}
+}
+
+func (v *validator) party(role, nameRule, countryRule, endpointRule string, party Party) {
+	v.required(nameRule, party.LegalEntity.RegistrationName, role+" name")
+	v.required(countryRule, party.PostalAddress.Country.IdentificationCode, role+" country code")
+	if party.EndpointID.Value == "" || party.EndpointID.SchemeID == "" {
+		v.fail(endpointRule, "%s electronic address and its scheme are mandatory, no scheme is known for country %q",
+			role, party.PostalAddress.Country.IdentificationCode)
+	}
+	if party.PartyTaxScheme != nil && !hasCountryPrefix(party.PartyTaxScheme.CompanyID) {
+		v.fail("BR-CO-09", "%s VAT identifier %q must start with a country prefix", role, party.PartyTaxScheme.CompanyID)
+	}
+}
+
+// lines checks every line, returning the sum of their net amounts in total and per VAT category and rate.
+func (v *validator) lines(lines []Line) (int64, map[TaxCategory]int64) {
+	if len(lines) == 0 {
+		v.fail("BR-16", "an invoice shall have at least one line")
+	}
+
+	total := int64(0)
+	bases := map[TaxCategory]int64{}
+	for _, line := range lines {
+		name := fmt.Sprintf("line %s", line.ID)
+		v.required("BR-21", line.ID, "Invoice line identifier (BT-126)")
+		if quantity := line.Quantity(); quantity == nil || quantity.Value == "" {
+			v.fail("BR-22", "%s invoiced quantity (BT-129) is mandatory", name)
+		}
+		v.required("BR-25", line.Item.Name, name+" item name (BT-153)")
+		v.required("BR-CO-04", line.Item.ClassifiedTaxCategory.ID, name+" VAT category code (BT-151)")
+
+		net := v.amount(line.LineExtensionAmount, name+" net amount")
+		price := v.amount(line.Price.PriceAmount, name+" net price")
+		if price < 0 {
+			v.fail("BR-27", "%s net price %s cannot be negative", name, line.Price.PriceAmount.Value)
+		}
+		total += net
+		bases[categoryKey(line.Item.ClassifiedTaxCategory)] += net
+	}
+	return total, bases
+}
+
+func (v *validator) totals(document Document, lineTotal int64) {
+	totals := document.LegalMonetaryTotal
+	lineExtension := v.amount(totals.LineExtensionAmount, "Sum of invoice line net amount (BT-106)")
+	taxExclusive := v.amount(totals.TaxExclusiveAmount, "Invoice total amount without VAT (BT-109)")
+	taxInclusive := v.amount(totals.TaxInclusiveAmount, "Invoice total amount with VAT (BT-112)")
+	payable := v.amount(totals.PayableAmount, "Amount due for payment (BT-115)")
+	taxTotal := v.amount(document.TaxTotal.TaxAmount, "Invoice total VAT amount (BT-110)")
+
+	if lineExtension != lineTotal {
+		v.fail("BR-CO-10", "sum of invoice line net amount %s differs from the sum of the lines %s",
+			totals.LineExtensionAmount.Value, money.FormatMinor(lineTotal))
+	}
+	if taxExclusive != lineExtension {
+		v.fail("BR-CO-13", "invoice total amount without VAT %s differs from the sum of the lines %s",
+			totals.TaxExclusiveAmount.Value, totals.LineExtensionAmount.Value)
+	}
+	if taxInclusive != taxExclusive+taxTotal {
+		v.fail("BR-CO-15", "invoice total amount with VAT %s differs from %s plus %s of VAT",
+			totals.TaxInclusiveAmount.Value, totals.TaxExclusiveAmount.Value, document.TaxTotal.TaxAmount.Value)
+	}
+	if payable != taxInclusive {
+		v.fail("BR-CO-16", "amount due for payment %s differs from the total amount with VAT %s",
+			totals.PayableAmount.Value, totals.TaxInclusiveAmount.Value)
+	}
+}
+
+// taxes checks the VAT breakdown against the lines and the rules of each VAT category.
+func (v *validator) taxes(document Document, lineBases map[TaxCategory]int64) {
+	subtotals := document.TaxTotal.TaxSubtotals
+	if len(subtotals) == 0 {
+		v.fail("BR-CO-18", "an invoice shall have at least one VAT breakdown")
+	}
+
+	taxTotal := int64(0)
+	for _, subtotal := range subtotals {
+		category := subtotal.TaxCategory
+		name := fmt.Sprintf("VAT breakdown %s %s%%", category.ID, category.Percent)
+		base := v.amount(subtotal.TaxableAmount, name+" taxable amount")
+		tax := v.amount(subtotal.TaxAmount, name+" tax amount")
+		taxTotal += tax
+
+		percentage, err := money.ParsePercentage(category.Percent)
+		if err != nil {
+			v.fail(categoryRule(category.ID, "05"), "%s rate %q is not a percentage", name, category.Percent)
+			continue
+		}
+		if lineBase := lineBases[categoryKey(category)]; base != lineBase {
+			v.fail(categoryRule(category.ID, "08"), "%s taxable amount %s differs from the sum of its lines %s",
+				name, subtotal.TaxableAmount.Value, money.FormatMinor(lineBase))
+		}
+		if expected := money.New(base, "").ApplyPercentage(percentage).Minor(); tax != expected {
+			v.fail("BR-CO-17", "%s tax amount %s is not its taxable amount times its rate, %s",
+				name, subtotal.TaxAmount.Value, money.FormatMinor(expected))
+		}
+
+		switch category.ID {
+		case CategoryExempt, CategoryExport, CategoryIntraEU, CategoryZeroRated:
+			if percentage != 0 {
+				v.fail(categoryRule(category.ID, "05"), "%s rate must be 0", name)
+			}
+		}
+		switch category.ID {
+		case CategoryExempt, CategoryExport, CategoryIntraEU:
+			if category.TaxExemptionReason == "" && category.TaxExemptionReasonCode == "" {
+				v.fail(categoryRule(category.ID, "10"), "%s needs an exemption reason", name)
+			}
+		}
+		if category.ID == CategoryIntraEU {
+			v.intraCommunity(document)
+		}
+	}
+
+	// The total VAT amount itself was already checked with the totals
+	if total, _ := money.ParseMinor(document.TaxTotal.TaxAmount.Value); total != taxTotal {
+		v.fail("BR-CO-14", "invoice total VAT amount %s differs from the sum of the VAT breakdown %s",
+			document.TaxTotal.TaxAmount.Value, money.FormatMinor(taxTotal))
+	}
+}
+
+// intraCommunity checks the rules of intra-community supplies: both parties are identified for VAT,
+// and the delivery date and country are stated.
+func (v *validator) intraCommunity(document Document) {
+	if document.Supplier.Party.PartyTaxScheme == nil || document.Customer.Party.PartyTaxScheme == nil {
+		v.fail("BR-IC-02", "intra-community supplies need the VAT identifiers of the seller and the buyer")
+	}
+	if document.Delivery == nil || document.Delivery.ActualDeliveryDate == "" {
+		v.fail("BR-IC-11", "intra-community supplies need the actual delivery date (BT-72)")
+	}
+	if document.Delivery == nil || document.Delivery.DeliveryLocation == nil ||
+		document.Delivery.DeliveryLocation.Address.Country.IdentificationCode == "" {
+		v.fail("BR-IC-12", "intra-community supplies need the deliver to country code (BT-80)")
+	}
+}
+
+// categoryKey identifies a VAT category and rate, whatever the exemption reason written on it.
+func categoryKey(category TaxCategory) TaxCategory {
+	return TaxCategory{ID: category.ID, Percent: category.Percent}
+}
+
+// categoryRule names the rule of a VAT category, e.g. BR-IC-10 for intra-community supplies.
+func categoryRule(category, number string) string {
+	if category == CategoryIntraEU {
+		category = "IC"
+	}
+	return "BR-" + category + "-" + number
+}
+
+func hasCountryPrefix(vatID string) bool {
+	return len(vatID) > 2 && vatID[0] >= 'A' && vatID[0] <= 'Z' && vatID[1] >= 'A' && vatID[1] <= 'Z'
+}
@@ -0,0 +1,110 @@
+package ubl_test
+
+import (
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/export/ubl"
+	"github.com/stretchr/testify/assert"
+)
+
+func eur(value string) ubl.Amount {
+	return ubl.Amount{Value: value, CurrencyID: "EUR"}
+}
+
+func party(name, country, vatID, scheme string) ubl.PartyRole {
+	return ubl.PartyRole{Party: ubl.Party{
+		EndpointID:     ubl.Identifier{Value: vatID, SchemeID: scheme},
+		PostalAddress:  ubl.Address{Country: ubl.Country{IdentificationCode: country}},
+		PartyTaxScheme: &ubl.PartyTaxScheme{CompanyID: vatID, TaxScheme: ubl.TaxScheme{ID: "VAT"}},
+		LegalEntity:    ubl.LegalEntity{RegistrationName: name},
+	}}
+}
+
+func standardRated() ubl.TaxCategory {
+	return ubl.TaxCategory{ID: ubl.CategoryStandard, Percent: "21.00", TaxScheme: ubl.TaxScheme{ID: "VAT"}}
+}
+
+// validDocument is a compliant invoice of a single 100.00 EUR line at 21%.
+func validDocument() ubl.Document {
+	return ubl.Document{
+		XMLName:          ubl.Document{}.XMLName,
+		CustomizationID:  ubl.CustomizationID,
+		ProfileID:        ubl.ProfileID,
+		ID:               "FAC-2025-000001",
+		IssueDate:        "2025-03-14",
+		InvoiceTypeCode:  "380",
+		DocumentCurrency: "EUR",
+		BuyerReference:   "account_A",
+		Supplier:         party("Billing MCP S.L.", "ES", "ESB12345674", "9920"),
+		Customer:         party("Kunde GmbH", "DE", "DE123456789", "9930"),
+		TaxTotal: ubl.TaxTotal{
+			TaxAmount: eur("21.00"),
+			TaxSubtotals: []ubl.TaxSubtotal{
+				{TaxableAmount: eur("100.00"), TaxAmount: eur("21.00"), TaxCategory: standardRated()},
+			},
+		},
+		LegalMonetaryTotal: ubl.MonetaryTotal{
+			LineExtensionAmount: eur("100.00"),
+			TaxExclusiveAmount:  eur("100.00"),
+			TaxInclusiveAmount:  eur("121.00"),
+			PayableAmount:       eur("121.00"),
+		},
+		InvoiceLines: []ubl.Line{{
+			ID:                  "1",
+			InvoicedQuantity:    &ubl.Quantity{Value: "1", UnitCode: "C62"},
+			LineExtensionAmount: eur("100.00"),
+			Item:                ubl.Item{Name: "Monthly subscription", ClassifiedTaxCategory: standardRated()},
+			Price:               ubl.Price{PriceAmount: eur("100.00")},
+		}},
+	}
+}
+
+func rules(violations ubl.Violations) []string {
+	var rules []string
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	return rules
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, ubl.Validate(validDocument()))
+
+	tests := []struct {
+		name     string
+		breakIt  func(*ubl.Document)
+		expected []string
+	}{
+		{"missing number", func(d *ubl.Document) { d.ID = "" }, []string{"BR-02"}},
+		{"missing buyer reference", func(d *ubl.Document) { d.BuyerReference = "" }, []string{"PEPPOL-EN16931-R003"}},
+		{"missing seller name", func(d *ubl.Document) { d.Supplier.Party.LegalEntity.RegistrationName = "" }, []string{"BR-06"}},
+		{"buyer without endpoint", func(d *ubl.Document) { d.Customer.Party.EndpointID.SchemeID = "" }, []string{"PEPPOL-EN16931-R010"}},
+		{"VAT identifier without prefix", func(d *ubl.Document) { d.Supplier.Party.PartyTaxScheme.CompanyID = "B12345674" }, []string{"BR-CO-09"}},
+		{"no lines", func(d *ubl.Document) { d.InvoiceLines = nil }, []string{"BR-16", "BR-CO-10", "BR-S-08"}},
+		{"negative price", func(d *ubl.Document) { d.InvoiceLines[0].Price.PriceAmount = eur("-100.00") }, []string{"BR-27"}},
+		{"wrong total with VAT", func(d *ubl.Document) { d.LegalMonetaryTotal.TaxInclusiveAmount = eur("120.00") }, []string{"BR-CO-15", "BR-CO-16"}},
+		{"wrong quota", func(d *ubl.Document) { d.TaxTotal.TaxSubtotals[0].TaxAmount = eur("20.00") }, []string{"BR-CO-17", "BR-CO-14"}},
+		{"other currency", func(d *ubl.Document) { d.LegalMonetaryTotal.PayableAmount.CurrencyID = "USD" }, []string{"PEPPOL-EN16931-R051"}},
+		{
+			"exempt without reason",
+			func(d *ubl.Document) {
+				exempt := ubl.TaxCategory{ID: ubl.CategoryIntraEU, Percent: "0.00", TaxScheme: ubl.TaxScheme{ID: "VAT"}}
+				d.InvoiceLines[0].Item.ClassifiedTaxCategory = exempt
+				d.TaxTotal = ubl.TaxTotal{TaxAmount: eur("0.00"), TaxSubtotals: []ubl.TaxSubtotal{
+					{TaxableAmount: eur("100.00"), TaxAmount: eur("0.00"), TaxCategory: exempt},
+				}}
+				d.LegalMonetaryTotal.TaxInclusiveAmount = eur("100.00")
+				d.LegalMonetaryTotal.PayableAmount = eur("100.00")
+			},
+			[]string{"BR-IC-10", "BR-IC-11", "BR-IC-12"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := validDocument()
+			tt.breakIt(&document)
+			assert.ElementsMatch(t, tt.expected, rules(ubl.Validate(document)))
+		})
+	}
+}
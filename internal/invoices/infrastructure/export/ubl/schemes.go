@@ -0,0 +1,10 @@
+package ubl
+
+// endpointSchemes are the Peppol electronic address schemes (EAS) of the VAT identifiers of each country.
+// Parties are addressed by their VAT identifier, so parties from countries without one cannot be addressed.
+var endpointSchemes = map[string]string{
+	"AT": "9914", "BE": "9925", "BG": "9926", "CY": "9928", "CZ": "9929", "DE": "9930", "EE": "9931",
+	"ES": "9920", "FR": "9957", "GB": "9932", "GR": "9933", "HR": "9934", "HU": "9910", "IE": "9935",
+	"IT": "0211", "LU": "9938", "LV": "9939", "MT": "9943", "NL": "9944", "PL": "9945", "PT": "9946",
+	"RO": "9947", "SI": "9949", "SK": "9950",
+}
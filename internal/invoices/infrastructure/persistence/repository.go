@@ -2,11 +2,18 @@ package persistence
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/infrastructure/persistence/sql"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 type Repository struct {
@@ -30,6 +37,10 @@ func (r Repository) GetInvoiceByID(id domain.InvoiceID) (invoice domain.Invoice,
 	// If it can take domain.InvoiceID directly, this conversion is not needed.
 	invoiceSqlModel, err := r.invoiceSqlClient.GetInvoiceByID(id.String())
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = fmt.Errorf("%w: %s", domain.ErrInvoiceNotFound, id)
+			return
+		}
 		r.logger.Error().Err(err).Msg("Failed to fetch invoice by ID")
 		return
 	}
@@ -46,6 +57,19 @@ func (r Repository) GetInvoiceByID(id domain.InvoiceID) (invoice domain.Invoice,
 	return
 }
 
+// LockInvoice retrieves an invoice and locks it until the transaction carried by the context ends
+func (r Repository) LockInvoice(ctx context.Context, id domain.InvoiceID) (domain.Invoice, error) {
+	sqlInvoice, err := r.invoiceSqlClient.LockInvoice(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Invoice{}, fmt.Errorf("%w: %s", domain.ErrInvoiceNotFound, id)
+		}
+		r.logger.Error().Err(err).Str("id", id.String()).Msg("Failed to lock invoice")
+		return domain.Invoice{}, err
+	}
+	return r.converter.ConvertInvoiceToDomain(sqlInvoice)
+}
+
 func (r Repository) GetInvoicesByAccountId(accountId string, criteria domain.Criteria) (invoices domain.Invoices, err error) { // Renamed from GetInvoicesByAccount
 	r.logger.Info().Str("account_id", accountId).Interface("criteria", criteria).Msg("Fetching invoices by criteria")
 
@@ -65,6 +89,26 @@ func (r Repository) GetInvoicesByAccountId(accountId string, criteria domain.Cri
 	return
 }
 
+// GetInvoicesPageByAccountId retrieves a page of the invoices of an account matching the criteria
+func (r Repository) GetInvoicesPageByAccountId(accountId string, criteria domain.Criteria, page pagination.Request) (pagination.Page[domain.Invoice], error) {
+	r.logger.Info().Str("account_id", accountId).Interface("criteria", criteria).Int("page_size", page.Size).Msg("Fetching page of invoices by criteria")
+
+	sqlPage, err := r.invoiceSqlClient.GetInvoicesPageByAccountId(accountId, r.converter.ConvertCriteriaToSql(criteria), page)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to fetch page of invoices by criteria")
+		return pagination.Page[domain.Invoice]{}, err
+	}
+
+	invoices, err := r.converter.ConvertInvoicesToDomain(sqlPage.Items)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to convert invoices to domain model")
+		return pagination.Page[domain.Invoice]{}, err
+	}
+
+	r.logger.Info().Int("count", len(invoices)).Msg("Fetched page of invoices by criteria")
+	return pagination.Page[domain.Invoice]{Items: invoices, NextCursor: sqlPage.NextCursor}, nil
+}
+
 // GetInvoiceLines retrieves all invoice lines (movements) for a specific invoice
 func (r Repository) GetInvoiceLines(ctx context.Context, id domain.InvoiceID) ([]domain.InvoiceLine, error) {
 	r.logger.Info().Str("invoice_id", id.String()).Msg("Fetching invoice lines")
@@ -79,9 +123,185 @@ func (r Repository) GetInvoiceLines(ctx context.Context, id domain.InvoiceID) ([
 	// Convert SQL models to domain models
 	lines := make([]domain.InvoiceLine, len(sqlLines))
 	for i, sqlLine := range sqlLines {
-		lines[i] = r.converter.SQLLineToInvoiceLine(sqlLine)
+		if lines[i], err = r.converter.SQLLineToInvoiceLine(sqlLine); err != nil {
+			r.logger.Error().Err(err).Str("movement_id", sqlLine.MovementID.String()).Msg("Failed to convert invoice line")
+			return nil, err
+		}
 	}
 
 	r.logger.Info().Str("invoice_id", id.String()).Int("count", len(lines)).Msg("Successfully fetched invoice lines")
 	return lines, nil
 }
+
+// GetInvoiceLinesPage retrieves a page of the invoice lines (movements) of a specific invoice
+func (r Repository) GetInvoiceLinesPage(ctx context.Context, id domain.InvoiceID, page pagination.Request) (pagination.Page[domain.InvoiceLine], error) {
+	r.logger.Info().Str("invoice_id", id.String()).Int("page_size", page.Size).Msg("Fetching page of invoice lines")
+
+	sqlPage, err := r.invoiceSqlClient.GetInvoiceLinesPageByInvoiceID(ctx, id.String(), page)
+	if err != nil {
+		r.logger.Error().Err(err).Str("invoice_id", id.String()).Msg("Failed to fetch page of invoice lines")
+		return pagination.Page[domain.InvoiceLine]{}, err
+	}
+
+	lines := make([]domain.InvoiceLine, len(sqlPage.Items))
+	for i, sqlLine := range sqlPage.Items {
+		if lines[i], err = r.converter.SQLLineToInvoiceLine(sqlLine); err != nil {
+			r.logger.Error().Err(err).Str("movement_id", sqlLine.MovementID.String()).Msg("Failed to convert invoice line")
+			return pagination.Page[domain.InvoiceLine]{}, err
+		}
+	}
+
+	r.logger.Info().Str("invoice_id", id.String()).Int("count", len(lines)).Msg("Successfully fetched page of invoice lines")
+	return pagination.Page[domain.InvoiceLine]{Items: lines, NextCursor: sqlPage.NextCursor}, nil
+}
+
+func (r Repository) CreateInvoice(ctx context.Context, invoice domain.Invoice) error {
+	r.logger.Info().Str("id", invoice.ID.String()).Msg("Creating invoice")
+
+	if err := r.invoiceSqlClient.CreateInvoice(ctx, r.converter.ConvertInvoiceToSql(invoice)); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to create invoice")
+		return err
+	}
+	return nil
+}
+
+// ChangeInvoiceStatus stores the new status of the invoice and records the change in its history
+func (r Repository) ChangeInvoiceStatus(ctx context.Context, invoice domain.Invoice, change domain.StatusChange) error {
+	r.logger.Info().Str("id", invoice.ID.String()).Str("from", string(change.From)).Str("to", string(change.To)).Msg("Changing invoice status")
+
+	sqlInvoice := r.converter.ConvertInvoiceToSql(invoice)
+	if err := r.invoiceSqlClient.UpdateInvoiceStatus(ctx, sqlInvoice, r.converter.StatusChangeToSQL(change)); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to change invoice status")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrStatusChangedConcurrently
+		}
+		return err
+	}
+	return nil
+}
+
+// GetPastDueInvoices retrieves the sent invoices whose due date is before the given time
+func (r Repository) GetPastDueInvoices(ctx context.Context, before time.Time) (domain.Invoices, error) {
+	r.logger.Info().Time("before", before).Msg("Fetching past due invoices")
+
+	sqlInvoices, err := r.invoiceSqlClient.GetInvoicesDueBefore(ctx, string(domain.InvoiceStatusSent), before)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to fetch past due invoices")
+		return nil, err
+	}
+
+	invoices, err := r.converter.ConvertInvoicesToDomain(sqlInvoices)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to convert invoices to domain model")
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// GetInvoiceStatusHistory retrieves the status changes of an invoice, oldest first
+func (r Repository) GetInvoiceStatusHistory(ctx context.Context, id domain.InvoiceID) ([]domain.StatusChange, error) {
+	r.logger.Info().Str("invoice_id", id.String()).Msg("Fetching invoice status history")
+
+	sqlHistory, err := r.invoiceSqlClient.GetInvoiceStatusHistory(ctx, id.String())
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to fetch invoice status history")
+		return nil, err
+	}
+
+	history := make([]domain.StatusChange, len(sqlHistory))
+	for i, sqlChange := range sqlHistory {
+		if history[i], err = r.converter.StatusChangeToDomain(sqlChange); err != nil {
+			r.logger.Error().Err(err).Msg("Failed to convert invoice status change")
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+// AddInvoiceLine stores a new line together with the invoice totals it changed
+func (r Repository) AddInvoiceLine(ctx context.Context, invoice domain.Invoice, line domain.InvoiceLine) error {
+	r.logger.Info().Str("invoice_id", invoice.ID.String()).Str("movement_id", line.MovementID.String()).Msg("Adding invoice line")
+
+	sqlInvoice := r.converter.ConvertInvoiceToSql(invoice)
+	sqlLine := r.converter.InvoiceLineToSQL(invoice, line)
+	if err := r.invoiceSqlClient.CreateInvoiceLine(ctx, sqlInvoice, sqlLine); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to add invoice line")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrInvoiceNotEditable
+		}
+		return err
+	}
+	return nil
+}
+
+// GetExchangeRate retrieves the rate between two currencies that applied on the given date
+func (r Repository) GetExchangeRate(ctx context.Context, from, to money.Currency, date time.Time) (money.ExchangeRate, error) {
+	r.logger.Info().Str("from", from.String()).Str("to", to.String()).Time("date", date).Msg("Fetching exchange rate")
+
+	sqlRate, err := r.invoiceSqlClient.GetExchangeRate(ctx, from.String(), to.String(), date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return money.ExchangeRate{}, domain.ErrExchangeRateNotFound
+		}
+		r.logger.Error().Err(err).Msg("Failed to fetch exchange rate")
+		return money.ExchangeRate{}, err
+	}
+
+	return r.converter.ExchangeRateToDomain(sqlRate)
+}
+
+// NextSequenceNumber hands out the next number of a numbering series for a period
+func (r Repository) NextSequenceNumber(ctx context.Context, series string, period int) (int64, error) {
+	return r.invoiceSqlClient.NextSequenceNumber(ctx, series, period)
+}
+
+// GetCreditedAmounts returns the base amount already credited on each line of the invoice
+func (r Repository) GetCreditedAmounts(ctx context.Context, invoice domain.Invoice) (map[uuid.UUID]money.Money, error) {
+	sqlAmounts, err := r.invoiceSqlClient.GetCreditedAmounts(ctx, invoice.ID.String())
+	if err != nil {
+		r.logger.Error().Err(err).Str("invoice_id", invoice.ID.String()).Msg("Failed to fetch credited amounts")
+		return nil, err
+	}
+
+	amounts := make(map[uuid.UUID]money.Money, len(sqlAmounts))
+	for _, amount := range sqlAmounts {
+		amounts[amount.CorrectedMovementID] = money.New(int64(amount.AmountWithoutTax), invoice.Currency)
+	}
+	return amounts, nil
+}
+
+// GetOutstandingAmount returns what is left to collect of the invoice
+func (r Repository) GetOutstandingAmount(ctx context.Context, invoice domain.Invoice) (money.Money, error) {
+	outstanding, err := r.invoiceSqlClient.GetOutstandingAmount(ctx, invoice.ID.String())
+	if err != nil {
+		r.logger.Error().Err(err).Str("invoice_id", invoice.ID.String()).Msg("Failed to fetch outstanding amount")
+		return money.Money{}, err
+	}
+	return money.New(int64(outstanding), invoice.Currency), nil
+}
+
+// GetAgingTotals sums the outstanding amount of the receivable invoices of the query by account, status and aging bucket
+func (r Repository) GetAgingTotals(ctx context.Context, query domain.AgingQuery) ([]domain.AgingTotal, error) {
+	buckets := make([]sql.AgingBucket, len(domain.AgingBuckets))
+	for i, limit := range domain.AgingBuckets {
+		buckets[i] = sql.AgingBucket{Name: string(limit.Bucket), MaxDaysPastDue: limit.MaxDaysPastDue}
+	}
+
+	sqlTotals, err := r.invoiceSqlClient.GetAgingTotals(ctx, query.AccountID, query.Currency.String(), query.AsOf, buckets)
+	if err != nil {
+		r.logger.Error().Err(err).Str("account_id", query.AccountID).Msg("Failed to fetch aging totals")
+		return nil, err
+	}
+
+	totals := make([]domain.AgingTotal, len(sqlTotals))
+	for i, total := range sqlTotals {
+		totals[i] = domain.AgingTotal{
+			AccountID:   total.AccountID,
+			Status:      domain.InvoiceStatus(total.Status),
+			Bucket:      domain.AgingBucket(total.Bucket),
+			Invoices:    total.Invoices,
+			Outstanding: money.New(int64(total.Outstanding), query.Currency),
+		}
+	}
+	return totals, nil
+}
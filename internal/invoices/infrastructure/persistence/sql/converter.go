@@ -1,9 +1,19 @@
 package sql
 
 import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
 )
 
+// invoicedMovementStatus is the movement status of a row that is already attached to an invoice.
+const invoicedMovementStatus = "INVOICED"
+
 type InvoiceSqlConverter struct {
 }
 
@@ -17,17 +27,72 @@ func (c InvoiceSqlConverter) ConvertInvoiceToDomain(invoice Invoice) (model.Invo
 		return model.Invoice{}, err
 	}
 
-	return model.Invoice{
+	currency := currencyOrDefault(invoice.Currency)
+	domainInvoice := model.Invoice{
 		ID:                    model.InvoiceID(invoice.ID),
 		AccountID:             invoice.AccountID,
 		IssueDate:             invoice.IssueDate,
 		DueDate:               invoice.DueDate,
-		TaxAmount:             invoice.TaxAmount,
-		TotalAmountWithoutTax: invoice.TotalAmountWithoutTax,
-		TotalAmountWithTax:    invoice.TotalAmountWithTax,
+		TaxAmount:             money.New(int64(invoice.TaxAmount), currency),
+		TotalAmountWithoutTax: money.New(int64(invoice.TotalAmountWithoutTax), currency),
+		TotalAmountWithTax:    money.New(int64(invoice.TotalAmountWithTax), currency),
 		Status:                domainStatus,
-		InvoiceNumber:         invoice.InvoiceNumber,
-	}, nil
+		Currency:              currency,
+		Type:                  model.InvoiceType(invoice.InvoiceType),
+		CorrectionReason:      invoice.CorrectionReason,
+		CustomerLocation:      taxes.Location{Country: invoice.CustomerCountry, PostalCode: invoice.CustomerPostalCode},
+		TaxBreakdown:          taxBreakdownToDomain(invoice.TaxBreakdown.Data, currency),
+	}
+	if domainInvoice.CustomerLocation.Country == "" {
+		domainInvoice.CustomerLocation = taxes.DefaultLocation
+	}
+	if domainInvoice.Type == "" {
+		domainInvoice.Type = model.InvoiceTypeStandard
+	}
+	if invoice.InvoiceNumber != nil {
+		domainInvoice.InvoiceNumber = *invoice.InvoiceNumber
+	}
+	if invoice.CorrectedInvoiceID != nil {
+		domainInvoice.CorrectedInvoiceID = model.InvoiceID(*invoice.CorrectedInvoiceID)
+	}
+	return domainInvoice, nil
+}
+
+func (c InvoiceSqlConverter) ConvertInvoiceToSql(invoice model.Invoice) Invoice {
+	sqlInvoice := Invoice{
+		BaseModel: commons.BaseModel{
+			ID: uuid.UUID(invoice.ID),
+		},
+		AccountID:             invoice.AccountID,
+		IssueDate:             invoice.IssueDate,
+		DueDate:               invoice.DueDate,
+		TaxAmount:             commons.Decimal(invoice.TaxAmount.Minor()),
+		TotalAmountWithoutTax: commons.Decimal(invoice.TotalAmountWithoutTax.Minor()),
+		TotalAmountWithTax:    commons.Decimal(invoice.TotalAmountWithTax.Minor()),
+		Status:                string(invoice.Status),
+		Currency:              invoice.Currency.String(),
+		InvoiceType:           string(invoice.Type),
+		CorrectionReason:      invoice.CorrectionReason,
+		CustomerCountry:       invoice.CustomerLocation.Country,
+		CustomerPostalCode:    invoice.CustomerLocation.PostalCode,
+		TaxBreakdown:          commons.JSON[[]TaxSummary]{Data: taxBreakdownToSQL(invoice.TaxBreakdown)},
+	}
+	if sqlInvoice.CustomerCountry == "" {
+		sqlInvoice.CustomerCountry = taxes.DefaultLocation.Country
+	}
+	if sqlInvoice.InvoiceType == "" {
+		sqlInvoice.InvoiceType = string(model.InvoiceTypeStandard)
+	}
+	// Drafts are not numbered yet
+	if invoice.InvoiceNumber != "" {
+		invoiceNumber := invoice.InvoiceNumber
+		sqlInvoice.InvoiceNumber = &invoiceNumber
+	}
+	if !invoice.CorrectedInvoiceID.IsNil() {
+		correctedID := uuid.UUID(invoice.CorrectedInvoiceID)
+		sqlInvoice.CorrectedInvoiceID = &correctedID
+	}
+	return sqlInvoice
 }
 
 func (c InvoiceSqlConverter) ConvertInvoicesToDomain(invoices []Invoice) ([]model.Invoice, error) {
@@ -45,8 +110,12 @@ func (c InvoiceSqlConverter) ConvertInvoicesToDomain(invoices []Invoice) ([]mode
 func (c InvoiceSqlConverter) ConvertCriteriaToSql(criteria model.Criteria) map[string]interface{} {
 	sqlCriteria := make(map[string]interface{})
 
-	if criteria.Status != "" {
-		sqlCriteria["status"] = criteria.Status
+	if len(criteria.Statuses) > 0 {
+		statuses := make([]string, len(criteria.Statuses))
+		for i, status := range criteria.Statuses {
+			statuses[i] = string(status)
+		}
+		sqlCriteria["statuses"] = statuses
 	}
 
 	if !criteria.IssueDateFrom.IsZero() {
@@ -57,17 +126,186 @@ func (c InvoiceSqlConverter) ConvertCriteriaToSql(criteria model.Criteria) map[s
 		sqlCriteria["issue_date_to"] = criteria.IssueDateTo
 	}
 
+	if !criteria.DueDateFrom.IsZero() {
+		sqlCriteria["due_date_from"] = criteria.DueDateFrom
+	}
+
+	if !criteria.DueDateTo.IsZero() {
+		sqlCriteria["due_date_to"] = criteria.DueDateTo
+	}
+
+	if criteria.TotalFrom != nil {
+		sqlCriteria["currency"] = criteria.TotalFrom.Currency().String()
+		sqlCriteria["total_from"] = commons.Decimal(criteria.TotalFrom.Minor())
+	}
+
+	if criteria.TotalTo != nil {
+		sqlCriteria["currency"] = criteria.TotalTo.Currency().String()
+		sqlCriteria["total_to"] = commons.Decimal(criteria.TotalTo.Minor())
+	}
+
+	if criteria.NumberPrefix != "" {
+		sqlCriteria["number_pattern"] = escapeLike(criteria.NumberPrefix) + "%"
+	}
+
+	if criteria.LineText != "" {
+		sqlCriteria["line_pattern"] = "%" + escapeLike(criteria.LineText) + "%"
+	}
+
+	if criteria.HasOverdueBalance {
+		sqlCriteria["overdue_before"] = time.Now()
+	}
+
 	return sqlCriteria
 }
 
+// escapeLike escapes the wildcards of a LIKE pattern, so the text is matched as it is
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
+
 // SQLLineToInvoiceLine converts a SQL model InvoiceLine to a domain InvoiceLine
-func (c InvoiceSqlConverter) SQLLineToInvoiceLine(line InvoiceLine) model.InvoiceLine {
-	return model.InvoiceLine{
+func (c InvoiceSqlConverter) SQLLineToInvoiceLine(line InvoiceLine) (model.InvoiceLine, error) {
+	invoiceCurrency := currencyOrDefault(line.InvoiceCurrency)
+	originalCurrency := currencyOrDefault(line.Currency)
+
+	rate := money.IdentityRate(invoiceCurrency)
+	if line.ExchangeRate != nil {
+		var err error
+		if rate, err = money.NewExchangeRate(originalCurrency, invoiceCurrency, *line.ExchangeRate); err != nil {
+			return model.InvoiceLine{}, err
+		}
+	}
+
+	domainLine := model.InvoiceLine{
 		MovementID:       line.MovementID,
 		Description:      line.Description,
-		AmountWithoutTax: line.AmountWithoutTax,
-		AmountWithTax:    line.AmountWithTax,
-		TaxPercentage:    line.TaxPercentage,
+		AmountWithoutTax: money.New(int64(line.AmountWithoutTax), invoiceCurrency),
+		AmountWithTax:    money.New(int64(line.AmountWithTax), invoiceCurrency),
+		TaxPercentage:    money.Percentage(line.TaxPercentage),
+		TaxRegime:        taxes.Regime(line.TaxRegime),
+		ExemptionCause:   taxes.ExemptionCause(line.TaxExemptionCause),
+		ProductCategory:  taxes.Category(line.ProductCategory),
 		OperationType:    line.OperationType,
+		TransactionDate:  line.TransactionDate,
+		OriginalAmount:   money.New(int64(line.Amount), originalCurrency),
+		ExchangeRate:     rate,
+	}
+	if domainLine.TaxRegime == "" {
+		domainLine.TaxRegime = taxes.RegimeIVA
+	}
+	if line.CorrectedMovementID != nil {
+		domainLine.CorrectedMovementID = *line.CorrectedMovementID
+	}
+	return domainLine, nil
+}
+
+// InvoiceLineToSQL converts a domain InvoiceLine to the movement row that backs it
+func (c InvoiceSqlConverter) InvoiceLineToSQL(invoice model.Invoice, line model.InvoiceLine) InvoiceLine {
+	sqlLine := InvoiceLine{
+		MovementID:        line.MovementID,
+		AccountID:         invoice.AccountID,
+		InvoiceID:         uuid.UUID(invoice.ID),
+		Description:       line.Description,
+		AmountWithoutTax:  commons.Decimal(line.AmountWithoutTax.Minor()),
+		AmountWithTax:     commons.Decimal(line.AmountWithTax.Minor()),
+		TaxPercentage:     commons.Decimal(line.TaxPercentage),
+		OperationType:     line.OperationType,
+		TaxRegime:         string(line.TaxRegime),
+		TaxExemptionCause: string(line.ExemptionCause),
+		ProductCategory:   string(line.ProductCategory),
+		Amount:            commons.Decimal(line.OriginalAmount.Minor()),
+		MovementType:      line.OperationType,
+		TransactionDate:   line.TransactionDate,
+		Status:            invoicedMovementStatus,
+		Currency:          line.OriginalAmount.Currency().String(),
+		ExchangeRate:      exchangeRateToSQL(line.ExchangeRate),
+	}
+	if line.CorrectedMovementID != uuid.Nil {
+		correctedID := line.CorrectedMovementID
+		sqlLine.CorrectedMovementID = &correctedID
+	}
+	return sqlLine
+}
+
+// StatusChangeToSQL converts a domain StatusChange to its history row
+func (c InvoiceSqlConverter) StatusChangeToSQL(change model.StatusChange) InvoiceStatusChange {
+	return InvoiceStatusChange{
+		ID:         change.ID,
+		InvoiceID:  uuid.UUID(change.InvoiceID),
+		FromStatus: string(change.From),
+		ToStatus:   string(change.To),
+		ChangedAt:  change.ChangedAt,
+	}
+}
+
+// StatusChangeToDomain converts a history row to a domain StatusChange
+func (c InvoiceSqlConverter) StatusChangeToDomain(change InvoiceStatusChange) (model.StatusChange, error) {
+	from, err := model.GetStatusFromString(change.FromStatus)
+	if err != nil {
+		return model.StatusChange{}, err
+	}
+	to, err := model.GetStatusFromString(change.ToStatus)
+	if err != nil {
+		return model.StatusChange{}, err
+	}
+	return model.StatusChange{
+		ID:        change.ID,
+		InvoiceID: model.InvoiceID(change.InvoiceID),
+		From:      from,
+		To:        to,
+		ChangedAt: change.ChangedAt,
+	}, nil
+}
+
+// ExchangeRateToDomain converts a SQL ExchangeRate to a money.ExchangeRate
+func (c InvoiceSqlConverter) ExchangeRateToDomain(rate ExchangeRate) (money.ExchangeRate, error) {
+	return money.NewExchangeRate(money.Currency(rate.BaseCurrency), money.Currency(rate.QuoteCurrency), rate.Rate)
+}
+
+// taxBreakdownToDomain reads the tax breakdown stored with an invoice, whose amounts are in the invoice currency
+func taxBreakdownToDomain(summaries []TaxSummary, currency money.Currency) model.TaxBreakdown {
+	breakdown := make(model.TaxBreakdown, len(summaries))
+	for i, summary := range summaries {
+		breakdown[i] = model.TaxSummary{
+			Regime:         taxes.Regime(summary.Regime),
+			Percentage:     money.Percentage(summary.Percentage),
+			ExemptionCause: taxes.ExemptionCause(summary.ExemptionCause),
+			TaxableBase:    money.New(int64(summary.TaxableBase), currency),
+			TaxAmount:      money.New(int64(summary.TaxAmount), currency),
+		}
+	}
+	return breakdown
+}
+
+// taxBreakdownToSQL converts the tax breakdown of an invoice into the JSON stored with it
+func taxBreakdownToSQL(breakdown model.TaxBreakdown) []TaxSummary {
+	summaries := make([]TaxSummary, len(breakdown))
+	for i, summary := range breakdown {
+		summaries[i] = TaxSummary{
+			Regime:         string(summary.Regime),
+			Percentage:     commons.Decimal(summary.Percentage),
+			ExemptionCause: string(summary.ExemptionCause),
+			TaxableBase:    commons.Decimal(summary.TaxableBase.Minor()),
+			TaxAmount:      commons.Decimal(summary.TaxAmount.Minor()),
+		}
+	}
+	return summaries
+}
+
+// exchangeRateToSQL only stores rates that actually converted the line into another currency
+func exchangeRateToSQL(rate money.ExchangeRate) *string {
+	if rate.IsZero() || rate.From == rate.To {
+		return nil
+	}
+	value := rate.String()
+	return &value
+}
+
+// currencyOrDefault reads currencies stored before the currency columns existed
+func currencyOrDefault(currency string) money.Currency {
+	if currency == "" {
+		return money.DefaultCurrency
 	}
+	return money.Currency(currency)
 }
@@ -14,11 +14,27 @@ type Invoice struct {
 	AccountID             string `gorm:"index"`
 	IssueDate             time.Time
 	DueDate               time.Time
-	TaxAmount             float64
-	TotalAmountWithoutTax float64
-	TotalAmountWithTax    float64
-	Status                string // e.g., "Draft", "Sent", "Paid", "Void"
-	InvoiceNumber         string `gorm:"index;unique"`
+	TaxAmount             commons.Decimal            `gorm:"type:decimal(12,2)"`
+	TotalAmountWithoutTax commons.Decimal            `gorm:"type:decimal(12,2)"`
+	TotalAmountWithTax    commons.Decimal            `gorm:"type:decimal(12,2)"`
+	Status                string                     // e.g., "Draft", "Sent", "Paid", "Void"
+	InvoiceNumber         *string                    `gorm:"index;unique"`
+	Currency              string                     `gorm:"type:char(3);not null;default:EUR"`
+	InvoiceType           string                     `gorm:"type:varchar(20);not null;default:STANDARD"`
+	CorrectedInvoiceID    *uuid.UUID                 `gorm:"type:uuid"`
+	CorrectionReason      string                     `gorm:"type:text"`
+	CustomerCountry       string                     `gorm:"type:char(2);not null;default:ES"`
+	CustomerPostalCode    string                     `gorm:"type:varchar(10)"`
+	TaxBreakdown          commons.JSON[[]TaxSummary] `gorm:"type:jsonb;not null;default:'[]'"`
+}
+
+// TaxSummary is an entry of the tax breakdown stored as JSON with the invoice.
+type TaxSummary struct {
+	Regime         string          `json:"regime"`
+	Percentage     commons.Decimal `json:"percentage"`
+	ExemptionCause string          `json:"exemption_cause,omitempty"`
+	TaxableBase    commons.Decimal `json:"taxable_base"`
+	TaxAmount      commons.Decimal `json:"tax_amount"`
 }
 
 // TableName specifies the table name for DBInvoice in the database.
@@ -28,16 +44,94 @@ func (Invoice) TableName() string {
 
 // InvoiceLine represents a line item in an invoice, which corresponds to a movement
 type InvoiceLine struct {
-	MovementID       uuid.UUID `gorm:"type:uuid;primaryKey"`
-	InvoiceID        uuid.UUID `gorm:"type:uuid;not null;index"`
-	Description      string    `gorm:"type:text"`
-	AmountWithoutTax float64   `gorm:"type:decimal(10,2);not null"`
-	AmountWithTax    float64   `gorm:"type:decimal(10,2);not null"`
-	TaxPercentage    float64   `gorm:"type:decimal(5,2);not null"`
-	OperationType    string    `gorm:"type:varchar(50);not null"` // "CREDIT" or "DEBIT"
+	MovementID       uuid.UUID       `gorm:"column:id;type:uuid;primaryKey"`
+	AccountID        string          `gorm:"type:varchar(255)"`
+	InvoiceID        uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Description      string          `gorm:"type:text"`
+	AmountWithoutTax commons.Decimal `gorm:"type:decimal(12,2);not null"`
+	AmountWithTax    commons.Decimal `gorm:"type:decimal(12,2);not null"`
+	TaxPercentage    commons.Decimal `gorm:"type:decimal(5,2);not null"`
+	OperationType    string          `gorm:"type:varchar(50);not null"` // "CREDIT" or "DEBIT"
+	// TaxRegime, TaxExemptionCause and ProductCategory record how the tax of the line was decided
+	TaxRegime         string `gorm:"type:varchar(10);not null;default:IVA"`
+	TaxExemptionCause string `gorm:"type:varchar(2)"`
+	ProductCategory   string `gorm:"type:varchar(50)"`
+	// Movement columns required when a line is inserted as a new movement row
+	Amount          commons.Decimal `gorm:"type:decimal(12,2);not null"`
+	MovementType    string          `gorm:"type:varchar(50);not null"`
+	TransactionDate time.Time       `gorm:"not null"`
+	Status          string          `gorm:"type:varchar(50);not null"`
+	// Currency and Amount hold the movement as it was recorded, before converting it to the invoice currency
+	Currency        string  `gorm:"type:char(3);not null"`
+	ExchangeRate    *string `gorm:"type:decimal(18,8)"`
+	InvoiceCurrency string  `gorm:"->;-:migration"` // Read from the joined invoice
+	// CorrectedMovementID is the line of the original invoice a credit note line rectifies
+	CorrectedMovementID *uuid.UUID `gorm:"type:uuid"`
 }
 
 // TableName specifies the table name for InvoiceLine in the database.
 func (InvoiceLine) TableName() string {
 	return "movements" // We're using the same table as movements since they represent the same data
 }
+
+// ExchangeRate represents the rate from a base currency to a quote currency published on a given date.
+type ExchangeRate struct {
+	commons.BaseModel
+	BaseCurrency  string    `gorm:"type:char(3);not null"`
+	QuoteCurrency string    `gorm:"type:char(3);not null"`
+	Rate          string    `gorm:"type:decimal(18,8);not null"`
+	RateDate      time.Time `gorm:"type:date;not null"`
+}
+
+// TableName specifies the table name for ExchangeRate in the database.
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+// InvoiceStatusChange represents a row of the status history of an invoice.
+type InvoiceStatusChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	InvoiceID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	FromStatus string    `gorm:"type:varchar(50);not null"`
+	ToStatus   string    `gorm:"type:varchar(50);not null"`
+	ChangedAt  time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for InvoiceStatusChange in the database.
+func (InvoiceStatusChange) TableName() string {
+	return "invoice_status_history"
+}
+
+// NumberSequence holds the last number handed out in a numbering series for a year,
+// or for year 0 when the series never resets.
+type NumberSequence struct {
+	Series     string `gorm:"type:varchar(20);primaryKey"`
+	Year       int    `gorm:"primaryKey"`
+	LastNumber int64  `gorm:"not null"`
+}
+
+// TableName specifies the table name for NumberSequence in the database.
+func (NumberSequence) TableName() string {
+	return "number_sequences"
+}
+
+// CreditedAmount is the base amount already credited on a line of an invoice.
+type CreditedAmount struct {
+	CorrectedMovementID uuid.UUID
+	AmountWithoutTax    commons.Decimal
+}
+
+// AgingBucket is an aging bucket holding the invoices past due up to MaxDaysPastDue days, and more than the bucket before.
+type AgingBucket struct {
+	Name           string
+	MaxDaysPastDue int
+}
+
+// AgingTotal is the outstanding amount of the receivable invoices of an account with a status in an aging bucket.
+type AgingTotal struct {
+	AccountID   string
+	Status      string
+	Bucket      string
+	Invoices    int
+	Outstanding commons.Decimal
+}
@@ -0,0 +1,37 @@
+package sql
+
+import (
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+// invoiceKeysets pages the invoices of an account by each of the fields they can be sorted by
+var invoiceKeysets = map[string]commons.Keyset[Invoice]{
+	model.SortByIssueDate:   invoiceKeyset("issue_date", func(invoice Invoice) any { return invoice.IssueDate }),
+	model.SortByDueDate:     invoiceKeyset("due_date", func(invoice Invoice) any { return invoice.DueDate }),
+	model.SortByTotalAmount: invoiceKeyset("total_amount_with_tax", func(invoice Invoice) any { return invoice.TotalAmountWithTax }),
+}
+
+// invoiceLineKeysets pages the lines of an invoice by each of the fields they can be sorted by
+var invoiceLineKeysets = map[string]commons.Keyset[InvoiceLine]{
+	model.SortByTransactionDate: invoiceLineKeyset("movements.transaction_date", func(line InvoiceLine) any { return line.TransactionDate }),
+	model.SortByAmount:          invoiceLineKeyset("movements.amount_with_tax", func(line InvoiceLine) any { return line.AmountWithTax }),
+}
+
+func invoiceKeyset(column string, value func(Invoice) any) commons.Keyset[Invoice] {
+	return commons.Keyset[Invoice]{
+		Column:   column,
+		Value:    value,
+		IDColumn: "id",
+		ID:       func(invoice Invoice) string { return invoice.ID.String() },
+	}
+}
+
+func invoiceLineKeyset(column string, value func(InvoiceLine) any) commons.Keyset[InvoiceLine] {
+	return commons.Keyset[InvoiceLine]{
+		Column:   column,
+		Value:    value,
+		IDColumn: "movements.id",
+		ID:       func(line InvoiceLine) string { return line.MovementID.String() },
+	}
+}
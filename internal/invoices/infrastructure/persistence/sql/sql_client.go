@@ -2,12 +2,26 @@ package sql
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	standardInvoiceType = "STANDARD"
+	draftInvoiceStatus  = "DRAFT"
+	voidInvoiceStatus   = "VOID"
+)
+
+// openInvoiceStatuses are the statuses of the issued invoices that are not paid nor voided
+var openInvoiceStatuses = []string{"SENT", "OVERDUE", "UNPAID"}
+
 type InvoiceSqlClient struct {
 	db         *gorm.DB
 	maxRetries int
@@ -38,22 +52,20 @@ func (c InvoiceSqlClient) GetInvoiceByID(id string) (invoice Invoice, err error)
 	return
 }
 
+// LockInvoice retrieves an invoice and locks its row until the transaction carried by the context ends
+func (c InvoiceSqlClient) LockInvoice(ctx context.Context, id string) (invoice Invoice, err error) {
+	err = commons.Conn(ctx, c.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Take(&invoice).Error
+	return
+}
+
 func (c InvoiceSqlClient) GetInvoicesByAccountId(accountId string, criteria map[string]interface{}) (invoices []Invoice, err error) {
 	c.logger.Info().Interface("criteria", criteria).Msg("Fetching invoices by criteria")
 
 	queryFn := func() *gorm.DB {
-		query := c.db.Where("account_id = ?", accountId)
-		if criteria["status"] != nil {
-			query = query.Where("status = ?", criteria["status"])
-		}
-		if criteria["issue_date_from"] != nil {
-			query = query.Where("issue_date >= ?", criteria["issue_date_from"])
-		}
-		if criteria["issue_date_to"] != nil {
-			query = query.Where("issue_date <= ?", criteria["issue_date_to"])
-		}
-		query = query.Order("issue_date DESC")
-		return query.Find(&invoices)
+		return c.invoicesOfAccount(accountId, criteria).Order("issue_date DESC").Find(&invoices)
 	}
 
 	rowsAffected, err := c.RunWithRetry(queryFn, c.maxRetries)
@@ -65,6 +77,71 @@ func (c InvoiceSqlClient) GetInvoicesByAccountId(accountId string, criteria map[
 	return
 }
 
+// GetInvoicesPageByAccountId retrieves a page of the invoices of an account matching the criteria
+func (c InvoiceSqlClient) GetInvoicesPageByAccountId(accountId string, criteria map[string]interface{}, page pagination.Request) (pagination.Page[Invoice], error) {
+	c.logger.Info().Interface("criteria", criteria).Int("page_size", page.Size).Str("sort", page.Sort.Field).Msg("Fetching page of invoices by criteria")
+
+	keyset, ok := invoiceKeysets[page.Sort.Field]
+	if !ok {
+		return pagination.Page[Invoice]{}, fmt.Errorf("%w: %s", pagination.ErrInvalidSort, page.Sort.Field)
+	}
+
+	var invoices []Invoice
+	queryFn := func() *gorm.DB {
+		invoices = nil
+		return c.invoicesOfAccount(accountId, criteria).Scopes(keyset.Scope(page)).Find(&invoices)
+	}
+
+	if _, err := c.RunWithRetry(queryFn, c.maxRetries); err != nil {
+		return pagination.Page[Invoice]{}, err
+	}
+
+	result := keyset.Page(invoices, page)
+	c.logger.Info().Int("count", len(result.Items)).Bool("more", result.NextCursor != "").Msg("Fetched page of invoices by criteria")
+	return result, nil
+}
+
+// invoicesOfAccount filters the invoices of an account by the criteria
+func (c InvoiceSqlClient) invoicesOfAccount(accountId string, criteria map[string]interface{}) *gorm.DB {
+	query := c.db.Where("account_id = ?", accountId)
+	if criteria["statuses"] != nil {
+		query = query.Where("status IN ?", criteria["statuses"])
+	}
+	if criteria["issue_date_from"] != nil {
+		query = query.Where("issue_date >= ?", criteria["issue_date_from"])
+	}
+	if criteria["issue_date_to"] != nil {
+		query = query.Where("issue_date <= ?", criteria["issue_date_to"])
+	}
+	if criteria["due_date_from"] != nil {
+		query = query.Where("due_date >= ?", criteria["due_date_from"])
+	}
+	if criteria["due_date_to"] != nil {
+		query = query.Where("due_date <= ?", criteria["due_date_to"])
+	}
+	if criteria["currency"] != nil {
+		query = query.Where("currency = ?", criteria["currency"])
+	}
+	if criteria["total_from"] != nil {
+		query = query.Where("total_amount_with_tax >= ?", criteria["total_from"])
+	}
+	if criteria["total_to"] != nil {
+		query = query.Where("total_amount_with_tax <= ?", criteria["total_to"])
+	}
+	if criteria["number_pattern"] != nil {
+		query = query.Where("invoice_number LIKE ?", criteria["number_pattern"])
+	}
+	if criteria["line_pattern"] != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM movements WHERE movements.invoice_id = invoices.id AND movements.deleted_at IS NULL AND movements.description ILIKE ?)",
+			criteria["line_pattern"])
+	}
+	if criteria["overdue_before"] != nil {
+		// Invoices are only marked as paid once fully settled, so the open ones still have a balance to pay
+		query = query.Where("status IN ? AND invoice_type = ? AND due_date < ?", openInvoiceStatuses, standardInvoiceType, criteria["overdue_before"])
+	}
+	return query
+}
+
 // GetInvoiceLinesByInvoiceID retrieves all invoice lines for a specific invoice
 func (c InvoiceSqlClient) GetInvoiceLinesByInvoiceID(ctx context.Context, invoiceID string) ([]InvoiceLine, error) {
 	c.logger.Info().Str("invoice_id", invoiceID).Msg("Fetching invoice lines by invoice ID")
@@ -73,7 +150,9 @@ func (c InvoiceSqlClient) GetInvoiceLinesByInvoiceID(ctx context.Context, invoic
 
 	queryFn := func() *gorm.DB {
 		return c.db.WithContext(ctx).
-			Where("invoice_id = ?", invoiceID).
+			Select("movements.*, invoices.currency AS invoice_currency").
+			Joins("JOIN invoices ON invoices.id = movements.invoice_id").
+			Where("movements.invoice_id = ?", invoiceID).
 			Find(&lines)
 	}
 
@@ -87,6 +166,275 @@ func (c InvoiceSqlClient) GetInvoiceLinesByInvoiceID(ctx context.Context, invoic
 	return lines, nil
 }
 
+// GetInvoiceLinesPageByInvoiceID retrieves a page of the invoice lines of a specific invoice
+func (c InvoiceSqlClient) GetInvoiceLinesPageByInvoiceID(ctx context.Context, invoiceID string, page pagination.Request) (pagination.Page[InvoiceLine], error) {
+	c.logger.Info().Str("invoice_id", invoiceID).Int("page_size", page.Size).Str("sort", page.Sort.Field).Msg("Fetching page of invoice lines by invoice ID")
+
+	keyset, ok := invoiceLineKeysets[page.Sort.Field]
+	if !ok {
+		return pagination.Page[InvoiceLine]{}, fmt.Errorf("%w: %s", pagination.ErrInvalidSort, page.Sort.Field)
+	}
+
+	var lines []InvoiceLine
+	queryFn := func() *gorm.DB {
+		lines = nil
+		return c.db.WithContext(ctx).
+			Select("movements.*, invoices.currency AS invoice_currency").
+			Joins("JOIN invoices ON invoices.id = movements.invoice_id").
+			Where("movements.invoice_id = ?", invoiceID).
+			Scopes(keyset.Scope(page)).
+			Find(&lines)
+	}
+
+	if _, err := c.RunWithRetry(queryFn, c.maxRetries); err != nil {
+		c.logger.Error().Err(err).Str("invoice_id", invoiceID).Msg("Failed to fetch page of invoice lines")
+		return pagination.Page[InvoiceLine]{}, err
+	}
+
+	result := keyset.Page(lines, page)
+	c.logger.Info().Str("invoice_id", invoiceID).Int("count", len(result.Items)).Bool("more", result.NextCursor != "").Msg("Successfully fetched page of invoice lines")
+	return result, nil
+}
+
+func (c InvoiceSqlClient) CreateInvoice(ctx context.Context, invoice Invoice) error {
+	c.logger.Info().Str("id", invoice.ID.String()).Msg("Creating invoice")
+
+	queryFn := func() *gorm.DB {
+		return commons.Conn(ctx, c.db).Create(&invoice)
+	}
+
+	if _, err := c.RunWithRetry(queryFn, c.maxRetries); err != nil {
+		return err
+	}
+
+	c.logger.Info().Str("id", invoice.ID.String()).Msg("Created invoice")
+	return nil
+}
+
+// UpdateInvoiceStatus persists the new status and amounts of an invoice and records the change in its history,
+// in a single transaction, which joins the one carried by the context if any.
+// It fails if the invoice is no longer in the status the change started from.
+func (c InvoiceSqlClient) UpdateInvoiceStatus(ctx context.Context, invoice Invoice, change InvoiceStatusChange) error {
+	c.logger.Info().Str("id", invoice.ID.String()).Str("from", change.FromStatus).Str("to", change.ToStatus).Msg("Updating invoice status")
+
+	err := commons.Conn(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Invoice{}).
+			Where("id = ? AND status = ?", invoice.ID, change.FromStatus).
+			Updates(invoiceUpdateColumns(invoice))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("invoice %s is no longer %s: %w", invoice.ID, change.FromStatus, gorm.ErrRecordNotFound)
+		}
+		return tx.Create(&change).Error
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Str("id", invoice.ID.String()).Msg("Failed to update invoice status")
+		return err
+	}
+
+	c.logger.Info().Str("id", invoice.ID.String()).Msg("Updated invoice status")
+	return nil
+}
+
+// GetInvoicesDueBefore retrieves the invoices in the given status whose due date is before the given time
+func (c InvoiceSqlClient) GetInvoicesDueBefore(ctx context.Context, status string, before time.Time) (invoices []Invoice, err error) {
+	c.logger.Info().Str("status", status).Time("before", before).Msg("Fetching invoices due before date")
+
+	queryFn := func() *gorm.DB {
+		return c.db.WithContext(ctx).
+			Where("status = ? AND due_date < ? AND invoice_type = ?", status, before, standardInvoiceType).
+			Order("due_date, id").
+			Find(&invoices)
+	}
+
+	rowsAffected, err := c.RunWithRetry(queryFn, c.maxRetries)
+	if err != nil {
+		return
+	}
+
+	c.logger.Info().Int("rows_affected", rowsAffected).Msg("Fetched invoices due before date")
+	return
+}
+
+// GetInvoiceStatusHistory retrieves the status changes of an invoice, oldest first
+func (c InvoiceSqlClient) GetInvoiceStatusHistory(ctx context.Context, invoiceID string) (history []InvoiceStatusChange, err error) {
+	c.logger.Info().Str("invoice_id", invoiceID).Msg("Fetching invoice status history")
+
+	queryFn := func() *gorm.DB {
+		return c.db.WithContext(ctx).Where("invoice_id = ?", invoiceID).Order("changed_at, id").Find(&history)
+	}
+
+	rowsAffected, err := c.RunWithRetry(queryFn, c.maxRetries)
+	if err != nil {
+		return
+	}
+
+	c.logger.Info().Int("rows_affected", rowsAffected).Msg("Fetched invoice status history")
+	return
+}
+
+// CreateInvoiceLine stores a new line and the updated invoice totals in a single transaction,
+// which joins the one carried by the context if any. It fails if the invoice is no longer a draft.
+func (c InvoiceSqlClient) CreateInvoiceLine(ctx context.Context, invoice Invoice, line InvoiceLine) error {
+	c.logger.Info().Str("invoice_id", invoice.ID.String()).Str("movement_id", line.MovementID.String()).Msg("Creating invoice line")
+
+	err := commons.Conn(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&line).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&Invoice{}).
+			Where("id = ? AND status = ?", invoice.ID, draftInvoiceStatus).
+			Updates(invoiceTotalsColumns(invoice))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("invoice %s is no longer %s: %w", invoice.ID, draftInvoiceStatus, gorm.ErrRecordNotFound)
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoice_id", invoice.ID.String()).Msg("Failed to create invoice line")
+		return err
+	}
+
+	c.logger.Info().Str("invoice_id", invoice.ID.String()).Msg("Created invoice line")
+	return nil
+}
+
+// GetExchangeRate retrieves the latest rate between two currencies published on or before the given date
+func (c InvoiceSqlClient) GetExchangeRate(ctx context.Context, baseCurrency, quoteCurrency string, date time.Time) (rate ExchangeRate, err error) {
+	c.logger.Info().Str("base_currency", baseCurrency).Str("quote_currency", quoteCurrency).Time("date", date).Msg("Fetching exchange rate")
+
+	queryFn := func() *gorm.DB {
+		return c.db.WithContext(ctx).
+			Where("base_currency = ? AND quote_currency = ? AND rate_date <= ?", baseCurrency, quoteCurrency, date).
+			Order("rate_date DESC").
+			First(&rate)
+	}
+
+	if _, err = c.RunWithRetry(queryFn, c.maxRetries); err != nil {
+		return
+	}
+
+	c.logger.Info().Str("rate", rate.Rate).Time("rate_date", rate.RateDate).Msg("Fetched exchange rate")
+	return
+}
+
+// NextSequenceNumber hands out the next number of a series for a year, 0 for series that never reset. The sequence row stays locked until
+// the transaction carried by the context ends, so numbers are gapless: a rolled back number is handed out again.
+func (c InvoiceSqlClient) NextSequenceNumber(ctx context.Context, series string, year int) (int64, error) {
+	var number int64
+	err := commons.Conn(ctx, c.db).Raw(`
+		INSERT INTO number_sequences (series, year, last_number) VALUES (?, ?, 1)
+		ON CONFLICT (series, year) DO UPDATE SET last_number = number_sequences.last_number + 1
+		RETURNING last_number`, series, year).Scan(&number).Error
+	if err != nil {
+		c.logger.Error().Err(err).Str("series", series).Int("year", year).Msg("Failed to allocate sequence number")
+		return 0, err
+	}
+	return number, nil
+}
+
+// GetCreditedAmounts sums, per line of an invoice, the base amounts credited by its credit notes that are not void
+func (c InvoiceSqlClient) GetCreditedAmounts(ctx context.Context, invoiceID string) (amounts []CreditedAmount, err error) {
+	err = commons.Conn(ctx, c.db).Model(&InvoiceLine{}).
+		Select("movements.corrected_movement_id, -SUM(movements.amount_without_tax) AS amount_without_tax").
+		Joins("JOIN invoices ON invoices.id = movements.invoice_id").
+		Where("invoices.corrected_invoice_id = ? AND invoices.status <> ? AND invoices.deleted_at IS NULL", invoiceID, voidInvoiceStatus).
+		Where("movements.corrected_movement_id IS NOT NULL AND movements.deleted_at IS NULL").
+		Group("movements.corrected_movement_id").
+		Scan(&amounts).Error
+	return
+}
+
+// outstandingAmount is what is left to collect of the invoice i: its total less its issued credit notes, whose
+// totals are negative, and the payments allocated to it
+const outstandingAmount = `i.total_amount_with_tax
+			+ COALESCE((SELECT SUM(cn.total_amount_with_tax) FROM invoices cn
+				WHERE cn.corrected_invoice_id = i.id AND cn.status NOT IN ('DRAFT', 'VOID') AND cn.deleted_at IS NULL), 0)
+			- COALESCE((SELECT SUM(pa.amount) FROM payment_allocations pa
+				JOIN payments p ON p.id = pa.payment_id AND p.deleted_at IS NULL
+				WHERE pa.invoice_id = i.id), 0)`
+
+// GetOutstandingAmount returns what is left to collect of an invoice, joining the transaction carried by the context
+func (c InvoiceSqlClient) GetOutstandingAmount(ctx context.Context, invoiceID string) (commons.Decimal, error) {
+	var row struct{ Outstanding commons.Decimal }
+	err := commons.Conn(ctx, c.db).
+		Raw("SELECT "+outstandingAmount+" AS outstanding FROM invoices i WHERE i.id = ? AND i.deleted_at IS NULL", invoiceID).
+		Scan(&row).Error
+	return row.Outstanding, err
+}
+
+// agingTotalsQuery sums by account, status and aging bucket what is outstanding of the receivable invoices
+const agingTotalsQuery = `
+	WITH receivables AS (
+		SELECT i.account_id, i.status, CAST(@as_of AS date) - CAST(i.due_date AS date) AS days_past_due,
+			` + outstandingAmount + ` AS outstanding
+		FROM invoices i
+		WHERE i.invoice_type = @standard AND i.status IN @open AND i.currency = @currency AND i.deleted_at IS NULL
+			AND (@account = '' OR i.account_id = @account)
+	)
+	SELECT account_id, status, @bucket AS bucket, COUNT(*) AS invoices, SUM(outstanding) AS outstanding
+	FROM receivables
+	WHERE outstanding > 0
+	GROUP BY account_id, status, bucket
+	ORDER BY account_id, status, bucket`
+
+// GetAgingTotals sums the outstanding amount of the open standard invoices in a currency by account, status and
+// aging bucket, as of a day. The buckets are given in order; an empty account ID sums the invoices of every account.
+func (c InvoiceSqlClient) GetAgingTotals(ctx context.Context, accountID, currency string, asOf time.Time, buckets []AgingBucket) (totals []AgingTotal, err error) {
+	c.logger.Info().Str("account_id", accountID).Str("currency", currency).Time("as_of", asOf).Msg("Fetching aging totals")
+
+	bucket := clause.Expr{SQL: "CASE"}
+	for _, b := range buckets {
+		bucket.SQL += " WHEN days_past_due <= ? THEN ?"
+		bucket.Vars = append(bucket.Vars, b.MaxDaysPastDue, b.Name)
+	}
+	bucket.SQL += " END"
+
+	queryFn := func() *gorm.DB {
+		totals = nil
+		return c.db.WithContext(ctx).Raw(agingTotalsQuery, map[string]interface{}{
+			"as_of":    asOf.Format(time.DateOnly),
+			"standard": standardInvoiceType,
+			"open":     openInvoiceStatuses,
+			"currency": currency,
+			"account":  accountID,
+			"bucket":   bucket,
+		}).Scan(&totals)
+	}
+
+	rowsAffected, err := c.RunWithRetry(queryFn, c.maxRetries)
+	if err != nil {
+		return
+	}
+
+	c.logger.Info().Int("rows_affected", rowsAffected).Msg("Fetched aging totals")
+	return
+}
+
+func invoiceUpdateColumns(invoice Invoice) map[string]interface{} {
+	columns := invoiceTotalsColumns(invoice)
+	columns["status"] = invoice.Status
+	columns["invoice_number"] = invoice.InvoiceNumber
+	columns["issue_date"] = invoice.IssueDate
+	columns["due_date"] = invoice.DueDate
+	return columns
+}
+
+// invoiceTotalsColumns are the columns changed by adding a line to a draft invoice
+func invoiceTotalsColumns(invoice Invoice) map[string]interface{} {
+	return map[string]interface{}{
+		"tax_amount":               invoice.TaxAmount,
+		"total_amount_without_tax": invoice.TotalAmountWithoutTax,
+		"total_amount_with_tax":    invoice.TotalAmountWithTax,
+		"tax_breakdown":            invoice.TaxBreakdown,
+	}
+}
+
 func (c InvoiceSqlClient) RunWithRetry(queryFn func() *gorm.DB, retries int) (rowsAffected int, err error) {
 	for i := 0; i < retries; i++ {
 		result := queryFn()
@@ -3,9 +3,15 @@ package ports
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 )
 
 var (
@@ -13,6 +19,11 @@ var (
 	ErrInvalidInvoices       = errors.New("invalid invoices")
 	ErrInvalidStatusCriteria = errors.New("invalid status criteria")
 	ErrInvalidDateCriteria   = errors.New("invalid date criteria")
+	ErrInvalidDate           = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidAmount         = errors.New("invalid amount, expected a decimal number such as 100.50")
+	ErrInvalidTaxPercentage  = errors.New("invalid tax percentage, expected a decimal number such as 21")
+	ErrInvalidCreditedLine   = errors.New("each credited line needs a movementId and an optional amount")
+	ErrInvalidAgingReport    = errors.New("invalid aging report")
 )
 
 type Converter struct{}
@@ -22,75 +33,181 @@ func NewConverter() Converter {
 }
 
 func (c Converter) ConvertDomainInvoiceToJsonInvoice(domainInvoice domain.Invoice) ([]byte, error) {
-	mcpInvoice := Invoice{
-		ID:               domainInvoice.ID.String(),
-		AmountWithoutTax: int(domainInvoice.TotalAmountWithoutTax),
-		AmountWithTax:    int(domainInvoice.TotalAmountWithTax),
-		Status:           string(domainInvoice.Status),
-		IssueDate:        domainInvoice.IssueDate.Format("2006-01-02"),
-		DueDate:          domainInvoice.DueDate.Format("2006-01-02"),
-	}
-	jsonData, err := json.Marshal(mcpInvoice)
+	jsonData, err := json.Marshal(c.convertDomainInvoice(domainInvoice))
 	if err != nil {
 		return nil, ErrInvalidInvoice
 	}
 	return jsonData, nil
 }
 
-func (c Converter) ConvertDomainInvoicesToJsonInvoices(domainInvoices domain.Invoices) ([]byte, error) {
-	mcpInvoices := make([]Invoice, len(domainInvoices))
-	for i, domainInvoice := range domainInvoices {
-		mcpInvoices[i] = Invoice{
-			ID:               domainInvoice.ID.String(),
-			AmountWithoutTax: int(domainInvoice.TotalAmountWithoutTax),
-			AmountWithTax:    int(domainInvoice.TotalAmountWithTax),
-			Status:           string(domainInvoice.Status),
-			IssueDate:        domainInvoice.IssueDate.Format("2006-01-02"),
-			DueDate:          domainInvoice.DueDate.Format("2006-01-02"),
+func (c Converter) convertDomainInvoice(domainInvoice domain.Invoice) Invoice {
+	invoice := Invoice{
+		ID:                 domainInvoice.ID.String(),
+		InvoiceNumber:      domainInvoice.InvoiceNumber,
+		AmountWithoutTax:   domainInvoice.TotalAmountWithoutTax.Amount(),
+		TaxAmount:          domainInvoice.TaxAmount.Amount(),
+		AmountWithTax:      domainInvoice.TotalAmountWithTax.Amount(),
+		Currency:           domainInvoice.Currency.String(),
+		Status:             string(domainInvoice.Status),
+		IssueDate:          domainInvoice.IssueDate.Format("2006-01-02"),
+		DueDate:            domainInvoice.DueDate.Format("2006-01-02"),
+		InvoiceType:        string(domainInvoice.Type),
+		CustomerCountry:    domainInvoice.CustomerLocation.Country,
+		CustomerPostalCode: domainInvoice.CustomerLocation.PostalCode,
+		TaxBreakdown:       make([]TaxSummaryDTO, len(domainInvoice.TaxBreakdown)),
+	}
+	for i, summary := range domainInvoice.TaxBreakdown {
+		invoice.TaxBreakdown[i] = TaxSummaryDTO{
+			Regime:         string(summary.Regime),
+			Percentage:     summary.Percentage.String(),
+			ExemptionCause: string(summary.ExemptionCause),
+			TaxableBase:    summary.TaxableBase.Amount(),
+			TaxAmount:      summary.TaxAmount.Amount(),
 		}
 	}
-	jsonData, err := json.Marshal(mcpInvoices)
+	if domainInvoice.Type == domain.InvoiceTypeCreditNote {
+		invoice.CorrectedInvoiceID = domainInvoice.CorrectedInvoiceID.String()
+		invoice.CorrectionReason = domainInvoice.CorrectionReason
+	}
+	return invoice
+}
+
+// ConvertInvoicesPageToJson converts a page of invoices and the cursor of the next one to JSON
+func (c Converter) ConvertInvoicesPageToJson(page pagination.Page[domain.Invoice]) ([]byte, error) {
+	mcpInvoices := make([]Invoice, len(page.Items))
+	for i, domainInvoice := range page.Items {
+		mcpInvoices[i] = c.convertDomainInvoice(domainInvoice)
+	}
+	jsonData, err := json.Marshal(InvoicesPage{Invoices: mcpInvoices, NextCursor: page.NextCursor})
 	if err != nil {
 		return nil, ErrInvalidInvoices
 	}
 	return jsonData, nil
 }
 
+// ConvertRequestArgsToCriteria parses the optional search arguments of GetInvoices. Every invalid argument is
+// reported at once, together with the filters that do not make sense together.
 func (c Converter) ConvertRequestArgsToCriteria(args map[string]any) (domain.Criteria, error) {
 	criteria := domain.Criteria{}
+	var errs []error
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
 
-	paramStatus, ok := (args["status"]).(string)
-	if !ok {
-		return criteria, ErrInvalidStatusCriteria
+	statuses, err := c.convertRequestStatuses(args["status"])
+	collect(err)
+	criteria.Statuses = statuses
+
+	criteria.IssueDateFrom, err = c.convertCriteriaDate(args, "issueDateFrom")
+	collect(err)
+	criteria.IssueDateTo, err = c.convertCriteriaDate(args, "issueDateTo")
+	collect(err)
+	criteria.DueDateFrom, err = c.convertCriteriaDate(args, "dueDateFrom")
+	collect(err)
+	criteria.DueDateTo, err = c.convertCriteriaDate(args, "dueDateTo")
+	collect(err)
+
+	currency, err := c.ConvertRequestCurrency(args, "currency", money.DefaultCurrency)
+	collect(err)
+	if err == nil {
+		criteria.TotalFrom, err = c.convertCriteriaAmount(args, "totalFrom", currency)
+		collect(err)
+		criteria.TotalTo, err = c.convertCriteriaAmount(args, "totalTo", currency)
+		collect(err)
 	}
 
-	if status, err := domain.GetStatusFromString(paramStatus); err != nil {
-		return criteria, err
-	} else {
-		criteria.Status = status
+	criteria.NumberPrefix, err = c.convertCriteriaText(args, "invoiceNumberPrefix")
+	collect(err)
+	criteria.LineText, err = c.convertCriteriaText(args, "lineText")
+	collect(err)
+
+	if value, ok := args["hasOverdueBalance"]; ok {
+		hasOverdueBalance, ok := value.(bool)
+		if !ok {
+			collect(fmt.Errorf("%w: hasOverdueBalance must be a boolean", domain.ErrInvalidCriteria))
+		}
+		criteria.HasOverdueBalance = hasOverdueBalance
 	}
 
-	paramStartDate, ok := (args["issueDateFrom"]).(string)
-	if !ok {
-		return criteria, ErrInvalidDateCriteria
+	if len(errs) == 0 {
+		collect(criteria.Validate())
 	}
-	if startDate, err := time.Parse(time.RFC3339, paramStartDate); err != nil {
-		return criteria, err
-	} else {
-		criteria.IssueDateFrom = startDate
+	if err := errors.Join(errs...); err != nil {
+		return domain.Criteria{}, err
 	}
+	return criteria, nil
+}
 
-	paramEndDate, ok := (args["issueDateTo"]).(string)
-	if !ok {
-		return criteria, ErrInvalidDateCriteria
+// convertRequestStatuses parses the status argument, a status or a list of them
+func (c Converter) convertRequestStatuses(value any) ([]domain.InvoiceStatus, error) {
+	var values []any
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		values = []any{v}
+	case []any:
+		values = v
+	default:
+		return nil, fmt.Errorf("%w: status must be a status or a list of them", ErrInvalidStatusCriteria)
 	}
-	if endDate, err := time.Parse(time.RFC3339, paramEndDate); err != nil {
-		return criteria, err
-	} else {
-		criteria.IssueDateTo = endDate
+
+	statuses := make([]domain.InvoiceStatus, 0, len(values))
+	for _, value := range values {
+		name, _ := value.(string)
+		status, err := domain.GetStatusFromString(strings.ToUpper(name))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatusCriteria, value)
+		}
+		statuses = append(statuses, status)
 	}
+	return statuses, nil
+}
 
-	return criteria, nil
+// convertCriteriaDate parses an optional date argument, in RFC 3339 or YYYY-MM-DD format
+func (c Converter) convertCriteriaDate(args map[string]any, key string) (time.Time, error) {
+	value, ok := args[key]
+	if !ok || value == "" {
+		return time.Time{}, nil
+	}
+	text, _ := value.(string)
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if date, err := time.Parse(layout, text); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %s must be a date in RFC 3339 or YYYY-MM-DD format", ErrInvalidDateCriteria, key)
+}
+
+// convertCriteriaAmount parses an optional decimal amount argument
+func (c Converter) convertCriteriaAmount(args map[string]any, key string, currency money.Currency) (*money.Money, error) {
+	value, ok := args[key]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	amount, err := money.ParseValue(value, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidAmount, key, err)
+	}
+	return &amount, nil
+}
+
+// convertCriteriaText reads an optional text argument
+func (c Converter) convertCriteriaText(args map[string]any, key string) (string, error) {
+	value, ok := args[key]
+	if !ok {
+		return "", nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s must be a text", domain.ErrInvalidCriteria, key)
+	}
+	return strings.TrimSpace(text), nil
 }
 
 // ConvertInvoiceMovementsToJson converts a slice of InvoiceMovementDTO to JSON
@@ -101,3 +218,258 @@ func (c Converter) ConvertInvoiceMovementsToJson(movements InvoiceMovementsDTO)
 	}
 	return jsonData, nil
 }
+
+// ConvertInvoiceLinesPageToJson converts a page of invoice lines and the cursor of the next one to JSON
+func (c Converter) ConvertInvoiceLinesPageToJson(page pagination.Page[domain.InvoiceLine]) ([]byte, error) {
+	jsonData, err := json.Marshal(InvoiceMovementsPageDTO{Movements: c.ConvertInvoiceLinesToDTO(page.Items), NextCursor: page.NextCursor})
+	if err != nil {
+		return nil, errors.New("invalid invoice movements")
+	}
+	return jsonData, nil
+}
+
+// ConvertStatusHistoryToJson converts the status history of an invoice to JSON
+func (c Converter) ConvertStatusHistoryToJson(history []domain.StatusChange) ([]byte, error) {
+	dtos := make([]StatusChangeDTO, len(history))
+	for i, change := range history {
+		dtos[i] = StatusChangeDTO{
+			FromStatus: string(change.From),
+			ToStatus:   string(change.To),
+			ChangedAt:  change.ChangedAt.Format(time.RFC3339),
+		}
+	}
+	jsonData, err := json.Marshal(dtos)
+	if err != nil {
+		return nil, errors.New("invalid invoice status history")
+	}
+	return jsonData, nil
+}
+
+// ConvertRequestArgsToInvoiceLine builds a domain invoice line from the AddInvoiceLine tool arguments.
+// The amount is expressed in the given currency. Lines with a product category have their tax resolved
+// by the tax engine, any other line needs an explicit tax percentage.
+func (c Converter) ConvertRequestArgsToInvoiceLine(args map[string]any, currency money.Currency) (domain.InvoiceLine, error) {
+	description, _ := args["description"].(string)
+	operationType, _ := args["operationType"].(string)
+
+	amountWithoutTax, err := money.ParseValue(args["amountWithoutTax"], currency)
+	if err != nil {
+		return domain.InvoiceLine{}, fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+	}
+
+	var category taxes.Category
+	if value, ok := args["productCategory"].(string); ok && value != "" {
+		if category, err = taxes.ParseCategory(value); err != nil {
+			return domain.InvoiceLine{}, err
+		}
+	}
+
+	var taxPercentage money.Percentage
+	if value, ok := args["taxPercentage"]; category == "" || (ok && value != nil) {
+		if taxPercentage, err = money.ParsePercentageValue(value); err != nil {
+			return domain.InvoiceLine{}, fmt.Errorf("%w: %w", ErrInvalidTaxPercentage, err)
+		}
+	}
+
+	line, err := domain.NewInvoiceLine(description, amountWithoutTax, taxPercentage, operationType)
+	if err != nil {
+		return domain.InvoiceLine{}, err
+	}
+	line.ProductCategory = category
+
+	// The transaction date selects the exchange rate, it defaults to today
+	if _, ok := args["transactionDate"]; ok {
+		if line.TransactionDate, err = c.ConvertRequestDate(args, "transactionDate"); err != nil {
+			return domain.InvoiceLine{}, err
+		}
+	}
+	return line, nil
+}
+
+// ConvertRequestArgsToCreditNote builds a credit note request from the IssueCreditNote tool arguments.
+// Credited amounts are expressed in the currency of the rectified invoice.
+func (c Converter) ConvertRequestArgsToCreditNote(args map[string]any, currency money.Currency) (domain.CreditNoteRequest, error) {
+	reason, _ := args["reason"].(string)
+	request := domain.CreditNoteRequest{Reason: reason}
+
+	if _, ok := args["issueDate"]; ok {
+		issueDate, err := c.ConvertRequestDate(args, "issueDate")
+		if err != nil {
+			return domain.CreditNoteRequest{}, err
+		}
+		request.IssueDate = issueDate
+	}
+
+	raw, ok := args["lines"]
+	if !ok || raw == nil {
+		return request, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return domain.CreditNoteRequest{}, ErrInvalidCreditedLine
+	}
+	request.Lines = make([]domain.CreditedLine, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			return domain.CreditNoteRequest{}, ErrInvalidCreditedLine
+		}
+		movementID, ok := fields["movementId"].(string)
+		if !ok {
+			return domain.CreditNoteRequest{}, ErrInvalidCreditedLine
+		}
+		id, err := uuid.Parse(movementID)
+		if err != nil {
+			return domain.CreditNoteRequest{}, fmt.Errorf("invalid movement ID %q: %w", movementID, err)
+		}
+		request.Lines[i].MovementID = id
+
+		if value, ok := fields["amount"]; ok && value != nil && value != "" {
+			amount, err := money.ParseValue(value, currency)
+			if err != nil {
+				return domain.CreditNoteRequest{}, fmt.Errorf("%w for movement %s: %w", ErrInvalidAmount, movementID, err)
+			}
+			request.Lines[i].AmountWithoutTax = &amount
+		}
+	}
+	return request, nil
+}
+
+// ConvertRequestCurrency parses an optional ISO 4217 currency argument, returning fallback when it is absent
+func (c Converter) ConvertRequestCurrency(args map[string]any, key string, fallback money.Currency) (money.Currency, error) {
+	value, ok := args[key].(string)
+	if !ok || value == "" {
+		return fallback, nil
+	}
+	return money.ParseCurrency(value)
+}
+
+// ConvertRequestLocation parses the optional customer location arguments, a zero location when they are omitted.
+// A postal code without country is only compared with the one of the account.
+func (c Converter) ConvertRequestLocation(args map[string]any) (taxes.Location, error) {
+	country, _ := args["customerCountry"].(string)
+	postalCode, _ := args["customerPostalCode"].(string)
+	if country == "" {
+		return taxes.Location{PostalCode: strings.TrimSpace(postalCode)}, nil
+	}
+	return taxes.NewLocation(country, postalCode)
+}
+
+// ConvertRequestArgsToBuyer parses the buyer arguments. Its postal code and country default to the given location
+func (c Converter) ConvertRequestArgsToBuyer(args map[string]any, location taxes.Location) (domain.Party, error) {
+	taxID, _ := args["buyerTaxId"].(string)
+	name, _ := args["buyerName"].(string)
+	address := domain.Address{PostalCode: location.PostalCode, Country: location.Country}
+	address.Street, _ = args["buyerAddress"].(string)
+	address.Town, _ = args["buyerTown"].(string)
+	address.Province, _ = args["buyerProvince"].(string)
+	if postalCode, ok := args["buyerPostalCode"].(string); ok && postalCode != "" {
+		address.PostalCode = postalCode
+	}
+	if country, ok := args["buyerCountry"].(string); ok && country != "" {
+		address.Country = country
+	}
+	return domain.NewParty(taxID, name, address)
+}
+
+// ConvertRequestDate parses a YYYY-MM-DD date argument
+func (c Converter) ConvertRequestDate(args map[string]any, key string) (time.Time, error) {
+	value, ok := args[key].(string)
+	if !ok {
+		return time.Time{}, ErrInvalidDate
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return date, nil
+}
+
+// ConvertInvoiceLinesToDTO converts domain invoice lines to their MCP representation
+func (c Converter) ConvertInvoiceLinesToDTO(lines []domain.InvoiceLine) InvoiceMovementsDTO {
+	movementDTOs := make(InvoiceMovementsDTO, 0, len(lines))
+	for _, line := range lines {
+		movementDTOs = append(movementDTOs, InvoiceMovementDTO{
+			MovementID:       line.MovementID.String(),
+			Description:      line.Description,
+			Amount:           line.AmountWithTax.Amount(),
+			AmountWithoutTax: line.AmountWithoutTax.Amount(),
+			AmountWithTax:    line.AmountWithTax.Amount(),
+			TaxPercentage:    line.TaxPercentage.String(),
+			TaxRegime:        string(line.TaxRegime),
+			ExemptionCause:   string(line.ExemptionCause),
+			ProductCategory:  string(line.ProductCategory),
+			Currency:         line.AmountWithTax.Currency().String(),
+			OperationType:    line.OperationType,
+			TransactionDate:  line.TransactionDate.Format(time.DateOnly),
+			OriginalAmount:   line.OriginalAmount.Amount(),
+			OriginalCurrency: line.OriginalAmount.Currency().String(),
+			ExchangeRate:     convertedRate(line.ExchangeRate),
+		})
+	}
+	return movementDTOs
+}
+
+// convertedRate only reports the rate of lines that were converted from another currency
+func convertedRate(rate money.ExchangeRate) string {
+	if rate.From == rate.To {
+		return ""
+	}
+	return rate.String()
+}
+
+// ConvertRequestArgsToAgingQuery reads the GetAgingReport tool arguments: the optional account, the currency, which
+// defaults to the default currency, and the day the invoices are aged on, which defaults to today.
+func (c Converter) ConvertRequestArgsToAgingQuery(args map[string]any) (domain.AgingQuery, error) {
+	currency, err := c.ConvertRequestCurrency(args, "currency", money.DefaultCurrency)
+	if err != nil {
+		return domain.AgingQuery{}, err
+	}
+	query := domain.AgingQuery{Currency: currency, AsOf: time.Now().UTC().Truncate(24 * time.Hour)}
+	query.AccountID, _ = args["accountId"].(string)
+	if value, ok := args["asOf"].(string); ok && value != "" {
+		if query.AsOf, err = time.Parse(time.DateOnly, value); err != nil {
+			return domain.AgingQuery{}, fmt.Errorf("%w: asOf %q", ErrInvalidDate, value)
+		}
+	}
+	return query, nil
+}
+
+func (c Converter) ConvertAgingReportToJson(report domain.AgingReport) ([]byte, error) {
+	jsonData, err := json.Marshal(c.ConvertAgingReport(report))
+	if err != nil {
+		return nil, ErrInvalidAgingReport
+	}
+	return jsonData, nil
+}
+
+// ConvertAgingReport converts an aging report to the DTO returned by the MCP API and the aging-report command
+func (c Converter) ConvertAgingReport(report domain.AgingReport) AgingReportDTO {
+	dto := AgingReportDTO{
+		AccountID: report.AccountID,
+		Currency:  report.Currency.String(),
+		AsOf:      report.AsOf.Format(time.DateOnly),
+		Totals:    c.convertAgingAmounts(report.Totals),
+		Statuses:  make([]StatusAgingDTO, len(report.Statuses)),
+		Accounts:  make([]AccountAgingDTO, len(report.Accounts)),
+	}
+	for i, status := range report.Statuses {
+		dto.Statuses[i] = StatusAgingDTO{Status: string(status.Status), AgingAmountsDTO: c.convertAgingAmounts(status.AgingAmounts)}
+	}
+	for i, account := range report.Accounts {
+		dto.Accounts[i] = AccountAgingDTO{AccountID: account.AccountID, AgingAmountsDTO: c.convertAgingAmounts(account.AgingAmounts)}
+	}
+	return dto
+}
+
+func (c Converter) convertAgingAmounts(amounts domain.AgingAmounts) AgingAmountsDTO {
+	return AgingAmountsDTO{
+		Current:    amounts.Buckets[domain.AgingBucketCurrent].Amount(),
+		Days1To30:  amounts.Buckets[domain.AgingBucket1To30].Amount(),
+		Days31To60: amounts.Buckets[domain.AgingBucket31To60].Amount(),
+		Days61To90: amounts.Buckets[domain.AgingBucket61To90].Amount(),
+		Over90:     amounts.Buckets[domain.AgingBucketOver90].Amount(),
+		Total:      amounts.Total.Amount(),
+		Invoices:   amounts.Invoices,
+	}
+}
@@ -0,0 +1,71 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	accounts "github.com/ricardogrande-masmovil/billing-mcp/internal/accounts/domain/model"
+	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+)
+
+// errorCode classifies the errors of the invoices context
+func errorCode(err error) toolerror.Code {
+	switch {
+	case errors.Is(err, domain.ErrInvoiceNotFound),
+		errors.Is(err, accounts.ErrAccountNotFound),
+		errors.Is(err, domain.ErrExchangeRateNotFound),
+		errors.Is(err, taxes.ErrTaxRateNotFound):
+		return toolerror.CodeNotFound
+	case errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrInvoiceAlreadyPaid),
+		errors.Is(err, domain.ErrInvoiceNotDraft),
+		errors.Is(err, domain.ErrVoidInvoiceCannotBePaid),
+		errors.Is(err, domain.ErrPaidInvoiceCannotBeVoided),
+		errors.Is(err, domain.ErrInvoiceNotEditable),
+		errors.Is(err, domain.ErrStatusChangedConcurrently),
+		errors.Is(err, domain.ErrInvoiceNotIssued),
+		errors.Is(err, domain.ErrInvoiceNotCreditable),
+		errors.Is(err, domain.ErrNothingToCredit),
+		errors.Is(err, domain.ErrInvoiceSettled):
+		return toolerror.CodeConflict
+	case errors.Is(err, ErrMissingAccountId),
+		errors.Is(err, ErrMissingInvoiceId),
+		errors.Is(err, ErrUnsupportedFormat),
+		errors.Is(err, ErrInvalidBuyer),
+		errors.Is(err, ErrInvalidPeriod),
+		errors.Is(err, ErrInvalidStatusCriteria),
+		errors.Is(err, ErrInvalidDateCriteria),
+		errors.Is(err, ErrInvalidDate),
+		errors.Is(err, ErrInvalidAmount),
+		errors.Is(err, ErrInvalidTaxPercentage),
+		errors.Is(err, ErrInvalidCreditedLine),
+		errors.Is(err, domain.ErrInvalidCriteria),
+		errors.Is(err, domain.ErrAccountIDEmpty),
+		errors.Is(err, domain.ErrQuantityNotPositive),
+		errors.Is(err, domain.ErrDueDateBeforeIssueDate),
+		errors.Is(err, domain.ErrLineDescriptionEmpty),
+		errors.Is(err, domain.ErrLineCurrencyMismatch),
+		errors.Is(err, domain.ErrCurrencyEmpty),
+		errors.Is(err, domain.ErrStatusUnknown),
+		errors.Is(err, domain.ErrPartyTaxIDEmpty),
+		errors.Is(err, domain.ErrPartyNameEmpty),
+		errors.Is(err, domain.ErrCorrectionReasonEmpty),
+		errors.Is(err, domain.ErrLineNotInInvoice),
+		errors.Is(err, domain.ErrCreditExceedsLine),
+		errors.Is(err, domain.ErrLineCreditedTwice),
+		errors.Is(err, domain.ErrCustomerLocationMismatch),
+		errors.Is(err, taxes.ErrUnknownCategory),
+		errors.Is(err, taxes.ErrUnknownRegime),
+		errors.Is(err, taxes.ErrInvalidLocation):
+		return toolerror.CodeInvalidArgument
+	default:
+		return ""
+	}
+}
+
+// toolError is the structured tool result of a failure of the invoices context
+func toolError(message string, err error) *mcp.CallToolResult {
+	return toolerror.Result(toolerror.Classify(err, errorCode), message, err)
+}
@@ -0,0 +1,70 @@
+package ports
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type httpController struct {
+	service   InvoiceService
+	renderer  DocumentRenderer
+	converter Converter
+	logger    zerolog.Logger
+}
+
+func NewHTTPController(service InvoiceService, renderer DocumentRenderer) httpController {
+	return httpController{
+		service:   service,
+		renderer:  renderer,
+		converter: NewConverter(),
+		logger:    log.With().Str("module", "invoicesHttpController").Logger(),
+	}
+}
+
+// ServeInvoicePDF serves an issued invoice as a PDF. The buyer is given with the same query parameters as the
+// arguments of the GetInvoicePDF tool, e.g. /invoices/{invoiceId}/pdf?buyerTaxId=B87654321&buyerName=...
+func (c httpController) ServeInvoicePDF(ectx echo.Context) error {
+	invoiceId, err := domain.ParseInvoiceID(ectx.Param("invoiceId"))
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse invoice ID")
+		return ectx.JSON(http.StatusBadRequest, toolerror.New(toolerror.CodeInvalidArgument, "Invalid invoice ID format", err))
+	}
+
+	// The route has no account, so the principal is authorized for the account of the invoice
+	invoice, err := c.service.GetInvoiceByID(invoiceId)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Msg("Failed to fetch invoice by ID")
+		return errorResponse(ectx, "Failed to fetch invoice", err)
+	}
+	if err := auth.Authorize(ectx.Request().Context(), invoice.AccountID, auth.AccessRead); err != nil {
+		c.logger.Warn().Err(err).Str("invoiceId", invoiceId.String()).Msg("Access denied to invoice PDF")
+		return errorResponse(ectx, "Access denied", err)
+	}
+
+	args := map[string]any{}
+	for name, values := range ectx.QueryParams() {
+		args[name] = values[0]
+	}
+
+	document, output, err := renderInvoicePDF(ectx.Request().Context(), c.service, c.renderer, c.converter, invoiceId, args)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Msg("Failed to render invoice PDF")
+		return errorResponse(ectx, "Failed to render invoice PDF", err)
+	}
+
+	ectx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", document.Invoice.InvoiceNumber+".pdf"))
+	return ectx.Blob(http.StatusOK, pdfMIMEType, output)
+}
+
+// errorResponse answers the same JSON error as the tools, with the HTTP status of its code
+func errorResponse(ectx echo.Context, message string, err error) error {
+	code := toolerror.Classify(err, errorCode)
+	return ectx.JSON(toolerror.HTTPStatus(code), toolerror.New(code, message, err))
+}
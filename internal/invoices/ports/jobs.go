@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/scheduler"
+	"github.com/rs/zerolog/log"
+)
+
+const MarkOverdueInvoicesJobName = "mark-overdue-invoices"
+
+// NewMarkOverdueInvoicesJob builds the scheduled job that moves past due sent invoices to OVERDUE
+func NewMarkOverdueInvoicesJob(service InvoiceService, interval time.Duration) scheduler.Job {
+	logger := log.With().Str("module", "invoicesJobs").Logger()
+	return scheduler.Job{
+		Name:     MarkOverdueInvoicesJobName,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			marked, err := service.MarkOverdueInvoices(ctx, time.Now())
+			logger.Info().Int("marked", marked).Msg("Marked past due invoices as overdue")
+			return err
+		},
+	}
+}
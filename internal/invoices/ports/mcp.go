@@ -2,34 +2,83 @@ package ports
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 var (
-	ErrMissingInvoiceId = errors.New("invoice_id is required")
-	ErrMissingAccountId = errors.New("account_id is required")
+	ErrMissingInvoiceId  = errors.New("invoice_id is required")
+	ErrMissingAccountId  = errors.New("account_id is required")
+	ErrUnsupportedFormat = errors.New("unsupported export format")
 )
 
 type InvoiceService interface {
 	GetInvoiceByID(id domain.InvoiceID) (domain.Invoice, error)
 	GetInvoicesByCriteria(accountId string, criteria domain.Criteria) (domain.Invoices, error)
+	GetInvoicesPageByCriteria(accountId string, criteria domain.Criteria, page pagination.Request) (pagination.Page[domain.Invoice], error)
 	GetInvoiceLines(ctx context.Context, id domain.InvoiceID) ([]domain.InvoiceLine, error)
+	GetInvoiceLinesPage(ctx context.Context, id domain.InvoiceID, page pagination.Request) (pagination.Page[domain.InvoiceLine], error)
+	CreateInvoice(ctx context.Context, accountId string, currency money.Currency, location taxes.Location, issueDate, dueDate time.Time) (domain.Invoice, error)
+	AddInvoiceLine(ctx context.Context, id domain.InvoiceID, line domain.InvoiceLine) (domain.Invoice, error)
+	SendInvoice(ctx context.Context, id domain.InvoiceID) (domain.Invoice, error)
+	VoidInvoice(ctx context.Context, id domain.InvoiceID) (domain.Invoice, error)
+	MarkInvoiceUnpaid(ctx context.Context, id domain.InvoiceID) (domain.Invoice, error)
+	GetInvoiceStatusHistory(ctx context.Context, id domain.InvoiceID) ([]domain.StatusChange, error)
+	MarkOverdueInvoices(ctx context.Context, now time.Time) (int, error)
+	IssueCreditNote(ctx context.Context, id domain.InvoiceID, request domain.CreditNoteRequest) (domain.Invoice, error)
+	GetInvoiceDocument(ctx context.Context, id domain.InvoiceID, buyer domain.Party) (domain.Document, error)
+	GetAgingReport(ctx context.Context, query domain.AgingQuery) (domain.AgingReport, error)
+}
+
+// DocumentExporter renders an invoice document in an electronic invoicing format.
+type DocumentExporter interface {
+	Export(document domain.Document) ([]byte, error)
+}
+
+// ExportFormat is an electronic invoicing format invoices can be exported as.
+type ExportFormat string
+
+const (
+	ExportFormatFacturae ExportFormat = "facturae" // Facturae 3.2.2
+	ExportFormatUBL      ExportFormat = "ubl"      // UBL 2.1, Peppol BIS Billing 3.0
+)
+
+// DocumentExporters are the exporters of each supported format.
+type DocumentExporters map[ExportFormat]DocumentExporter
+
+// Export renders the document in the given format.
+func (e DocumentExporters) Export(format ExportFormat, document domain.Document) ([]byte, error) {
+	exporter, ok := e[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	return exporter.Export(document)
 }
 
 type controller struct {
 	service   InvoiceService
+	exporters DocumentExporters
+	renderer  DocumentRenderer
 	converter Converter
 	logger    zerolog.Logger
 }
 
-func NewController(service InvoiceService) controller {
+func NewController(service InvoiceService, exporters DocumentExporters, renderer DocumentRenderer) controller {
 	return controller{
 		service:   service,
+		exporters: exporters,
+		renderer:  renderer,
 		converter: NewConverter(),
 		logger:    log.With().Str("module", "invoicesMcpController").Logger(),
 	}
@@ -41,30 +90,17 @@ func (c controller) GetInvoice(ctx context.Context, request mcp.CallToolRequest)
 	args, ok := request.Params.Arguments.(map[string]interface{})
 	if !ok {
 		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
-		return mcp.NewToolResultErrorFromErr("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
 	}
-	requestedInvoiceId, ok := args["invoiceId"].(string)
-	if !ok || requestedInvoiceId == "" {
-		c.logger.Error().Msg("Invoice ID is required")
-		return mcp.NewToolResultErrorFromErr("Missing request parameter", ErrMissingInvoiceId), nil
-	}
-
-	invoiceId, err := domain.ParseInvoiceID(requestedInvoiceId)
-	if err != nil {
-		c.logger.Error().Err(err).Msg("Failed to parse invoice ID")
-		return mcp.NewToolResultErrorFromErr("Invalid invoice ID format", err), nil
-	}
-
-	invoice, err := c.service.GetInvoiceByID(invoiceId)
-	if err != nil {
-		c.logger.Error().Err(err).Msg("Failed to fetch invoice by ID")
-		return nil, err
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
 	}
 
 	jsonData, err := c.converter.ConvertDomainInvoiceToJsonInvoice(invoice)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Failed to convert invoice to JSON")
-		return nil, err
+		return toolError("Failed to convert invoice to JSON", err), nil
 	}
 
 	response := mcp.NewToolResultText(string(jsonData))
@@ -77,26 +113,36 @@ func (c controller) GetInvoices(ctx context.Context, request mcp.CallToolRequest
 	args, ok := request.Params.Arguments.(map[string]interface{})
 	if !ok {
 		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
-		return mcp.NewToolResultErrorFromErr("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
 	}
 	accountId, ok := args["accountId"].(string)
 	if !ok || accountId == "" {
 		c.logger.Error().Msg("Account ID is required")
-		return mcp.NewToolResultErrorFromErr("Missing request parameter", ErrMissingAccountId), nil
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
 	}
 
 	criteria, err := c.converter.ConvertRequestArgsToCriteria(args)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid search criteria")
+		return toolerror.InvalidArgument("Invalid search criteria", err), nil
+	}
 
-	invoices, err := c.service.GetInvoicesByCriteria(accountId, criteria)
+	page, err := pagination.FromArgs(args, domain.InvoiceSortFields, domain.InvoiceDefaultSort)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid pagination")
+		return toolerror.InvalidArgument("Invalid pagination", err), nil
+	}
+
+	invoices, err := c.service.GetInvoicesPageByCriteria(accountId, criteria, page)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Failed to fetch invoices by criteria")
-		return nil, err
+		return toolError("Failed to fetch invoices by criteria", err), nil
 	}
 
-	jsonData, err := c.converter.ConvertDomainInvoicesToJsonInvoices(invoices)
+	jsonData, err := c.converter.ConvertInvoicesPageToJson(invoices)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Failed to convert invoices to JSON")
-		return nil, err
+		return toolError("Failed to convert invoices to JSON", err), nil
 	}
 
 	response := mcp.NewToolResultText(string(jsonData))
@@ -110,59 +156,361 @@ func (c controller) GetInvoiceMovements(ctx context.Context, request mcp.CallToo
 	args, ok := request.Params.Arguments.(map[string]interface{})
 	if !ok {
 		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
-		return mcp.NewToolResultErrorFromErr("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
 	}
 
-	// Extract invoiceId from arguments
-	requestedInvoiceId, ok := args["invoiceId"].(string)
-	if !ok || requestedInvoiceId == "" {
-		c.logger.Error().Msg("Invoice ID is required")
-		return mcp.NewToolResultErrorFromErr("Missing request parameter", ErrMissingInvoiceId), nil
+	// Check the invoice belongs to the account before reading its lines
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	requestedInvoiceId := invoice.ID.String()
+
+	page, err := pagination.FromArgs(args, domain.LineSortFields, domain.LineDefaultSort)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid pagination")
+		return toolerror.InvalidArgument("Invalid pagination", err), nil
+	}
+
+	// Fetch a page of the invoice lines from service
+	lines, err := c.service.GetInvoiceLinesPage(ctx, invoice.ID, page)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", requestedInvoiceId).Msg("Failed to get invoice lines")
+		return toolError("Failed to retrieve invoice lines", err), nil
+	}
+
+	// Convert the page of movements to JSON
+	jsonData, err := c.converter.ConvertInvoiceLinesPageToJson(lines)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert invoice movements to JSON")
+		return toolError("Failed to convert invoice movements to JSON", err), nil
 	}
 
-	// Extract accountId from arguments (not used in this implementation but required by API contract)
-	_, ok = args["accountId"].(string)
+	c.logger.Info().Str("invoiceId", requestedInvoiceId).Int("movementsCount", len(lines.Items)).Msg("Successfully retrieved invoice movements")
+	response := mcp.NewToolResultText(string(jsonData))
+	return response, nil
+}
+
+func (c controller) CreateInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in CreateInvoice tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
 	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
 		c.logger.Error().Msg("Account ID is required")
-		return mcp.NewToolResultErrorFromErr("Missing request parameter", ErrMissingAccountId), nil
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
+	}
+
+	issueDate, err := c.converter.ConvertRequestDate(args, "issueDate")
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse issue date")
+		return toolerror.InvalidArgument("Invalid issue date", err), nil
+	}
+	dueDate, err := c.converter.ConvertRequestDate(args, "dueDate")
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse due date")
+		return toolerror.InvalidArgument("Invalid due date", err), nil
+	}
+
+	currency, err := c.converter.ConvertRequestCurrency(args, "currency", money.DefaultCurrency)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse currency")
+		return toolerror.InvalidArgument("Invalid currency", err), nil
+	}
+
+	location, err := c.converter.ConvertRequestLocation(args)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse customer location")
+		return toolerror.InvalidArgument("Invalid customer location", err), nil
+	}
+
+	invoice, err := c.service.CreateInvoice(ctx, accountId, currency, location, issueDate, dueDate)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to create invoice")
+		return toolError("Failed to create invoice", err), nil
+	}
+
+	return c.invoiceResult(invoice)
+}
+
+func (c controller) AddInvoiceLine(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in AddInvoiceLine tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Lines are expressed in the invoice currency unless another one is given
+	currency, err := c.converter.ConvertRequestCurrency(args, "currency", "")
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse currency")
+		return toolerror.InvalidArgument("Invalid currency", err), nil
+	}
+	if currency == "" {
+		currency = invoice.Currency
+	}
+
+	line, err := c.converter.ConvertRequestArgsToInvoiceLine(args, currency)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid invoice line")
+		return toolerror.InvalidArgument("Invalid invoice line", err), nil
+	}
+
+	invoice, err = c.service.AddInvoiceLine(ctx, invoice.ID, line)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to add invoice line")
+		return toolError("Failed to add invoice line", err), nil
+	}
+
+	return c.invoiceResult(invoice)
+}
+
+func (c controller) SendInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in SendInvoice tool")
+	return c.changeStatus(ctx, request, "Failed to send invoice", c.service.SendInvoice)
+}
+
+func (c controller) VoidInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in VoidInvoice tool")
+	return c.changeStatus(ctx, request, "Failed to void invoice", c.service.VoidInvoice)
+}
+
+func (c controller) MarkInvoiceUnpaid(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in MarkInvoiceUnpaid tool")
+	return c.changeStatus(ctx, request, "Failed to mark invoice as unpaid", c.service.MarkInvoiceUnpaid)
+}
+
+func (c controller) GetInvoiceStatusHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in GetInvoiceStatusHistory tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	history, err := c.service.GetInvoiceStatusHistory(ctx, invoice.ID)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoice.ID.String()).Msg("Failed to get invoice status history")
+		return toolError("Failed to get invoice status history", err), nil
+	}
+
+	jsonData, err := c.converter.ConvertStatusHistoryToJson(history)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert invoice status history to JSON")
+		return toolError("Failed to convert invoice status history to JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (c controller) IssueCreditNote(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in IssueCreditNote tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Credited amounts are expressed in the currency of the rectified invoice
+	creditNoteRequest, err := c.converter.ConvertRequestArgsToCreditNote(args, invoice.Currency)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid credit note request")
+		return toolerror.InvalidArgument("Invalid credit note request", err), nil
+	}
+
+	creditNote, err := c.service.IssueCreditNote(ctx, invoice.ID, creditNoteRequest)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoice.ID.String()).Msg("Failed to issue credit note")
+		return toolError("Failed to issue credit note", err), nil
+	}
+
+	return c.invoiceResult(creditNote)
+}
+
+func (c controller) ExportInvoice(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in ExportInvoice tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	invoiceId := invoice.ID
+	format := ExportFormatFacturae
+	if requestedFormat, ok := args["format"].(string); ok && requestedFormat != "" {
+		format = ExportFormat(requestedFormat)
+	}
+	if _, ok := c.exporters[format]; !ok {
+		c.logger.Error().Str("format", string(format)).Msg("Unsupported export format")
+		return toolerror.InvalidArgument("Invalid export format", fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)), nil
+	}
+
+	// The buyer address defaults to the location the invoice was taxed for
+	buyer, err := c.converter.ConvertRequestArgsToBuyer(args, invoice.CustomerLocation)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid buyer")
+		return toolerror.InvalidArgument("Invalid buyer", err), nil
+	}
+
+	document, err := c.service.GetInvoiceDocument(ctx, invoiceId, buyer)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Msg("Failed to get invoice document")
+		return toolError("Failed to export invoice", err), nil
+	}
+
+	// Exporters fail on documents breaking the rules of their format, such as an incomplete buyer address
+	output, err := c.exporters.Export(format, document)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Str("format", string(format)).Msg("Failed to export invoice")
+		return toolerror.InvalidArgument("Failed to export invoice", err), nil
+	}
+	return mcp.NewToolResultText(string(output)), nil
+}
+
+func (c controller) GetInvoicePDF(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in GetInvoicePDF tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	invoiceId := invoice.ID
+
+	document, output, err := renderInvoicePDF(ctx, c.service, c.renderer, c.converter, invoiceId, args)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Msg("Failed to render invoice PDF")
+		return toolError("Failed to render invoice PDF", err), nil
+	}
+
+	return mcp.NewToolResultResource(
+		fmt.Sprintf("Invoice %s as a PDF document", document.Invoice.InvoiceNumber),
+		mcp.BlobResourceContents{
+			URI:      InvoicePDFResourceURI(document.Invoice.AccountID, invoiceId),
+			MIMEType: pdfMIMEType,
+			Blob:     base64.StdEncoding.EncodeToString(output),
+		},
+	), nil
+}
+
+// changeStatus handles the tools that only apply a status transition to an invoice
+func (c controller) changeStatus(ctx context.Context, request mcp.CallToolRequest, failureMsg string, apply func(context.Context, domain.InvoiceID) (domain.Invoice, error)) (*mcp.CallToolResult, error) {
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	invoice, errResult := c.accountInvoiceFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	invoiceId := invoice.ID
+
+	changed, err := apply(ctx, invoiceId)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Msg(failureMsg)
+		return toolError(failureMsg, err), nil
+	}
+
+	return c.invoiceResult(changed)
+}
+
+// accountInvoiceFromArgs fetches the invoice of the invoiceId argument, checking it belongs to the account of the
+// accountId argument. It returns a tool error result when an argument is invalid or the account has no such invoice.
+func (c controller) accountInvoiceFromArgs(args map[string]interface{}) (domain.Invoice, *mcp.CallToolResult) {
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
+		c.logger.Error().Msg("Account ID is required")
+		return domain.Invoice{}, toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId)
+	}
+	invoiceId, errResult := c.invoiceIdFromArgs(args)
+	if errResult != nil {
+		return domain.Invoice{}, errResult
+	}
+
+	invoice, err := c.invoiceOfAccount(accountId, invoiceId)
+	if err != nil {
+		return domain.Invoice{}, toolError("Failed to fetch invoice", err)
+	}
+	return invoice, nil
+}
+
+// invoiceIdFromArgs extracts and parses the invoiceId argument, returning a tool error result when it is invalid
+func (c controller) invoiceIdFromArgs(args map[string]interface{}) (domain.InvoiceID, *mcp.CallToolResult) {
+	requestedInvoiceId, ok := args["invoiceId"].(string)
+	if !ok || requestedInvoiceId == "" {
+		c.logger.Error().Msg("Invoice ID is required")
+		return domain.InvoiceID{}, toolerror.InvalidArgument("Missing request parameter", ErrMissingInvoiceId)
 	}
 
-	// Parse the invoice ID
 	invoiceId, err := domain.ParseInvoiceID(requestedInvoiceId)
 	if err != nil {
 		c.logger.Error().Err(err).Msg("Failed to parse invoice ID")
-		return mcp.NewToolResultErrorFromErr("Invalid invoice ID format", err), nil
+		return domain.InvoiceID{}, toolerror.InvalidArgument("Invalid invoice ID format", err)
 	}
+	return invoiceId, nil
+}
 
-	// Fetch invoice lines from service
-	lines, err := c.service.GetInvoiceLines(ctx, invoiceId)
+func (c controller) invoiceResult(invoice domain.Invoice) (*mcp.CallToolResult, error) {
+	jsonData, err := c.converter.ConvertDomainInvoiceToJsonInvoice(invoice)
 	if err != nil {
-		c.logger.Error().Err(err).Str("invoiceId", requestedInvoiceId).Msg("Failed to get invoice lines")
-		return mcp.NewToolResultErrorFromErr("Failed to retrieve invoice lines", err), nil
+		c.logger.Error().Err(err).Msg("Failed to convert invoice to JSON")
+		return toolError("Failed to convert invoice to JSON", err), nil
 	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (c controller) GetAgingReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in GetAgingReport tool")
 
-	// Convert lines to InvoiceMovementDTO
-	var movementDTOs []InvoiceMovementDTO
-	for _, line := range lines {
-		movementDTOs = append(movementDTOs, InvoiceMovementDTO{
-			MovementID:       line.MovementID.String(),
-			Description:      line.Description,
-			Amount:           line.AmountWithTax,
-			AmountWithoutTax: line.AmountWithoutTax,
-			AmountWithTax:    line.AmountWithTax,
-			TaxPercentage:    line.TaxPercentage,
-			OperationType:    line.OperationType,
-		})
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	query, err := c.converter.ConvertRequestArgsToAgingQuery(args)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid aging report request")
+		return toolerror.InvalidArgument("Invalid aging report request", err), nil
 	}
 
-	// Convert movements to JSON
-	jsonData, err := c.converter.ConvertInvoiceMovementsToJson(movementDTOs)
+	report, err := c.service.GetAgingReport(ctx, query)
 	if err != nil {
-		c.logger.Error().Err(err).Msg("Failed to convert invoice movements to JSON")
-		return nil, err
+		c.logger.Error().Err(err).Str("accountId", query.AccountID).Msg("Failed to get aging report")
+		return toolError("Failed to get aging report", err), nil
 	}
 
-	c.logger.Info().Str("invoiceId", requestedInvoiceId).Int("movementsCount", len(movementDTOs)).Msg("Successfully retrieved invoice movements")
-	response := mcp.NewToolResultText(string(jsonData))
-	return response, nil
+	jsonData, err := c.converter.ConvertAgingReportToJson(report)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert aging report to JSON")
+		return toolError("Failed to convert aging report to JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
 }
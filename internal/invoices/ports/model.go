@@ -2,25 +2,121 @@ package ports
 
 type Invoices = []Invoice
 
+// InvoicesPage represents a page of the invoices of an account, with the cursor of the next one when there are more
+type InvoicesPage struct {
+	Invoices   Invoices `json:"invoices"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
+
+// Invoice represents an invoice as returned by the MCP API.
+// Amounts are exact decimal strings, e.g. "100.50".
 type Invoice struct {
 	ID               string `json:"id"`
-	AmountWithoutTax int    `json:"amount_without_tax"`
-	AmountWithTax    int    `json:"amount_with_tax"`
+	InvoiceNumber    string `json:"invoice_number"`
+	AmountWithoutTax string `json:"amount_without_tax"`
+	TaxAmount        string `json:"tax_amount"`
+	AmountWithTax    string `json:"amount_with_tax"`
+	Currency         string `json:"currency"`
 	Status           string `json:"status"`
 	IssueDate        string `json:"issue_date"`
 	DueDate          string `json:"due_date"`
+	InvoiceType      string `json:"invoice_type"`
+	// Credit notes reference the invoice they rectify
+	CorrectedInvoiceID string `json:"corrected_invoice_id,omitempty"`
+	CorrectionReason   string `json:"correction_reason,omitempty"`
+	// The customer location decides the taxes of the invoice, broken down per regime and rate
+	CustomerCountry    string          `json:"customer_country"`
+	CustomerPostalCode string          `json:"customer_postal_code,omitempty"`
+	TaxBreakdown       []TaxSummaryDTO `json:"tax_breakdown"`
+}
+
+// TaxSummaryDTO represents the taxable base and tax quota of the lines of an invoice taxed alike
+type TaxSummaryDTO struct {
+	Regime         string `json:"regime"`
+	Percentage     string `json:"percentage"`
+	ExemptionCause string `json:"exemption_cause,omitempty"`
+	TaxableBase    string `json:"taxable_base"`
+	TaxAmount      string `json:"tax_amount"`
 }
 
 // InvoiceMovementDTO represents a simplified view of a movement in the context of an invoice
 type InvoiceMovementDTO struct {
-	MovementID       string  `json:"movement_id"`
-	Description      string  `json:"description"`
-	Amount           float64 `json:"amount"`
-	AmountWithoutTax float64 `json:"amount_without_tax"`
-	AmountWithTax    float64 `json:"amount_with_tax"`
-	TaxPercentage    float64 `json:"tax_percentage"`
-	OperationType    string  `json:"operation_type"`
+	MovementID       string `json:"movement_id"`
+	Description      string `json:"description"`
+	Amount           string `json:"amount"`
+	AmountWithoutTax string `json:"amount_without_tax"`
+	AmountWithTax    string `json:"amount_with_tax"`
+	TaxPercentage    string `json:"tax_percentage"`
+	TaxRegime        string `json:"tax_regime"`
+	ExemptionCause   string `json:"exemption_cause,omitempty"`
+	ProductCategory  string `json:"product_category,omitempty"`
+	Currency         string `json:"currency"`
+	OperationType    string `json:"operation_type"`
+	TransactionDate  string `json:"transaction_date"`
+	// The movement as it was recorded, before converting it to the invoice currency
+	OriginalAmount   string `json:"original_amount"`
+	OriginalCurrency string `json:"original_currency"`
+	ExchangeRate     string `json:"exchange_rate,omitempty"`
 }
 
 // InvoiceMovementsDTO is a slice of InvoiceMovementDTO
 type InvoiceMovementsDTO = []InvoiceMovementDTO
+
+// InvoiceMovementsPageDTO represents a page of the movements of an invoice, with the cursor of the next one when there are more
+type InvoiceMovementsPageDTO struct {
+	Movements  InvoiceMovementsDTO `json:"movements"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+}
+
+// StatusChangeDTO represents a transition in the status history of an invoice
+type StatusChangeDTO struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+// InvoiceDetailDTO represents an invoice together with its lines
+type InvoiceDetailDTO struct {
+	Invoice Invoice             `json:"invoice"`
+	Lines   InvoiceMovementsDTO `json:"lines"`
+}
+
+// PeriodInvoicesDTO represents the invoices issued to an account in a month, e.g. 2025-03
+type PeriodInvoicesDTO struct {
+	Period   string             `json:"period"`
+	Invoices []InvoiceDetailDTO `json:"invoices"`
+}
+
+// AgingReportDTO represents the accounts receivable aging as returned by the MCP API.
+// Amounts are exact decimal strings, e.g. "100.50".
+type AgingReportDTO struct {
+	AccountID string            `json:"account_id,omitempty"`
+	Currency  string            `json:"currency"`
+	AsOf      string            `json:"as_of"`
+	Totals    AgingAmountsDTO   `json:"totals"`
+	Statuses  []StatusAgingDTO  `json:"statuses"`
+	Accounts  []AccountAgingDTO `json:"accounts"`
+}
+
+// AgingAmountsDTO represents the outstanding amounts by aging bucket, in days past the due date.
+type AgingAmountsDTO struct {
+	Current    string `json:"current"`
+	Days1To30  string `json:"days_1_30"`
+	Days31To60 string `json:"days_31_60"`
+	Days61To90 string `json:"days_61_90"`
+	Over90     string `json:"days_over_90"`
+	Total      string `json:"total"`
+	Invoices   int    `json:"invoices"`
+}
+
+// StatusAgingDTO represents the aging of the receivable invoices with a status.
+type StatusAgingDTO struct {
+	Status string `json:"status"`
+	AgingAmountsDTO
+}
+
+// AccountAgingDTO represents the aging of the receivable invoices of an account.
+type AccountAgingDTO struct {
+	AccountID string `json:"account_id"`
+	AgingAmountsDTO
+}
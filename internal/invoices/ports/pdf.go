@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+)
+
+const pdfMIMEType = "application/pdf"
+
+// ErrInvalidBuyer wraps the errors of the buyer given to render an invoice.
+var ErrInvalidBuyer = errors.New("invalid buyer")
+
+// DocumentRenderer renders an invoice document as a printable file.
+type DocumentRenderer interface {
+	Render(document domain.Document) ([]byte, error)
+}
+
+// renderInvoicePDF renders an issued invoice as a PDF. The buyer is read from the buyer arguments when its
+// tax ID is given; otherwise the document identifies the buyer by the account and location of the invoice.
+func renderInvoicePDF(ctx context.Context, service InvoiceService, renderer DocumentRenderer, converter Converter, id domain.InvoiceID, args map[string]any) (domain.Document, []byte, error) {
+	var buyer domain.Party
+	if taxID, _ := args["buyerTaxId"].(string); taxID != "" {
+		invoice, err := service.GetInvoiceByID(id)
+		if err != nil {
+			return domain.Document{}, nil, err
+		}
+		if buyer, err = converter.ConvertRequestArgsToBuyer(args, invoice.CustomerLocation); err != nil {
+			return domain.Document{}, nil, fmt.Errorf("%w: %w", ErrInvalidBuyer, err)
+		}
+	}
+
+	document, err := service.GetInvoiceDocument(ctx, id, buyer)
+	if err != nil {
+		return domain.Document{}, nil, err
+	}
+	output, err := renderer.Render(document)
+	if err != nil {
+		return domain.Document{}, nil, fmt.Errorf("failed to render invoice %s: %w", document.Invoice.InvoiceNumber, err)
+	}
+	return document, output, nil
+}
@@ -0,0 +1,194 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+)
+
+const periodLayout = "2006-01"
+
+var ErrInvalidPeriod = errors.New("period must be a month in YYYY-MM format")
+
+// ExplainInvoicePrompt handles the ExplainInvoice prompt, which asks to explain an invoice to its customer
+func (c controller) ExplainInvoicePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	c.logger.Info().Msg("Processing request in ExplainInvoice prompt")
+
+	invoice, lines, err := c.invoiceWithLines(ctx, request.Params.Arguments["accountId"], request.Params.Arguments["invoiceId"])
+	if err != nil {
+		return nil, err
+	}
+	invoiceContent, err := c.invoiceContent(invoice, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Explain invoice %s of account %s", invoiceName(invoice), invoice.AccountID),
+		append([]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(fmt.Sprintf(
+				"Explain invoice %s of account %s to the customer in plain words. Go through what each line charges or credits, "+
+					"the taxes applied per regime and rate and how the total due is reached. Point out credits, amounts converted "+
+					"from another currency and anything the customer could find unusual. Only use the invoice data below.",
+				invoiceName(invoice), invoice.AccountID,
+			))),
+		}, invoiceContent...),
+	), nil
+}
+
+// CompareInvoicesPrompt handles the CompareInvoices prompt, which asks to compare the invoices of a month with the previous one
+func (c controller) CompareInvoicesPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	c.logger.Info().Msg("Processing request in CompareInvoices prompt")
+
+	accountId := request.Params.Arguments["accountId"]
+	if accountId == "" {
+		return nil, ErrMissingAccountId
+	}
+	period, err := time.Parse(periodLayout, request.Params.Arguments["period"])
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse period")
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPeriod, request.Params.Arguments["period"])
+	}
+	previous := period.AddDate(0, -1, 0)
+
+	messages := []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(fmt.Sprintf(
+			"Compare the invoices of account %s issued in %s with the ones issued in %s. Summarize how the total billed and "+
+				"the taxes changed, and explain the differences line by line: new, removed and changed charges, credits and "+
+				"currency conversions. Only use the invoice data below.",
+			accountId, period.Format(periodLayout), previous.Format(periodLayout),
+		))),
+	}
+	for _, month := range []time.Time{previous, period} {
+		content, err := c.periodContent(ctx, accountId, month)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, content)
+	}
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Compare the invoices of account %s in %s with %s", accountId, period.Format(periodLayout), previous.Format(periodLayout)),
+		messages,
+	), nil
+}
+
+// DisputeResponsePrompt handles the DisputeResponse prompt, which asks to draft the answer to a customer disputing an invoice
+func (c controller) DisputeResponsePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	c.logger.Info().Msg("Processing request in DisputeResponse prompt")
+
+	invoice, lines, err := c.invoiceWithLines(ctx, request.Params.Arguments["accountId"], request.Params.Arguments["invoiceId"])
+	if err != nil {
+		return nil, err
+	}
+	invoiceContent, err := c.invoiceContent(invoice, lines)
+	if err != nil {
+		return nil, err
+	}
+	history, err := c.service.GetInvoiceStatusHistory(ctx, invoice.ID)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoice.ID.String()).Msg("Failed to get invoice status history")
+		return nil, err
+	}
+	historyData, err := c.converter.ConvertStatusHistoryToJson(history)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := fmt.Sprintf(
+		"Draft the answer to the customer of account %s, who disputes invoice %s. Be courteous and precise, justify each "+
+			"disputed charge with the lines, dates and taxes below, and do not promise anything the data does not support. "+
+			"If part of the invoice is wrong, acknowledge it and propose a credit note for the affected lines, to be issued "+
+			"with the IssueCreditNote tool.",
+		invoice.AccountID, invoiceName(invoice),
+	)
+	if complaint := strings.TrimSpace(request.Params.Arguments["complaint"]); complaint != "" {
+		instructions += "\n\nThe customer wrote:\n" + complaint
+	}
+
+	messages := append([]mcp.PromptMessage{mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(instructions))}, invoiceContent...)
+	messages = append(messages, mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Status history of the invoice:\n"+string(historyData))))
+	return mcp.NewGetPromptResult(fmt.Sprintf("Answer the dispute of invoice %s", invoiceName(invoice)), messages), nil
+}
+
+// invoiceWithLines fetches an invoice of an account and its lines
+func (c controller) invoiceWithLines(ctx context.Context, accountId, invoiceId string) (domain.Invoice, []domain.InvoiceLine, error) {
+	invoice, err := c.accountInvoice(accountId, invoiceId)
+	if err != nil {
+		return domain.Invoice{}, nil, err
+	}
+	lines, err := c.service.GetInvoiceLines(ctx, invoice.ID)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId).Msg("Failed to get invoice lines")
+		return domain.Invoice{}, nil, err
+	}
+	return invoice, lines, nil
+}
+
+// invoiceContent embeds the invoice as its resource, followed by its lines
+func (c controller) invoiceContent(invoice domain.Invoice, lines []domain.InvoiceLine) ([]mcp.PromptMessage, error) {
+	invoiceData, err := c.converter.ConvertDomainInvoiceToJsonInvoice(invoice)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert invoice to JSON")
+		return nil, err
+	}
+	linesData, err := c.converter.ConvertInvoiceMovementsToJson(c.converter.ConvertInvoiceLinesToDTO(lines))
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert invoice lines to JSON")
+		return nil, err
+	}
+
+	return []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+			URI:      InvoiceResourceURI(invoice.AccountID, invoice.ID),
+			MIMEType: jsonMIMEType,
+			Text:     string(invoiceData),
+		})),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("Lines of the invoice:\n"+string(linesData))),
+	}, nil
+}
+
+// periodContent lists the invoices issued to an account in a month with their lines
+func (c controller) periodContent(ctx context.Context, accountId string, month time.Time) (mcp.PromptMessage, error) {
+	invoices, err := c.service.GetInvoicesByCriteria(accountId, domain.Criteria{
+		IssueDateFrom: month,
+		IssueDateTo:   month.AddDate(0, 1, -1),
+	})
+	if err != nil {
+		c.logger.Error().Err(err).Str("accountId", accountId).Msg("Failed to fetch invoices by criteria")
+		return mcp.PromptMessage{}, err
+	}
+
+	period := PeriodInvoicesDTO{Period: month.Format(periodLayout), Invoices: make([]InvoiceDetailDTO, len(invoices))}
+	for i, invoice := range invoices {
+		lines, err := c.service.GetInvoiceLines(ctx, invoice.ID)
+		if err != nil {
+			c.logger.Error().Err(err).Str("invoiceId", invoice.ID.String()).Msg("Failed to get invoice lines")
+			return mcp.PromptMessage{}, err
+		}
+		period.Invoices[i] = InvoiceDetailDTO{
+			Invoice: c.converter.convertDomainInvoice(invoice),
+			Lines:   c.converter.ConvertInvoiceLinesToDTO(lines),
+		}
+	}
+
+	jsonData, err := json.Marshal(period)
+	if err != nil {
+		return mcp.PromptMessage{}, fmt.Errorf("failed to convert the invoices of %s to JSON: %w", period.Period, err)
+	}
+	return mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(fmt.Sprintf("Invoices issued in %s:\n%s", period.Period, jsonData))), nil
+}
+
+// invoiceName names an invoice by its number, or by its ID while it is a draft
+func invoiceName(invoice domain.Invoice) string {
+	if invoice.InvoiceNumber == "" {
+		return "draft " + invoice.ID.String()
+	}
+	return invoice.InvoiceNumber
+}
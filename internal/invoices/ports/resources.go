@@ -0,0 +1,139 @@
+package ports
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	domain "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+)
+
+const jsonMIMEType = "application/json"
+
+// InvoiceResourceURI is the URI of the JSON resource of an invoice.
+func InvoiceResourceURI(accountId string, id domain.InvoiceID) string {
+	return fmt.Sprintf("billing://accounts/%s/invoices/%s", accountId, id)
+}
+
+// InvoicePDFResourceURI is the URI of the PDF resource of an issued invoice.
+func InvoicePDFResourceURI(accountId string, id domain.InvoiceID) string {
+	return InvoiceResourceURI(accountId, id) + "/pdf"
+}
+
+// ReadInvoiceResource handles the billing://accounts/{accountId}/invoices/{invoiceId} resource template
+func (c controller) ReadInvoiceResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	c.logger.Info().Str("uri", request.Params.URI).Msg("Processing request in invoice resource")
+
+	invoice, err := c.accountInvoiceFromResource(request)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := c.converter.ConvertDomainInvoiceToJsonInvoice(invoice)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert invoice to JSON")
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: request.Params.URI, MIMEType: jsonMIMEType, Text: string(jsonData)},
+	}, nil
+}
+
+// ReadInvoicePDFResource handles the billing://accounts/{accountId}/invoices/{invoiceId}/pdf resource template.
+// The buyer is identified by the account and location of the invoice, like in the GetInvoicePDF tool without buyer arguments.
+func (c controller) ReadInvoicePDFResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	c.logger.Info().Str("uri", request.Params.URI).Msg("Processing request in invoice PDF resource")
+
+	invoice, err := c.accountInvoiceFromResource(request)
+	if err != nil {
+		return nil, err
+	}
+
+	_, output, err := renderInvoicePDF(ctx, c.service, c.renderer, c.converter, invoice.ID, nil)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoice.ID.String()).Msg("Failed to render invoice PDF")
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.BlobResourceContents{URI: request.Params.URI, MIMEType: pdfMIMEType, Blob: base64.StdEncoding.EncodeToString(output)},
+	}, nil
+}
+
+// ListInvoiceResources lists the resources of the invoices of an account. Issued invoices also list their PDF.
+func (c controller) ListInvoiceResources(ctx context.Context, accountId string) ([]mcp.Resource, error) {
+	invoices, err := c.service.GetInvoicesByCriteria(accountId, domain.Criteria{})
+	if err != nil {
+		c.logger.Error().Err(err).Str("accountId", accountId).Msg("Failed to fetch invoices of the account")
+		return nil, err
+	}
+
+	resources := make([]mcp.Resource, 0, len(invoices))
+	for _, invoice := range invoices {
+		name := invoiceName(invoice)
+		resources = append(resources, mcp.NewResource(InvoiceResourceURI(accountId, invoice.ID), "Invoice "+name,
+			mcp.WithResourceDescription(fmt.Sprintf("%s %s invoice of %s", invoice.Status, invoice.Type, invoice.IssueDate.Format("2006-01-02"))),
+			mcp.WithMIMEType(jsonMIMEType),
+		))
+		if invoice.InvoiceNumber != "" {
+			resources = append(resources, mcp.NewResource(InvoicePDFResourceURI(accountId, invoice.ID), "Invoice "+name+" (PDF)",
+				mcp.WithResourceDescription("Printable PDF document of invoice "+name),
+				mcp.WithMIMEType(pdfMIMEType),
+			))
+		}
+	}
+	return resources, nil
+}
+
+// accountInvoiceFromResource fetches the invoice of a resource, checking it belongs to the account of the URI
+func (c controller) accountInvoiceFromResource(request mcp.ReadResourceRequest) (domain.Invoice, error) {
+	return c.accountInvoice(resourceArgument(request, "accountId"), resourceArgument(request, "invoiceId"))
+}
+
+// accountInvoice fetches an invoice checking it belongs to the given account
+func (c controller) accountInvoice(accountId, requestedInvoiceId string) (domain.Invoice, error) {
+	if accountId == "" {
+		return domain.Invoice{}, ErrMissingAccountId
+	}
+	if requestedInvoiceId == "" {
+		return domain.Invoice{}, ErrMissingInvoiceId
+	}
+
+	invoiceId, err := domain.ParseInvoiceID(requestedInvoiceId)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse invoice ID")
+		return domain.Invoice{}, fmt.Errorf("invalid invoice ID format: %w", err)
+	}
+
+	return c.invoiceOfAccount(accountId, invoiceId)
+}
+
+// invoiceOfAccount fetches an invoice checking it belongs to the given account
+func (c controller) invoiceOfAccount(accountId string, invoiceId domain.InvoiceID) (domain.Invoice, error) {
+	invoice, err := c.service.GetInvoiceByID(invoiceId)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", invoiceId.String()).Msg("Failed to fetch invoice by ID")
+		return domain.Invoice{}, err
+	}
+	// Invoices of other accounts are reported as missing, so they cannot be probed through another account
+	if invoice.AccountID != accountId {
+		c.logger.Warn().Str("invoiceId", invoiceId.String()).Str("accountId", accountId).Msg("Invoice does not belong to the account")
+		return domain.Invoice{}, fmt.Errorf("%w: %s", domain.ErrInvoiceNotFound, invoiceId)
+	}
+	return invoice, nil
+}
+
+// resourceArgument reads a variable of a resource template. The server gives the values of the URI as lists.
+func resourceArgument(request mcp.ReadResourceRequest, name string) string {
+	switch value := request.Params.Arguments[name].(type) {
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	}
+	return ""
+}
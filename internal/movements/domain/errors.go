@@ -13,4 +13,10 @@ var (
 	ErrMovementUpdateFailed = errors.New("movement update failed")
 	// ErrMovementDeletionFailed is returned when movement deletion fails.
 	ErrMovementDeletionFailed = errors.New("movement deletion failed")
+	// ErrMovementNotCancellable is returned when a movement that is not pending is cancelled.
+	ErrMovementNotCancellable = errors.New("only pending movements can be cancelled")
+	// ErrMovementAlreadyCancelled is returned when the status of a cancelled movement is changed.
+	ErrMovementAlreadyCancelled = errors.New("cancelled movements cannot change status")
+	// ErrStatusChangedConcurrently is returned when the status of a movement changed since it was read.
+	ErrStatusChangedConcurrently = errors.New("movement status was changed concurrently")
 )
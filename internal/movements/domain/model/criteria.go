@@ -1,10 +1,25 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+)
 
 // SearchCriteria represents the criteria for searching movements.
 type SearchCriteria struct {
+	AccountID string
 	InvoiceID *uuid.UUID
 	Status    *Status
 	// Add other filter fields as needed
 }
+
+// Fields the movements can be sorted by
+const (
+	SortByTransactionDate = "transactionDate"
+	SortByAmount          = "amount"
+)
+
+var (
+	SortFields  = []string{SortByTransactionDate, SortByAmount}
+	DefaultSort = pagination.Sort{Field: SortByTransactionDate, Direction: pagination.Descending}
+)
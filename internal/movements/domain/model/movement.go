@@ -1,17 +1,32 @@
 package model
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrAccountIDEmpty      = errors.New("account ID cannot be empty")
+	ErrAmountZero          = errors.New("movement amount cannot be zero")
+	ErrDescriptionEmpty    = errors.New("movement description cannot be empty")
+	ErrUnknownMovementType = errors.New("unknown movement type")
 )
 
 // Movement represents an invoice movement.
+// Pending movements may not belong to an invoice yet (uuid.Nil InvoiceID) until a billing run invoices them.
 type Movement struct {
 	MovementID      uuid.UUID
+	AccountID       string
 	InvoiceID       uuid.UUID
-	Amount          float64
+	Amount          money.Money       // Amount including tax
+	TaxPercentage   *money.Percentage // Nil when the tax is resolved when the movement is invoiced
+	ProductCategory taxes.Category    // When set, the tax of the movement is resolved from it when it is invoiced
 	MovementType    MovementType
 	Description     string
 	TransactionDate time.Time
@@ -40,17 +55,32 @@ func MovementTypeFromString(s string) (MovementType, error) {
 	case string(MovementTypeDebit):
 		return MovementTypeDebit, nil
 	default:
-		return "", fmt.Errorf("invalid movement type: %s", s)
+		return "", fmt.Errorf("%w: %s", ErrUnknownMovementType, s)
 	}
 }
 
-// NewMovement creates a new movement.
-func NewMovement(invoiceID uuid.UUID, amount float64, movementType MovementType, description string) (*Movement, error) {
-	// TODO: Add validation logic
+// NewMovement creates a new pending movement, not invoiced yet.
+func NewMovement(accountID string, amount money.Money, taxPercentage *money.Percentage, category taxes.Category, movementType MovementType, description string) (*Movement, error) {
+	if accountID == "" {
+		return nil, ErrAccountIDEmpty
+	}
+	if amount.IsZero() {
+		return nil, ErrAmountZero
+	}
+	if _, err := MovementTypeFromString(string(movementType)); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(description) == "" {
+		return nil, ErrDescriptionEmpty
+	}
+
 	return &Movement{
 		MovementID:      uuid.New(),
-		InvoiceID:       invoiceID,
+		AccountID:       accountID,
+		InvoiceID:       uuid.Nil,
 		Amount:          amount,
+		TaxPercentage:   taxPercentage,
+		ProductCategory: category,
 		MovementType:    movementType,
 		Description:     description,
 		TransactionDate: time.Now(),
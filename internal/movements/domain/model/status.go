@@ -1,6 +1,11 @@
 package model
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidTransition = errors.New("invalid movement status transition")
 
 // Status represents the status of a movement.
 type Status string
@@ -30,3 +35,21 @@ func StatusFromString(s string) (Status, error) {
 		return "", fmt.Errorf("invalid movement status: %s", s)
 	}
 }
+
+// transitions lists, for every status, the statuses a movement can be moved to by a status change. A movement only
+// becomes INVOICED when an invoice takes it as a line, and neither INVOICED nor CANCELLED movements change status.
+var transitions = map[Status][]Status{
+	StatusPending:   {StatusCancelled},
+	StatusInvoiced:  {},
+	StatusCancelled: {},
+}
+
+// CanTransitionTo reports whether the transition table allows moving from s to the given status.
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	"github.com/rs/zerolog"
 )
 
@@ -14,9 +17,11 @@ import (
 type MovementRepository interface {
 	Create(ctx context.Context, movement *model.Movement) error
 	GetByID(ctx context.Context, id uuid.UUID) (*model.Movement, error)
-	UpdateStatus(ctx context.Context, movement *model.Movement) error // Renamed from Update
+	// UpdateStatus stores the status of the movement, or fails with ErrStatusChangedConcurrently when it is no longer in the from status.
+	UpdateStatus(ctx context.Context, movement *model.Movement, from model.Status) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	Search(ctx context.Context, criteria *model.SearchCriteria) ([]*model.Movement, error)
+	SearchPage(ctx context.Context, criteria *model.SearchCriteria, page pagination.Request) (pagination.Page[*model.Movement], error)
 }
 
 // MovementService provides business logic for movements.
@@ -34,11 +39,12 @@ func NewMovementService(logger zerolog.Logger, repository MovementRepository) *M
 	}
 }
 
-// CreateMovement creates a new movement.
-func (s *MovementService) CreateMovement(ctx context.Context, invoiceID uuid.UUID, amount float64, movementType model.MovementType, description string) (*model.Movement, error) {
+// CreateMovement creates a new pending movement for an account, invoiced by the next billing run of its period.
+// Movements are only added to an invoice by invoicing them, which keeps the totals of the invoice in line with its lines.
+func (s *MovementService) CreateMovement(ctx context.Context, accountID string, amount money.Money, taxPercentage *money.Percentage, category taxes.Category, movementType model.MovementType, description string) (*model.Movement, error) {
 	log := s.logger.With().Str("method", "CreateMovement").Logger()
 
-	movement, err := model.NewMovement(invoiceID, amount, movementType, description)
+	movement, err := model.NewMovement(accountID, amount, taxPercentage, category, movementType, description)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create new movement domain model")
 		return nil, fmt.Errorf("failed to create new movement: %w", err)
@@ -67,7 +73,7 @@ func (s *MovementService) GetMovement(ctx context.Context, id uuid.UUID) (*model
 	return movement, nil
 }
 
-// UpdateMovementStatus updates the status of an existing movement.
+// UpdateMovementStatus updates the status of an existing movement, as long as the transition table allows it.
 func (s *MovementService) UpdateMovementStatus(ctx context.Context, id uuid.UUID, status model.Status) (*model.Movement, error) {
 	log := s.logger.With().Str("method", "UpdateMovementStatus").Str("movementID", id.String()).Str("newStatus", string(status)).Logger()
 
@@ -77,10 +83,18 @@ func (s *MovementService) UpdateMovementStatus(ctx context.Context, id uuid.UUID
 		return nil, fmt.Errorf("failed to get movement with ID %s for update: %w", id, err)
 	}
 
-	// TODO: Add business logic for status transitions if needed
+	if movement.Status == model.StatusCancelled {
+		log.Warn().Msg("Rejected status change of a cancelled movement")
+		return nil, ErrMovementAlreadyCancelled
+	}
+	if !movement.Status.CanTransitionTo(status) {
+		log.Warn().Str("status", string(movement.Status)).Msg("Rejected movement status transition")
+		return nil, fmt.Errorf("%w: from %s to %s", model.ErrInvalidTransition, movement.Status, status)
+	}
+	from := movement.Status
 	movement.Status = status
 
-	if err := s.repository.UpdateStatus(ctx, movement); err != nil { // Renamed from Update
+	if err := s.repository.UpdateStatus(ctx, movement, from); err != nil {
 		log.Error().Err(err).Msg("Failed to update movement status in repository")
 		return nil, fmt.Errorf("failed to update movement status: %w", err)
 	}
@@ -89,6 +103,31 @@ func (s *MovementService) UpdateMovementStatus(ctx context.Context, id uuid.UUID
 	return movement, nil
 }
 
+// CancelMovement cancels a pending movement so it is never invoiced.
+func (s *MovementService) CancelMovement(ctx context.Context, id uuid.UUID) (*model.Movement, error) {
+	log := s.logger.With().Str("method", "CancelMovement").Str("movementID", id.String()).Logger()
+
+	movement, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get movement for cancellation")
+		return nil, fmt.Errorf("failed to get movement with ID %s for cancellation: %w", id, err)
+	}
+
+	if movement.Status != model.StatusPending {
+		log.Warn().Str("status", string(movement.Status)).Msg("Rejected cancellation of a non-pending movement")
+		return nil, ErrMovementNotCancellable
+	}
+	movement.Status = model.StatusCancelled
+
+	if err := s.repository.UpdateStatus(ctx, movement, model.StatusPending); err != nil {
+		log.Error().Err(err).Msg("Failed to cancel movement in repository")
+		return nil, fmt.Errorf("failed to cancel movement: %w", err)
+	}
+
+	log.Info().Msg("Movement cancelled successfully")
+	return movement, nil
+}
+
 // SearchMovements searches for movements based on criteria.
 func (s *MovementService) SearchMovements(ctx context.Context, criteria *model.SearchCriteria) ([]*model.Movement, error) {
 	log := s.logger.With().Str("method", "SearchMovements").Logger()
@@ -102,3 +141,17 @@ func (s *MovementService) SearchMovements(ctx context.Context, criteria *model.S
 	log.Info().Int("count", len(movements)).Msg("Movements searched successfully")
 	return movements, nil
 }
+
+// SearchMovementsPage searches for a page of the movements matching the criteria.
+func (s *MovementService) SearchMovementsPage(ctx context.Context, criteria *model.SearchCriteria, page pagination.Request) (pagination.Page[*model.Movement], error) {
+	log := s.logger.With().Str("method", "SearchMovementsPage").Logger()
+
+	movements, err := s.repository.SearchPage(ctx, criteria, page)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search page of movements in repository")
+		return pagination.Page[*model.Movement]{}, fmt.Errorf("failed to search movements: %w", err)
+	}
+
+	log.Info().Int("count", len(movements.Items)).Bool("more", movements.NextCursor != "").Msg("Page of movements searched successfully")
+	return movements, nil
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: /Users/ricardo/GitHub/billing-mcp/internal/movements/domain/service.go
+// Source: internal/movements/domain/service.go
 //
 // Generated by this command:
 //
-//	mockgen -source=/Users/ricardo/GitHub/billing-mcp/internal/movements/domain/service.go -destination=/Users/ricardo/GitHub/billing-mcp/internal/movements/domain/service_mock.go -package=domain MovementRepository
+//	mockgen -source=internal/movements/domain/service.go -destination=internal/movements/domain/service_mock.go -package=domain MovementRepository
 //
 
 // Package domain is a generated GoMock package.
@@ -15,6 +15,7 @@ import (
 
 	uuid "github.com/google/uuid"
 	model "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	pagination "github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -100,16 +101,31 @@ func (mr *MockMovementRepositoryMockRecorder) Search(ctx, criteria any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockMovementRepository)(nil).Search), ctx, criteria)
 }
 
+// SearchPage mocks base method.
+func (m *MockMovementRepository) SearchPage(ctx context.Context, criteria *model.SearchCriteria, page pagination.Request) (pagination.Page[*model.Movement], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPage", ctx, criteria, page)
+	ret0, _ := ret[0].(pagination.Page[*model.Movement])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPage indicates an expected call of SearchPage.
+func (mr *MockMovementRepositoryMockRecorder) SearchPage(ctx, criteria, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPage", reflect.TypeOf((*MockMovementRepository)(nil).SearchPage), ctx, criteria, page)
+}
+
 // UpdateStatus mocks base method.
-func (m *MockMovementRepository) UpdateStatus(ctx context.Context, movement *model.Movement) error {
+func (m *MockMovementRepository) UpdateStatus(ctx context.Context, movement *model.Movement, from model.Status) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, movement)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, movement, from)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockMovementRepositoryMockRecorder) UpdateStatus(ctx, movement any) *gomock.Call {
+func (mr *MockMovementRepositoryMockRecorder) UpdateStatus(ctx, movement, from any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockMovementRepository)(nil).UpdateStatus), ctx, movement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockMovementRepository)(nil).UpdateStatus), ctx, movement, from)
 }
@@ -8,6 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -22,26 +25,27 @@ func TestMovementService_CreateMovement(t *testing.T) {
 	service := domain.NewMovementService(logger, mockRepo)
 
 	ctx := context.Background()
-	invoiceID := uuid.New()
-	amount := 100.50
+	accountID := "account_A"
+	amount := money.New(10050, money.DefaultCurrency)
 	movementType := model.MovementTypeCredit
 	description := "Test Credit Movement"
 
 	// Capture the argument passed to Create, as the ID is generated within NewMovement
 	mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *model.Movement) error {
-		assert.Equal(t, invoiceID, m.InvoiceID)
+		assert.Equal(t, accountID, m.AccountID)
+		assert.Equal(t, uuid.Nil, m.InvoiceID, "movements are created pending, without an invoice")
 		assert.Equal(t, amount, m.Amount)
 		assert.Equal(t, movementType, m.MovementType)
 		assert.Equal(t, description, m.Description)
+		assert.Equal(t, taxes.CategoryGeneral, m.ProductCategory)
 		assert.NotEqual(t, uuid.Nil, m.MovementID)
 		assert.Equal(t, model.StatusPending, m.Status) // NewMovement sets status to Pending
 		return nil
 	}).Times(1)
 
-	createdMovement, err := service.CreateMovement(ctx, invoiceID, amount, movementType, description)
+	createdMovement, err := service.CreateMovement(ctx, accountID, amount, nil, taxes.CategoryGeneral, movementType, description)
 	assert.NoError(t, err)
 	assert.NotNil(t, createdMovement)
-	assert.Equal(t, invoiceID, createdMovement.InvoiceID)
 	assert.Equal(t, amount, createdMovement.Amount)
 	assert.Equal(t, movementType, createdMovement.MovementType)
 	assert.Equal(t, description, createdMovement.Description)
@@ -63,7 +67,7 @@ func TestMovementService_GetMovement(t *testing.T) {
 	expectedMovement := &model.Movement{
 		MovementID:      movementID,
 		InvoiceID:       uuid.New(),
-		Amount:          200.00,
+		Amount:          money.New(20000, money.DefaultCurrency),
 		MovementType:    model.MovementTypeDebit,
 		Description:     "Test Debit Movement",
 		TransactionDate: now,
@@ -94,7 +98,7 @@ func TestMovementService_UpdateMovementStatus(t *testing.T) {
 	originalMovement := &model.Movement{
 		MovementID:      movementID,
 		InvoiceID:       uuid.New(),
-		Amount:          300.00,
+		Amount:          money.New(30000, money.DefaultCurrency),
 		MovementType:    model.MovementTypeCredit,
 		Description:     "Test Update Status",
 		TransactionDate: now,
@@ -102,7 +106,7 @@ func TestMovementService_UpdateMovementStatus(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().GetByID(ctx, movementID).Return(originalMovement, nil).Times(1)
-	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *model.Movement) error {
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), originalStatus).DoAndReturn(func(_ context.Context, m *model.Movement, _ model.Status) error {
 		assert.Equal(t, movementID, m.MovementID)
 		assert.Equal(t, newStatus, m.Status) // Check that status is updated
 		return nil
@@ -135,7 +139,7 @@ func TestMovementService_SearchMovements(t *testing.T) {
 		{
 			MovementID:      uuid.New(),
 			InvoiceID:       invoiceUUID,
-			Amount:          50.25,
+			Amount:          money.New(5025, money.DefaultCurrency),
 			MovementType:    model.MovementTypeCredit, // Assuming search might return this type
 			Description:     "Search Result 1",
 			TransactionDate: now,
@@ -149,3 +153,158 @@ func TestMovementService_SearchMovements(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedMovements, results)
 }
+
+func TestMovementService_SearchMovementsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := domain.NewMockMovementRepository(ctrl)
+	service := domain.NewMovementService(zerolog.Nop(), mockRepo)
+
+	ctx := context.Background()
+	criteria := &model.SearchCriteria{AccountID: "account_A"}
+	page := pagination.Request{Size: 1, Sort: model.DefaultSort}
+	expectedPage := pagination.Page[*model.Movement]{
+		Items:      []*model.Movement{{MovementID: uuid.New(), AccountID: "account_A", Amount: money.New(5025, money.DefaultCurrency)}},
+		NextCursor: "next",
+	}
+
+	mockRepo.EXPECT().SearchPage(ctx, criteria, page).Return(expectedPage, nil)
+
+	result, err := service.SearchMovementsPage(ctx, criteria, page)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPage, result)
+}
+
+func TestMovementService_CancelMovement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := domain.NewMockMovementRepository(ctrl)
+	logger := zerolog.Nop()
+	service := domain.NewMovementService(logger, mockRepo)
+
+	ctx := context.Background()
+	movementID := uuid.New()
+	pendingMovement := &model.Movement{
+		MovementID:      movementID,
+		InvoiceID:       uuid.New(),
+		Amount:          money.New(7500, money.DefaultCurrency),
+		MovementType:    model.MovementTypeCredit,
+		Description:     "Test Cancel",
+		TransactionDate: time.Now().Truncate(time.Microsecond),
+		Status:          model.StatusPending,
+	}
+
+	mockRepo.EXPECT().GetByID(ctx, movementID).Return(pendingMovement, nil).Times(1)
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), model.StatusPending).DoAndReturn(func(_ context.Context, m *model.Movement, _ model.Status) error {
+		assert.Equal(t, model.StatusCancelled, m.Status)
+		return nil
+	}).Times(1)
+
+	cancelledMovement, err := service.CancelMovement(ctx, movementID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusCancelled, cancelledMovement.Status)
+}
+
+func TestMovementService_CancelMovement_NotPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := domain.NewMockMovementRepository(ctrl)
+	logger := zerolog.Nop()
+	service := domain.NewMovementService(logger, mockRepo)
+
+	ctx := context.Background()
+	movementID := uuid.New()
+	invoicedMovement := &model.Movement{
+		MovementID: movementID,
+		InvoiceID:  uuid.New(),
+		Status:     model.StatusInvoiced,
+	}
+
+	mockRepo.EXPECT().GetByID(ctx, movementID).Return(invoicedMovement, nil).Times(1)
+
+	_, err := service.CancelMovement(ctx, movementID)
+	assert.ErrorIs(t, err, domain.ErrMovementNotCancellable)
+}
+
+func TestMovementService_CancelMovement_ChangedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := domain.NewMockMovementRepository(ctrl)
+	logger := zerolog.Nop()
+	service := domain.NewMovementService(logger, mockRepo)
+
+	ctx := context.Background()
+	movementID := uuid.New()
+	pendingMovement := &model.Movement{
+		MovementID: movementID,
+		InvoiceID:  uuid.New(),
+		Status:     model.StatusPending,
+	}
+
+	mockRepo.EXPECT().GetByID(ctx, movementID).Return(pendingMovement, nil).Times(1)
+	mockRepo.EXPECT().UpdateStatus(ctx, gomock.Any(), model.StatusPending).Return(domain.ErrStatusChangedConcurrently).Times(1)
+
+	_, err := service.CancelMovement(ctx, movementID)
+	assert.ErrorIs(t, err, domain.ErrStatusChangedConcurrently)
+}
+
+func TestMovementService_CreateMovement_RejectsInvalidMovements(t *testing.T) {
+	amount := money.New(10050, money.DefaultCurrency)
+
+	tests := []struct {
+		name         string
+		accountID    string
+		amount       money.Money
+		movementType model.MovementType
+		description  string
+		expected     error
+	}{
+		{"without account", "", amount, model.MovementTypeDebit, "Monthly fee", model.ErrAccountIDEmpty},
+		{"zero amount", "account_A", money.Zero(money.DefaultCurrency), model.MovementTypeDebit, "Monthly fee", model.ErrAmountZero},
+		{"unknown type", "account_A", amount, model.MovementType("REFUND"), "Monthly fee", model.ErrUnknownMovementType},
+		{"blank description", "account_A", amount, model.MovementTypeDebit, "  ", model.ErrDescriptionEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service := domain.NewMovementService(zerolog.Nop(), domain.NewMockMovementRepository(ctrl))
+
+			_, err := service.CreateMovement(context.Background(), tt.accountID, tt.amount, nil, "", tt.movementType, tt.description)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMovementService_UpdateMovementStatus_RejectsInvalidTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     model.Status
+		to       model.Status
+		expected error
+	}{
+		{"invoiced back to pending", model.StatusInvoiced, model.StatusPending, model.ErrInvalidTransition},
+		{"pending to invoiced without an invoice", model.StatusPending, model.StatusInvoiced, model.ErrInvalidTransition},
+		{"pending to pending", model.StatusPending, model.StatusPending, model.ErrInvalidTransition},
+		{"cancelled to pending", model.StatusCancelled, model.StatusPending, domain.ErrMovementAlreadyCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := domain.NewMockMovementRepository(ctrl)
+			service := domain.NewMovementService(zerolog.Nop(), mockRepo)
+			ctx := context.Background()
+			movement := &model.Movement{MovementID: uuid.New(), Status: tt.from}
+
+			mockRepo.EXPECT().GetByID(ctx, movement.MovementID).Return(movement, nil)
+
+			_, err := service.UpdateMovementStatus(ctx, movement.MovementID, tt.to)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
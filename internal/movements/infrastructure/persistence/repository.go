@@ -2,13 +2,16 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain"
 	domainmodel "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/infrastructure/persistence/sql"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	"github.com/rs/zerolog"
+	"gorm.io/gorm"
 )
 
 // MovementSQLRepository implements the domain.MovementRepository interface using SQL.
@@ -51,6 +54,9 @@ func (r *MovementSQLRepository) Create(ctx context.Context, movement *domainmode
 func (r *MovementSQLRepository) GetByID(ctx context.Context, id uuid.UUID) (*domainmodel.Movement, error) {
 	r.logger.Debug().Stringer("movementID", id).Msg("Getting movement by ID")
 	sqlMovement, err := r.client.GetMovementByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrMovementNotFound, id)
+	}
 	if err != nil {
 		r.logger.Warn().Err(err).Stringer("movementID", id).Msg("Failed to get movement by ID from client")
 		return nil, fmt.Errorf("repository: failed to get movement by ID %s: %w", id, err)
@@ -77,16 +83,17 @@ func (r *MovementSQLRepository) Update(ctx context.Context, movement *domainmode
 	return nil
 }
 
-// UpdateStatus updates the status of an existing movement.
-func (r *MovementSQLRepository) UpdateStatus(ctx context.Context, movement *domainmodel.Movement) error {
-	r.logger.Debug().Stringer("movementID", movement.MovementID).Str("status", string(movement.Status)).Msg("Updating movement status")
+// UpdateStatus updates the status of an existing movement that is still in the from status.
+func (r *MovementSQLRepository) UpdateStatus(ctx context.Context, movement *domainmodel.Movement, from domainmodel.Status) error {
+	r.logger.Debug().Stringer("movementID", movement.MovementID).Str("from", string(from)).Str("status", string(movement.Status)).Msg("Updating movement status")
 
-	// The domain service should fetch the movement, update its status and UpdatedAt, then pass it here.
-	// This repository method is responsible for persisting that change.
-	sqlMovement := r.converter.ToSQLMovement(movement)
-	err := r.client.UpdateMovement(ctx, sqlMovement) // Assumes UpdateMovement can handle status and UpdatedAt changes
+	// Only the status is written, so a status change never moves the movement to another invoice
+	err := r.client.UpdateMovementStatus(ctx, movement.MovementID, string(from), string(movement.Status))
 	if err != nil {
 		r.logger.Error().Err(err).Stringer("movementID", movement.MovementID).Msg("Failed to update movement status in repository")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: movement %s is no longer %s", domain.ErrStatusChangedConcurrently, movement.MovementID, from)
+		}
 		return fmt.Errorf("repository: failed to update movement status for ID %s: %w", movement.MovementID, err)
 	}
 	r.logger.Info().Stringer("movementID", movement.MovementID).Msg("Movement status updated successfully in repository")
@@ -121,3 +128,20 @@ func (r *MovementSQLRepository) Search(ctx context.Context, criteria *domainmode
 	r.logger.Info().Int("count", len(domainMovements)).Msg("Movements search completed in repository")
 	return domainMovements, nil
 }
+
+// SearchPage retrieves a page of the movements matching the criteria.
+func (r *MovementSQLRepository) SearchPage(ctx context.Context, criteria *domainmodel.SearchCriteria, page pagination.Request) (pagination.Page[*domainmodel.Movement], error) {
+	r.logger.Debug().Interface("criteria", criteria).Int("pageSize", page.Size).Msg("Searching page of movements")
+	sqlPage, err := r.client.SearchMovementsPage(ctx, criteria, page)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("Failed to search page of movements in repository")
+		return pagination.Page[*domainmodel.Movement]{}, fmt.Errorf("repository: failed to search movements: %w", err)
+	}
+
+	domainMovements := make([]*domainmodel.Movement, len(sqlPage.Items))
+	for i, sm := range sqlPage.Items {
+		domainMovements[i] = r.converter.ToDomainMovement(&sm)
+	}
+	r.logger.Info().Int("count", len(domainMovements)).Msg("Movements page search completed in repository")
+	return pagination.Page[*domainmodel.Movement]{Items: domainMovements, NextCursor: sqlPage.NextCursor}, nil
+}
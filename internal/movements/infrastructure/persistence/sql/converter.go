@@ -1,7 +1,10 @@
 package sql
 
 import (
+	"github.com/google/uuid"
 	domainmodel "github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
 	"github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
 )
 
@@ -23,8 +26,11 @@ func (c *MovementConverter) ToDomainMovement(sqlMovement *Movement) *domainmodel
 
 	return &domainmodel.Movement{
 		MovementID:      sqlMovement.ID,
-		InvoiceID:       sqlMovement.InvoiceID,
-		Amount:          sqlMovement.Amount,
+		AccountID:       sqlMovement.AccountID,
+		InvoiceID:       invoiceIDToDomain(sqlMovement.InvoiceID),
+		Amount:          money.New(int64(sqlMovement.Amount), currencyOrDefault(sqlMovement.Currency)),
+		TaxPercentage:   taxPercentageToDomain(sqlMovement.TaxPercentage),
+		ProductCategory: taxes.Category(sqlMovement.ProductCategory),
 		MovementType:    movementType,
 		Description:     sqlMovement.Description,
 		TransactionDate: sqlMovement.TransactionDate,
@@ -41,11 +47,54 @@ func (c *MovementConverter) ToSQLMovement(domainMovement *domainmodel.Movement)
 		BaseModel: persistence.BaseModel{
 			ID:        domainMovement.MovementID,
 		},
-		InvoiceID:       domainMovement.InvoiceID,
-		Amount:          domainMovement.Amount,
+		AccountID:       domainMovement.AccountID,
+		InvoiceID:       invoiceIDToSQL(domainMovement.InvoiceID),
+		Amount:          persistence.Decimal(domainMovement.Amount.Minor()),
+		Currency:        domainMovement.Amount.Currency().String(),
+		TaxPercentage:   taxPercentageToSQL(domainMovement.TaxPercentage),
+		ProductCategory: string(domainMovement.ProductCategory),
 		MovementType:    domainMovement.MovementType.String(),
 		Description:     domainMovement.Description,
 		TransactionDate: domainMovement.TransactionDate,
 		Status:          domainMovement.Status.String(),
 	}
 }
+
+// currencyOrDefault reads movements stored before the currency column existed.
+func currencyOrDefault(currency string) money.Currency {
+	if currency == "" {
+		return money.DefaultCurrency
+	}
+	return money.Currency(currency)
+}
+
+// invoiceIDToDomain maps a movement that is not invoiced yet to uuid.Nil.
+func invoiceIDToDomain(invoiceID *uuid.UUID) uuid.UUID {
+	if invoiceID == nil {
+		return uuid.Nil
+	}
+	return *invoiceID
+}
+
+func invoiceIDToSQL(invoiceID uuid.UUID) *uuid.UUID {
+	if invoiceID == uuid.Nil {
+		return nil
+	}
+	return &invoiceID
+}
+
+func taxPercentageToDomain(taxPercentage *persistence.Decimal) *money.Percentage {
+	if taxPercentage == nil {
+		return nil
+	}
+	percentage := money.Percentage(*taxPercentage)
+	return &percentage
+}
+
+func taxPercentageToSQL(taxPercentage *money.Percentage) *persistence.Decimal {
+	if taxPercentage == nil {
+		return nil
+	}
+	decimal := persistence.Decimal(*taxPercentage)
+	return &decimal
+}
@@ -12,12 +12,16 @@ import (
 // It maps to the "movements" table in the database.
 type Movement struct {
 	persistence.BaseModel
-	InvoiceID       uuid.UUID `gorm:"type:uuid;not null"`
-	Amount          float64   `gorm:"type:decimal(10,2);not null"`
-	MovementType    string    `gorm:"type:varchar(50);not null"`
-	Description     string    `gorm:"type:text"`
-	TransactionDate time.Time `gorm:"not null"`
-	Status          string    `gorm:"type:varchar(50);not null"`
+	AccountID       string               `gorm:"type:varchar(255);index"`
+	InvoiceID       *uuid.UUID           `gorm:"type:uuid"`
+	Amount          persistence.Decimal  `gorm:"type:decimal(12,2);not null"`
+	Currency        string               `gorm:"type:char(3);not null;default:EUR"`
+	TaxPercentage   *persistence.Decimal `gorm:"type:decimal(5,2)"`
+	ProductCategory string               `gorm:"type:varchar(50)"`
+	MovementType    string               `gorm:"type:varchar(50);not null"`
+	Description     string               `gorm:"type:text"`
+	TransactionDate time.Time            `gorm:"not null"`
+	Status          string               `gorm:"type:varchar(50);not null"`
 }
 
 // TableName specifies the table name for the Movement model.
@@ -0,0 +1,21 @@
+package sql
+
+import (
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+// movementKeysets pages the movements by each of the fields they can be sorted by
+var movementKeysets = map[string]persistence.Keyset[Movement]{
+	model.SortByTransactionDate: movementKeyset("transaction_date", func(movement Movement) any { return movement.TransactionDate }),
+	model.SortByAmount:          movementKeyset("amount", func(movement Movement) any { return movement.Amount }),
+}
+
+func movementKeyset(column string, value func(Movement) any) persistence.Keyset[Movement] {
+	return persistence.Keyset[Movement]{
+		Column:   column,
+		Value:    value,
+		IDColumn: "id",
+		ID:       func(movement Movement) string { return movement.ID.String() },
+	}
+}
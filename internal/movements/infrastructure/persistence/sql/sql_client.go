@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 )
@@ -75,6 +76,25 @@ func (c *MovementSqlClient) UpdateMovement(ctx context.Context, m *Movement) err
 	return nil
 }
 
+// UpdateMovementStatus updates only the status of a movement, leaving its invoice and amounts as they are.
+// It fails if the movement is no longer in the from status.
+func (c *MovementSqlClient) UpdateMovementStatus(ctx context.Context, id uuid.UUID, from, status string) error {
+	log := c.logger.With().Str("method", "UpdateMovementStatus").Stringer("movementID", id).Str("from", from).Str("status", status).Logger()
+
+	result := c.db.WithContext(ctx).Model(&Movement{}).Where("id = ? AND status = ?", id, from).Update("status", status)
+
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to update movement status")
+		return fmt.Errorf("failed to update status of movement with ID %s: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		log.Warn().Msg("Movement not found in the expected status for status update")
+		return fmt.Errorf("movement with ID %s is no longer %s: %w", id, from, gorm.ErrRecordNotFound)
+	}
+	log.Info().Msg("Movement status updated successfully")
+	return nil
+}
+
 // DeleteMovement deletes a movement by its ID.
 func (c *MovementSqlClient) DeleteMovement(ctx context.Context, id uuid.UUID) error {
 	log := c.logger.With().Str("method", "DeleteMovement").Stringer("movementID", id).Logger()
@@ -99,8 +119,43 @@ func (c *MovementSqlClient) SearchMovements(ctx context.Context, criteria *model
 	log.Debug().Msg("Searching movements")
 
 	var movements []Movement
+	if err := c.searchQuery(ctx, criteria).Order("transaction_date DESC").Find(&movements).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to search movements")
+		return nil, fmt.Errorf("failed to search movements: %w", err)
+	}
+
+	log.Info().Int("count", len(movements)).Msg("Movements search completed")
+	return movements, nil
+}
+
+// SearchMovementsPage searches for a page of the movements matching the criteria.
+func (c *MovementSqlClient) SearchMovementsPage(ctx context.Context, criteria *model.SearchCriteria, page pagination.Request) (pagination.Page[Movement], error) {
+	log := c.logger.With().Str("method", "SearchMovementsPage").Interface("criteria", criteria).Int("pageSize", page.Size).Logger()
+	log.Debug().Msg("Searching page of movements")
+
+	keyset, ok := movementKeysets[page.Sort.Field]
+	if !ok {
+		return pagination.Page[Movement]{}, fmt.Errorf("%w: %s", pagination.ErrInvalidSort, page.Sort.Field)
+	}
+
+	var movements []Movement
+	if err := c.searchQuery(ctx, criteria).Scopes(keyset.Scope(page)).Find(&movements).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to search page of movements")
+		return pagination.Page[Movement]{}, fmt.Errorf("failed to search movements: %w", err)
+	}
+
+	result := keyset.Page(movements, page)
+	log.Info().Int("count", len(result.Items)).Bool("more", result.NextCursor != "").Msg("Movements page search completed")
+	return result, nil
+}
+
+// searchQuery filters the movements by the criteria.
+func (c *MovementSqlClient) searchQuery(ctx context.Context, criteria *model.SearchCriteria) *gorm.DB {
 	query := c.db.WithContext(ctx)
 
+	if criteria.AccountID != "" {
+		query = query.Where("account_id = ?", criteria.AccountID)
+	}
 	if criteria.InvoiceID != nil {
 		query = query.Where("invoice_id = ?", *criteria.InvoiceID)
 	}
@@ -108,12 +163,5 @@ func (c *MovementSqlClient) SearchMovements(ctx context.Context, criteria *model
 		query = query.Where("status = ?", criteria.Status.String())
 	}
 	// Add other criteria as needed, e.g., date ranges, movement type
-
-	if err := query.Order("transaction_date DESC").Find(&movements).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to search movements")
-		return nil, fmt.Errorf("failed to search movements: %w", err)
-	}
-
-	log.Info().Int("count", len(movements)).Msg("Movements search completed")
-	return movements, nil
+	return query
 }
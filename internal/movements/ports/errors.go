@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"errors"
+
+	mcpSdk "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+)
+
+// errorCode classifies the errors of the movements context
+func errorCode(err error) toolerror.Code {
+	switch {
+	case errors.Is(err, domain.ErrMovementNotFound):
+		return toolerror.CodeNotFound
+	case errors.Is(err, domain.ErrMovementNotCancellable),
+		errors.Is(err, domain.ErrMovementAlreadyCancelled),
+		errors.Is(err, domain.ErrStatusChangedConcurrently),
+		errors.Is(err, model.ErrInvalidTransition):
+		return toolerror.CodeConflict
+	case errors.Is(err, domain.ErrInvalidMovementData),
+		errors.Is(err, model.ErrAccountIDEmpty),
+		errors.Is(err, model.ErrAmountZero),
+		errors.Is(err, model.ErrDescriptionEmpty),
+		errors.Is(err, model.ErrUnknownMovementType):
+		return toolerror.CodeInvalidArgument
+	default:
+		return ""
+	}
+}
+
+// toolError is the structured tool result of a failure of the movements context
+func toolError(message string, err error) *mcpSdk.CallToolResult {
+	return toolerror.Result(toolerror.Classify(err, errorCode), message, err)
+}
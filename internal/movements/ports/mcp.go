@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,10 @@ import (
 	mcpSdk "github.com/mark3labs/mcp-go/mcp"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain"
 	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	taxes "github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
 	"github.com/rs/zerolog"
 )
 
@@ -32,69 +37,292 @@ func (h *MCPMovementsHandler) GetMovement(ctx context.Context, request mcpSdk.Ca
 	log := h.logger.With().Str("method", "GetMovement").Logger()
 	log.Debug().Msg("Processing GetMovement request")
 
-	// Extract arguments map from request
+	args, errResult := h.accountArgs(request)
+	if errResult != nil {
+		return errResult, nil
+	}
+	movementID, errResult := h.movementIDFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	movementIDStr := movementID.String()
+
+	// Fetch the movement, checking it belongs to the account
+	movement, errResult := h.accountMovement(ctx, args["accountId"].(string), movementID)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Convert to response DTO
+	response := convertToMovementDTO(movement)
+
+	// Convert to JSON
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert movement to JSON")
+		return toolError("Failed to convert movement to JSON", err), nil
+	}
+
+	log.Info().Str("movementId", movementIDStr).Msg("Successfully retrieved movement")
+
+	// Return the response as text
+	result := mcpSdk.NewToolResultText(string(jsonData))
+	return result, nil
+}
+
+// CreateMovement handles the CreateMovement MCP tool
+func (h *MCPMovementsHandler) CreateMovement(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+	log := h.logger.With().Str("method", "CreateMovement").Logger()
+	log.Debug().Msg("Processing CreateMovement request")
+
+	args, errResult := h.accountArgs(request)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var err error
+	var taxPercentage *money.Percentage
+	if value, ok := args["taxPercentage"]; ok {
+		percentage, err := money.ParsePercentageValue(value)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid taxPercentage parameter")
+			return toolerror.InvalidArgument("Invalid parameter", fmt.Errorf("taxPercentage must be a decimal number such as 21: %w", err)), nil
+		}
+		taxPercentage = &percentage
+	}
+
+	var category taxes.Category
+	if categoryStr, ok := args["productCategory"].(string); ok && categoryStr != "" {
+		if category, err = taxes.ParseCategory(categoryStr); err != nil {
+			log.Error().Err(err).Str("productCategory", categoryStr).Msg("Invalid productCategory parameter")
+			return toolerror.InvalidArgument("Invalid parameter", err), nil
+		}
+	}
+
+	currency := money.DefaultCurrency
+	if currencyStr, ok := args["currency"].(string); ok && currencyStr != "" {
+		if currency, err = money.ParseCurrency(currencyStr); err != nil {
+			log.Error().Err(err).Str("currency", currencyStr).Msg("Invalid currency parameter")
+			return toolerror.InvalidArgument("Invalid parameter", err), nil
+		}
+	}
+
+	amount, err := money.ParseValue(args["amount"], currency)
+	if err != nil {
+		log.Error().Err(err).Msg("Missing or invalid amount parameter")
+		return toolerror.InvalidArgument("Invalid parameter", fmt.Errorf("amount must be a decimal number such as 100.50: %w", err)), nil
+	}
+
+	movementTypeStr, _ := args["movementType"].(string)
+	movementType, err := model.MovementTypeFromString(movementTypeStr)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid movementType parameter")
+		return toolerror.InvalidArgument("Invalid parameter", err), nil
+	}
+
+	description, _ := args["description"].(string)
+
+	accountID := args["accountId"].(string)
+	movement, err := h.movementService.CreateMovement(ctx, accountID, amount, taxPercentage, category, movementType, description)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create movement")
+		return toolError("Failed to create movement", err), nil
+	}
+
+	log.Info().Str("movementId", movement.MovementID.String()).Msg("Successfully created movement")
+	return movementResult(convertToMovementDTO(movement))
+}
+
+// SearchMovements handles the SearchMovements MCP tool
+func (h *MCPMovementsHandler) SearchMovements(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+	log := h.logger.With().Str("method", "SearchMovements").Logger()
+	log.Debug().Msg("Processing SearchMovements request")
+
+	args, errResult := h.accountArgs(request)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	criteria := &model.SearchCriteria{AccountID: args["accountId"].(string)}
+
+	if invoiceIDStr, ok := args["invoiceId"].(string); ok && invoiceIDStr != "" {
+		invoiceID, err := uuid.Parse(invoiceIDStr)
+		if err != nil {
+			log.Error().Err(err).Str("invoiceId", invoiceIDStr).Msg("Failed to parse invoiceId")
+			return toolerror.InvalidArgument("Invalid format", fmt.Errorf("invalid invoice ID format: %w", err)), nil
+		}
+		criteria.InvoiceID = &invoiceID
+	}
+
+	if statusStr, ok := args["status"].(string); ok && statusStr != "" {
+		status, err := model.StatusFromString(statusStr)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid status parameter")
+			return toolerror.InvalidArgument("Invalid parameter", err), nil
+		}
+		criteria.Status = &status
+	}
+
+	page, err := pagination.FromArgs(args, model.SortFields, model.DefaultSort)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid pagination parameters")
+		return toolerror.InvalidArgument("Invalid pagination", err), nil
+	}
+
+	movements, err := h.movementService.SearchMovementsPage(ctx, criteria, page)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to search movements")
+		return toolError("Failed to search movements", err), nil
+	}
+
+	response := MovementsPageDTO{Movements: make(MovementsDTO, len(movements.Items)), NextCursor: movements.NextCursor}
+	for i, movement := range movements.Items {
+		response.Movements[i] = *convertToMovementDTO(movement)
+	}
+
+	log.Info().Int("count", len(response.Movements)).Msg("Successfully searched movements")
+	return movementResult(response)
+}
+
+// CancelMovement handles the CancelMovement MCP tool
+func (h *MCPMovementsHandler) CancelMovement(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+	log := h.logger.With().Str("method", "CancelMovement").Logger()
+	log.Debug().Msg("Processing CancelMovement request")
+
+	args, errResult := h.accountArgs(request)
+	if errResult != nil {
+		return errResult, nil
+	}
+	movementID, errResult := h.movementIDFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if _, errResult := h.accountMovement(ctx, args["accountId"].(string), movementID); errResult != nil {
+		return errResult, nil
+	}
+
+	movement, err := h.movementService.CancelMovement(ctx, movementID)
+	if err != nil {
+		log.Error().Err(err).Str("movementId", movementID.String()).Msg("Failed to cancel movement")
+		return toolError("Failed to cancel movement", err), nil
+	}
+
+	log.Info().Str("movementId", movementID.String()).Msg("Successfully cancelled movement")
+	return movementResult(convertToMovementDTO(movement))
+}
+
+// UpdateMovementStatus handles the UpdateMovementStatus MCP tool
+func (h *MCPMovementsHandler) UpdateMovementStatus(ctx context.Context, request mcpSdk.CallToolRequest) (*mcpSdk.CallToolResult, error) {
+	log := h.logger.With().Str("method", "UpdateMovementStatus").Logger()
+	log.Debug().Msg("Processing UpdateMovementStatus request")
+
+	args, errResult := h.accountArgs(request)
+	if errResult != nil {
+		return errResult, nil
+	}
+	movementID, errResult := h.movementIDFromArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if _, errResult := h.accountMovement(ctx, args["accountId"].(string), movementID); errResult != nil {
+		return errResult, nil
+	}
+
+	statusStr, _ := args["status"].(string)
+	status, err := model.StatusFromString(statusStr)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid status parameter")
+		return toolerror.InvalidArgument("Invalid parameter", err), nil
+	}
+
+	movement, err := h.movementService.UpdateMovementStatus(ctx, movementID, status)
+	if err != nil {
+		log.Error().Err(err).Str("movementId", movementID.String()).Msg("Failed to update movement status")
+		return toolError("Failed to update movement status", err), nil
+	}
+
+	log.Info().Str("movementId", movementID.String()).Str("status", statusStr).Msg("Successfully updated movement status")
+	return movementResult(convertToMovementDTO(movement))
+}
+
+// Helper functions for request parsing
+
+// accountArgs extracts the arguments map and checks the mandatory accountId parameter
+func (h *MCPMovementsHandler) accountArgs(request mcpSdk.CallToolRequest) (map[string]interface{}, *mcpSdk.CallToolResult) {
 	args, ok := request.Params.Arguments.(map[string]interface{})
 	if !ok {
-		log.Error().Msg("Invalid arguments type in request")
-		return mcpSdk.NewToolResultErrorFromErr("Invalid arguments", fmt.Errorf("arguments must be a map")), nil
+		h.logger.Error().Msg("Invalid arguments type in request")
+		return nil, toolerror.InvalidArgument("Invalid arguments", fmt.Errorf("arguments must be a map"))
 	}
 
-	// Extract accountId from arguments (we validate it but don't use it in this implementation)
 	accountIDStr, ok := args["accountId"].(string)
 	if !ok || accountIDStr == "" {
-		log.Error().Msg("Missing or invalid accountId parameter")
-		return mcpSdk.NewToolResultErrorFromErr("Missing parameter", fmt.Errorf("accountId is required")), nil
+		h.logger.Error().Msg("Missing or invalid accountId parameter")
+		return nil, toolerror.InvalidArgument("Missing parameter", fmt.Errorf("accountId is required"))
 	}
+	return args, nil
+}
 
-	// Extract movementId from arguments
+// movementIDFromArgs extracts and parses the movementId parameter
+func (h *MCPMovementsHandler) movementIDFromArgs(args map[string]interface{}) (uuid.UUID, *mcpSdk.CallToolResult) {
 	movementIDStr, ok := args["movementId"].(string)
 	if !ok || movementIDStr == "" {
-		log.Error().Msg("Missing or invalid movementId parameter")
-		return mcpSdk.NewToolResultErrorFromErr("Missing parameter", fmt.Errorf("movementId is required")), nil
+		h.logger.Error().Msg("Missing or invalid movementId parameter")
+		return uuid.Nil, toolerror.InvalidArgument("Missing parameter", fmt.Errorf("movementId is required"))
 	}
 
-	// Parse the UUID
 	movementID, err := uuid.Parse(movementIDStr)
 	if err != nil {
-		log.Error().Err(err).Str("movementId", movementIDStr).Msg("Failed to parse movementId")
-		return mcpSdk.NewToolResultErrorFromErr("Invalid format", fmt.Errorf("invalid movement ID format: %w", err)), nil
+		h.logger.Error().Err(err).Str("movementId", movementIDStr).Msg("Failed to parse movementId")
+		return uuid.Nil, toolerror.InvalidArgument("Invalid format", fmt.Errorf("invalid movement ID format: %w", err))
 	}
+	return movementID, nil
+}
 
-	// Fetch the movement from domain service
+// accountMovement fetches a movement checking it belongs to the account. Movements of other accounts are reported
+// as missing, so they cannot be probed through another account.
+func (h *MCPMovementsHandler) accountMovement(ctx context.Context, accountID string, movementID uuid.UUID) (*model.Movement, *mcpSdk.CallToolResult) {
 	movement, err := h.movementService.GetMovement(ctx, movementID)
-	if err != nil {
-		log.Error().Err(err).Str("movementId", movementIDStr).Msg("Failed to get movement")
-		return nil, fmt.Errorf("failed to retrieve movement: %w", err)
+	if err != nil && !errors.Is(err, domain.ErrMovementNotFound) {
+		h.logger.Error().Err(err).Str("movementId", movementID.String()).Msg("Failed to get movement")
+		return nil, toolError("Failed to retrieve movement", err)
+	}
+	if err != nil || movement.AccountID != accountID {
+		h.logger.Warn().Str("movementId", movementID.String()).Str("accountId", accountID).Msg("Movement not found in the account")
+		return nil, toolError("Failed to retrieve movement", fmt.Errorf("%w: %s", domain.ErrMovementNotFound, movementID))
 	}
+	return movement, nil
+}
 
-	// Convert to response DTO
-	response := convertToMovementDTO(movement)
+// Helper functions for conversion
 
-	// Convert to JSON
+// movementResult marshals a response DTO into a text tool result
+func movementResult(response any) (*mcpSdk.CallToolResult, error) {
 	jsonData, err := json.Marshal(response)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to convert movement to JSON")
-		return nil, fmt.Errorf("failed to convert movement to JSON: %w", err)
+		return toolError("Failed to convert movements to JSON", err), nil
 	}
-
-	log.Info().Str("movementId", movementIDStr).Msg("Successfully retrieved movement")
-
-	// Return the response as text
-	result := mcpSdk.NewToolResultText(string(jsonData))
-	return result, nil
+	return mcpSdk.NewToolResultText(string(jsonData)), nil
 }
 
-// Helper functions for conversion
-
 // convertToMovementDTO converts a domain Movement to a DTO
 func convertToMovementDTO(m *model.Movement) *MovementDTO {
-	return &MovementDTO{
+	dto := &MovementDTO{
 		ID:              m.MovementID.String(),
-		InvoiceID:       m.InvoiceID.String(),
-		Amount:          m.Amount,
+		AccountID:       m.AccountID,
+		Amount:          m.Amount.Amount(),
+		Currency:        m.Amount.Currency().String(),
+		ProductCategory: string(m.ProductCategory),
 		MovementType:    string(m.MovementType),
 		Description:     m.Description,
 		TransactionDate: m.TransactionDate.Format(time.RFC3339),
 		Status:          string(m.Status),
 	}
+	if m.InvoiceID != uuid.Nil {
+		dto.InvoiceID = m.InvoiceID.String()
+	}
+	if m.TaxPercentage != nil {
+		dto.TaxPercentage = m.TaxPercentage.String()
+	}
+	return dto
 }
@@ -2,27 +2,37 @@ package ports
 
 // MovementDTO represents a movement as returned by the MCP API
 type MovementDTO struct {
-	ID              string  `json:"id"`
-	InvoiceID       string  `json:"invoice_id"`
-	Amount          float64 `json:"amount"`
-	MovementType    string  `json:"movement_type"`
-	Description     string  `json:"description"`
-	TransactionDate string  `json:"transaction_date"`
-	Status          string  `json:"status"`
+	ID              string `json:"id"`
+	AccountID       string `json:"account_id"`
+	InvoiceID       string `json:"invoice_id,omitempty"`
+	Amount          string `json:"amount"`
+	Currency        string `json:"currency"`
+	TaxPercentage   string `json:"tax_percentage,omitempty"`
+	ProductCategory string `json:"product_category,omitempty"`
+	MovementType    string `json:"movement_type"`
+	Description     string `json:"description"`
+	TransactionDate string `json:"transaction_date"`
+	Status          string `json:"status"`
 }
 
 // MovementsDTO is a slice of MovementDTO
 type MovementsDTO = []MovementDTO
 
+// MovementsPageDTO represents a page of movements, with the cursor of the next one when there are more
+type MovementsPageDTO struct {
+	Movements  MovementsDTO `json:"movements"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
 // InvoiceMovementDTO represents a simplified view of a movement in the context of an invoice
 type InvoiceMovementDTO struct {
-	MovementID       string  `json:"movement_id"`
-	Description      string  `json:"description"`
-	Amount           float64 `json:"amount"`
-	AmountWithoutTax float64 `json:"amount_without_tax"`
-	AmountWithTax    float64 `json:"amount_with_tax"`
-	TaxPercentage    float64 `json:"tax_percentage"`
-	OperationType    string  `json:"operation_type"`
+	MovementID       string `json:"movement_id"`
+	Description      string `json:"description"`
+	Amount           string `json:"amount"`
+	AmountWithoutTax string `json:"amount_without_tax"`
+	AmountWithTax    string `json:"amount_with_tax"`
+	TaxPercentage    string `json:"tax_percentage"`
+	OperationType    string `json:"operation_type"`
 }
 
 // InvoiceMovementsDTO is a slice of InvoiceMovementDTO
@@ -0,0 +1,90 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	mcpSdk "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/movements/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+)
+
+const jsonMIMEType = "application/json"
+
+// MovementResourceURI returns the URI of the resource of a movement
+func MovementResourceURI(movementID uuid.UUID) string {
+	return fmt.Sprintf("billing://movements/%s", movementID)
+}
+
+// ReadMovementResource handles the billing://movements/{movementId} resource template
+func (h *MCPMovementsHandler) ReadMovementResource(ctx context.Context, request mcpSdk.ReadResourceRequest) ([]mcpSdk.ResourceContents, error) {
+	log := h.logger.With().Str("method", "ReadMovementResource").Logger()
+	log.Debug().Str("uri", request.Params.URI).Msg("Processing movement resource request")
+
+	movementIDStr := resourceArgument(request, "movementId")
+	if movementIDStr == "" {
+		log.Error().Msg("Missing movementId in resource URI")
+		return nil, fmt.Errorf("movementId is required")
+	}
+
+	movementID, err := uuid.Parse(movementIDStr)
+	if err != nil {
+		log.Error().Err(err).Str("movementId", movementIDStr).Msg("Failed to parse movementId")
+		return nil, fmt.Errorf("invalid movement ID format: %w", err)
+	}
+
+	movement, err := h.movementService.GetMovement(ctx, movementID)
+	if err != nil {
+		log.Error().Err(err).Str("movementId", movementIDStr).Msg("Failed to get movement")
+		return nil, fmt.Errorf("failed to retrieve movement: %w", err)
+	}
+	// The URI has no account, so the principal is authorized for the account of the movement
+	if err := auth.Authorize(ctx, movement.AccountID, auth.AccessRead); err != nil {
+		log.Warn().Err(err).Str("movementId", movementIDStr).Msg("Access denied to movement")
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(convertToMovementDTO(movement))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert movement to JSON")
+		return nil, fmt.Errorf("failed to convert movement to JSON: %w", err)
+	}
+
+	return []mcpSdk.ResourceContents{
+		mcpSdk.TextResourceContents{URI: request.Params.URI, MIMEType: jsonMIMEType, Text: string(jsonData)},
+	}, nil
+}
+
+// ListMovementResources lists the resources of the movements of an account
+func (h *MCPMovementsHandler) ListMovementResources(ctx context.Context, accountID string) ([]mcpSdk.Resource, error) {
+	movements, err := h.movementService.SearchMovements(ctx, &model.SearchCriteria{AccountID: accountID})
+	if err != nil {
+		h.logger.Error().Err(err).Str("accountId", accountID).Msg("Failed to search movements of the account")
+		return nil, fmt.Errorf("failed to search movements: %w", err)
+	}
+
+	resources := make([]mcpSdk.Resource, len(movements))
+	for i, movement := range movements {
+		resources[i] = mcpSdk.NewResource(MovementResourceURI(movement.MovementID), "Movement "+movement.Description,
+			mcpSdk.WithResourceDescription(fmt.Sprintf("%s %s movement of %s %s on %s", movement.Status, movement.MovementType,
+				movement.Amount.Amount(), movement.Amount.Currency(), movement.TransactionDate.Format("2006-01-02"))),
+			mcpSdk.WithMIMEType(jsonMIMEType),
+		)
+	}
+	return resources, nil
+}
+
+// resourceArgument reads a variable of a resource template, whose values the server gives as lists
+func resourceArgument(request mcpSdk.ReadResourceRequest, name string) string {
+	switch value := request.Params.Arguments[name].(type) {
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	}
+	return ""
+}
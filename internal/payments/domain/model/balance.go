@@ -0,0 +1,79 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrInvoiceNotFound          = errors.New("invoice not found")
+	ErrInvoiceNotPayable        = errors.New("invoice does not accept payments")
+	ErrInvoiceAccountMismatch   = errors.New("invoice does not belong to the account of the payment")
+	ErrAllocationExceedsBalance = errors.New("allocation exceeds the outstanding balance of the invoice")
+)
+
+// payableStatuses are the invoice statuses that accept payments: issued and not settled nor voided.
+var payableStatuses = map[string]bool{
+	"SENT":    true,
+	"OVERDUE": true,
+	"UNPAID":  true,
+}
+
+// InvoiceBalance is what has been paid and credited of an invoice and what is still outstanding.
+type InvoiceBalance struct {
+	InvoiceID     uuid.UUID
+	AccountID     string
+	InvoiceNumber string
+	Status        string
+	DueDate       time.Time
+	Total         money.Money
+	Credited      money.Money // Sum of its issued credit notes, as a positive amount
+	Paid          money.Money
+	Allocations   []InvoicePayment
+}
+
+// InvoicePayment is a payment allocated to an invoice, as seen from the invoice.
+type InvoicePayment struct {
+	PaymentID   uuid.UUID
+	Method      PaymentMethod
+	PaymentDate time.Time
+	Amount      money.Money
+}
+
+// Outstanding returns the amount still to be paid: the total less what was credited and paid.
+func (b InvoiceBalance) Outstanding() money.Money {
+	outstanding, _ := b.Total.Sub(b.Credited)
+	outstanding, _ = outstanding.Sub(b.Paid)
+	return outstanding
+}
+
+// IsSettled reports whether the invoice has been paid or credited in full.
+func (b InvoiceBalance) IsSettled() bool {
+	return !b.Outstanding().IsPositive()
+}
+
+// IsPayable reports whether the invoice accepts payments.
+func (b InvoiceBalance) IsPayable() bool {
+	return payableStatuses[b.Status] && !b.IsSettled()
+}
+
+// ApplyPayment returns the balance once the given amount is paid.
+func (b InvoiceBalance) ApplyPayment(amount money.Money) (InvoiceBalance, error) {
+	if !b.IsPayable() {
+		return InvoiceBalance{}, fmt.Errorf("%w: invoice %s is %s", ErrInvoiceNotPayable, b.InvoiceID, b.Status)
+	}
+	cmp, err := amount.Cmp(b.Outstanding())
+	if err != nil {
+		return InvoiceBalance{}, err
+	}
+	if cmp > 0 {
+		return InvoiceBalance{}, fmt.Errorf("%w: %s outstanding", ErrAllocationExceedsBalance, b.Outstanding())
+	}
+
+	b.Paid, _ = b.Paid.Add(amount)
+	return b, nil
+}
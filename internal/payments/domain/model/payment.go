@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrAccountIDEmpty          = errors.New("account ID cannot be empty")
+	ErrNonPositiveAmount       = errors.New("payment amount must be positive")
+	ErrPaymentDateEmpty        = errors.New("payment date cannot be empty")
+	ErrUnknownPaymentMethod    = errors.New("unknown payment method")
+	ErrAllocationExceedsAmount = errors.New("allocations exceed the payment amount")
+	ErrDuplicateAllocation     = errors.New("invoice is already allocated in this payment")
+)
+
+// PaymentMethod is the way a payment was collected.
+type PaymentMethod string
+
+const (
+	PaymentMethodTransfer    PaymentMethod = "TRANSFER"
+	PaymentMethodCard        PaymentMethod = "CARD"
+	PaymentMethodDirectDebit PaymentMethod = "DIRECT_DEBIT"
+	PaymentMethodCash        PaymentMethod = "CASH"
+)
+
+var paymentMethods = map[PaymentMethod]bool{
+	PaymentMethodTransfer:    true,
+	PaymentMethodCard:        true,
+	PaymentMethodDirectDebit: true,
+	PaymentMethodCash:        true,
+}
+
+// ParsePaymentMethod parses a payment method, case insensitively.
+func ParsePaymentMethod(method string) (PaymentMethod, error) {
+	m := PaymentMethod(strings.ToUpper(strings.TrimSpace(method)))
+	if !paymentMethods[m] {
+		return "", fmt.Errorf("%w: %q", ErrUnknownPaymentMethod, method)
+	}
+	return m, nil
+}
+
+// Allocation is the part of a payment applied to an invoice.
+type Allocation struct {
+	ID        uuid.UUID
+	InvoiceID uuid.UUID
+	Amount    money.Money
+}
+
+// Payment is an amount received from an account, allocated across one or more of its invoices.
+// The part of the amount not allocated to any invoice stays as a credit of the account.
+type Payment struct {
+	ID          uuid.UUID
+	AccountID   string
+	Amount      money.Money
+	Method      PaymentMethod
+	PaymentDate time.Time
+	Reference   string
+	Allocations []Allocation
+}
+
+func NewPayment(accountID string, amount money.Money, method PaymentMethod, paymentDate time.Time, reference string) (Payment, error) {
+	if accountID == "" {
+		return Payment{}, ErrAccountIDEmpty
+	}
+	if !amount.IsPositive() {
+		return Payment{}, ErrNonPositiveAmount
+	}
+	if !paymentMethods[method] {
+		return Payment{}, fmt.Errorf("%w: %q", ErrUnknownPaymentMethod, method)
+	}
+	if paymentDate.IsZero() {
+		return Payment{}, ErrPaymentDateEmpty
+	}
+
+	return Payment{
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Amount:      amount,
+		Method:      method,
+		PaymentDate: paymentDate,
+		Reference:   reference,
+	}, nil
+}
+
+// Allocated returns the part of the payment already applied to invoices.
+func (p Payment) Allocated() money.Money {
+	allocated := money.Zero(p.Amount.Currency())
+	for _, allocation := range p.Allocations {
+		// Allocations are validated to share the payment currency
+		allocated, _ = allocated.Add(allocation.Amount)
+	}
+	return allocated
+}
+
+// Unallocated returns the part of the payment not applied to any invoice yet.
+func (p Payment) Unallocated() money.Money {
+	unallocated, _ := p.Amount.Sub(p.Allocated())
+	return unallocated
+}
+
+// Allocate applies part of the payment to an invoice, within its outstanding balance.
+// It returns the balance of the invoice once the allocation is applied.
+func (p *Payment) Allocate(balance InvoiceBalance, amount money.Money) (InvoiceBalance, error) {
+	if balance.AccountID != p.AccountID {
+		return InvoiceBalance{}, ErrInvoiceAccountMismatch
+	}
+	if !balance.IsPayable() {
+		return InvoiceBalance{}, fmt.Errorf("%w: invoice %s is %s", ErrInvoiceNotPayable, balance.InvoiceID, balance.Status)
+	}
+	if amount.Currency() != p.Amount.Currency() {
+		return InvoiceBalance{}, fmt.Errorf("%w: %s and %s", money.ErrCurrencyMismatch, p.Amount.Currency(), amount.Currency())
+	}
+	for _, allocation := range p.Allocations {
+		if allocation.InvoiceID == balance.InvoiceID {
+			return InvoiceBalance{}, ErrDuplicateAllocation
+		}
+	}
+	if !p.Unallocated().IsPositive() {
+		return InvoiceBalance{}, ErrAllocationExceedsAmount
+	}
+	if !amount.IsPositive() {
+		return InvoiceBalance{}, ErrNonPositiveAmount
+	}
+	if cmp, _ := amount.Cmp(p.Unallocated()); cmp > 0 {
+		return InvoiceBalance{}, ErrAllocationExceedsAmount
+	}
+
+	updated, err := balance.ApplyPayment(amount)
+	if err != nil {
+		return InvoiceBalance{}, err
+	}
+
+	p.Allocations = append(p.Allocations, Allocation{ID: uuid.New(), InvoiceID: balance.InvoiceID, Amount: amount})
+	return updated, nil
+}
@@ -0,0 +1,106 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openBalance(total, paid int64) model.InvoiceBalance {
+	return model.InvoiceBalance{
+		InvoiceID: uuid.New(),
+		AccountID: "account_A",
+		Status:    "SENT",
+		Total:     money.New(total, "EUR"),
+		Credited:  money.Zero("EUR"),
+		Paid:      money.New(paid, "EUR"),
+	}
+}
+
+func TestNewPayment(t *testing.T) {
+	date := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	payment, err := model.NewPayment("account_A", money.New(10000, "EUR"), model.PaymentMethodTransfer, date, "TRF-1")
+	require.NoError(t, err)
+	assert.Equal(t, "100.00", payment.Unallocated().Amount())
+
+	_, err = model.NewPayment("account_A", money.Zero("EUR"), model.PaymentMethodTransfer, date, "")
+	assert.ErrorIs(t, err, model.ErrNonPositiveAmount)
+
+	_, err = model.NewPayment("account_A", money.New(100, "EUR"), "CHEQUE", date, "")
+	assert.ErrorIs(t, err, model.ErrUnknownPaymentMethod)
+
+	method, err := model.ParsePaymentMethod("direct_debit")
+	require.NoError(t, err)
+	assert.Equal(t, model.PaymentMethodDirectDebit, method)
+}
+
+func TestPayment_Allocate(t *testing.T) {
+	payment, err := model.NewPayment("account_A", money.New(10000, "EUR"), model.PaymentMethodCard, time.Now(), "")
+	require.NoError(t, err)
+
+	first := openBalance(6050, 0)
+	updated, err := payment.Allocate(first, money.New(6050, "EUR"))
+	require.NoError(t, err)
+	assert.True(t, updated.IsSettled())
+	assert.Equal(t, "39.50", payment.Unallocated().Amount())
+
+	_, err = payment.Allocate(first, money.New(100, "EUR"))
+	assert.ErrorIs(t, err, model.ErrDuplicateAllocation)
+
+	second := openBalance(12100, 2100)
+	_, err = payment.Allocate(second, money.New(4000, "EUR"))
+	assert.ErrorIs(t, err, model.ErrAllocationExceedsAmount)
+
+	partial, err := payment.Allocate(second, money.New(3950, "EUR"))
+	require.NoError(t, err)
+	assert.False(t, partial.IsSettled())
+	assert.Equal(t, "60.50", partial.Outstanding().Amount())
+	assert.True(t, payment.Unallocated().IsZero())
+	assert.Len(t, payment.Allocations, 2)
+}
+
+func TestPayment_AllocateRejectsInvalidInvoices(t *testing.T) {
+	payment, err := model.NewPayment("account_A", money.New(10000, "EUR"), model.PaymentMethodCash, time.Now(), "")
+	require.NoError(t, err)
+
+	draft := openBalance(5000, 0)
+	draft.Status = "DRAFT"
+	_, err = payment.Allocate(draft, money.New(5000, "EUR"))
+	assert.ErrorIs(t, err, model.ErrInvoiceNotPayable)
+
+	otherAccount := openBalance(5000, 0)
+	otherAccount.AccountID = "account_B"
+	_, err = payment.Allocate(otherAccount, money.New(5000, "EUR"))
+	assert.ErrorIs(t, err, model.ErrInvoiceAccountMismatch)
+
+	_, err = payment.Allocate(openBalance(5000, 0), money.New(5001, "EUR"))
+	assert.ErrorIs(t, err, model.ErrAllocationExceedsBalance)
+
+	_, err = payment.Allocate(openBalance(5000, 0), money.New(5000, "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	assert.Empty(t, payment.Allocations, "rejected allocations must not change the payment")
+}
+
+func TestInvoiceBalance_CreditNotesReduceOutstanding(t *testing.T) {
+	payment, err := model.NewPayment("account_A", money.New(10000, "EUR"), model.PaymentMethodTransfer, time.Now(), "")
+	require.NoError(t, err)
+
+	partlyCredited := openBalance(12100, 2100)
+	partlyCredited.Credited = money.New(6000, "EUR")
+	assert.Equal(t, "40.00", partlyCredited.Outstanding().Amount())
+	_, err = payment.Allocate(partlyCredited, money.New(4001, "EUR"))
+	assert.ErrorIs(t, err, model.ErrAllocationExceedsBalance)
+
+	fullyCredited := openBalance(12100, 0)
+	fullyCredited.Credited = money.New(12100, "EUR")
+	assert.True(t, fullyCredited.IsSettled())
+	_, err = payment.Allocate(fullyCredited, money.New(100, "EUR"))
+	assert.ErrorIs(t, err, model.ErrInvoiceNotPayable)
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Repository defines the persistence needed by payments.
+// It's implemented by an adapter in the infrastructure layer.
+type Repository interface {
+	CreatePayment(ctx context.Context, payment model.Payment) error
+	// GetInvoiceBalance returns the balance of an invoice, or model.ErrInvoiceNotFound.
+	GetInvoiceBalance(ctx context.Context, invoiceID uuid.UUID) (model.InvoiceBalance, error)
+	// LockInvoiceBalance returns the balance of an invoice, locking it until the transaction ends.
+	LockInvoiceBalance(ctx context.Context, invoiceID uuid.UUID) (model.InvoiceBalance, error)
+	// LockOpenInvoiceBalances returns and locks the balances of the payable invoices of an account
+	// in the given currency, oldest due date first.
+	LockOpenInvoiceBalances(ctx context.Context, accountID string, currency money.Currency) ([]model.InvoiceBalance, error)
+}
+
+// Transactor runs a unit of work in a single database transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// InvoiceSettler moves a fully paid invoice to PAID through the invoice state machine.
+type InvoiceSettler interface {
+	MarkInvoicePaid(ctx context.Context, id invoices.InvoiceID) (invoices.Invoice, error)
+}
+
+// AllocationRequest asks to apply part of a payment to an invoice.
+// A nil Amount applies as much of the payment as the invoice still owes.
+type AllocationRequest struct {
+	InvoiceID uuid.UUID
+	Amount    *money.Money
+}
+
+// PaymentResult is a registered payment together with the balances of the invoices it was allocated to.
+type PaymentResult struct {
+	Payment  model.Payment
+	Balances []model.InvoiceBalance
+}
+
+type Service struct {
+	repo       Repository
+	transactor Transactor
+	settler    InvoiceSettler
+	logger     zerolog.Logger
+}
+
+func NewService(repo Repository, transactor Transactor, settler InvoiceSettler) Service {
+	return Service{
+		repo:       repo,
+		transactor: transactor,
+		settler:    settler,
+		logger:     log.With().Str("module", "paymentsService").Logger(),
+	}
+}
+
+// RegisterPayment records a payment and allocates it to the requested invoices, in order.
+// Without requests the payment is allocated to the open invoices of the account, oldest due date first.
+// Invoices left without outstanding balance are marked as PAID in the same transaction.
+func (s Service) RegisterPayment(ctx context.Context, payment model.Payment, requests []AllocationRequest) (PaymentResult, error) {
+	logger := s.logger.With().Str("payment_id", payment.ID.String()).Str("account_id", payment.AccountID).Logger()
+	logger.Info().Str("amount", payment.Amount.String()).Int("allocations", len(requests)).Msg("Registering payment")
+
+	var result PaymentResult
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		balances, err := s.allocate(ctx, &payment, requests)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.CreatePayment(ctx, payment); err != nil {
+			return fmt.Errorf("failed to store payment: %w", err)
+		}
+
+		for i, balance := range balances {
+			if !balance.IsSettled() {
+				continue
+			}
+			invoice, err := s.settler.MarkInvoicePaid(ctx, invoices.InvoiceID(balance.InvoiceID))
+			if err != nil {
+				return fmt.Errorf("failed to mark invoice %s as paid: %w", balance.InvoiceID, err)
+			}
+			balances[i].Status = string(invoice.Status)
+		}
+
+		result = PaymentResult{Payment: payment, Balances: balances}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to register payment")
+		return PaymentResult{}, err
+	}
+
+	logger.Info().Str("unallocated", payment.Unallocated().String()).Msg("Registered payment")
+	return result, nil
+}
+
+// allocate applies the payment to the requested invoices, returning their updated balances.
+func (s Service) allocate(ctx context.Context, payment *model.Payment, requests []AllocationRequest) ([]model.InvoiceBalance, error) {
+	if len(requests) == 0 {
+		return s.allocateToOpenInvoices(ctx, payment)
+	}
+
+	balances := make([]model.InvoiceBalance, 0, len(requests))
+	for _, request := range requests {
+		balance, err := s.repo.LockInvoiceBalance(ctx, request.InvoiceID)
+		if err != nil {
+			return nil, err
+		}
+
+		amount := minAmount(balance.Outstanding(), payment.Unallocated())
+		if request.Amount != nil {
+			amount = *request.Amount
+		}
+
+		updated, err := payment.Allocate(balance, amount)
+		if err != nil {
+			return nil, fmt.Errorf("cannot allocate %s to invoice %s: %w", amount, request.InvoiceID, err)
+		}
+		balances = append(balances, updated)
+	}
+	return balances, nil
+}
+
+func (s Service) allocateToOpenInvoices(ctx context.Context, payment *model.Payment) ([]model.InvoiceBalance, error) {
+	open, err := s.repo.LockOpenInvoiceBalances(ctx, payment.AccountID, payment.Amount.Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []model.InvoiceBalance
+	for _, balance := range open {
+		if !payment.Unallocated().IsPositive() {
+			break
+		}
+		updated, err := payment.Allocate(balance, minAmount(balance.Outstanding(), payment.Unallocated()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot allocate to invoice %s: %w", balance.InvoiceID, err)
+		}
+		balances = append(balances, updated)
+	}
+	return balances, nil
+}
+
+// GetInvoiceBalance returns what has been paid of an invoice of the account and what is still outstanding.
+func (s Service) GetInvoiceBalance(ctx context.Context, accountID string, invoiceID uuid.UUID) (model.InvoiceBalance, error) {
+	s.logger.Info().Str("invoice_id", invoiceID.String()).Msg("Fetching invoice balance")
+
+	balance, err := s.repo.GetInvoiceBalance(ctx, invoiceID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to fetch invoice balance")
+		return model.InvoiceBalance{}, err
+	}
+	// Invoices of other accounts are reported as missing so their existence is not disclosed
+	if balance.AccountID != accountID {
+		return model.InvoiceBalance{}, model.ErrInvoiceNotFound
+	}
+	return balance, nil
+}
+
+func minAmount(a, b money.Money) money.Money {
+	if cmp, _ := a.Cmp(b); cmp > 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/payments/domain/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/payments/domain/service.go -destination=internal/payments/domain/service_mock.go -package=domain Repository,Transactor,InvoiceSettler
+//
+
+// Package domain is a generated GoMock package.
+package domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	model "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	model0 "github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	money "github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePayment mocks base method.
+func (m *MockRepository) CreatePayment(ctx context.Context, payment model0.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockRepositoryMockRecorder) CreatePayment(ctx, payment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockRepository)(nil).CreatePayment), ctx, payment)
+}
+
+// GetInvoiceBalance mocks base method.
+func (m *MockRepository) GetInvoiceBalance(ctx context.Context, invoiceID uuid.UUID) (model0.InvoiceBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceBalance", ctx, invoiceID)
+	ret0, _ := ret[0].(model0.InvoiceBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceBalance indicates an expected call of GetInvoiceBalance.
+func (mr *MockRepositoryMockRecorder) GetInvoiceBalance(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceBalance", reflect.TypeOf((*MockRepository)(nil).GetInvoiceBalance), ctx, invoiceID)
+}
+
+// LockInvoiceBalance mocks base method.
+func (m *MockRepository) LockInvoiceBalance(ctx context.Context, invoiceID uuid.UUID) (model0.InvoiceBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockInvoiceBalance", ctx, invoiceID)
+	ret0, _ := ret[0].(model0.InvoiceBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockInvoiceBalance indicates an expected call of LockInvoiceBalance.
+func (mr *MockRepositoryMockRecorder) LockInvoiceBalance(ctx, invoiceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockInvoiceBalance", reflect.TypeOf((*MockRepository)(nil).LockInvoiceBalance), ctx, invoiceID)
+}
+
+// LockOpenInvoiceBalances mocks base method.
+func (m *MockRepository) LockOpenInvoiceBalances(ctx context.Context, accountID string, currency money.Currency) ([]model0.InvoiceBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOpenInvoiceBalances", ctx, accountID, currency)
+	ret0, _ := ret[0].([]model0.InvoiceBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockOpenInvoiceBalances indicates an expected call of LockOpenInvoiceBalances.
+func (mr *MockRepositoryMockRecorder) LockOpenInvoiceBalances(ctx, accountID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOpenInvoiceBalances", reflect.TypeOf((*MockRepository)(nil).LockOpenInvoiceBalances), ctx, accountID, currency)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
+
+// MockInvoiceSettler is a mock of InvoiceSettler interface.
+type MockInvoiceSettler struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceSettlerMockRecorder
+	isgomock struct{}
+}
+
+// MockInvoiceSettlerMockRecorder is the mock recorder for MockInvoiceSettler.
+type MockInvoiceSettlerMockRecorder struct {
+	mock *MockInvoiceSettler
+}
+
+// NewMockInvoiceSettler creates a new mock instance.
+func NewMockInvoiceSettler(ctrl *gomock.Controller) *MockInvoiceSettler {
+	mock := &MockInvoiceSettler{ctrl: ctrl}
+	mock.recorder = &MockInvoiceSettlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceSettler) EXPECT() *MockInvoiceSettlerMockRecorder {
+	return m.recorder
+}
+
+// MarkInvoicePaid mocks base method.
+func (m *MockInvoiceSettler) MarkInvoicePaid(ctx context.Context, id model.InvoiceID) (model.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkInvoicePaid", ctx, id)
+	ret0, _ := ret[0].(model.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkInvoicePaid indicates an expected call of MarkInvoicePaid.
+func (mr *MockInvoiceSettlerMockRecorder) MarkInvoicePaid(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInvoicePaid", reflect.TypeOf((*MockInvoiceSettler)(nil).MarkInvoicePaid), ctx, id)
+}
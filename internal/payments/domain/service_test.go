@@ -0,0 +1,124 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	invoices "github.com/ricardogrande-masmovil/billing-mcp/internal/invoices/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type mocks struct {
+	repo    *domain.MockRepository
+	settler *domain.MockInvoiceSettler
+}
+
+func newService(ctrl *gomock.Controller) (domain.Service, mocks) {
+	m := mocks{repo: domain.NewMockRepository(ctrl), settler: domain.NewMockInvoiceSettler(ctrl)}
+	transactor := domain.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+	).AnyTimes()
+	return domain.NewService(m.repo, transactor, m.settler), m
+}
+
+func newPayment(t *testing.T, minor int64) model.Payment {
+	payment, err := model.NewPayment("account_A", money.New(minor, "EUR"), model.PaymentMethodTransfer, time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), "TRF-1")
+	require.NoError(t, err)
+	return payment
+}
+
+func balance(status string, total, paid int64, dueDay int) model.InvoiceBalance {
+	return model.InvoiceBalance{
+		InvoiceID: uuid.New(),
+		AccountID: "account_A",
+		Status:    status,
+		DueDate:   time.Date(2025, 1, dueDay, 0, 0, 0, 0, time.UTC),
+		Total:     money.New(total, "EUR"),
+		Credited:  money.Zero("EUR"),
+		Paid:      money.New(paid, "EUR"),
+	}
+}
+
+func TestService_RegisterPayment_AllocatesToOpenInvoicesOldestFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+
+	oldest := balance("OVERDUE", 12100, 2100, 5)
+	newest := balance("SENT", 6050, 0, 20)
+	m.repo.EXPECT().LockOpenInvoiceBalances(ctx, "account_A", money.Currency("EUR")).Return([]model.InvoiceBalance{oldest, newest}, nil)
+	m.repo.EXPECT().CreatePayment(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, payment model.Payment) error {
+		require.Len(t, payment.Allocations, 2)
+		assert.Equal(t, "100.00", payment.Allocations[0].Amount.Amount())
+		assert.Equal(t, "20.00", payment.Allocations[1].Amount.Amount())
+		return nil
+	})
+	m.settler.EXPECT().MarkInvoicePaid(ctx, invoices.InvoiceID(oldest.InvoiceID)).Return(invoices.Invoice{Status: invoices.InvoiceStatusPaid}, nil)
+
+	result, err := service.RegisterPayment(ctx, newPayment(t, 12000), nil)
+	require.NoError(t, err)
+
+	require.Len(t, result.Balances, 2)
+	assert.Equal(t, "PAID", result.Balances[0].Status)
+	assert.True(t, result.Balances[0].Outstanding().IsZero())
+	assert.Equal(t, "SENT", result.Balances[1].Status, "partially paid invoices keep their status")
+	assert.Equal(t, "40.50", result.Balances[1].Outstanding().Amount())
+	assert.True(t, result.Payment.Unallocated().IsZero())
+}
+
+func TestService_RegisterPayment_ExplicitAllocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+
+	invoice := balance("SENT", 10000, 0, 15)
+	partialAmount := money.New(2500, "EUR")
+	m.repo.EXPECT().LockInvoiceBalance(ctx, invoice.InvoiceID).Return(invoice, nil)
+	m.repo.EXPECT().CreatePayment(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.RegisterPayment(ctx, newPayment(t, 5000), []domain.AllocationRequest{
+		{InvoiceID: invoice.InvoiceID, Amount: &partialAmount},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "75.00", result.Balances[0].Outstanding().Amount())
+	assert.Equal(t, "25.00", result.Payment.Unallocated().Amount(), "the rest of the payment stays as a credit")
+}
+
+func TestService_RegisterPayment_RejectsOverAllocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+
+	invoice := balance("SENT", 10000, 9000, 15)
+	tooMuch := money.New(2000, "EUR")
+	m.repo.EXPECT().LockInvoiceBalance(ctx, invoice.InvoiceID).Return(invoice, nil)
+
+	_, err := service.RegisterPayment(ctx, newPayment(t, 5000), []domain.AllocationRequest{
+		{InvoiceID: invoice.InvoiceID, Amount: &tooMuch},
+	})
+	assert.ErrorIs(t, err, model.ErrAllocationExceedsBalance)
+}
+
+func TestService_GetInvoiceBalance_HidesOtherAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+	ctx := context.Background()
+
+	invoice := balance("SENT", 10000, 0, 15)
+	m.repo.EXPECT().GetInvoiceBalance(ctx, invoice.InvoiceID).Return(invoice, nil).Times(2)
+
+	found, err := service.GetInvoiceBalance(ctx, "account_A", invoice.InvoiceID)
+	require.NoError(t, err)
+	assert.Equal(t, "100.00", found.Outstanding().Amount())
+
+	_, err = service.GetInvoiceBalance(ctx, "account_B", invoice.InvoiceID)
+	assert.ErrorIs(t, err, model.ErrInvoiceNotFound)
+}
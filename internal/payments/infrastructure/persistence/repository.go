@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/infrastructure/persistence/sql"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	client    sql.PaymentSqlClient
+	converter sql.PaymentSqlConverter
+	logger    zerolog.Logger
+}
+
+func NewRepository(client sql.PaymentSqlClient, converter sql.PaymentSqlConverter) Repository {
+	return Repository{
+		client:    client,
+		converter: converter,
+		logger:    log.With().Str("component", "PaymentsPersistenceRepository").Logger(),
+	}
+}
+
+func (r Repository) CreatePayment(ctx context.Context, payment model.Payment) error {
+	r.logger.Info().Str("id", payment.ID.String()).Int("allocations", len(payment.Allocations)).Msg("Creating payment")
+
+	sqlPayment, allocations := r.converter.PaymentToSql(payment)
+	if err := r.client.CreatePayment(ctx, sqlPayment, allocations); err != nil {
+		r.logger.Error().Err(err).Msg("Failed to create payment")
+		return err
+	}
+	return nil
+}
+
+func (r Repository) GetInvoiceBalance(ctx context.Context, invoiceID uuid.UUID) (model.InvoiceBalance, error) {
+	balance, err := r.getBalance(ctx, invoiceID, false)
+	if err != nil {
+		return model.InvoiceBalance{}, err
+	}
+
+	payments, err := r.client.GetInvoicePayments(ctx, invoiceID)
+	if err != nil {
+		r.logger.Error().Err(err).Str("invoice_id", invoiceID.String()).Msg("Failed to fetch invoice payments")
+		return model.InvoiceBalance{}, err
+	}
+	return r.converter.BalanceToDomain(balance, payments), nil
+}
+
+func (r Repository) LockInvoiceBalance(ctx context.Context, invoiceID uuid.UUID) (model.InvoiceBalance, error) {
+	balance, err := r.getBalance(ctx, invoiceID, true)
+	if err != nil {
+		return model.InvoiceBalance{}, err
+	}
+	return r.converter.BalanceToDomain(balance, nil), nil
+}
+
+func (r Repository) LockOpenInvoiceBalances(ctx context.Context, accountID string, currency money.Currency) ([]model.InvoiceBalance, error) {
+	sqlBalances, err := r.client.LockOpenInvoiceBalances(ctx, accountID, currency.String())
+	if err != nil {
+		r.logger.Error().Err(err).Str("account_id", accountID).Msg("Failed to fetch open invoices")
+		return nil, err
+	}
+
+	balances := make([]model.InvoiceBalance, len(sqlBalances))
+	for i, balance := range sqlBalances {
+		balances[i] = r.converter.BalanceToDomain(balance, nil)
+	}
+	return balances, nil
+}
+
+func (r Repository) getBalance(ctx context.Context, invoiceID uuid.UUID, lock bool) (sql.InvoiceBalance, error) {
+	balance, err := r.client.GetInvoiceBalance(ctx, invoiceID, lock)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return sql.InvoiceBalance{}, model.ErrInvoiceNotFound
+		}
+		r.logger.Error().Err(err).Str("invoice_id", invoiceID.String()).Msg("Failed to fetch invoice balance")
+		return sql.InvoiceBalance{}, err
+	}
+	return balance, nil
+}
@@ -0,0 +1,60 @@
+package sql
+
+import (
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+type PaymentSqlConverter struct {
+}
+
+func NewPaymentSqlConverter() PaymentSqlConverter {
+	return PaymentSqlConverter{}
+}
+
+func (c PaymentSqlConverter) PaymentToSql(payment model.Payment) (Payment, []PaymentAllocation) {
+	sqlPayment := Payment{
+		BaseModel:   commons.BaseModel{ID: payment.ID},
+		AccountID:   payment.AccountID,
+		Amount:      commons.Decimal(payment.Amount.Minor()),
+		Currency:    payment.Amount.Currency().String(),
+		Method:      string(payment.Method),
+		PaymentDate: payment.PaymentDate,
+		Reference:   payment.Reference,
+	}
+
+	allocations := make([]PaymentAllocation, len(payment.Allocations))
+	for i, allocation := range payment.Allocations {
+		allocations[i] = PaymentAllocation{
+			ID:        allocation.ID,
+			PaymentID: payment.ID,
+			InvoiceID: allocation.InvoiceID,
+			Amount:    commons.Decimal(allocation.Amount.Minor()),
+		}
+	}
+	return sqlPayment, allocations
+}
+
+func (c PaymentSqlConverter) BalanceToDomain(balance InvoiceBalance, payments []InvoicePayment) model.InvoiceBalance {
+	currency := money.Currency(balance.Currency)
+	domainBalance := model.InvoiceBalance{
+		InvoiceID:     balance.ID,
+		AccountID:     balance.AccountID,
+		InvoiceNumber: balance.InvoiceNumber,
+		Status:        balance.Status,
+		DueDate:       balance.DueDate,
+		Total:         money.New(int64(balance.TotalAmountWithTax), currency),
+		Credited:      money.New(int64(balance.CreditedAmount), currency),
+		Paid:          money.New(int64(balance.PaidAmount), currency),
+	}
+	for _, payment := range payments {
+		domainBalance.Allocations = append(domainBalance.Allocations, model.InvoicePayment{
+			PaymentID:   payment.PaymentID,
+			Method:      model.PaymentMethod(payment.Method),
+			PaymentDate: payment.PaymentDate,
+			Amount:      money.New(int64(payment.Amount), currency),
+		})
+	}
+	return domainBalance
+}
@@ -0,0 +1,60 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+// Payment represents a payment received from an account in the database.
+type Payment struct {
+	commons.BaseModel
+	AccountID   string          `gorm:"type:varchar(255);not null;index"`
+	Amount      commons.Decimal `gorm:"type:decimal(12,2);not null"`
+	Currency    string          `gorm:"type:char(3);not null"`
+	Method      string          `gorm:"type:varchar(50);not null"`
+	PaymentDate time.Time       `gorm:"type:date;not null"`
+	Reference   string          `gorm:"type:varchar(255)"`
+}
+
+// TableName specifies the table name for Payment in the database.
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// PaymentAllocation represents the part of a payment applied to an invoice.
+type PaymentAllocation struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	PaymentID uuid.UUID       `gorm:"type:uuid;not null;index"`
+	InvoiceID uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Amount    commons.Decimal `gorm:"type:decimal(12,2);not null"`
+	CreatedAt time.Time
+}
+
+// TableName specifies the table name for PaymentAllocation in the database.
+func (PaymentAllocation) TableName() string {
+	return "payment_allocations"
+}
+
+// InvoiceBalance is an invoice together with the sums of its issued credit notes and of the payments allocated to it.
+type InvoiceBalance struct {
+	ID                 uuid.UUID
+	AccountID          string
+	InvoiceNumber      string
+	Status             string
+	DueDate            time.Time
+	Currency           string
+	TotalAmountWithTax commons.Decimal
+	CreditedAmount     commons.Decimal
+	PaidAmount         commons.Decimal
+}
+
+// InvoicePayment is a payment allocated to an invoice.
+type InvoicePayment struct {
+	InvoiceID   uuid.UUID
+	PaymentID   uuid.UUID
+	Method      string
+	PaymentDate time.Time
+	Amount      commons.Decimal
+}
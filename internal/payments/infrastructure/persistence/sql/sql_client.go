@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// payableStatuses are the invoice statuses that accept payments
+var payableStatuses = []string{"SENT", "OVERDUE", "UNPAID"}
+
+// standardInvoiceType is the type of the invoices that are charged to accounts, credit notes are not paid
+const standardInvoiceType = "STANDARD"
+
+// paidAmountColumn sums the payments allocated to each invoice
+const paidAmountColumn = "COALESCE((SELECT SUM(pa.amount) FROM payment_allocations pa WHERE pa.invoice_id = invoices.id), 0) AS paid_amount"
+
+// creditedAmountColumn sums the issued credit notes of each invoice, whose totals are negative, as a positive amount
+const creditedAmountColumn = "-COALESCE((SELECT SUM(cn.total_amount_with_tax) FROM invoices cn WHERE cn.corrected_invoice_id = invoices.id AND cn.status NOT IN ('DRAFT', 'VOID') AND cn.deleted_at IS NULL), 0) AS credited_amount"
+
+// PaymentSqlClient runs the payments queries. Every method joins the transaction carried by the context, if any.
+type PaymentSqlClient struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewPaymentSqlClient(db *gorm.DB) PaymentSqlClient {
+	return PaymentSqlClient{
+		db:     db,
+		logger: log.With().Str("component", "PaymentSqlClient").Logger(),
+	}
+}
+
+// CreatePayment stores the payment and its allocations
+func (c PaymentSqlClient) CreatePayment(ctx context.Context, payment Payment, allocations []PaymentAllocation) error {
+	return commons.Conn(ctx, c.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&payment).Error; err != nil {
+			return err
+		}
+		if len(allocations) == 0 {
+			return nil
+		}
+		return tx.Create(&allocations).Error
+	})
+}
+
+// GetInvoiceBalance returns an invoice with the amounts credited and paid so far, locking it when lock is set
+func (c PaymentSqlClient) GetInvoiceBalance(ctx context.Context, invoiceID uuid.UUID, lock bool) (balance InvoiceBalance, err error) {
+	query := c.balances(ctx).Where("invoices.id = ?", invoiceID)
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "invoices"}})
+	}
+	err = query.Take(&balance).Error
+	return
+}
+
+// LockOpenInvoiceBalances locks the payable standard invoices of an account in a currency, oldest due date first
+func (c PaymentSqlClient) LockOpenInvoiceBalances(ctx context.Context, accountID, currency string) (balances []InvoiceBalance, err error) {
+	err = c.balances(ctx).
+		Where("invoices.account_id = ? AND invoices.currency = ? AND invoices.status IN ?", accountID, currency, payableStatuses).
+		Where("invoices.invoice_type = ?", standardInvoiceType).
+		Order("invoices.due_date, invoices.id").
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "invoices"}}).
+		Find(&balances).Error
+	return
+}
+
+// GetInvoicePayments returns the payments allocated to an invoice, oldest first
+func (c PaymentSqlClient) GetInvoicePayments(ctx context.Context, invoiceID uuid.UUID) (payments []InvoicePayment, err error) {
+	err = commons.Conn(ctx, c.db).Table("payment_allocations pa").
+		Select("pa.invoice_id, pa.payment_id, p.method, p.payment_date, pa.amount").
+		Joins("JOIN payments p ON p.id = pa.payment_id AND p.deleted_at IS NULL").
+		Where("pa.invoice_id = ?", invoiceID).
+		Order("p.payment_date, pa.created_at").
+		Scan(&payments).Error
+	return
+}
+
+func (c PaymentSqlClient) balances(ctx context.Context) *gorm.DB {
+	return commons.Conn(ctx, c.db).Table("invoices").
+		Select("invoices.id, invoices.account_id, COALESCE(invoices.invoice_number, '') AS invoice_number, invoices.status, invoices.due_date, invoices.currency, invoices.total_amount_with_tax, " + creditedAmountColumn + ", " + paidAmountColumn).
+		Where("invoices.deleted_at IS NULL")
+}
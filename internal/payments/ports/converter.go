@@ -0,0 +1,156 @@
+package ports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrInvalidDate       = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidAllocation = errors.New("each allocation needs an invoiceId and an optional amount")
+	ErrInvalidPayment    = errors.New("invalid payment")
+	ErrInvalidBalance    = errors.New("invalid invoice balance")
+)
+
+type Converter struct{}
+
+func NewConverter() Converter {
+	return Converter{}
+}
+
+// ConvertRequestArgsToPayment builds the payment to register from the RegisterPayment tool arguments
+func (c Converter) ConvertRequestArgsToPayment(args map[string]any, accountID string) (model.Payment, error) {
+	currency := money.DefaultCurrency
+	if value, ok := args["currency"].(string); ok && value != "" {
+		parsed, err := money.ParseCurrency(value)
+		if err != nil {
+			return model.Payment{}, err
+		}
+		currency = parsed
+	}
+
+	amount, err := money.ParseValue(args["amount"], currency)
+	if err != nil {
+		return model.Payment{}, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	methodArg, _ := args["method"].(string)
+	method, err := model.ParsePaymentMethod(methodArg)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	paymentDate := time.Now().UTC().Truncate(24 * time.Hour)
+	if value, ok := args["paymentDate"].(string); ok && value != "" {
+		if paymentDate, err = time.Parse(time.DateOnly, value); err != nil {
+			return model.Payment{}, ErrInvalidDate
+		}
+	}
+
+	reference, _ := args["reference"].(string)
+	return model.NewPayment(accountID, amount, method, paymentDate, reference)
+}
+
+// ConvertRequestArgsToAllocations reads the optional allocations argument, a list of {invoiceId, amount} objects
+func (c Converter) ConvertRequestArgsToAllocations(args map[string]any, currency money.Currency) ([]domain.AllocationRequest, error) {
+	raw, ok := args["allocations"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, ErrInvalidAllocation
+	}
+
+	requests := make([]domain.AllocationRequest, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			return nil, ErrInvalidAllocation
+		}
+		invoiceID, ok := fields["invoiceId"].(string)
+		if !ok {
+			return nil, ErrInvalidAllocation
+		}
+		id, err := uuid.Parse(invoiceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid invoice ID %q: %w", invoiceID, err)
+		}
+		requests[i].InvoiceID = id
+
+		if value, ok := fields["amount"]; ok && value != nil && value != "" {
+			amount, err := money.ParseValue(value, currency)
+			if err != nil {
+				return nil, fmt.Errorf("invalid amount for invoice %s: %w", invoiceID, err)
+			}
+			requests[i].Amount = &amount
+		}
+	}
+	return requests, nil
+}
+
+func (c Converter) ConvertPaymentResultToJson(result domain.PaymentResult) ([]byte, error) {
+	payment := result.Payment
+	dto := Payment{
+		ID:                payment.ID.String(),
+		AccountID:         payment.AccountID,
+		Amount:            payment.Amount.Amount(),
+		UnallocatedAmount: payment.Unallocated().Amount(),
+		Currency:          payment.Amount.Currency().String(),
+		Method:            string(payment.Method),
+		PaymentDate:       payment.PaymentDate.Format(time.DateOnly),
+		Reference:         payment.Reference,
+		Allocations:       make([]Allocation, len(payment.Allocations)),
+		Invoices:          make([]InvoiceBalance, len(result.Balances)),
+	}
+	for i, allocation := range payment.Allocations {
+		dto.Allocations[i] = Allocation{InvoiceID: allocation.InvoiceID.String(), Amount: allocation.Amount.Amount()}
+	}
+	for i, balance := range result.Balances {
+		dto.Invoices[i] = c.convertBalance(balance)
+	}
+
+	jsonData, err := json.Marshal(dto)
+	if err != nil {
+		return nil, ErrInvalidPayment
+	}
+	return jsonData, nil
+}
+
+func (c Converter) ConvertBalanceToJson(balance model.InvoiceBalance) ([]byte, error) {
+	jsonData, err := json.Marshal(c.convertBalance(balance))
+	if err != nil {
+		return nil, ErrInvalidBalance
+	}
+	return jsonData, nil
+}
+
+func (c Converter) convertBalance(balance model.InvoiceBalance) InvoiceBalance {
+	dto := InvoiceBalance{
+		InvoiceID:         balance.InvoiceID.String(),
+		InvoiceNumber:     balance.InvoiceNumber,
+		Status:            balance.Status,
+		DueDate:           balance.DueDate.Format(time.DateOnly),
+		Currency:          balance.Total.Currency().String(),
+		TotalAmount:       balance.Total.Amount(),
+		CreditedAmount:    balance.Credited.Amount(),
+		PaidAmount:        balance.Paid.Amount(),
+		OutstandingAmount: balance.Outstanding().Amount(),
+	}
+	for _, payment := range balance.Allocations {
+		dto.Payments = append(dto.Payments, InvoicePayment{
+			PaymentID:   payment.PaymentID.String(),
+			Method:      string(payment.Method),
+			PaymentDate: payment.PaymentDate.Format(time.DateOnly),
+			Amount:      payment.Amount.Amount(),
+		})
+	}
+	return dto
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+)
+
+// errorCode classifies the errors of the payments context. An invoice of another account is reported as not found,
+// like the invoices context does.
+func errorCode(err error) toolerror.Code {
+	switch {
+	case errors.Is(err, model.ErrInvoiceNotFound),
+		errors.Is(err, model.ErrInvoiceAccountMismatch):
+		return toolerror.CodeNotFound
+	case errors.Is(err, model.ErrInvoiceNotPayable):
+		return toolerror.CodeConflict
+	case errors.Is(err, ErrMissingAccountId),
+		errors.Is(err, ErrMissingInvoiceId),
+		errors.Is(err, ErrInvalidDate),
+		errors.Is(err, ErrInvalidAllocation),
+		errors.Is(err, model.ErrAllocationExceedsBalance),
+		errors.Is(err, model.ErrAccountIDEmpty),
+		errors.Is(err, model.ErrNonPositiveAmount),
+		errors.Is(err, model.ErrPaymentDateEmpty),
+		errors.Is(err, model.ErrUnknownPaymentMethod),
+		errors.Is(err, model.ErrAllocationExceedsAmount),
+		errors.Is(err, model.ErrDuplicateAllocation):
+		return toolerror.CodeInvalidArgument
+	default:
+		return ""
+	}
+}
+
+// toolError is the structured tool result of a failure of the payments context
+func toolError(message string, err error) *mcp.CallToolResult {
+	return toolerror.Result(toolerror.Classify(err, errorCode), message, err)
+}
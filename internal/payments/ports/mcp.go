@@ -0,0 +1,115 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/payments/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrMissingAccountId = errors.New("account_id is required")
+	ErrMissingInvoiceId = errors.New("invoice_id is required")
+)
+
+type PaymentService interface {
+	RegisterPayment(ctx context.Context, payment model.Payment, requests []domain.AllocationRequest) (domain.PaymentResult, error)
+	GetInvoiceBalance(ctx context.Context, accountID string, invoiceID uuid.UUID) (model.InvoiceBalance, error)
+}
+
+type controller struct {
+	service   PaymentService
+	converter Converter
+	logger    zerolog.Logger
+}
+
+func NewController(service PaymentService) controller {
+	return controller{
+		service:   service,
+		converter: NewConverter(),
+		logger:    log.With().Str("module", "paymentsMcpController").Logger(),
+	}
+}
+
+func (c controller) RegisterPayment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in RegisterPayment tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
+		c.logger.Error().Msg("Account ID is required")
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
+	}
+
+	payment, err := c.converter.ConvertRequestArgsToPayment(args, accountId)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid payment")
+		return toolerror.InvalidArgument("Invalid payment", err), nil
+	}
+	allocations, err := c.converter.ConvertRequestArgsToAllocations(args, payment.Amount.Currency())
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Invalid payment allocations")
+		return toolerror.InvalidArgument("Invalid payment allocations", err), nil
+	}
+
+	result, err := c.service.RegisterPayment(ctx, payment, allocations)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to register payment")
+		return toolError("Failed to register payment", err), nil
+	}
+
+	jsonData, err := c.converter.ConvertPaymentResultToJson(result)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert payment to JSON")
+		return toolError("Failed to convert payment to JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
+
+func (c controller) GetInvoiceBalance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	c.logger.Info().Msg("Processing request in GetInvoiceBalance tool")
+
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		c.logger.Error().Msg("Arguments must be a map[string]interface{}")
+		return toolerror.InvalidArgument("Invalid arguments type", errors.New("arguments must be a map[string]interface{}")), nil
+	}
+	accountId, ok := args["accountId"].(string)
+	if !ok || accountId == "" {
+		c.logger.Error().Msg("Account ID is required")
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingAccountId), nil
+	}
+	requestedInvoiceId, ok := args["invoiceId"].(string)
+	if !ok || requestedInvoiceId == "" {
+		c.logger.Error().Msg("Invoice ID is required")
+		return toolerror.InvalidArgument("Missing request parameter", ErrMissingInvoiceId), nil
+	}
+	invoiceId, err := uuid.Parse(requestedInvoiceId)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to parse invoice ID")
+		return toolerror.InvalidArgument("Invalid invoice ID format", err), nil
+	}
+
+	balance, err := c.service.GetInvoiceBalance(ctx, accountId, invoiceId)
+	if err != nil {
+		c.logger.Error().Err(err).Str("invoiceId", requestedInvoiceId).Msg("Failed to get invoice balance")
+		return toolError("Failed to get invoice balance", err), nil
+	}
+
+	jsonData, err := c.converter.ConvertBalanceToJson(balance)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("Failed to convert invoice balance to JSON")
+		return toolError("Failed to convert invoice balance to JSON", err), nil
+	}
+	return mcp.NewToolResultText(string(jsonData)), nil
+}
@@ -0,0 +1,44 @@
+package ports
+
+// Payment represents a registered payment as returned by the MCP API.
+// Amounts are exact decimal strings, e.g. "100.50".
+type Payment struct {
+	ID                string           `json:"id"`
+	AccountID         string           `json:"account_id"`
+	Amount            string           `json:"amount"`
+	UnallocatedAmount string           `json:"unallocated_amount"`
+	Currency          string           `json:"currency"`
+	Method            string           `json:"method"`
+	PaymentDate       string           `json:"payment_date"`
+	Reference         string           `json:"reference,omitempty"`
+	Allocations       []Allocation     `json:"allocations"`
+	Invoices          []InvoiceBalance `json:"invoices"`
+}
+
+// Allocation represents the part of a payment applied to an invoice.
+type Allocation struct {
+	InvoiceID string `json:"invoice_id"`
+	Amount    string `json:"amount"`
+}
+
+// InvoiceBalance represents what has been paid and credited of an invoice and what is still outstanding.
+type InvoiceBalance struct {
+	InvoiceID         string           `json:"invoice_id"`
+	InvoiceNumber     string           `json:"invoice_number"`
+	Status            string           `json:"status"`
+	DueDate           string           `json:"due_date"`
+	Currency          string           `json:"currency"`
+	TotalAmount       string           `json:"total_amount"`
+	CreditedAmount    string           `json:"credited_amount"`
+	PaidAmount        string           `json:"paid_amount"`
+	OutstandingAmount string           `json:"outstanding_amount"`
+	Payments          []InvoicePayment `json:"payments,omitempty"`
+}
+
+// InvoicePayment represents a payment allocated to the invoice being reported.
+type InvoicePayment struct {
+	PaymentID   string `json:"payment_id"`
+	Method      string `json:"method"`
+	PaymentDate string `json:"payment_date"`
+	Amount      string `json:"amount"`
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidLocation = errors.New("invalid customer location")
+
+// Territory is a tax territory, Spanish ones apply their own indirect tax.
+type Territory string
+
+const (
+	TerritoryMainland      Territory = "MAINLAND" // Peninsula and Balearic Islands
+	TerritoryCanaryIslands Territory = "CANARY_ISLANDS"
+	TerritoryCeuta         Territory = "CEUTA"
+	TerritoryMelilla       Territory = "MELILLA"
+	TerritoryEU            Territory = "EU"     // Other member states of the European Union
+	TerritoryNonEU         Territory = "NON_EU" // Anywhere outside the European Union
+)
+
+// Regime returns the indirect tax levied in the territory.
+// Operations with customers outside Spain are reported under IVA, as exempt ones.
+func (t Territory) Regime() Regime {
+	switch t {
+	case TerritoryCanaryIslands:
+		return RegimeIGIC
+	case TerritoryCeuta, TerritoryMelilla:
+		return RegimeIPSI
+	default:
+		return RegimeIVA
+	}
+}
+
+// IsSpanish reports whether Spanish taxes apply in the territory.
+func (t Territory) IsSpanish() bool {
+	return t != TerritoryEU && t != TerritoryNonEU
+}
+
+// euCountries are the ISO 3166 codes of the member states of the European Union other than Spain.
+var euCountries = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "CY": true, "CZ": true, "DE": true, "DK": true, "EE": true, "FI": true,
+	"FR": true, "GR": true, "HR": true, "HU": true, "IE": true, "IT": true, "LT": true, "LU": true, "LV": true,
+	"MT": true, "NL": true, "PL": true, "PT": true, "RO": true, "SE": true, "SI": true, "SK": true,
+}
+
+// Location is where the customer is established, which decides the tax territory of an operation.
+type Location struct {
+	Country    string // ISO 3166-1 alpha-2 code, e.g. ES
+	PostalCode string // Required to tell Spanish territories apart, optional elsewhere
+}
+
+// DefaultLocation is used for customers whose location is unknown: mainland Spain.
+var DefaultLocation = Location{Country: "ES"}
+
+// NewLocation validates and normalizes a customer location.
+func NewLocation(country, postalCode string) (Location, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	postalCode = strings.TrimSpace(postalCode)
+	if len(country) != 2 || !isLetters(country) {
+		return Location{}, fmt.Errorf("%w: country %q is not an ISO 3166 alpha-2 code", ErrInvalidLocation, country)
+	}
+	if country == "ES" && postalCode != "" && (len(postalCode) != 5 || !isDigits(postalCode)) {
+		return Location{}, fmt.Errorf("%w: %q is not a Spanish postal code", ErrInvalidLocation, postalCode)
+	}
+	return Location{Country: country, PostalCode: postalCode}, nil
+}
+
+// Territory returns the tax territory of the location. Spanish postal codes are assigned by province:
+// 35 and 38 are the Canary Islands, 51 is Ceuta and 52 is Melilla.
+func (l Location) Territory() Territory {
+	switch {
+	case l.Country == "ES":
+		switch provinceCode(l.PostalCode) {
+		case "35", "38":
+			return TerritoryCanaryIslands
+		case "51":
+			return TerritoryCeuta
+		case "52":
+			return TerritoryMelilla
+		default:
+			return TerritoryMainland
+		}
+	case euCountries[l.Country]:
+		return TerritoryEU
+	default:
+		return TerritoryNonEU
+	}
+}
+
+func provinceCode(postalCode string) string {
+	if len(postalCode) < 2 {
+		return ""
+	}
+	return postalCode[:2]
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
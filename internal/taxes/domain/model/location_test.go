@@ -0,0 +1,56 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocation_Territory(t *testing.T) {
+	tests := []struct {
+		country    string
+		postalCode string
+		territory  model.Territory
+		regime     model.Regime
+	}{
+		{"ES", "28001", model.TerritoryMainland, model.RegimeIVA},
+		{"ES", "07001", model.TerritoryMainland, model.RegimeIVA}, // Balearic Islands
+		{"ES", "", model.TerritoryMainland, model.RegimeIVA},
+		{"ES", "35001", model.TerritoryCanaryIslands, model.RegimeIGIC},
+		{"ES", "38001", model.TerritoryCanaryIslands, model.RegimeIGIC},
+		{"ES", "51001", model.TerritoryCeuta, model.RegimeIPSI},
+		{"ES", "52001", model.TerritoryMelilla, model.RegimeIPSI},
+		{"FR", "75001", model.TerritoryEU, model.RegimeIVA},
+		{"US", "10001", model.TerritoryNonEU, model.RegimeIVA},
+	}
+
+	for _, tt := range tests {
+		location, err := model.NewLocation(tt.country, tt.postalCode)
+		require.NoError(t, err)
+		assert.Equal(t, tt.territory, location.Territory(), "%s %s", tt.country, tt.postalCode)
+		assert.Equal(t, tt.regime, location.Territory().Regime(), "%s %s", tt.country, tt.postalCode)
+	}
+}
+
+func TestNewLocation(t *testing.T) {
+	location, err := model.NewLocation(" es ", "35001")
+	require.NoError(t, err)
+	assert.Equal(t, model.Location{Country: "ES", PostalCode: "35001"}, location)
+
+	for _, invalid := range [][2]string{{"", ""}, {"ESP", ""}, {"E1", ""}, {"ES", "3500"}, {"ES", "AB123"}} {
+		_, err := model.NewLocation(invalid[0], invalid[1])
+		assert.ErrorIs(t, err, model.ErrInvalidLocation, "%v", invalid)
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	category, err := model.ParseCategory("basic_food")
+	require.NoError(t, err)
+	assert.Equal(t, model.RateTypeSuperReduced, category.RateType())
+
+	_, err = model.ParseCategory("TOBACCO")
+	assert.ErrorIs(t, err, model.ErrUnknownCategory)
+	assert.Contains(t, model.Categories(), "HEALTHCARE")
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+var (
+	ErrUnknownCategory = errors.New("unknown product category")
+	ErrUnknownRegime   = errors.New("unknown tax regime")
+	ErrTaxRateNotFound = errors.New("tax rate not found")
+)
+
+// Regime is the indirect tax an operation is subject to.
+type Regime string
+
+const (
+	RegimeIVA  Regime = "IVA"  // Impuesto sobre el Valor Añadido, Peninsula and Balearic Islands
+	RegimeIGIC Regime = "IGIC" // Impuesto General Indirecto Canario, Canary Islands
+	RegimeIPSI Regime = "IPSI" // Impuesto sobre la Producción, los Servicios y la Importación, Ceuta and Melilla
+)
+
+// ParseRegime parses a tax regime, case insensitively.
+func ParseRegime(value string) (Regime, error) {
+	switch regime := Regime(strings.ToUpper(value)); regime {
+	case RegimeIVA, RegimeIGIC, RegimeIPSI:
+		return regime, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownRegime, value)
+	}
+}
+
+// RateType is the kind of rate of a regime an operation is taxed at.
+type RateType string
+
+const (
+	RateTypeGeneral      RateType = "GENERAL"
+	RateTypeReduced      RateType = "REDUCED"
+	RateTypeSuperReduced RateType = "SUPER_REDUCED"
+	RateTypeExempt       RateType = "EXEMPT"
+)
+
+// ExemptionCause identifies why an operation is exempt, with the codes of the Spanish SII.
+type ExemptionCause string
+
+const (
+	ExemptionArticle20 ExemptionCause = "E1" // Exempt by its nature (health care, education, insurance, finance...)
+	ExemptionExport    ExemptionCause = "E2" // Supplies to customers outside the European Union
+	ExemptionIntraEU   ExemptionCause = "E5" // Supplies to customers in other member states
+)
+
+// Category is the kind of product or service sold, which decides the rate type it is taxed at.
+type Category string
+
+const (
+	CategoryGeneral            Category = "GENERAL"
+	CategoryFood               Category = "FOOD"
+	CategoryWater              Category = "WATER"
+	CategoryPassengerTransport Category = "PASSENGER_TRANSPORT"
+	CategoryHospitality        Category = "HOSPITALITY"
+	CategoryBasicFood          Category = "BASIC_FOOD"
+	CategoryBooks              Category = "BOOKS"
+	CategoryMedicines          Category = "MEDICINES"
+	CategoryHealthcare         Category = "HEALTHCARE"
+	CategoryEducation          Category = "EDUCATION"
+	CategoryFinancial          Category = "FINANCIAL"
+	CategoryInsurance          Category = "INSURANCE"
+)
+
+// categoryRates maps every product category to the rate type it is taxed at in all Spanish territories.
+var categoryRates = map[Category]RateType{
+	CategoryGeneral:            RateTypeGeneral,
+	CategoryFood:               RateTypeReduced,
+	CategoryWater:              RateTypeReduced,
+	CategoryPassengerTransport: RateTypeReduced,
+	CategoryHospitality:        RateTypeReduced,
+	CategoryBasicFood:          RateTypeSuperReduced,
+	CategoryBooks:              RateTypeSuperReduced,
+	CategoryMedicines:          RateTypeSuperReduced,
+	CategoryHealthcare:         RateTypeExempt,
+	CategoryEducation:          RateTypeExempt,
+	CategoryFinancial:          RateTypeExempt,
+	CategoryInsurance:          RateTypeExempt,
+}
+
+// Categories lists the known product categories, sorted.
+func Categories() []string {
+	categories := make([]string, 0, len(categoryRates))
+	for category := range categoryRates {
+		categories = append(categories, string(category))
+	}
+	slices.Sort(categories)
+	return categories
+}
+
+// ParseCategory parses a product category, case insensitively.
+func ParseCategory(value string) (Category, error) {
+	category := Category(strings.ToUpper(value))
+	if _, ok := categoryRates[category]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCategory, value)
+	}
+	return category, nil
+}
+
+// RateType returns the rate type the category is taxed at.
+func (c Category) RateType() RateType {
+	return categoryRates[c]
+}
+
+// Rate is the percentage of a rate type in a territory during a period of time.
+type Rate struct {
+	Territory  Territory
+	Type       RateType
+	Percentage money.Percentage
+	ValidFrom  time.Time
+	ValidTo    time.Time // Zero while the rate is in force
+}
+
+// Tax is the tax an operation is subject to.
+type Tax struct {
+	Regime         Regime
+	RateType       RateType
+	Percentage     money.Percentage
+	ExemptionCause ExemptionCause // Only set for exempt operations
+}
+
+// Exempt returns the tax of an exempt operation of the given regime.
+func Exempt(regime Regime, cause ExemptionCause) Tax {
+	return Tax{Regime: regime, RateType: RateTypeExempt, ExemptionCause: cause}
+}
+
+// IsExempt reports whether the operation is exempt.
+func (t Tax) IsExempt() bool {
+	return t.RateType == RateTypeExempt
+}
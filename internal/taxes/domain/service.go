@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type Repository interface {
+	// GetTaxRate returns the rate of a type in force in a territory on the given date.
+	GetTaxRate(ctx context.Context, territory model.Territory, rateType model.RateType, date time.Time) (model.Rate, error)
+}
+
+// Service is the tax engine: it resolves the tax of an operation from what is sold, where the customer is and when.
+type Service struct {
+	repo   Repository
+	logger zerolog.Logger
+}
+
+func NewService(repo Repository) Service {
+	return Service{
+		repo:   repo,
+		logger: log.With().Str("module", "taxesService").Logger(),
+	}
+}
+
+// Resolve returns the tax of selling a product category to a customer at the given location on the given date.
+// Customers outside Spain are not charged Spanish taxes, those operations are exempt.
+func (s Service) Resolve(ctx context.Context, category model.Category, location model.Location, date time.Time) (model.Tax, error) {
+	territory := location.Territory()
+	logger := s.logger.With().Str("category", string(category)).Str("territory", string(territory)).Logger()
+
+	switch territory {
+	case model.TerritoryEU:
+		return model.Exempt(model.RegimeIVA, model.ExemptionIntraEU), nil
+	case model.TerritoryNonEU:
+		return model.Exempt(model.RegimeIVA, model.ExemptionExport), nil
+	}
+
+	rateType := category.RateType()
+	switch rateType {
+	case "":
+		return model.Tax{}, fmt.Errorf("%w: %q", model.ErrUnknownCategory, category)
+	case model.RateTypeExempt:
+		return model.Exempt(territory.Regime(), model.ExemptionArticle20), nil
+	}
+
+	rate, err := s.repo.GetTaxRate(ctx, territory, rateType, date)
+	if err != nil {
+		logger.Error().Err(err).Time("date", date).Msg("Failed to fetch tax rate")
+		return model.Tax{}, err
+	}
+
+	logger.Debug().Str("percentage", rate.Percentage.String()).Msg("Resolved tax")
+	return model.Tax{Regime: territory.Regime(), RateType: rateType, Percentage: rate.Percentage}, nil
+}
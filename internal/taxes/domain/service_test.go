@@ -0,0 +1,89 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo)
+	ctx := context.Background()
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().GetTaxRate(ctx, model.TerritoryMainland, model.RateTypeReduced, date).
+		Return(model.Rate{Territory: model.TerritoryMainland, Type: model.RateTypeReduced, Percentage: 1000}, nil)
+	mockRepo.EXPECT().GetTaxRate(ctx, model.TerritoryCanaryIslands, model.RateTypeGeneral, date).
+		Return(model.Rate{Territory: model.TerritoryCanaryIslands, Type: model.RateTypeGeneral, Percentage: 700}, nil)
+
+	tests := []struct {
+		name     string
+		category model.Category
+		location model.Location
+		expected model.Tax
+	}{
+		{
+			name:     "reduced IVA on the mainland",
+			category: model.CategoryFood,
+			location: model.Location{Country: "ES", PostalCode: "28001"},
+			expected: model.Tax{Regime: model.RegimeIVA, RateType: model.RateTypeReduced, Percentage: 1000},
+		},
+		{
+			name:     "general IGIC on the Canary Islands",
+			category: model.CategoryGeneral,
+			location: model.Location{Country: "ES", PostalCode: "35001"},
+			expected: model.Tax{Regime: model.RegimeIGIC, RateType: model.RateTypeGeneral, Percentage: 700},
+		},
+		{
+			name:     "exempt by its nature under IPSI",
+			category: model.CategoryHealthcare,
+			location: model.Location{Country: "ES", PostalCode: "52001"},
+			expected: model.Exempt(model.RegimeIPSI, model.ExemptionArticle20),
+		},
+		{
+			name:     "intra-EU supply",
+			category: model.CategoryGeneral,
+			location: model.Location{Country: "DE"},
+			expected: model.Exempt(model.RegimeIVA, model.ExemptionIntraEU),
+		},
+		{
+			name:     "export",
+			category: model.CategoryBooks,
+			location: model.Location{Country: "US"},
+			expected: model.Exempt(model.RegimeIVA, model.ExemptionExport),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tax, err := service.Resolve(ctx, tt.category, tt.location, date)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tax)
+		})
+	}
+}
+
+func TestService_Resolve_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := domain.NewMockRepository(ctrl)
+	service := domain.NewService(mockRepo)
+	ctx := context.Background()
+	date := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.Resolve(ctx, "TOBACCO", model.DefaultLocation, date)
+	assert.ErrorIs(t, err, model.ErrUnknownCategory)
+
+	mockRepo.EXPECT().GetTaxRate(ctx, model.TerritoryMainland, model.RateTypeGeneral, date).Return(model.Rate{}, model.ErrTaxRateNotFound)
+	tax, err := service.Resolve(ctx, model.CategoryGeneral, model.DefaultLocation, date)
+	assert.ErrorIs(t, err, model.ErrTaxRateNotFound)
+	assert.Equal(t, money.Percentage(0), tax.Percentage)
+}
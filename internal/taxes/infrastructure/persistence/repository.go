@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/infrastructure/persistence/sql"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	client    sql.TaxSqlClient
+	converter sql.TaxSqlConverter
+	logger    zerolog.Logger
+}
+
+func NewRepository(client sql.TaxSqlClient, converter sql.TaxSqlConverter) Repository {
+	return Repository{
+		client:    client,
+		converter: converter,
+		logger:    log.With().Str("component", "TaxesPersistenceRepository").Logger(),
+	}
+}
+
+// GetTaxRate retrieves the rate of a type in force in a territory on the given date
+func (r Repository) GetTaxRate(ctx context.Context, territory model.Territory, rateType model.RateType, date time.Time) (model.Rate, error) {
+	rate, err := r.client.GetTaxRate(ctx, string(territory), string(rateType), date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Rate{}, fmt.Errorf("%w: %s %s on %s", model.ErrTaxRateNotFound, territory, rateType, date.Format(time.DateOnly))
+		}
+		r.logger.Error().Err(err).Msg("Failed to fetch tax rate")
+		return model.Rate{}, err
+	}
+	return r.converter.RateToDomain(rate), nil
+}
@@ -0,0 +1,25 @@
+package sql
+
+import (
+	"github.com/ricardogrande-masmovil/billing-mcp/internal/taxes/domain/model"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+type TaxSqlConverter struct{}
+
+func NewTaxSqlConverter() TaxSqlConverter {
+	return TaxSqlConverter{}
+}
+
+func (c TaxSqlConverter) RateToDomain(rate TaxRate) model.Rate {
+	domainRate := model.Rate{
+		Territory:  model.Territory(rate.Territory),
+		Type:       model.RateType(rate.RateType),
+		Percentage: money.Percentage(rate.Percentage),
+		ValidFrom:  rate.ValidFrom,
+	}
+	if rate.ValidTo != nil {
+		domainRate.ValidTo = *rate.ValidTo
+	}
+	return domainRate
+}
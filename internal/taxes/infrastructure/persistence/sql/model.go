@@ -0,0 +1,22 @@
+package sql
+
+import (
+	"time"
+
+	commons "github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+)
+
+// TaxRate represents the percentage of a rate type in a tax territory during a period of time.
+type TaxRate struct {
+	commons.BaseModel
+	Territory  string          `gorm:"type:varchar(20);not null"`
+	RateType   string          `gorm:"type:varchar(20);not null"`
+	Percentage commons.Decimal `gorm:"type:decimal(5,2);not null"`
+	ValidFrom  time.Time       `gorm:"type:date;not null"`
+	ValidTo    *time.Time      `gorm:"type:date"`
+}
+
+// TableName specifies the table name for TaxRate in the database.
+func (TaxRate) TableName() string {
+	return "tax_rates"
+}
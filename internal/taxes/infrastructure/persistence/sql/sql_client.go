@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type TaxSqlClient struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewTaxSqlClient(db *gorm.DB) TaxSqlClient {
+	return TaxSqlClient{
+		db:     db,
+		logger: log.With().Str("component", "TaxesSqlClient").Logger(),
+	}
+}
+
+// GetTaxRate retrieves the rate of a type in force in a territory on the given date
+func (c TaxSqlClient) GetTaxRate(ctx context.Context, territory, rateType string, date time.Time) (rate TaxRate, err error) {
+	err = c.db.WithContext(ctx).
+		Where("territory = ? AND rate_type = ? AND valid_from <= ?", territory, rateType, date).
+		Where("valid_to IS NULL OR valid_to >= ?", date).
+		Order("valid_from DESC").
+		First(&rate).Error
+	return
+}
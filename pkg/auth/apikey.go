@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const minAPIKeyLength = 32
+
+// APIKey is a static API key, identified by its name, and the accounts it is granted.
+type APIKey struct {
+	Name string
+	Key  string
+	Grant
+}
+
+// APIKeys authenticates the static API keys sent in the X-API-Key header.
+type APIKeys struct {
+	keys []apiKeyDigest
+}
+
+type apiKeyDigest struct {
+	name   string
+	digest [sha256.Size]byte
+	grant  Grant
+}
+
+// NewAPIKeys creates the authenticator of the given keys. Keys must be at least 32 characters long, and are granted
+// the agent role unless they have another one.
+func NewAPIKeys(keys []APIKey) (APIKeys, error) {
+	digests := make([]apiKeyDigest, 0, len(keys))
+	for _, key := range keys {
+		if key.Name == "" {
+			return APIKeys{}, errors.New("API keys must have a name")
+		}
+		if len(key.Key) < minAPIKeyLength {
+			return APIKeys{}, fmt.Errorf("API key %q must be at least %d characters long", key.Name, minAPIKeyLength)
+		}
+		grant := key.Grant.withDefaultRole()
+		if err := grant.Validate(); err != nil {
+			return APIKeys{}, fmt.Errorf("API key %q: %w", key.Name, err)
+		}
+		digests = append(digests, apiKeyDigest{name: key.Name, digest: sha256.Sum256([]byte(key.Key)), grant: grant})
+	}
+	return APIKeys{keys: digests}, nil
+}
+
+// Authenticate compares the key of the request with every configured key in constant time.
+func (a APIKeys) Authenticate(r *http.Request) (Principal, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return Principal{}, ErrMissingCredentials
+	}
+
+	digest := sha256.Sum256([]byte(key))
+	var matched *apiKeyDigest
+	for i, expected := range a.keys {
+		if subtle.ConstantTimeCompare(digest[:], expected.digest[:]) == 1 {
+			matched = &a.keys[i]
+		}
+	}
+	if matched == nil {
+		return Principal{}, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+	}
+	return Principal{Subject: matched.name, Method: MethodAPIKey, Grant: matched.grant}, nil
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInsufficientScope  = errors.New("insufficient scope")
+)
+
+// APIKeyHeader is the header API keys are sent in.
+const APIKeyHeader = "X-API-Key"
+
+// Authenticator authenticates the client of an HTTP request. It returns ErrMissingCredentials when the request
+// carries no credentials it handles, so that another authenticator can try them.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+// Authenticators accepts the credentials of any of its authenticators, tried in order.
+type Authenticators []Authenticator
+
+// Authenticate returns the principal of the first authenticator handling the credentials of the request.
+func (a Authenticators) Authenticate(r *http.Request) (Principal, error) {
+	for _, authenticator := range a {
+		principal, err := authenticator.Authenticate(r)
+		if !errors.Is(err, ErrMissingCredentials) {
+			return principal, err
+		}
+	}
+	return Principal{}, ErrMissingCredentials
+}
+
+// bearerToken returns the token of the Authorization header. Tokens are never read from the query string.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
@@ -0,0 +1,151 @@
+package auth_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	apiKey     = "0123456789abcdef0123456789abcdef"
+	hmacSecret = "a-shared-secret-of-at-least-32-bytes"
+)
+
+// signJWT builds a compact token with the given header and claims, signed by sign.
+func signJWT(t *testing.T, header, claims map[string]any, sign func(signingInput []byte) []byte) string {
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func signHS256(secret string) func([]byte) []byte {
+	return func(signingInput []byte) []byte {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(signingInput)
+		return mac.Sum(nil)
+	}
+}
+
+func hmacClaims() map[string]any {
+	return map[string]any{
+		"iss":      "billing-backoffice",
+		"aud":      "billing-mcp",
+		"sub":      "agent-42",
+		"scope":    "billing:read billing:write",
+		"role":     "supervisor",
+		"accounts": []string{"account_A"},
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func requestWith(header, value string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	if value != "" {
+		request.Header.Set(header, value)
+	}
+	return request
+}
+
+func TestAPIKeys_Authenticate(t *testing.T) {
+	apiKeys, err := auth.NewAPIKeys([]auth.APIKey{{Name: "backoffice", Key: apiKey}})
+	require.NoError(t, err)
+
+	principal, err := apiKeys.Authenticate(requestWith(auth.APIKeyHeader, apiKey))
+	require.NoError(t, err)
+	assert.Equal(t, auth.Principal{Subject: "backoffice", Method: auth.MethodAPIKey, Grant: auth.Grant{Role: auth.RoleAgent}}, principal)
+
+	_, err = apiKeys.Authenticate(requestWith(auth.APIKeyHeader, "another-key-of-32-characters-long"))
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+
+	_, err = apiKeys.Authenticate(requestWith("Authorization", "Bearer "+apiKey))
+	assert.ErrorIs(t, err, auth.ErrMissingCredentials, "API keys are only read from their header")
+}
+
+func TestNewAPIKeys_InvalidKey(t *testing.T) {
+	_, err := auth.NewAPIKeys([]auth.APIKey{{Name: "backoffice", Key: "secret"}})
+	assert.ErrorContains(t, err, "at least 32 characters")
+
+	_, err = auth.NewAPIKeys([]auth.APIKey{{Name: "backoffice", Key: apiKey, Grant: auth.Grant{Role: "root"}}})
+	assert.ErrorIs(t, err, auth.ErrUnknownRole)
+}
+
+func TestHMACTokens_Authenticate(t *testing.T) {
+	tokens, err := auth.NewHMACTokens(hmacSecret, "billing-backoffice", "billing-mcp")
+	require.NoError(t, err)
+	header := map[string]any{"alg": "HS256", "typ": "JWT"}
+
+	token := signJWT(t, header, hmacClaims(), signHS256(hmacSecret))
+	principal, err := tokens.Authenticate(requestWith("Authorization", "Bearer "+token))
+	require.NoError(t, err)
+	assert.Equal(t, "agent-42", principal.Subject)
+	assert.Equal(t, auth.MethodHMAC, principal.Method)
+	assert.True(t, principal.HasScope("billing:write"))
+	assert.Equal(t, auth.Grant{Role: auth.RoleSupervisor, Accounts: []string{"account_A"}}, principal.Grant)
+
+	expired := hmacClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	otherAudience := hmacClaims()
+	otherAudience["aud"] = []string{"another-service"}
+	otherIssuer := hmacClaims()
+	otherIssuer["iss"] = "someone-else"
+	withoutExpiration := hmacClaims()
+	delete(withoutExpiration, "exp")
+	unknownRole := hmacClaims()
+	unknownRole["role"] = "root"
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong secret", signJWT(t, header, hmacClaims(), signHS256("another-secret-of-at-least-32-bytes"))},
+		{"expired", signJWT(t, header, expired, signHS256(hmacSecret))},
+		{"other audience", signJWT(t, header, otherAudience, signHS256(hmacSecret))},
+		{"other issuer", signJWT(t, header, otherIssuer, signHS256(hmacSecret))},
+		{"without expiration", signJWT(t, header, withoutExpiration, signHS256(hmacSecret))},
+		{"unknown role", signJWT(t, header, unknownRole, signHS256(hmacSecret))},
+		{"malformed", "not.a-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokens.Authenticate(requestWith("Authorization", "Bearer "+tt.token))
+			assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+		})
+	}
+
+	unsigned := signJWT(t, map[string]any{"alg": "none"}, hmacClaims(), func([]byte) []byte { return nil })
+	_, err = tokens.Authenticate(requestWith("Authorization", "Bearer "+unsigned))
+	assert.ErrorIs(t, err, auth.ErrMissingCredentials, "tokens of other algorithms are left to other authenticators")
+}
+
+func TestAuthenticators_Authenticate(t *testing.T) {
+	apiKeys, err := auth.NewAPIKeys([]auth.APIKey{{Name: "backoffice", Key: apiKey}})
+	require.NoError(t, err)
+	tokens, err := auth.NewHMACTokens(hmacSecret, "", "")
+	require.NoError(t, err)
+	authenticators := auth.Authenticators{apiKeys, tokens}
+
+	principal, err := authenticators.Authenticate(requestWith(auth.APIKeyHeader, apiKey))
+	require.NoError(t, err)
+	assert.Equal(t, auth.MethodAPIKey, principal.Method)
+
+	token := signJWT(t, map[string]any{"alg": "HS256"}, hmacClaims(), signHS256(hmacSecret))
+	principal, err = authenticators.Authenticate(requestWith("Authorization", "Bearer "+token))
+	require.NoError(t, err)
+	assert.Equal(t, auth.MethodHMAC, principal.Method)
+
+	_, err = authenticators.Authenticate(requestWith("", ""))
+	assert.ErrorIs(t, err, auth.ErrMissingCredentials)
+}
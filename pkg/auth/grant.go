@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Roles of a principal, deciding the accounts it may access.
+const (
+	RoleAgent      = "agent"      // Reads and operates the accounts granted to it
+	RoleSupervisor = "supervisor" // Reads every account, and operates the accounts granted to it
+	RoleAdmin      = "admin"      // Reads and operates every account
+)
+
+// Access is what a request does with the data of an account.
+type Access string
+
+const (
+	AccessRead  Access = "read"
+	AccessWrite Access = "write"
+)
+
+var (
+	ErrAccessDenied = errors.New("access denied")
+	ErrUnknownRole  = errors.New("unknown role")
+)
+
+// Grant is the role of a principal and the accounts granted to it.
+type Grant struct {
+	Role     string   // Defaults to agent
+	Accounts []string // Accounts an agent or supervisor operates, ignored for admins
+}
+
+// Validate checks the role is known.
+func (g Grant) Validate() error {
+	switch g.Role {
+	case RoleAgent, RoleSupervisor, RoleAdmin:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q, expected %q, %q or %q", ErrUnknownRole, g.Role, RoleAgent, RoleSupervisor, RoleAdmin)
+	}
+}
+
+// withDefaultRole returns the grant with the agent role when it has none.
+func (g Grant) withDefaultRole() Grant {
+	if g.Role == "" {
+		g.Role = RoleAgent
+	}
+	return g
+}
+
+// CanAccess tells whether the grant allows the access to the account.
+func (g Grant) CanAccess(accountId string, access Access) bool {
+	switch g.Role {
+	case RoleAdmin:
+		return true
+	case RoleSupervisor:
+		return access == AccessRead || slices.Contains(g.Accounts, accountId)
+	case RoleAgent:
+		return slices.Contains(g.Accounts, accountId)
+	default:
+		return false
+	}
+}
+
+// CanAccessAll tells whether the grant allows the access to every account at once, like a billing run does.
+func (g Grant) CanAccessAll(access Access) bool {
+	return g.Role == RoleAdmin || (g.Role == RoleSupervisor && access == AccessRead)
+}
+
+// Authorize checks the principal of the context may access the account. Requests without a principal are only
+// served when no authentication is configured, so they are let through.
+func Authorize(ctx context.Context, accountId string, access Access) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.CanAccess(accountId, access) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %q may not %s account %q", ErrAccessDenied, principal.Role, principal.Subject, access, accountId)
+}
+
+// AuthorizeAll checks the principal of the context may access every account at once.
+func AuthorizeAll(ctx context.Context, access Access) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.CanAccessAll(access) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %q may not %s every account", ErrAccessDenied, principal.Role, principal.Subject, access)
+}
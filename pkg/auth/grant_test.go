@@ -0,0 +1,61 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrant_CanAccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		grant    auth.Grant
+		account  string
+		access   auth.Access
+		expected bool
+	}{
+		{"agent reads a granted account", auth.Grant{Role: auth.RoleAgent, Accounts: []string{"account_A"}}, "account_A", auth.AccessRead, true},
+		{"agent operates a granted account", auth.Grant{Role: auth.RoleAgent, Accounts: []string{"account_A"}}, "account_A", auth.AccessWrite, true},
+		{"agent reads another account", auth.Grant{Role: auth.RoleAgent, Accounts: []string{"account_A"}}, "account_B", auth.AccessRead, false},
+		{"supervisor reads another account", auth.Grant{Role: auth.RoleSupervisor, Accounts: []string{"account_A"}}, "account_B", auth.AccessRead, true},
+		{"supervisor operates a granted account", auth.Grant{Role: auth.RoleSupervisor, Accounts: []string{"account_A"}}, "account_A", auth.AccessWrite, true},
+		{"supervisor operates another account", auth.Grant{Role: auth.RoleSupervisor, Accounts: []string{"account_A"}}, "account_B", auth.AccessWrite, false},
+		{"admin operates any account", auth.Grant{Role: auth.RoleAdmin}, "account_B", auth.AccessWrite, true},
+		{"unknown role", auth.Grant{Role: "root", Accounts: []string{"account_A"}}, "account_A", auth.AccessRead, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.grant.CanAccess(tt.account, tt.access))
+		})
+	}
+}
+
+func TestGrant_CanAccessAll(t *testing.T) {
+	agent := auth.Grant{Role: auth.RoleAgent, Accounts: []string{"account_A"}}
+	supervisor := auth.Grant{Role: auth.RoleSupervisor}
+	admin := auth.Grant{Role: auth.RoleAdmin}
+
+	assert.False(t, agent.CanAccessAll(auth.AccessRead))
+	assert.True(t, supervisor.CanAccessAll(auth.AccessRead))
+	assert.False(t, supervisor.CanAccessAll(auth.AccessWrite))
+	assert.True(t, admin.CanAccessAll(auth.AccessWrite))
+}
+
+func TestAuthorize(t *testing.T) {
+	agent := auth.Principal{Subject: "agent-42", Method: auth.MethodHMAC, Grant: auth.Grant{Role: auth.RoleAgent, Accounts: []string{"account_A"}}}
+	ctx := auth.WithPrincipal(context.Background(), agent)
+
+	assert.NoError(t, auth.Authorize(ctx, "account_A", auth.AccessWrite))
+
+	err := auth.Authorize(ctx, "account_B", auth.AccessRead)
+	assert.ErrorIs(t, err, auth.ErrAccessDenied)
+	assert.EqualError(t, err, `access denied: agent "agent-42" may not read account "account_B"`)
+
+	assert.ErrorIs(t, auth.AuthorizeAll(ctx, auth.AccessWrite), auth.ErrAccessDenied)
+
+	// Without authentication configured requests carry no principal
+	assert.NoError(t, auth.Authorize(context.Background(), "account_B", auth.AccessWrite))
+	assert.NoError(t, auth.AuthorizeAll(context.Background(), auth.AccessWrite))
+}
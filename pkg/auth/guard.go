@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// MetadataPath is where the protected resource metadata is served, see RFC 9728.
+const MetadataPath = "/.well-known/oauth-protected-resource"
+
+// Guard protects the routes of the server with its authenticators, any of which can authenticate a request.
+// Without authenticators it lets every request through.
+type Guard struct {
+	authenticators Authenticators
+	metadata       *ResourceMetadata // Advertised when tokens are issued by OAuth authorization servers
+	logger         zerolog.Logger
+}
+
+func NewGuard(logger zerolog.Logger, authenticators ...Authenticator) *Guard {
+	guard := &Guard{
+		authenticators: authenticators,
+		logger:         logger.With().Str("module", "authGuard").Logger(),
+	}
+	for _, authenticator := range authenticators {
+		if oauth, ok := authenticator.(OAuthTokens); ok {
+			metadata := oauth.Metadata()
+			guard.metadata = &metadata
+		}
+	}
+	return guard
+}
+
+// Enabled tells whether requests are authenticated.
+func (g *Guard) Enabled() bool {
+	return len(g.authenticators) > 0
+}
+
+// Register serves the protected resource metadata, when there is any, both at the root of the well-known path and
+// at the path of the resource, where clients following the MCP authorization specification look for it.
+func (g *Guard) Register(e *echo.Echo) {
+	if g.metadata == nil {
+		return
+	}
+	serve := func(ectx echo.Context) error {
+		return ectx.JSON(http.StatusOK, g.metadata)
+	}
+	e.GET(MetadataPath, serve)
+	if resource, err := url.Parse(g.metadata.Resource); err == nil && strings.Trim(resource.Path, "/") != "" {
+		e.GET(MetadataPath+"/"+strings.Trim(resource.Path, "/"), serve)
+	}
+}
+
+// Middleware authenticates the requests, passing the principal on in their context.
+func (g *Guard) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		if !g.Enabled() {
+			return next(ectx)
+		}
+
+		request := ectx.Request()
+		principal, err := g.authenticators.Authenticate(request)
+		if err != nil {
+			g.logger.Warn().Err(err).Str("path", request.URL.Path).Str("remoteIp", ectx.RealIP()).Msg("Unauthenticated request")
+			return g.challenge(ectx, err)
+		}
+
+		ectx.SetRequest(request.WithContext(WithPrincipal(request.Context(), principal)))
+		return next(ectx)
+	}
+}
+
+// challenge rejects a request, telling the client how to authenticate, see RFC 6750 and RFC 9728.
+func (g *Guard) challenge(ectx echo.Context, err error) error {
+	status, challenge := http.StatusUnauthorized, `Bearer realm="billing-mcp"`
+	switch {
+	case errors.Is(err, ErrInsufficientScope):
+		status = http.StatusForbidden
+		challenge += `, error="insufficient_scope"`
+		if g.metadata != nil {
+			challenge += fmt.Sprintf(`, scope=%q`, strings.Join(g.metadata.ScopesSupported, " "))
+		}
+	case errors.Is(err, ErrInvalidCredentials):
+		challenge += `, error="invalid_token"`
+	}
+	if g.metadata != nil {
+		challenge += fmt.Sprintf(`, resource_metadata=%q`, g.metadataURL())
+	}
+
+	ectx.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+	return ectx.JSON(status, map[string]string{"error": err.Error()})
+}
+
+// metadataURL is the absolute URL of the metadata of the resource.
+func (g *Guard) metadataURL() string {
+	resource, err := url.Parse(g.metadata.Resource)
+	if err != nil {
+		return MetadataPath
+	}
+	path := MetadataPath
+	if trimmed := strings.Trim(resource.Path, "/"); trimmed != "" {
+		path += "/" + trimmed
+	}
+	return (&url.URL{Scheme: resource.Scheme, Host: resource.Host, Path: path}).String()
+}
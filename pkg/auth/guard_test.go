@@ -0,0 +1,106 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGuardedEcho serves /protected behind the guard, answering with the subject of the principal.
+func newGuardedEcho(guard *auth.Guard) *echo.Echo {
+	e := echo.New()
+	guard.Register(e)
+	e.GET("/protected", func(ectx echo.Context) error {
+		principal, ok := auth.PrincipalFromContext(ectx.Request().Context())
+		if !ok {
+			return ectx.String(http.StatusOK, "anonymous")
+		}
+		return ectx.String(http.StatusOK, principal.Subject)
+	}, guard.Middleware)
+	return e
+}
+
+func serve(e *echo.Echo, header, value string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if value != "" {
+		request.Header.Set(header, value)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGuard_Middleware(t *testing.T) {
+	apiKeys, err := auth.NewAPIKeys([]auth.APIKey{{Name: "backoffice", Key: apiKey}})
+	require.NoError(t, err)
+	e := newGuardedEcho(auth.NewGuard(zerolog.Nop(), apiKeys))
+
+	response := serve(e, auth.APIKeyHeader, apiKey)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "backoffice", response.Body.String(), "the principal is passed in the request context")
+
+	response = serve(e, "", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Equal(t, `Bearer realm="billing-mcp"`, response.Header().Get(echo.HeaderWWWAuthenticate))
+
+	response = serve(e, auth.APIKeyHeader, "another-key-of-32-characters-long")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Equal(t, `Bearer realm="billing-mcp", error="invalid_token"`, response.Header().Get(echo.HeaderWWWAuthenticate))
+}
+
+func TestGuard_Middleware_WithoutAuthenticators(t *testing.T) {
+	guard := auth.NewGuard(zerolog.Nop())
+	assert.False(t, guard.Enabled())
+
+	response := serve(newGuardedEcho(guard), "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "anonymous", response.Body.String())
+}
+
+func TestGuard_OAuth(t *testing.T) {
+	server := newAuthorizationServer(t)
+	tokens, err := auth.NewOAuthTokens(resourceServer, server.jwks(t))
+	require.NoError(t, err)
+	e := newGuardedEcho(auth.NewGuard(zerolog.Nop(), tokens))
+
+	response := serve(e, "Authorization", "Bearer "+server.signES256(t, oauthClaims()))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "user-7", response.Body.String())
+
+	// Clients discover the authorization server from the challenge, as the MCP authorization specification requires
+	response = serve(e, "", "")
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Equal(t,
+		`Bearer realm="billing-mcp", resource_metadata="https://billing.example.com/.well-known/oauth-protected-resource/mcp"`,
+		response.Header().Get(echo.HeaderWWWAuthenticate))
+
+	withoutScope := oauthClaims()
+	withoutScope["scope"] = "openid"
+	withoutScope["exp"] = time.Now().Add(time.Minute).Unix()
+	response = serve(e, "Authorization", "Bearer "+server.signRS256(t, withoutScope))
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Contains(t, response.Header().Get(echo.HeaderWWWAuthenticate), `error="insufficient_scope", scope="billing"`)
+
+	for _, path := range []string{auth.MetadataPath, auth.MetadataPath + "/mcp"} {
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, recorder.Code, path)
+
+		var metadata auth.ResourceMetadata
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &metadata))
+		assert.Equal(t, auth.ResourceMetadata{
+			Resource:               resource,
+			AuthorizationServers:   []string{"https://auth.example.com"},
+			ScopesSupported:        []string{"billing"},
+			BearerMethodsSupported: []string{"header"},
+		}, metadata)
+	}
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// leeway tolerates the clock skew between the issuer of the tokens and the server.
+const leeway = time.Minute
+
+// jwt is a compact JSON Web Token whose signature is still to be verified.
+type jwt struct {
+	header       jwtHeader
+	claims       claims
+	signingInput string // Header and payload, as signed
+	signature    []byte
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Type      string `json:"typ"`
+}
+
+// claims are the registered claims of a token, with the OAuth scope and the grant of its subject.
+type claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	Scope     string   `json:"scope"` // Space separated, see RFC 8693
+	ClientID  string   `json:"client_id"`
+	Role      string   `json:"role"`     // Defaults to agent
+	Accounts  []string `json:"accounts"` // Accounts granted to the subject
+}
+
+// audience is the aud claim, either a string or a list of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("aud must be a string or a list of strings")
+	}
+	*a = list
+	return nil
+}
+
+// parseJWT decodes a compact token without verifying it.
+func parseJWT(token string) (jwt, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwt{}, fmt.Errorf("%w: malformed token", ErrInvalidCredentials)
+	}
+
+	var parsed jwt
+	if err := decodeSegment(parts[0], &parsed.header); err != nil {
+		return jwt{}, fmt.Errorf("%w: malformed token header: %w", ErrInvalidCredentials, err)
+	}
+	if err := decodeSegment(parts[1], &parsed.claims); err != nil {
+		return jwt{}, fmt.Errorf("%w: malformed token claims: %w", ErrInvalidCredentials, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwt{}, fmt.Errorf("%w: malformed token signature: %w", ErrInvalidCredentials, err)
+	}
+	parsed.signingInput = parts[0] + "." + parts[1]
+	parsed.signature = signature
+	return parsed, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// validate checks the token is in force and was issued by the issuer for the audience.
+func (c claims) validate(issuer, audience string, now time.Time) error {
+	if c.ExpiresAt == 0 {
+		return fmt.Errorf("%w: token without expiration", ErrInvalidCredentials)
+	}
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(leeway)) {
+		return fmt.Errorf("%w: token expired", ErrInvalidCredentials)
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return fmt.Errorf("%w: token not valid yet", ErrInvalidCredentials)
+	}
+	if issuer != "" && c.Issuer != issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidCredentials, c.Issuer)
+	}
+	if audience != "" && !slices.Contains(c.Audience, audience) {
+		return fmt.Errorf("%w: token not issued for %q", ErrInvalidCredentials, audience)
+	}
+	if c.Subject == "" && c.ClientID == "" {
+		return fmt.Errorf("%w: token without subject", ErrInvalidCredentials)
+	}
+	if err := c.grant().Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	return nil
+}
+
+// principal is the client the token was issued to.
+func (c claims) principal(method string) Principal {
+	subject := c.Subject
+	if subject == "" {
+		subject = c.ClientID
+	}
+	return Principal{Subject: subject, Method: method, Scopes: strings.Fields(c.Scope), Grant: c.grant()}
+}
+
+func (c claims) grant() Grant {
+	return Grant{Role: c.Role, Accounts: c.Accounts}.withDefaultRole()
+}
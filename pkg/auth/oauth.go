@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	algorithmRS256 = "RS256"
+	algorithmES256 = "ES256"
+
+	minRSAKeyBits = 2048
+)
+
+// ResourceServer describes the server as an OAuth 2.1 protected resource, see the MCP authorization specification.
+type ResourceServer struct {
+	Resource             string   // Canonical URI of the MCP server, e.g. https://billing.example.com/mcp, the audience of its tokens
+	AuthorizationServers []string // Issuers of the tokens, advertised to the clients
+	Issuer               string   // Expected iss claim, any of the authorization servers when empty
+	Scopes               []string // Scopes every token must be granted
+}
+
+// ResourceMetadata is the OAuth 2.0 Protected Resource Metadata of the server, see RFC 9728.
+type ResourceMetadata struct {
+	Resource               string   `json:"resource"`
+	AuthorizationServers   []string `json:"authorization_servers"`
+	ScopesSupported        []string `json:"scopes_supported,omitempty"`
+	BearerMethodsSupported []string `json:"bearer_methods_supported"`
+}
+
+// OAuthTokens authenticates the access tokens issued by an authorization server, JWTs signed with RS256 or ES256
+// by one of the keys of its JWKS, as an OAuth 2.1 resource server.
+type OAuthTokens struct {
+	server ResourceServer
+	keys   map[string]crypto.PublicKey // By key ID
+	now    func() time.Time
+}
+
+// NewOAuthTokens creates the authenticator of the tokens for the resource server, verified with the keys of the JWKS.
+func NewOAuthTokens(server ResourceServer, jwks []byte) (OAuthTokens, error) {
+	if _, err := url.ParseRequestURI(server.Resource); err != nil || server.Resource == "" {
+		return OAuthTokens{}, fmt.Errorf("the resource must be the absolute URI of the server: %q", server.Resource)
+	}
+	if len(server.AuthorizationServers) == 0 {
+		return OAuthTokens{}, errors.New("at least one authorization server is required")
+	}
+	keys, err := parseJWKS(jwks)
+	if err != nil {
+		return OAuthTokens{}, err
+	}
+	return OAuthTokens{server: server, keys: keys, now: time.Now}, nil
+}
+
+// LoadOAuthTokens creates the authenticator of the tokens for the resource server, reading the JWKS from a file.
+func LoadOAuthTokens(server ResourceServer, jwksPath string) (OAuthTokens, error) {
+	jwks, err := os.ReadFile(jwksPath)
+	if err != nil {
+		return OAuthTokens{}, fmt.Errorf("failed to read JWKS %s: %w", jwksPath, err)
+	}
+	return NewOAuthTokens(server, jwks)
+}
+
+// Metadata returns the metadata clients discover the authorization servers with.
+func (a OAuthTokens) Metadata() ResourceMetadata {
+	return ResourceMetadata{
+		Resource:               a.server.Resource,
+		AuthorizationServers:   a.server.AuthorizationServers,
+		ScopesSupported:        a.server.Scopes,
+		BearerMethodsSupported: []string{"header"},
+	}
+}
+
+// Authenticate verifies the signature, the issuer, the audience, the validity and the scopes of the bearer token.
+// Tokens signed with another algorithm are left to other authenticators.
+func (a OAuthTokens) Authenticate(r *http.Request) (Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return Principal{}, ErrMissingCredentials
+	}
+	parsed, err := parseJWT(token)
+	if err != nil {
+		return Principal{}, err
+	}
+	if parsed.header.Algorithm != algorithmRS256 && parsed.header.Algorithm != algorithmES256 {
+		return Principal{}, ErrMissingCredentials
+	}
+
+	if err := a.verify(parsed); err != nil {
+		return Principal{}, err
+	}
+	if err := parsed.claims.validate(a.server.Issuer, a.server.Resource, a.now()); err != nil {
+		return Principal{}, err
+	}
+	if a.server.Issuer == "" && !slices.Contains(a.server.AuthorizationServers, parsed.claims.Issuer) {
+		return Principal{}, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidCredentials, parsed.claims.Issuer)
+	}
+
+	principal := parsed.claims.principal(MethodOAuth)
+	for _, scope := range a.server.Scopes {
+		if !principal.HasScope(scope) {
+			return Principal{}, fmt.Errorf("%w: %s is required", ErrInsufficientScope, scope)
+		}
+	}
+	return principal, nil
+}
+
+// verify checks the signature with the key of the token, or with every key when the token names none.
+func (a OAuthTokens) verify(token jwt) error {
+	digest := sha256.Sum256([]byte(token.signingInput))
+	keys := a.keys
+	if token.header.KeyID != "" {
+		key, ok := a.keys[token.header.KeyID]
+		if !ok {
+			return fmt.Errorf("%w: unknown signing key %q", ErrInvalidCredentials, token.header.KeyID)
+		}
+		keys = map[string]crypto.PublicKey{token.header.KeyID: key}
+	}
+
+	for _, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			if token.header.Algorithm == algorithmRS256 && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], token.signature) == nil {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			if token.header.Algorithm == algorithmES256 && len(token.signature) == 64 {
+				r := new(big.Int).SetBytes(token.signature[:32])
+				s := new(big.Int).SetBytes(token.signature[32:])
+				if ecdsa.Verify(key, digest[:], r, s) {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%w: bad token signature", ErrInvalidCredentials)
+}
+
+// jwk is a public JSON Web Key, see RFC 7517.
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC keys
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// parseJWKS reads the signing keys of a JWKS. Keys for encryption and of unsupported types are skipped.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for i, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		kid := key.KeyID
+		if kid == "" {
+			kid = fmt.Sprintf("#%d", i)
+		}
+		switch key.KeyType {
+		case "RSA":
+			n, err := decodeBigInt(key.N)
+			if err != nil {
+				return nil, fmt.Errorf("invalid modulus of JWK %s: %w", kid, err)
+			}
+			if n.BitLen() < minRSAKeyBits {
+				return nil, fmt.Errorf("RSA JWK %s must be at least %d bits long", kid, minRSAKeyBits)
+			}
+			e, err := decodeBigInt(key.E)
+			if err != nil || !e.IsInt64() {
+				return nil, fmt.Errorf("invalid exponent of JWK %s", kid)
+			}
+			keys[kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			if key.Curve != "P-256" {
+				continue
+			}
+			x, errX := decodeBigInt(key.X)
+			y, errY := decodeBigInt(key.Y)
+			if errX != nil || errY != nil || !onP256(x, y) {
+				return nil, fmt.Errorf("invalid point of JWK %s", kid)
+			}
+			keys[kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS without RSA or P-256 signing keys")
+	}
+	return keys, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// onP256 tells whether the coordinates are a point of the P-256 curve.
+func onP256(x, y *big.Int) bool {
+	if x.BitLen() > 256 || y.BitLen() > 256 {
+		return false
+	}
+	point := make([]byte, 65)
+	point[0] = 4 // Uncompressed
+	x.FillBytes(point[1:33])
+	y.FillBytes(point[33:])
+	_, err := ecdh.P256().NewPublicKey(point)
+	return err == nil
+}
@@ -0,0 +1,149 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const resource = "https://billing.example.com/mcp"
+
+var resourceServer = auth.ResourceServer{
+	Resource:             resource,
+	AuthorizationServers: []string{"https://auth.example.com"},
+	Scopes:               []string{"billing"},
+}
+
+// authorizationServer holds the signing keys of a test authorization server.
+type authorizationServer struct {
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+func newAuthorizationServer(t *testing.T) authorizationServer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return authorizationServer{rsaKey: rsaKey, ecKey: ecKey}
+}
+
+func (s authorizationServer) jwks(t *testing.T) []byte {
+	encode := func(value *big.Int, size int) string {
+		return base64.RawURLEncoding.EncodeToString(value.FillBytes(make([]byte, size)))
+	}
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]any{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encode(s.rsaKey.N, 256), "e": encode(big.NewInt(int64(s.rsaKey.E)), 3)},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(s.ecKey.X, 32), "y": encode(s.ecKey.Y, 32)},
+		{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "AQAB", "e": "AQAB"},
+	}})
+	require.NoError(t, err)
+	return jwks
+}
+
+func (s authorizationServer) signRS256(t *testing.T, claims map[string]any) string {
+	return signJWT(t, map[string]any{"alg": "RS256", "kid": "rsa-1"}, claims, func(signingInput []byte) []byte {
+		digest := sha256.Sum256(signingInput)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return signature
+	})
+}
+
+func (s authorizationServer) signES256(t *testing.T, claims map[string]any) string {
+	return signJWT(t, map[string]any{"alg": "ES256", "kid": "ec-1"}, claims, func(signingInput []byte) []byte {
+		digest := sha256.Sum256(signingInput)
+		r, sig, err := ecdsa.Sign(rand.Reader, s.ecKey, digest[:])
+		require.NoError(t, err)
+		return append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...)
+	})
+}
+
+func oauthClaims() map[string]any {
+	return map[string]any{
+		"iss":   "https://auth.example.com",
+		"aud":   []string{resource},
+		"sub":   "user-7",
+		"scope": "billing openid",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestOAuthTokens_Authenticate(t *testing.T) {
+	server := newAuthorizationServer(t)
+	tokens, err := auth.NewOAuthTokens(resourceServer, server.jwks(t))
+	require.NoError(t, err)
+
+	for name, token := range map[string]string{
+		"RS256": server.signRS256(t, oauthClaims()),
+		"ES256": server.signES256(t, oauthClaims()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			principal, err := tokens.Authenticate(requestWith("Authorization", "Bearer "+token))
+			require.NoError(t, err)
+			assert.Equal(t, auth.Principal{Subject: "user-7", Method: auth.MethodOAuth, Scopes: []string{"billing", "openid"}, Grant: auth.Grant{Role: auth.RoleAgent}}, principal)
+		})
+	}
+}
+
+func TestOAuthTokens_Authenticate_Rejected(t *testing.T) {
+	server := newAuthorizationServer(t)
+	tokens, err := auth.NewOAuthTokens(resourceServer, server.jwks(t))
+	require.NoError(t, err)
+
+	otherAudience := oauthClaims()
+	otherAudience["aud"] = "https://another.example.com/mcp"
+	otherIssuer := oauthClaims()
+	otherIssuer["iss"] = "https://evil.example.com"
+	expired := oauthClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	withoutScope := oauthClaims()
+	withoutScope["scope"] = "openid"
+	tampered := server.signRS256(t, oauthClaims())
+	tampered = tampered[:len(tampered)-4] + "AAAA"
+
+	tests := []struct {
+		name     string
+		token    string
+		expected error
+	}{
+		{"token for another resource", server.signRS256(t, otherAudience), auth.ErrInvalidCredentials},
+		{"unknown issuer", server.signRS256(t, otherIssuer), auth.ErrInvalidCredentials},
+		{"expired", server.signES256(t, expired), auth.ErrInvalidCredentials},
+		{"tampered signature", tampered, auth.ErrInvalidCredentials},
+		{"signed by another server", newAuthorizationServer(t).signRS256(t, oauthClaims()), auth.ErrInvalidCredentials},
+		{"without the required scope", server.signRS256(t, withoutScope), auth.ErrInsufficientScope},
+		{"HMAC token", signJWT(t, map[string]any{"alg": "HS256"}, oauthClaims(), signHS256(hmacSecret)), auth.ErrMissingCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokens.Authenticate(requestWith("Authorization", "Bearer "+tt.token))
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNewOAuthTokens_InvalidConfig(t *testing.T) {
+	jwks := newAuthorizationServer(t).jwks(t)
+
+	_, err := auth.NewOAuthTokens(auth.ResourceServer{Resource: "billing", AuthorizationServers: []string{"https://auth.example.com"}}, jwks)
+	assert.ErrorContains(t, err, "absolute URI")
+
+	_, err = auth.NewOAuthTokens(auth.ResourceServer{Resource: resource}, jwks)
+	assert.ErrorContains(t, err, "authorization server")
+
+	_, err = auth.NewOAuthTokens(resourceServer, []byte(`{"keys": []}`))
+	assert.ErrorContains(t, err, "signing keys")
+}
@@ -0,0 +1,41 @@
+// Package auth authenticates the clients of the HTTP endpoints and carries who they are through the request context.
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Methods a principal can be authenticated with.
+const (
+	MethodAPIKey = "api-key"
+	MethodHMAC   = "hmac"
+	MethodOAuth  = "oauth"
+	MethodStdio  = "stdio" // The client that launched the server, trusted as its operator
+)
+
+// Principal is the authenticated client of a request, with the accounts it was granted.
+type Principal struct {
+	Subject string   // Name of the API key, or subject of the token
+	Method  string   // How the principal was authenticated
+	Scopes  []string // Scopes granted by the token, none for API keys
+	Grant
+}
+
+// HasScope tells whether the principal was granted the given scope.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of the context carrying the principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal carried by the context, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,50 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency     = errors.New("unknown ISO 4217 currency code")
+	ErrUnsupportedCurrency = errors.New("currency without 2 decimal digits is not supported")
+)
+
+// iso4217 holds the active ISO 4217 currency codes.
+var iso4217 = toSet(`AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL
+BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP
+GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF
+KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN
+NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD
+SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD UYU UZS VES VND
+VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+
+// minorUnits holds the decimal digits of the ISO 4217 currencies that don't have 2. Amounts are kept
+// in hundredths, so these currencies can't be represented.
+var minorUnits = map[Currency]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// ParseCurrency validates an ISO 4217 currency code, accepting lower case input.
+// Only currencies with 2 decimal digits are accepted.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if _, ok := iso4217[currency]; !ok {
+		return "", ErrUnknownCurrency
+	}
+	if digits, ok := minorUnits[currency]; ok {
+		return "", fmt.Errorf("%w: %s has %d decimal digits", ErrUnsupportedCurrency, currency, digits)
+	}
+	return currency, nil
+}
+
+func toSet(codes string) map[Currency]struct{} {
+	set := make(map[Currency]struct{})
+	for _, code := range strings.Fields(codes) {
+		set[Currency(code)] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,80 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// RateDecimals is the number of decimals kept when an exchange rate is rendered or stored.
+const RateDecimals = 8
+
+var ErrInvalidExchangeRate = errors.New("exchange rate must be a positive decimal number")
+
+// ExchangeRate converts amounts from one currency into another.
+// One unit of From is worth Rate units of To.
+type ExchangeRate struct {
+	From Currency
+	To   Currency
+	rate *big.Rat
+}
+
+// NewExchangeRate parses a decimal rate such as "1.0854" for the given currency pair.
+func NewExchangeRate(from, to Currency, rate string) (ExchangeRate, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return ExchangeRate{}, ErrInvalidExchangeRate
+	}
+	return ExchangeRate{From: from, To: to, rate: r}, nil
+}
+
+// IdentityRate returns the rate that converts a currency into itself.
+func IdentityRate(currency Currency) ExchangeRate {
+	return ExchangeRate{From: currency, To: currency, rate: big.NewRat(1, 1)}
+}
+
+// IsZero reports whether the rate has not been set.
+func (r ExchangeRate) IsZero() bool {
+	return r.rate == nil
+}
+
+// Invert returns the rate for the opposite direction.
+func (r ExchangeRate) Invert() ExchangeRate {
+	return ExchangeRate{From: r.To, To: r.From, rate: new(big.Rat).Inv(r.rate)}
+}
+
+// Convert converts an amount in the From currency into the To currency,
+// rounding half away from zero to the cent.
+func (r ExchangeRate) Convert(m Money) (Money, error) {
+	if r.IsZero() {
+		return Money{}, ErrInvalidExchangeRate
+	}
+	if m.currency != r.From {
+		return Money{}, fmt.Errorf("%w: rate is for %s, amount is in %s", ErrCurrencyMismatch, r.From, m.currency)
+	}
+
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt64(m.minor), r.rate)
+	return New(roundRat(converted), r.To), nil
+}
+
+// String returns the rate as a decimal string with RateDecimals decimals.
+func (r ExchangeRate) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	return r.rate.FloatString(RateDecimals)
+}
+
+// roundRat rounds a rational number half away from zero to an integer.
+func roundRat(r *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	doubled := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	if doubled.Cmp(r.Denom()) >= 0 {
+		if r.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient.Int64()
+}
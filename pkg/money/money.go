@@ -0,0 +1,273 @@
+// Package money provides exact monetary amounts for the billing domain.
+//
+// Amounts are held as an integer number of minor units (cents) together with
+// their currency, so additions, tax calculations and roundings never suffer
+// from binary floating point errors.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimal digits kept for every amount. Currencies with a different
+// number of decimal digits are rejected by ParseCurrency.
+const Scale = 2
+
+const minorPerUnit = 100
+
+var (
+	ErrInvalidAmount    = errors.New("invalid monetary amount")
+	ErrTooManyDecimals  = errors.New("monetary amount has more than 2 decimal digits")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Currency is an ISO 4217 currency code.
+type Currency string
+
+// DefaultCurrency is the currency used when none is specified.
+const DefaultCurrency Currency = "EUR"
+
+// String returns the currency code.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// Money is an exact amount of a given currency.
+type Money struct {
+	minor    int64
+	currency Currency
+}
+
+// New creates an amount from its minor units, e.g. New(10050, "EUR") is 100.50 EUR.
+func New(minor int64, currency Currency) Money {
+	return Money{minor: minor, currency: currency}
+}
+
+// Zero returns a zero amount of the given currency.
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Parse parses a decimal string such as "100.50" or "-3.2" into an amount.
+func Parse(amount string, currency Currency) (Money, error) {
+	minor, err := ParseMinor(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(minor, currency), nil
+}
+
+// ParseValue parses a decimal amount given either as a string or as a JSON number.
+func ParseValue(value any, currency Currency) (Money, error) {
+	switch v := value.(type) {
+	case string:
+		return Parse(v, currency)
+	case json.Number:
+		return Parse(v.String(), currency)
+	case float64:
+		return Parse(strconv.FormatFloat(v, 'f', -1, 64), currency)
+	case int:
+		return New(int64(v)*minorPerUnit, currency), nil
+	case int64:
+		return New(v*minorPerUnit, currency), nil
+	default:
+		return Money{}, ErrInvalidAmount
+	}
+}
+
+// ParseMinor parses a decimal string with at most Scale decimals into minor units.
+func ParseMinor(amount string) (int64, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return 0, ErrInvalidAmount
+	}
+
+	negative := false
+	switch amount[0] {
+	case '-':
+		negative = true
+		amount = amount[1:]
+	case '+':
+		amount = amount[1:]
+	}
+
+	units, decimals, _ := strings.Cut(amount, ".")
+	if units == "" && decimals == "" {
+		return 0, ErrInvalidAmount
+	}
+	if !isDigits(units) || !isDigits(decimals) {
+		return 0, ErrInvalidAmount
+	}
+	if len(decimals) > Scale {
+		// Trailing zeros beyond the scale do not change the value, e.g. "10.500".
+		if strings.Trim(decimals[Scale:], "0") != "" {
+			return 0, ErrTooManyDecimals
+		}
+		decimals = decimals[:Scale]
+	}
+	decimals += strings.Repeat("0", Scale-len(decimals))
+	if units == "" {
+		units = "0"
+	}
+
+	minor, err := strconv.ParseInt(units+decimals, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	if negative {
+		minor = -minor
+	}
+	return minor, nil
+}
+
+// FormatMinor renders minor units as a decimal string with Scale decimals.
+func FormatMinor(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, minor/minorPerUnit, Scale, minor%minorPerUnit)
+}
+
+// Minor returns the amount in minor units.
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Amount returns the decimal representation of the amount without currency, e.g. "100.50".
+func (m Money) Amount() string {
+	return FormatMinor(m.minor)
+}
+
+// String returns the amount followed by its currency, e.g. "100.50 EUR".
+func (m Money) String() string {
+	return m.Amount() + " " + m.currency.String()
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// IsPositive reports whether the amount is above zero.
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+// Add returns the sum of both amounts. They must share the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return New(m.minor+other.minor, m.currency), nil
+}
+
+// Sub returns the difference of both amounts. They must share the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return New(m.minor-other.minor, m.currency), nil
+}
+
+// Neg returns the amount with its sign inverted.
+func (m Money) Neg() Money {
+	return New(-m.minor, m.currency)
+}
+
+// Cmp compares both amounts, returning -1, 0 or +1. They must share the same currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.minor < other.minor:
+		return -1, nil
+	case m.minor > other.minor:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// ApplyPercentage returns the given percentage of the amount, rounded half away from zero to the cent.
+func (m Money) ApplyPercentage(p Percentage) Money {
+	return New(divRound(m.minor*int64(p), 100*percentageScale), m.currency)
+}
+
+// RemovePercentage returns the base amount that, increased by the given percentage, gives this amount,
+// rounded half away from zero to the cent. It splits an amount including tax into its taxable base.
+func (m Money) RemovePercentage(p Percentage) Money {
+	return New(divRound(m.minor*100*percentageScale, 100*percentageScale+int64(p)), m.currency)
+}
+
+// Sum adds up a list of amounts of the given currency.
+func Sum(currency Currency, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	return nil
+}
+
+// divRound divides rounding half away from zero.
+func divRound(numerator, denominator int64) int64 {
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	if 2*abs(remainder) >= abs(denominator) {
+		if (numerator < 0) != (denominator < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return quotient
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// RoundFloat converts a float amount to minor units, rounding to the nearest cent.
+// It is only meant for reading legacy floating point values.
+func RoundFloat(v float64) int64 {
+	return int64(math.Round(v * minorPerUnit))
+}
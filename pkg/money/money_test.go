@@ -0,0 +1,139 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"100.50", 10050},
+		{"100.5", 10050},
+		{"100", 10000},
+		{"-3.2", -320},
+		{".75", 75},
+		{"10.500", 1050},
+	}
+
+	for _, tt := range tests {
+		amount, err := money.Parse(tt.input, money.DefaultCurrency)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, amount.Minor(), tt.input)
+	}
+
+	for _, invalid := range []string{"", "abc", "1.2.3", "1,50", "-"} {
+		_, err := money.Parse(invalid, money.DefaultCurrency)
+		assert.ErrorIs(t, err, money.ErrInvalidAmount, invalid)
+	}
+
+	_, err := money.Parse("0.001", money.DefaultCurrency)
+	assert.ErrorIs(t, err, money.ErrTooManyDecimals)
+}
+
+func TestParseValue(t *testing.T) {
+	amount, err := money.ParseValue(100.5, money.DefaultCurrency)
+	require.NoError(t, err)
+	assert.Equal(t, "100.50", amount.Amount())
+
+	a, b := 0.1, 0.2
+	_, err = money.ParseValue(a+b, money.DefaultCurrency)
+	assert.ErrorIs(t, err, money.ErrTooManyDecimals, "float noise must not be silently rounded")
+
+	_, err = money.ParseValue(true, money.DefaultCurrency)
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := money.New(10050, "EUR")
+	b := money.New(-2525, "EUR")
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, "75.25", sum.Amount())
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	assert.Equal(t, "125.75 EUR", diff.String())
+
+	_, err = a.Add(money.New(1, "USD"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	total, err := money.Sum("EUR", a, a, b)
+	require.NoError(t, err)
+	assert.Equal(t, int64(17575), total.Minor())
+}
+
+func TestMoney_ApplyPercentage(t *testing.T) {
+	tests := []struct {
+		amount     int64
+		percentage money.Percentage
+		expected   int64
+	}{
+		{10000, 2100, 2100}, // 100.00 * 21% = 21.00
+		{4568, 1000, 457},   // 45.68 * 10% = 4.568 -> 4.57
+		{1250, 2100, 263},   // 12.50 * 21% = 2.625 -> 2.63
+		{-1250, 2100, -263}, // rounding is symmetric for negative amounts
+		{999, 400, 40},      // 9.99 * 4% = 0.3996 -> 0.40
+	}
+
+	for _, tt := range tests {
+		result := money.New(tt.amount, "EUR").ApplyPercentage(tt.percentage)
+		assert.Equal(t, tt.expected, result.Minor(), "%d * %s%%", tt.amount, tt.percentage)
+	}
+}
+
+func TestMoney_RemovePercentage(t *testing.T) {
+	assert.Equal(t, "45.68", money.New(5025, "EUR").RemovePercentage(1000).Amount(), "50.25 / 1.10 = 45.6818")
+	assert.Equal(t, "125.63", money.New(15075, "EUR").RemovePercentage(2000).Amount(), "150.75 / 1.20 = 125.625")
+	assert.Equal(t, "100.00", money.New(12100, "EUR").RemovePercentage(2100).Amount())
+	assert.Equal(t, "-100.00", money.New(-12100, "EUR").RemovePercentage(2100).Amount())
+}
+
+func TestParsePercentage(t *testing.T) {
+	p, err := money.ParsePercentage("21")
+	require.NoError(t, err)
+	assert.Equal(t, money.Percentage(2100), p)
+	assert.Equal(t, "21.00", p.String())
+
+	_, err = money.ParsePercentage("-1")
+	assert.ErrorIs(t, err, money.ErrNegativePercentage)
+}
+
+func TestParseCurrency(t *testing.T) {
+	currency, err := money.ParseCurrency("usd")
+	require.NoError(t, err)
+	assert.Equal(t, money.Currency("USD"), currency)
+
+	_, err = money.ParseCurrency("XYZ")
+	assert.ErrorIs(t, err, money.ErrUnknownCurrency)
+
+	for _, code := range []string{"JPY", "KRW", "KWD", "BHD", "OMR", "JOD", "TND"} {
+		_, err = money.ParseCurrency(code)
+		assert.ErrorIs(t, err, money.ErrUnsupportedCurrency, code)
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	rate, err := money.NewExchangeRate("USD", "EUR", "0.92135")
+	require.NoError(t, err)
+
+	converted, err := rate.Convert(money.New(10050, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, "92.60 EUR", converted.String(), "100.50 * 0.92135 = 92.595675")
+
+	back, err := rate.Invert().Convert(converted)
+	require.NoError(t, err)
+	assert.Equal(t, "100.50 USD", back.String())
+
+	_, err = rate.Convert(money.New(100, "GBP"))
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	_, err = money.NewExchangeRate("USD", "EUR", "-1")
+	assert.ErrorIs(t, err, money.ErrInvalidExchangeRate)
+}
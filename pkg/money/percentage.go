@@ -0,0 +1,41 @@
+package money
+
+import "errors"
+
+// percentageScale is the number of units per whole percent, percentages keep two decimals.
+const percentageScale = 100
+
+var ErrNegativePercentage = errors.New("percentage cannot be negative")
+
+// Percentage is an exact percentage with two decimals, stored in hundredths of a percent.
+// For example 21% is Percentage(2100) and 5.5% is Percentage(550).
+type Percentage int64
+
+// ParsePercentage parses a decimal string such as "21" or "5.5" into a Percentage.
+func ParsePercentage(value string) (Percentage, error) {
+	hundredths, err := ParseMinor(value)
+	if err != nil {
+		return 0, err
+	}
+	if hundredths < 0 {
+		return 0, ErrNegativePercentage
+	}
+	return Percentage(hundredths), nil
+}
+
+// ParsePercentageValue parses a percentage given either as a string or as a JSON number.
+func ParsePercentageValue(value any) (Percentage, error) {
+	amount, err := ParseValue(value, "")
+	if err != nil {
+		return 0, err
+	}
+	if amount.IsNegative() {
+		return 0, ErrNegativePercentage
+	}
+	return Percentage(amount.Minor()), nil
+}
+
+// String returns the decimal representation of the percentage, e.g. "21.00".
+func (p Percentage) String() string {
+	return FormatMinor(int64(p))
+}
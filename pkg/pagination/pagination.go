@@ -0,0 +1,131 @@
+// Package pagination pages the lists returned by the MCP tools, so a large account never floods the context of an
+// agent. Pages are cursor based: the cursor points after the last item of a page, and the next page starts there
+// even when items are added meanwhile.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+const (
+	DefaultPageSize = 20  // Size of the pages when the request does not set one
+	MaxPageSize     = 100 // Larger page sizes are lowered to it
+)
+
+var (
+	ErrInvalidPageSize = errors.New("page size must be a positive integer")
+	ErrInvalidSort     = errors.New("invalid sort")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+)
+
+// Direction is the order of a sort.
+type Direction string
+
+const (
+	Ascending  Direction = "asc"
+	Descending Direction = "desc"
+)
+
+// Sort is the field a list is sorted by and its direction. Items sharing the value of the field are sorted by ID.
+type Sort struct {
+	Field     string
+	Direction Direction
+}
+
+// Request asks for a page of a list.
+type Request struct {
+	Size   int
+	Sort   Sort
+	Cursor *Cursor // Nil for the first page
+}
+
+// Cursor is the position after the last item of a page: the value of its sort field and its ID.
+type Cursor struct {
+	Field     string    `json:"f"`
+	Direction Direction `json:"d"`
+	Value     string    `json:"v"`
+	ID        string    `json:"id"`
+}
+
+// Page is a page of a list, with the cursor of the next page when there are more items.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+// Encode returns the cursor as the opaque string handed to the clients.
+func (c Cursor) Encode() string {
+	jsonData, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(jsonData)
+}
+
+// ParseCursor reads a cursor returned by Encode.
+func ParseCursor(encoded string) (Cursor, error) {
+	jsonData, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(jsonData, &cursor); err != nil || cursor.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// FromArgs reads the pageSize, cursor, sortBy and sortOrder arguments of a tool. The list can be sorted by the given
+// fields, by the default sort when the arguments set none. A cursor continues the sort of the page it was returned
+// with, so the next pages only need it.
+func FromArgs(args map[string]any, fields []string, defaultSort Sort) (Request, error) {
+	request := Request{Size: DefaultPageSize, Sort: defaultSort}
+
+	if value, ok := args["pageSize"]; ok {
+		size, ok := value.(float64)
+		if !ok || size < 1 || size != math.Trunc(size) {
+			return Request{}, ErrInvalidPageSize
+		}
+		request.Size = int(min(size, MaxPageSize))
+	}
+
+	if encoded, ok := args["cursor"].(string); ok && encoded != "" {
+		cursor, err := ParseCursor(encoded)
+		if err != nil {
+			return Request{}, err
+		}
+		request.Cursor = &cursor
+		request.Sort = Sort{Field: cursor.Field, Direction: cursor.Direction}
+	}
+
+	if field, ok := args["sortBy"].(string); ok && field != "" {
+		request.Sort.Field = field
+	}
+	if direction, ok := args["sortOrder"].(string); ok && direction != "" {
+		request.Sort.Direction = Direction(direction)
+	}
+	if !slices.Contains(fields, request.Sort.Field) {
+		return Request{}, fmt.Errorf("%w: sortBy must be one of %v", ErrInvalidSort, fields)
+	}
+	if request.Sort.Direction != Ascending && request.Sort.Direction != Descending {
+		return Request{}, fmt.Errorf("%w: sortOrder must be %q or %q", ErrInvalidSort, Ascending, Descending)
+	}
+	if request.Cursor != nil && (request.Cursor.Field != request.Sort.Field || request.Cursor.Direction != request.Sort.Direction) {
+		return Request{}, fmt.Errorf("%w: it continues a list sorted by %s %s", ErrInvalidCursor, request.Cursor.Field, request.Cursor.Direction)
+	}
+	return request, nil
+}
+
+// NewPage builds the page from the items fetched for the request, which are one more than the page size when another
+// page follows. The position of an item is its sort value and its ID.
+func NewPage[T any](items []T, request Request, position func(item T) (value, id string)) Page[T] {
+	if len(items) <= request.Size {
+		return Page[T]{Items: items}
+	}
+	items = items[:request.Size]
+	value, id := position(items[len(items)-1])
+	cursor := Cursor{Field: request.Sort.Field, Direction: request.Sort.Direction, Value: value, ID: id}
+	return Page[T]{Items: items, NextCursor: cursor.Encode()}
+}
@@ -0,0 +1,78 @@
+package pagination_test
+
+import (
+	"testing"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fields      = []string{"issueDate", "totalAmount"}
+	defaultSort = pagination.Sort{Field: "issueDate", Direction: pagination.Descending}
+)
+
+func TestFromArgs_Defaults(t *testing.T) {
+	request, err := pagination.FromArgs(map[string]any{}, fields, defaultSort)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Request{Size: pagination.DefaultPageSize, Sort: defaultSort}, request)
+}
+
+func TestFromArgs(t *testing.T) {
+	request, err := pagination.FromArgs(map[string]any{"pageSize": float64(5), "sortBy": "totalAmount", "sortOrder": "asc"}, fields, defaultSort)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Request{Size: 5, Sort: pagination.Sort{Field: "totalAmount", Direction: pagination.Ascending}}, request)
+
+	request, err = pagination.FromArgs(map[string]any{"pageSize": float64(5000)}, fields, defaultSort)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.MaxPageSize, request.Size, "page sizes are capped")
+}
+
+func TestFromArgs_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		expected error
+	}{
+		{"zero page size", map[string]any{"pageSize": float64(0)}, pagination.ErrInvalidPageSize},
+		{"fractional page size", map[string]any{"pageSize": 2.5}, pagination.ErrInvalidPageSize},
+		{"page size as text", map[string]any{"pageSize": "10"}, pagination.ErrInvalidPageSize},
+		{"unknown sort field", map[string]any{"sortBy": "customer"}, pagination.ErrInvalidSort},
+		{"unknown sort order", map[string]any{"sortOrder": "up"}, pagination.ErrInvalidSort},
+		{"malformed cursor", map[string]any{"cursor": "not a cursor"}, pagination.ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pagination.FromArgs(tt.args, fields, defaultSort)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestFromArgs_Cursor(t *testing.T) {
+	cursor := pagination.Cursor{Field: "totalAmount", Direction: pagination.Ascending, Value: "100.50", ID: "0f8fad5b-d9cb-469f-a165-70867728950e"}
+
+	// The cursor continues the sort of its page without repeating it
+	request, err := pagination.FromArgs(map[string]any{"cursor": cursor.Encode()}, fields, defaultSort)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Sort{Field: "totalAmount", Direction: pagination.Ascending}, request.Sort)
+	assert.Equal(t, &cursor, request.Cursor)
+
+	_, err = pagination.FromArgs(map[string]any{"cursor": cursor.Encode(), "sortBy": "issueDate"}, fields, defaultSort)
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor, "a cursor cannot continue another sort")
+}
+
+func TestNewPage(t *testing.T) {
+	request := pagination.Request{Size: 2, Sort: defaultSort}
+	position := func(item int) (string, string) { return "value", "id" }
+
+	page := pagination.NewPage([]int{1, 2}, request, position)
+	assert.Equal(t, pagination.Page[int]{Items: []int{1, 2}}, page, "the last page has no cursor")
+
+	page = pagination.NewPage([]int{1, 2, 3}, request, position)
+	assert.Equal(t, []int{1, 2}, page.Items)
+	cursor, err := pagination.ParseCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Cursor{Field: "issueDate", Direction: pagination.Descending, Value: "value", ID: "id"}, cursor)
+}
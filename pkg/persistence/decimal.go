@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+)
+
+// Decimal maps a DECIMAL(p,2) column to an exact integer number of hundredths.
+// It is used for monetary amounts (in cents) and percentages (in hundredths of a percent).
+type Decimal int64
+
+// Scan implements sql.Scanner. PostgreSQL returns NUMERIC values as text.
+func (d *Decimal) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = 0
+	case []byte:
+		return d.parse(string(v))
+	case string:
+		return d.parse(v)
+	case float64:
+		*d = Decimal(money.RoundFloat(v))
+	case int64:
+		*d = Decimal(v * 100)
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", value)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, writing the value as a decimal string.
+func (d Decimal) Value() (driver.Value, error) {
+	return money.FormatMinor(int64(d)), nil
+}
+
+// MarshalJSON writes the value as an exact JSON number, e.g. 100.50.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(money.FormatMinor(int64(d))), nil
+}
+
+// UnmarshalJSON reads a JSON number or a decimal string without going through floating point.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	return d.parse(strings.Trim(string(data), `"`))
+}
+
+func (d *Decimal) parse(s string) error {
+	minor, err := money.ParseMinor(s)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Decimal: %w", s, err)
+	}
+	*d = Decimal(minor)
+	return nil
+}
@@ -0,0 +1,39 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON maps a JSONB column to a Go value.
+type JSON[T any] struct {
+	Data T
+}
+
+// Scan implements sql.Scanner.
+func (j *JSON[T]) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		var zero T
+		j.Data = zero
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return json.Unmarshal(data, &j.Data)
+}
+
+// Value implements driver.Valuer, writing the value as JSON text.
+func (j JSON[T]) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Keyset pages the rows of a query by a sort column, breaking ties with the ID column, so the rows after a cursor are
+// found with the index of the column instead of counting the rows before them.
+type Keyset[T any] struct {
+	Column   string
+	Value    func(row T) any // Value of the sort column in a row
+	IDColumn string
+	ID       func(row T) string
+}
+
+// Scope sorts the query and restricts it to the rows after the cursor of the page. It fetches one row more than the
+// page size, so Page tells whether another page follows.
+func (k Keyset[T]) Scope(page pagination.Request) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		operator, order := ">", "ASC"
+		if page.Sort.Direction == pagination.Descending {
+			operator, order = "<", "DESC"
+		}
+		if page.Cursor != nil {
+			db = db.Where(fmt.Sprintf("(%[1]s %[3]s ? OR (%[1]s = ? AND %[2]s %[3]s ?))", k.Column, k.IDColumn, operator),
+				page.Cursor.Value, page.Cursor.Value, page.Cursor.ID)
+		}
+		return db.Order(fmt.Sprintf("%s %s, %s %s", k.Column, order, k.IDColumn, order)).Limit(page.Size + 1)
+	}
+}
+
+// Page builds the page from the rows fetched with Scope.
+func (k Keyset[T]) Page(rows []T, page pagination.Request) pagination.Page[T] {
+	return pagination.NewPage(rows, page, func(row T) (string, string) {
+		return cursorValue(k.Value(row)), k.ID(row)
+	})
+}
+
+// cursorValue writes a sort value as the database reads it back in the condition of the next page.
+func cursorValue(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case driver.Valuer:
+		if dbValue, err := v.Value(); err == nil {
+			return fmt.Sprint(dbValue)
+		}
+	}
+	return fmt.Sprint(value)
+}
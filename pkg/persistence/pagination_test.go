@@ -0,0 +1,67 @@
+package persistence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type row struct {
+	ID        string
+	IssueDate time.Time
+	Total     persistence.Decimal
+}
+
+var byTotal = persistence.Keyset[row]{
+	Column:   "total",
+	Value:    func(r row) any { return r.Total },
+	IDColumn: "id",
+	ID:       func(r row) string { return r.ID },
+}
+
+// dryRun builds the statements of the queries without a database.
+func dryRun(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+func TestKeyset_Scope(t *testing.T) {
+	page := pagination.Request{Size: 10, Sort: pagination.Sort{Field: "total", Direction: pagination.Descending}}
+
+	var rows []row
+	statement := dryRun(t).Table("rows").Scopes(byTotal.Scope(page)).Find(&rows).Statement
+	assert.Equal(t, `SELECT * FROM "rows" ORDER BY total DESC, id DESC LIMIT $1`, statement.SQL.String())
+	assert.Equal(t, []any{11}, statement.Vars, "one more row tells whether another page follows")
+
+	page.Sort.Direction = pagination.Ascending
+	page.Cursor = &pagination.Cursor{Field: "total", Direction: pagination.Ascending, Value: "100.50", ID: "b"}
+	statement = dryRun(t).Table("rows").Scopes(byTotal.Scope(page)).Find(&rows).Statement
+	assert.Equal(t, `SELECT * FROM "rows" WHERE (total > $1 OR (total = $2 AND id > $3)) ORDER BY total ASC, id ASC LIMIT $4`, statement.SQL.String())
+	assert.Equal(t, []any{"100.50", "100.50", "b", 11}, statement.Vars)
+}
+
+func TestKeyset_Page(t *testing.T) {
+	page := pagination.Request{Size: 1, Sort: pagination.Sort{Field: "total", Direction: pagination.Ascending}}
+
+	result := byTotal.Page([]row{{ID: "a", Total: 10050}, {ID: "b", Total: 20000}}, page)
+	assert.Equal(t, []row{{ID: "a", Total: 10050}}, result.Items)
+
+	cursor, err := pagination.ParseCursor(result.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Cursor{Field: "total", Direction: pagination.Ascending, Value: "100.50", ID: "a"}, cursor,
+		"the cursor holds the value as the database reads it")
+
+	byIssueDate := persistence.Keyset[row]{Column: "issue_date", Value: func(r row) any { return r.IssueDate }, IDColumn: "id", ID: byTotal.ID}
+	issueDate := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+	result = byIssueDate.Page([]row{{ID: "a", IssueDate: issueDate}, {ID: "b"}}, page)
+	cursor, err = pagination.ParseCursor(result.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-01T10:30:00Z", cursor.Value)
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	RunStatusSucceeded = "SUCCEEDED"
+	RunStatusFailed    = "FAILED"
+)
+
+// JobRun records the last execution of a scheduled job.
+type JobRun struct {
+	Name       string    `gorm:"type:varchar(100);primaryKey"`
+	StartedAt  time.Time `gorm:"not null"`
+	FinishedAt time.Time `gorm:"not null"`
+	Status     string    `gorm:"type:varchar(50);not null"`
+	Error      *string   `gorm:"type:text"`
+}
+
+// TableName specifies the table name for JobRun in the database.
+func (JobRun) TableName() string {
+	return "scheduled_job_runs"
+}
+
+// PostgresGuard makes jobs exclusive with a transaction-level advisory lock keyed by the job name,
+// and records every run it performs in scheduled_job_runs.
+type PostgresGuard struct {
+	db *gorm.DB
+}
+
+func NewPostgresGuard(db *gorm.DB) PostgresGuard {
+	return PostgresGuard{db: db}
+}
+
+// RunExclusive runs fn while holding the advisory lock of the job, skipping it when another session holds it.
+// The lock is released when the transaction ends, so a crashed replica never keeps it.
+func (g PostgresGuard) RunExclusive(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	ran := false
+	var jobErr error
+
+	// The transaction outlives ctx so that a job interrupted by a shutdown is still recorded
+	err := g.db.WithContext(context.WithoutCancel(ctx)).Transaction(func(tx *gorm.DB) error {
+		var acquired bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", name).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+
+		ran = true
+		run := JobRun{Name: name, StartedAt: time.Now().UTC(), Status: RunStatusSucceeded}
+		jobErr = fn(ctx)
+		run.FinishedAt = time.Now().UTC()
+		if jobErr != nil {
+			msg := jobErr.Error()
+			run.Status, run.Error = RunStatusFailed, &msg
+		}
+
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&run).Error
+	})
+	if err != nil {
+		return ran, err
+	}
+	return ran, jobErr
+}
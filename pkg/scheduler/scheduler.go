@@ -0,0 +1,91 @@
+// Package scheduler runs background jobs periodically for as long as the application is up.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Job is a unit of work run every Interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Guard runs a job exclusively across every replica of the application.
+// It reports whether the job ran, as it is skipped while another replica holds it.
+type Guard interface {
+	RunExclusive(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
+
+// Scheduler runs its jobs once on start and then on every tick of their interval, until stopped.
+type Scheduler struct {
+	jobs   []Job
+	guard  Guard
+	logger zerolog.Logger
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(guard Guard, logger zerolog.Logger, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		jobs:   jobs,
+		guard:  guard,
+		logger: logger.With().Str("module", "scheduler").Logger(),
+	}
+}
+
+// Start launches every job in the background. Jobs stop when ctx is cancelled or Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+	s.logger.Info().Int("jobs", len(s.jobs)).Msg("Scheduler started")
+}
+
+// Stop cancels the running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.logger.Info().Msg("Scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	logger := s.logger.With().Str("job", job.Name).Logger()
+	started := time.Now()
+
+	ran, err := s.guard.RunExclusive(ctx, job.Name, job.Run)
+	switch {
+	case err != nil:
+		logger.Error().Err(err).Dur("duration", time.Since(started)).Msg("Scheduled job failed")
+	case !ran:
+		logger.Debug().Msg("Scheduled job skipped, another instance is running it")
+	default:
+		logger.Info().Dur("duration", time.Since(started)).Msg("Scheduled job completed")
+	}
+}
@@ -0,0 +1,95 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/scheduler"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubGuard runs jobs only while it holds the lock
+type stubGuard struct {
+	locked atomic.Bool
+}
+
+func (g *stubGuard) RunExclusive(ctx context.Context, _ string, fn func(ctx context.Context) error) (bool, error) {
+	if g.locked.Load() {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func TestScheduler_RunsJobsUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	job := scheduler.Job{
+		Name:     "count",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	}
+
+	s := scheduler.NewScheduler(&stubGuard{}, zerolog.Nop(), job)
+	s.Start(context.Background())
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond, "job runs on start and on every tick")
+
+	s.Stop()
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "no job runs after Stop returns")
+}
+
+func TestScheduler_SkipsJobsHeldByAnotherInstance(t *testing.T) {
+	var runs atomic.Int32
+	guard := &stubGuard{}
+	guard.locked.Store(true)
+
+	s := scheduler.NewScheduler(guard, zerolog.Nop(), scheduler.Job{
+		Name:     "count",
+		Interval: 5 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+	s.Start(context.Background())
+	time.Sleep(30 * time.Millisecond)
+	assert.Zero(t, runs.Load())
+
+	guard.locked.Store(false)
+	assert.Eventually(t, func() bool { return runs.Load() > 0 }, time.Second, 5*time.Millisecond)
+	s.Stop()
+}
+
+func TestScheduler_StopCancelsRunningJob(t *testing.T) {
+	started := make(chan struct{})
+	s := scheduler.NewScheduler(&stubGuard{}, zerolog.Nop(), scheduler.Job{
+		Name:     "blocking",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	s.Start(context.Background())
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not wait for the job to be cancelled")
+	}
+}
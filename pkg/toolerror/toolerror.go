@@ -0,0 +1,119 @@
+// Package toolerror is the taxonomy of the errors answered by the MCP tools, shared by every context. A failed tool
+// call answers a JSON error with a stable code, so agents can tell a missing invoice from an invalid argument or an
+// outage and react to it.
+package toolerror
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// Code classifies why a tool call failed.
+type Code string
+
+const (
+	CodeNotFound        Code = "NOT_FOUND"        // The account, or its invoice, movement or billing run, does not exist
+	CodeInvalidArgument Code = "INVALID_ARGUMENT" // An argument is missing, malformed or breaks a business rule
+	CodeForbidden       Code = "FORBIDDEN"        // The principal may not access the account
+	CodeConflict        Code = "CONFLICT"         // The current state of the invoice or movement does not allow the operation
+	CodeUnavailable     Code = "UNAVAILABLE"      // A dependency such as the database failed, the call can be retried
+	CodeInternal        Code = "INTERNAL"         // Any other failure
+)
+
+// Error is the JSON content of a failed tool call.
+type Error struct {
+	Code      Code   `json:"code"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"` // Whether the same call may succeed later
+}
+
+// Classifier returns the code of the errors of a context, or an empty code for the ones it does not know.
+type Classifier func(err error) Code
+
+// Classify returns the code the first classifier gives to err, falling back to the errors shared by every context.
+func Classify(err error, classifiers ...Classifier) Code {
+	for _, classify := range classifiers {
+		if code := classify(err); code != "" {
+			return code
+		}
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, auth.ErrAccessDenied):
+		return CodeForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return CodeNotFound
+	case errors.Is(err, money.ErrInvalidAmount),
+		errors.Is(err, money.ErrTooManyDecimals),
+		errors.Is(err, money.ErrCurrencyMismatch),
+		errors.Is(err, money.ErrUnknownCurrency),
+		errors.Is(err, money.ErrUnsupportedCurrency),
+		errors.Is(err, money.ErrNegativePercentage),
+		errors.Is(err, money.ErrInvalidExchangeRate),
+		errors.Is(err, pagination.ErrInvalidPageSize),
+		errors.Is(err, pagination.ErrInvalidSort),
+		errors.Is(err, pagination.ErrInvalidCursor):
+		return CodeInvalidArgument
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, driver.ErrBadConn),
+		errors.As(err, &netErr):
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// New is the error of a failure. The message is followed by the error, unless the failure is internal or a
+// dependency's, whose details stay in the logs of the server.
+func New(code Code, message string, err error) Error {
+	toolErr := Error{Code: code, Message: message, Retryable: code == CodeUnavailable}
+	if err != nil && code != CodeInternal && code != CodeUnavailable {
+		toolErr.Message = fmt.Sprintf("%s: %v", message, err)
+	}
+	return toolErr
+}
+
+// Result is the tool result of a failure, with the JSON of its error as content.
+func Result(code Code, message string, err error) *mcp.CallToolResult {
+	toolErr := New(code, message, err)
+	jsonData, marshalErr := json.Marshal(toolErr)
+	if marshalErr != nil {
+		return mcp.NewToolResultError(toolErr.Message)
+	}
+	return mcp.NewToolResultError(string(jsonData))
+}
+
+// InvalidArgument is the tool result of an argument the handler rejected.
+func InvalidArgument(message string, err error) *mcp.CallToolResult {
+	return Result(CodeInvalidArgument, message, err)
+}
+
+// HTTPStatus is the status the HTTP endpoints answer for the code.
+func HTTPStatus(code Code) int {
+	switch code {
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeInvalidArgument:
+		return http.StatusBadRequest
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
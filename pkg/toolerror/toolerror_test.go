@@ -0,0 +1,82 @@
+package toolerror_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/auth"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/money"
+	"github.com/ricardogrande-masmovil/billing-mcp/pkg/toolerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var errInvoiceNotFound = errors.New("invoice not found")
+
+func classifyInvoices(err error) toolerror.Code {
+	if errors.Is(err, errInvoiceNotFound) {
+		return toolerror.CodeNotFound
+	}
+	return ""
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected toolerror.Code
+	}{
+		{"error of the context", fmt.Errorf("%w: 42", errInvoiceNotFound), toolerror.CodeNotFound},
+		{"access denied", fmt.Errorf("%w: agent may not read account", auth.ErrAccessDenied), toolerror.CodeForbidden},
+		{"missing record", gorm.ErrRecordNotFound, toolerror.CodeNotFound},
+		{"invalid amount", money.ErrInvalidAmount, toolerror.CodeInvalidArgument},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), toolerror.CodeUnavailable},
+		{"anything else", errors.New("boom"), toolerror.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, toolerror.Classify(tt.err, classifyInvoices))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	notFound := toolerror.New(toolerror.CodeNotFound, "Failed to fetch invoice", errInvoiceNotFound)
+	assert.Equal(t, toolerror.Error{Code: toolerror.CodeNotFound, Message: "Failed to fetch invoice: invoice not found"}, notFound)
+
+	// The details of internal failures and outages stay in the logs
+	internal := toolerror.New(toolerror.CodeInternal, "Failed to fetch invoice", errors.New("pq: relation \"invoices\" does not exist"))
+	assert.Equal(t, toolerror.Error{Code: toolerror.CodeInternal, Message: "Failed to fetch invoice"}, internal)
+
+	unavailable := toolerror.New(toolerror.CodeUnavailable, "Failed to fetch invoice", context.DeadlineExceeded)
+	assert.Equal(t, toolerror.Error{Code: toolerror.CodeUnavailable, Message: "Failed to fetch invoice", Retryable: true}, unavailable)
+}
+
+func TestResult(t *testing.T) {
+	result := toolerror.InvalidArgument("Missing request parameter", errors.New("account_id is required"))
+	require.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"code":"INVALID_ARGUMENT","message":"Missing request parameter: account_id is required","retryable":false}`, text.Text)
+
+	var toolErr toolerror.Error
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &toolErr))
+	assert.Equal(t, toolerror.CodeInvalidArgument, toolErr.Code)
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, toolerror.HTTPStatus(toolerror.CodeNotFound))
+	assert.Equal(t, http.StatusBadRequest, toolerror.HTTPStatus(toolerror.CodeInvalidArgument))
+	assert.Equal(t, http.StatusForbidden, toolerror.HTTPStatus(toolerror.CodeForbidden))
+	assert.Equal(t, http.StatusConflict, toolerror.HTTPStatus(toolerror.CodeConflict))
+	assert.Equal(t, http.StatusServiceUnavailable, toolerror.HTTPStatus(toolerror.CodeUnavailable))
+	assert.Equal(t, http.StatusInternalServerError, toolerror.HTTPStatus(toolerror.CodeInternal))
+}
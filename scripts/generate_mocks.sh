@@ -12,6 +12,12 @@ fi
 # Directories
 BASE_DIR=$(pwd)
 MOVEMENTS_DOMAIN_DIR="${BASE_DIR}/internal/movements/domain"
+BILLING_DOMAIN_DIR="${BASE_DIR}/internal/billing/domain"
+INVOICES_DOMAIN_DIR="${BASE_DIR}/internal/invoices/domain"
+PAYMENTS_DOMAIN_DIR="${BASE_DIR}/internal/payments/domain"
+TAXES_DOMAIN_DIR="${BASE_DIR}/internal/taxes/domain"
+ACCOUNTS_DOMAIN_DIR="${BASE_DIR}/internal/accounts/domain"
+MCP_API_DIR="${BASE_DIR}/api/mcp"
 
 # Generate mocks for MovementRepository in service.go
 # Output to service_mock.go in the same directory
@@ -20,4 +26,40 @@ mockgen -source="${MOVEMENTS_DOMAIN_DIR}/service.go" \
         -package=domain \
         MovementRepository
 
+# Generate mocks for the billing Repository and Transactor in service.go
+mockgen -source="${BILLING_DOMAIN_DIR}/service.go" \
+        -destination="${BILLING_DOMAIN_DIR}/service_mock.go" \
+        -package=domain \
+        Repository,Transactor
+
+# Generate mocks for the invoices Repository, Transactor, TaxResolver and AccountRegistry in service.go
+mockgen -source="${INVOICES_DOMAIN_DIR}/service.go" \
+        -destination="${INVOICES_DOMAIN_DIR}/service_mock.go" \
+        -package=domain \
+        Repository,Transactor,TaxResolver,AccountRegistry
+
+# Generate mocks for the payments Repository, Transactor and InvoiceSettler in service.go
+mockgen -source="${PAYMENTS_DOMAIN_DIR}/service.go" \
+        -destination="${PAYMENTS_DOMAIN_DIR}/service_mock.go" \
+        -package=domain \
+        Repository,Transactor,InvoiceSettler
+
+# Generate mocks for the taxes Repository in service.go
+mockgen -source="${TAXES_DOMAIN_DIR}/service.go" \
+        -destination="${TAXES_DOMAIN_DIR}/service_mock.go" \
+        -package=domain \
+        Repository
+
+# Generate mocks for the accounts Repository and Transactor in service.go
+mockgen -source="${ACCOUNTS_DOMAIN_DIR}/service.go" \
+        -destination="${ACCOUNTS_DOMAIN_DIR}/service_mock.go" \
+        -package=domain \
+        Repository,Transactor
+
+# Generate mocks for the controllers of the MCP API in mcp.go
+mockgen -source="${MCP_API_DIR}/mcp.go" \
+        -destination="${MCP_API_DIR}/mcp_mock.go" \
+        -package=mcp \
+        HealthController,InvoicesHTTPController,InvoicesController,MovementsController,BillingController,PaymentsController,AccountsController
+
 echo "Mocks generated successfully."